@@ -1,8 +1,12 @@
 package main
 
 import (
+	"auth/internal/cache"
 	"auth/internal/db"
 	"auth/internal/grpcserver"
+	"auth/internal/keys"
+	"auth/internal/notifier"
+	"auth/internal/password"
 	"auth/internal/repository/pg"
 	"auth/internal/service"
 	"context"
@@ -10,6 +14,8 @@ import (
 	"log"
 	"net"
 	"os"
+	"strconv"
+	"time"
 
 	pb "auth/internal/pb/auth/v1"
 
@@ -30,11 +36,36 @@ func main() {
 	grpcAddr := ":" + grpcPort
 
 	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		println("need JWT_TOKEN")
+	var keySet *keys.KeySet
+	if keyDir := os.Getenv("JWT_KEY_DIR"); keyDir != "" {
+		var err error
+		keySet, err = keys.Load(keyDir, os.Getenv("JWT_ACTIVE_KID"))
+		if err != nil {
+			log.Fatalf("load JWT keys: %v", err)
+		}
+	}
+	if jwtSecret == "" && keySet == nil {
+		println("need JWT_SECRET or JWT_KEY_DIR")
 		return
 	}
 
+	minLength := 8
+	if v := os.Getenv("PASSWORD_MIN_LENGTH"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			log.Fatalf("PASSWORD_MIN_LENGTH must be a positive integer")
+		}
+		minLength = n
+	}
+	policy, err := password.NewPolicy(minLength, os.Getenv("PASSWORD_BREACHED_LIST"))
+	if err != nil {
+		log.Fatalf("load password policy: %v", err)
+	}
+	resetNotifier, err := notifier.New(os.Getenv("NOTIFIER"), os.Getenv("NOTIFIER_FILE"))
+	if err != nil {
+		log.Fatalf("notifier: %v", err)
+	}
+
 	lis, err := net.Listen("tcp", grpcAddr)
 	if err != nil {
 		log.Fatalf("listen gRPC: %v", err)
@@ -55,8 +86,24 @@ func main() {
 	defer dbConn.Close()
 	println("Db connected")
 
+	redisConn, err := cache.Connect()
+	if err != nil {
+		println("Redis connect error: %v", err.Error())
+		healthSrv.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+		return
+	}
+	defer redisConn.Close()
+
 	userRepo := pg.NewUserPgRepository(dbConn)
-	authService := service.NewAuthService(userRepo, jwtSecret)
+	spaceRepo := pg.NewSpacePgRepository(dbConn)
+	refreshRepo := pg.NewRefreshTokenPgRepository(dbConn)
+	revocationRepo := pg.NewRevocationPgRepository(dbConn, redisConn)
+	totpRepo := pg.NewTOTPPgRepository(dbConn)
+	attemptRepo := pg.NewLoginAttemptPgRepository(dbConn)
+	authEventRepo := pg.NewAuthEventPgRepository(dbConn)
+	resetRepo := pg.NewPasswordResetPgRepository(dbConn)
+	apiKeyRepo := pg.NewAPIKeyPgRepository(dbConn)
+	authService := service.NewAuthService(userRepo, spaceRepo, refreshRepo, revocationRepo, totpRepo, attemptRepo, authEventRepo, resetRepo, apiKeyRepo, resetNotifier, policy, jwtSecret, keySet)
 	authServer := grpcserver.NewAuthServer(authService)
 
 	pb.RegisterAuthServiceServer(grpcSrv, authServer)
@@ -75,12 +122,44 @@ func main() {
 		return nil
 	})
 
+	if keySet != nil {
+		g.Go(func() error {
+			return keySet.Run(gctx, time.Minute)
+		})
+	}
+
 	g.Go(func() error {
 		<-gctx.Done()
 		grpcSrv.GracefulStop()
 		return nil
 	})
 
+	g.Go(func() error {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-gctx.Done():
+				return nil
+			case <-ticker.C:
+				now := time.Now()
+				revocations, err := revocationRepo.DeleteExpiredRevocations(now)
+				if err != nil {
+					log.Printf("token cleanup: %v", err)
+					continue
+				}
+				refreshTokens, err := refreshRepo.DeleteExpiredRefreshTokens(now)
+				if err != nil {
+					log.Printf("token cleanup: %v", err)
+					continue
+				}
+				if revocations > 0 || refreshTokens > 0 {
+					log.Printf("token cleanup: removed %d revoked access tokens and %d refresh tokens", revocations, refreshTokens)
+				}
+			}
+		}
+	})
+
 	if err := g.Wait(); err != nil && !errors.Is(err, context.Canceled) {
 		log.Fatalf("server error: %v", err)
 	}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrAPIKeyInvalid  = errors.New("invalid, expired or revoked api key")
+	ErrInvalidScope   = errors.New("invalid scope")
+	ErrInvalidExpiry  = errors.New("expiry must be in the future")
+)
+
+const (
+	apiKeyPrefix = "lk_"
+	// APIKeyPrefixLength is how much of a key is stored in clear so that
+	// users can tell their keys apart.
+	APIKeyPrefixLength = len(apiKeyPrefix) + 8
+)
+
+// APIKeyScopes are the "<resource>:<access>" scopes a key can be given. The
+// resource is the gateway path segment after /api; read covers the routes
+// the read permission guards, write those of the write permission.
+var APIKeyScopes = []string{
+	"transactions:read", "transactions:write",
+	"budget:read", "budget:write",
+	"categories:read", "categories:write",
+	"rules:read", "rules:write",
+	"goals:read", "goals:write",
+	"webhooks:read", "webhooks:write",
+	"reports:read",
+	"events:read",
+	"audit:read",
+}
+
+func ValidScope(scope string) bool {
+	for _, s := range APIKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey is a long-lived credential of a user for scripts. Like refresh
+// tokens it is stored only as a hash, next to its prefix. A key created in
+// a shared ledger acts in that space. A zero ExpiresAt never expires.
+type APIKey struct {
+	ID         string
+	UserID     string
+	SpaceID    string
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	CreatedAt  time.Time
+}
+
+// NewAPIKey returns a key and its prefix. Keys are hashed with
+// HashRefreshToken.
+func NewAPIKey() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return key, key[:APIKeyPrefixLength], nil
+}
+
+type APIKeyRepository interface {
+	CreateAPIKey(key *APIKey) error
+	// ListAPIKeys returns the user's keys that are not revoked, newest first.
+	ListAPIKeys(userID string) ([]APIKey, error)
+	RevokeAPIKey(id, userID string) error
+	// UseAPIKey records the use of an active key and returns it. Unknown,
+	// expired and revoked keys fail with ErrAPIKeyInvalid.
+	UseAPIKey(keyHash string) (*APIKey, error)
+}
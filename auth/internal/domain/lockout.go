@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrLoginLocked = errors.New("too many failed login attempts")
+
+// Failed logins are counted separately per login and per client IP.
+const (
+	AttemptScopeLogin = "login"
+	AttemptScopeIP    = "ip"
+)
+
+const (
+	EventLoginLocked   = "login.locked"
+	EventLoginUnlocked = "login.unlocked"
+)
+
+type LoginAttemptRepository interface {
+	// LockedUntil returns the end of the lock on key, zero when there is none.
+	LockedUntil(scope, key string) (time.Time, error)
+	// RecordFailure counts a failed attempt and returns the failures so far.
+	// The count starts over when the previous failure is older than window.
+	RecordFailure(scope, key string, window time.Duration) (int, error)
+	Lock(scope, key string, until time.Time) error
+	// Reset clears the failures and the lock of key and reports whether
+	// there were any.
+	Reset(scope, key string) (bool, error)
+}
+
+// AuthEvent is an entry of the append-only auth audit table. Lock events
+// carry either Login or IP, depending on what was locked; ActorUserID is the
+// admin who unlocked.
+type AuthEvent struct {
+	ID          int64
+	Type        string
+	Login       string
+	IP          string
+	ActorUserID string
+	Failures    int
+	LockedUntil time.Time
+	CreatedAt   time.Time
+}
+
+type AuthEventFilter struct {
+	Login    string
+	IP       string
+	Type     string
+	BeforeID int64
+	Limit    int
+}
+
+type AuthEventRepository interface {
+	RecordEvent(event *AuthEvent) error
+	// ListEvents returns events newest first.
+	ListEvents(filter AuthEventFilter) ([]AuthEvent, error)
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrWeakPassword      = errors.New("password does not meet the policy")
+	ErrWrongPassword     = errors.New("wrong password")
+	ErrResetTokenInvalid = errors.New("invalid or expired reset token")
+)
+
+const (
+	EventPasswordChanged = "password.changed"
+	EventPasswordReset   = "password.reset"
+)
+
+// PasswordReset is a single-use reset token; like refresh tokens it is stored
+// only as a hash.
+type PasswordReset struct {
+	UserID    string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+type PasswordResetRepository interface {
+	// CreatePasswordReset stores the reset and drops the user's earlier ones.
+	CreatePasswordReset(reset *PasswordReset) error
+	// PasswordResetUser returns the user of an unused, unexpired reset.
+	PasswordResetUser(tokenHash string) (string, error)
+	// ResetPassword uses up the reset and sets the password hash of its user,
+	// whom it returns.
+	ResetPassword(tokenHash, passwordHash string) (string, error)
+}
+
+// Notifier delivers reset tokens to users.
+type Notifier interface {
+	PasswordReset(login, token string, expiresAt time.Time) error
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// RevocationRepository keeps access tokens from being accepted before they
+// expire: single tokens by jti, and every token of a user issued before the
+// user's token version was bumped.
+type RevocationRepository interface {
+	RevokeToken(jti string, userID string, expiresAt time.Time) error
+	IsTokenRevoked(jti string) (bool, error)
+	GetTokenVersion(userID string) (int, error)
+	BumpTokenVersion(userID string) (int, error)
+	// DeleteExpiredRevocations drops denylist entries of tokens that expired
+	// before before, which no longer pass validation anyway.
+	DeleteExpiredRevocations(before time.Time) (int64, error)
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrSpaceNotFound      = errors.New("space not found")
+	ErrInvitationNotFound = errors.New("invitation not found")
+	ErrAlreadyMember      = errors.New("user is already a member or invited")
+	ErrOwnerCannotLeave   = errors.New("the owner cannot leave the space")
+)
+
+// Space is a ledger shared by its members. The ledger scopes budgets and
+// transactions by the space ID of the caller's access token.
+type Space struct {
+	ID        string
+	Name      string
+	OwnerID   string
+	CreatedAt time.Time
+}
+
+type SpaceMember struct {
+	UserID   string
+	Login    string
+	JoinedAt time.Time
+}
+
+type Invitation struct {
+	ID           string
+	SpaceID      string
+	SpaceName    string
+	InviteeID    string
+	InvitedBy    string
+	InviterLogin string
+	CreatedAt    time.Time
+}
+
+type SpaceRepository interface {
+	// CreateSpace stores the space and adds its owner as the first member.
+	CreateSpace(space *Space) error
+	GetSpace(id string) (*Space, error)
+	ListSpaces(userID string) ([]Space, error)
+	IsMember(spaceID, userID string) (bool, error)
+	ListMembers(spaceID string) ([]SpaceMember, error)
+	// RemoveMember also clears the user's active space if it was this one.
+	RemoveMember(spaceID, userID string) error
+	CreateInvitation(invitation *Invitation) error
+	ListInvitations(inviteeID string) ([]Invitation, error)
+	// AcceptInvitation turns the invitation into a membership and returns
+	// the space joined.
+	AcceptInvitation(id, inviteeID string) (string, error)
+	DeclineInvitation(id, inviteeID string) error
+	// SetActiveSpace selects the space new access tokens are issued for; an
+	// empty spaceID selects the user's personal ledger.
+	SetActiveSpace(userID, spaceID string) error
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var (
+	ErrRefreshTokenInvalid = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused means an already rotated token was presented
+	// again, so it has leaked; the whole family is revoked.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+)
+
+// RefreshToken is stored only as a hash. Every rotation issues a new token in
+// the same family and marks the old one used.
+type RefreshToken struct {
+	ID        string
+	UserID    string
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+func NewRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+type RefreshTokenRepository interface {
+	// CreateRefreshToken stores the token, starting a new family when
+	// FamilyID is empty.
+	CreateRefreshToken(token *RefreshToken) error
+	// RotateRefreshToken marks the token with tokenHash used and stores next
+	// in its family for the same user.
+	RotateRefreshToken(tokenHash string, next *RefreshToken) error
+	// RevokeRefreshToken revokes the family of the token with tokenHash if
+	// it belongs to userID.
+	RevokeRefreshToken(tokenHash string, userID string) error
+	RevokeUserRefreshTokens(userID string) error
+	// DeleteExpiredRefreshTokens drops the families whose every token expired
+	// before before, keeping used tokens of live families so that their reuse
+	// is still caught.
+	DeleteExpiredRefreshTokens(before time.Time) (int64, error)
+}
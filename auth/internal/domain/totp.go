@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCode        = errors.New("invalid code")
+	ErrTOTPNotEnrolled    = errors.New("two-factor authentication is not enrolled")
+	ErrTOTPAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrChallengeInvalid   = errors.New("invalid or expired login challenge")
+)
+
+// RecoveryCodeCount codes are issued when 2FA is activated; each one can
+// replace a TOTP code once.
+const RecoveryCodeCount = 10
+
+// LoginChallenge is the second step of a login with 2FA. Like refresh tokens
+// it is stored only as a hash.
+type LoginChallenge struct {
+	UserID    string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+func NewRecoveryCode() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+// HashRecoveryCode ignores case, spaces and dashes, so codes can be typed
+// as the user wrote them down.
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+type TOTPRepository interface {
+	// SetTOTPSecret stores a secret awaiting activation, replacing an earlier
+	// one. It fails with ErrTOTPAlreadyEnabled once 2FA is on.
+	SetTOTPSecret(userID, secret string) error
+	// GetTOTPSecret returns ErrTOTPNotEnrolled when no secret is stored.
+	GetTOTPSecret(userID string) (secret string, enabled bool, err error)
+	// EnableTOTP turns 2FA on and replaces the user's recovery codes.
+	EnableTOTP(userID string, recoveryCodeHashes []string) error
+	DisableTOTP(userID string) error
+	// UseTOTPStep records step as the last one accepted for the user and
+	// reports false if it is not later than the previous one.
+	UseTOTPStep(userID string, step int64) (bool, error)
+	// UseRecoveryCode marks the code used and reports false if the user has
+	// no such unused code.
+	UseRecoveryCode(userID, codeHash string) (bool, error)
+	CreateChallenge(challenge *LoginChallenge) error
+	// ChallengeAttempt counts an attempt at the challenge and returns its
+	// user. Expired challenges and those out of attempts fail with
+	// ErrChallengeInvalid.
+	ChallengeAttempt(tokenHash string) (string, error)
+	DeleteChallenge(tokenHash string) error
+}
@@ -1,22 +1,59 @@
 package domain
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"errors"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	RoleOwner  = "owner"
+	RoleMember = "member"
+	RoleViewer = "viewer"
+	RoleAdmin  = "admin"
+)
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrInvalidRole  = errors.New("invalid role")
+)
 
 type User struct {
-	ID       string
-	Login    string
-	Password string
+	ID            string
+	Login         string
+	Password      string
+	Role          string
+	ActiveSpaceID string
+	TOTPEnabled   bool
+	CreatedAt     time.Time
+}
+
+func ValidRole(role string) bool {
+	switch role {
+	case RoleOwner, RoleMember, RoleViewer, RoleAdmin:
+		return true
+	}
+	return false
 }
 
 func (u *User) HashPassword() error {
-	hashed, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+	hashed, err := HashPassword(u.Password)
 	if err != nil {
 		return err
 	}
-	u.Password = string(hashed)
+	u.Password = hashed
 	return nil
 }
 
+func HashPassword(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
 func (u *User) CheckPassword(password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
 	return err == nil
@@ -24,5 +61,10 @@ func (u *User) CheckPassword(password string) bool {
 
 type UserRepository interface {
 	GetUserByLogin(login string) (*User, error)
+	GetUserByID(id string) (*User, error)
 	CreateUser(user *User) error
+	ListUsers() ([]User, error)
+	SetUserRole(id, role string) error
+	SetPassword(id, passwordHash string) error
+	DeleteUser(id string) error
 }
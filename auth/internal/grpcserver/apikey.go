@@ -0,0 +1,97 @@
+package grpcserver
+
+import (
+	"auth/internal/domain"
+	pb "auth/internal/pb/auth/v1"
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *AuthServer) CreateAPIKey(ctx context.Context, req *pb.CreateAPIKeyRequest) (*pb.CreateAPIKeyResponse, error) {
+	if req.GetToken() == "" || req.GetName() == "" || len(req.GetScopes()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "token, name and scopes are required")
+	}
+	var expiresAt time.Time
+	if req.GetExpiresAt() != "" {
+		var err error
+		expiresAt, err = time.Parse(time.RFC3339, req.GetExpiresAt())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "expires_at must be an RFC 3339 time")
+		}
+	}
+	key, plaintext, err := s.authService.CreateAPIKey(req.GetToken(), req.GetName(), req.GetScopes(), expiresAt)
+	if err != nil {
+		return nil, tokenError("create api key", err)
+	}
+	return &pb.CreateAPIKeyResponse{ApiKey: apiKeyToPb(key), Key: plaintext}, nil
+}
+
+func (s *AuthServer) ListAPIKeys(ctx context.Context, req *pb.ListAPIKeysRequest) (*pb.ListAPIKeysResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	keys, err := s.authService.ListAPIKeys(req.GetToken())
+	if err != nil {
+		return nil, tokenError("list api keys", err)
+	}
+	resp := &pb.ListAPIKeysResponse{ApiKeys: make([]*pb.APIKey, 0, len(keys))}
+	for i := range keys {
+		resp.ApiKeys = append(resp.ApiKeys, apiKeyToPb(&keys[i]))
+	}
+	return resp, nil
+}
+
+func (s *AuthServer) RevokeAPIKey(ctx context.Context, req *pb.RevokeAPIKeyRequest) (*emptypb.Empty, error) {
+	if req.GetToken() == "" || req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token and id are required")
+	}
+	err := s.authService.RevokeAPIKey(req.GetToken(), req.GetId())
+	if err != nil {
+		return nil, tokenError("revoke api key", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthServer) ValidateAPIKey(ctx context.Context, req *pb.ValidateAPIKeyRequest) (*pb.ValidateAPIKeyResponse, error) {
+	if req.GetKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+	identity, err := s.authService.ValidateAPIKey(req.GetKey())
+	if errors.Is(err, domain.ErrAPIKeyInvalid) {
+		return &pb.ValidateAPIKeyResponse{Valid: false}, nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "validate api key: %v", err)
+	}
+	return &pb.ValidateAPIKeyResponse{
+		Valid:   true,
+		UserId:  identity.UserID,
+		Role:    identity.Role,
+		SpaceId: identity.SpaceID,
+		Scopes:  identity.Scopes,
+		KeyId:   identity.KeyID,
+	}, nil
+}
+
+func apiKeyToPb(key *domain.APIKey) *pb.APIKey {
+	resp := &pb.APIKey{
+		Id:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    key.Scopes,
+		SpaceId:   key.SpaceID,
+		CreatedAt: key.CreatedAt.Format(time.RFC3339),
+	}
+	if !key.ExpiresAt.IsZero() {
+		resp.ExpiresAt = key.ExpiresAt.Format(time.RFC3339)
+	}
+	if !key.LastUsedAt.IsZero() {
+		resp.LastUsedAt = key.LastUsedAt.Format(time.RFC3339)
+	}
+	return resp
+}
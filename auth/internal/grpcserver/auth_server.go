@@ -1,9 +1,12 @@
 package grpcserver
 
 import (
+	"auth/internal/domain"
 	pb "auth/internal/pb/auth/v1"
 	"auth/internal/service"
 	"context"
+	"errors"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -24,27 +27,161 @@ func (s *AuthServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Login
 		return nil, status.Error(codes.InvalidArgument, "login and password are required")
 	}
 
-	token, err := s.authService.Login(req.GetLogin(), req.GetPassword())
+	tokens, err := s.authService.Login(req.GetLogin(), req.GetPassword(), clientIP(ctx))
+	if errors.Is(err, domain.ErrLoginLocked) {
+		return nil, status.Errorf(codes.ResourceExhausted, "login failed: %v", err)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, "login failed: %v", err)
 	}
 
-	return &pb.LoginResponse{Token: token}, nil
+	return loginResponse(tokens), nil
 }
 
-func (s *AuthServer) ValidateToken(ctx context.Context, req *pb.ValidateTokenRequest) (*pb.ValidateTokenResponse, error) {
+func (s *AuthServer) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.LoginResponse, error) {
+	if req.GetRefreshToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh_token is required")
+	}
+
+	tokens, err := s.authService.Refresh(req.GetRefreshToken())
+	if errors.Is(err, domain.ErrRefreshTokenInvalid) || errors.Is(err, domain.ErrRefreshTokenReused) {
+		return nil, status.Errorf(codes.Unauthenticated, "refresh failed: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "refresh failed: %v", err)
+	}
+
+	return loginResponse(tokens), nil
+}
+
+func (s *AuthServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*emptypb.Empty, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	err := s.authService.Logout(req.GetToken(), req.GetRefreshToken())
+	if err != nil {
+		return nil, tokenError("logout", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthServer) LogoutAll(ctx context.Context, req *pb.LogoutAllRequest) (*emptypb.Empty, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	err := s.authService.LogoutAll(req.GetToken())
+	if err != nil {
+		return nil, tokenError("logout all", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthServer) GetJWKS(ctx context.Context, req *emptypb.Empty) (*pb.JWKSResponse, error) {
+	jwks := s.authService.JWKS()
+	resp := &pb.JWKSResponse{Keys: make([]*pb.JWK, 0, len(jwks))}
+	for _, k := range jwks {
+		resp.Keys = append(resp.Keys, &pb.JWK{
+			Kty: k.Kty,
+			Kid: k.Kid,
+			Use: k.Use,
+			Alg: k.Alg,
+			N:   k.N,
+			E:   k.E,
+			Crv: k.Crv,
+			X:   k.X,
+		})
+	}
+	return resp, nil
+}
+
+func (s *AuthServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
 	if req.GetToken() == "" {
 		return nil, status.Error(codes.InvalidArgument, "token is required")
 	}
+	users, err := s.authService.ListUsers(req.GetToken())
+	if err != nil {
+		return nil, tokenError("list users", err)
+	}
+	resp := &pb.ListUsersResponse{Users: make([]*pb.User, 0, len(users))}
+	for _, u := range users {
+		resp.Users = append(resp.Users, &pb.User{
+			Id:        u.ID,
+			Login:     u.Login,
+			Role:      u.Role,
+			CreatedAt: u.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
+
+func (s *AuthServer) SetUserRole(ctx context.Context, req *pb.SetUserRoleRequest) (*emptypb.Empty, error) {
+	if req.GetToken() == "" || req.GetUserId() == "" || req.GetRole() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token, user_id and role are required")
+	}
+	err := s.authService.SetUserRole(req.GetToken(), req.GetUserId(), req.GetRole())
+	if err != nil {
+		return nil, tokenError("set user role", err)
+	}
+	return &emptypb.Empty{}, nil
+}
 
-	userID, err := s.authService.ValidateToken(req.GetToken())
+func (s *AuthServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*emptypb.Empty, error) {
+	if req.GetToken() == "" || req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token and user_id are required")
+	}
+	err := s.authService.DeleteUser(req.GetToken(), req.GetUserId())
 	if err != nil {
+		return nil, tokenError("delete user", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func tokenError(op string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidToken):
+		return status.Errorf(codes.Unauthenticated, "%s: %v", op, err)
+	case errors.Is(err, service.ErrPermissionDenied), errors.Is(err, domain.ErrWrongPassword):
+		return status.Errorf(codes.PermissionDenied, "%s: %v", op, err)
+	case errors.Is(err, domain.ErrInvalidRole), errors.Is(err, service.ErrSelfManagement), errors.Is(err, domain.ErrInvalidCode), errors.Is(err, domain.ErrWeakPassword),
+		errors.Is(err, domain.ErrInvalidScope), errors.Is(err, domain.ErrInvalidExpiry):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+	case errors.Is(err, domain.ErrUserNotFound), errors.Is(err, domain.ErrSpaceNotFound), errors.Is(err, domain.ErrInvitationNotFound), errors.Is(err, domain.ErrAPIKeyNotFound):
+		return status.Errorf(codes.NotFound, "%s: %v", op, err)
+	case errors.Is(err, domain.ErrAlreadyMember):
+		return status.Errorf(codes.AlreadyExists, "%s: %v", op, err)
+	case errors.Is(err, domain.ErrOwnerCannotLeave), errors.Is(err, domain.ErrTOTPNotEnrolled), errors.Is(err, domain.ErrTOTPAlreadyEnabled):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", op, err)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", op, err)
+}
+
+func loginResponse(tokens *service.Tokens) *pb.LoginResponse {
+	return &pb.LoginResponse{
+		Token:          tokens.AccessToken,
+		RefreshToken:   tokens.RefreshToken,
+		ChallengeToken: tokens.ChallengeToken,
+		ExpiresIn:      int64(tokens.ExpiresIn.Seconds()),
+	}
+}
+
+func (s *AuthServer) ValidateToken(ctx context.Context, req *pb.ValidateTokenRequest) (*pb.ValidateTokenResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	claims, err := s.authService.ValidateToken(req.GetToken())
+	if errors.Is(err, service.ErrInvalidToken) {
 		return &pb.ValidateTokenResponse{Valid: false}, nil
 	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "validate token: %v", err)
+	}
 
 	return &pb.ValidateTokenResponse{
-		UserId: userID,
-		Valid:  true,
+		UserId:  claims.UserID,
+		Valid:   true,
+		Role:    claims.Role,
+		SpaceId: claims.SpaceID,
 	}, nil
 }
 
@@ -53,8 +190,11 @@ func (s *AuthServer) Register(ctx context.Context, req *pb.RegisterRequest) (*em
 		return nil, status.Error(codes.InvalidArgument, "login and password are required")
 	}
 	err := s.authService.Register(req.Login, req.Password)
+	if errors.Is(err, domain.ErrWeakPassword) {
+		return nil, status.Errorf(codes.InvalidArgument, "register: %v", err)
+	}
 	if err != nil {
 		return nil, err
 	}
-	return nil, nil
+	return &emptypb.Empty{}, nil
 }
@@ -0,0 +1,81 @@
+package grpcserver
+
+import (
+	"auth/internal/domain"
+	pb "auth/internal/pb/auth/v1"
+	"context"
+	"net"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// clientIPKey is set by the gateway to the address of the HTTP client.
+const clientIPKey = "x-real-ip"
+
+// clientIP prefers the address forwarded by the gateway over the peer
+// address, which for proxied calls is the gateway itself.
+func clientIP(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(clientIPKey); len(v) > 0 && v[0] != "" {
+			return v[0]
+		}
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func (s *AuthServer) UnlockLogin(ctx context.Context, req *pb.UnlockLoginRequest) (*emptypb.Empty, error) {
+	if req.GetToken() == "" || (req.GetLogin() == "" && req.GetIp() == "") {
+		return nil, status.Error(codes.InvalidArgument, "token and login or ip are required")
+	}
+	err := s.authService.UnlockLogin(req.GetToken(), req.GetLogin(), req.GetIp())
+	if err != nil {
+		return nil, tokenError("unlock login", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthServer) ListAuthEvents(ctx context.Context, req *pb.ListAuthEventsRequest) (*pb.ListAuthEventsResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	events, err := s.authService.ListAuthEvents(req.GetToken(), domain.AuthEventFilter{
+		Login:    req.GetLogin(),
+		IP:       req.GetIp(),
+		Type:     req.GetType(),
+		BeforeID: req.GetBeforeId(),
+		Limit:    int(req.GetLimit()),
+	})
+	if err != nil {
+		return nil, tokenError("list auth events", err)
+	}
+	resp := &pb.ListAuthEventsResponse{Events: make([]*pb.AuthEvent, 0, len(events))}
+	for _, e := range events {
+		event := &pb.AuthEvent{
+			Id:          e.ID,
+			Type:        e.Type,
+			Login:       e.Login,
+			Ip:          e.IP,
+			ActorUserId: e.ActorUserID,
+			Failures:    int32(e.Failures),
+			CreatedAt:   e.CreatedAt.Format(time.RFC3339),
+		}
+		if !e.LockedUntil.IsZero() {
+			event.LockedUntil = e.LockedUntil.Format(time.RFC3339)
+		}
+		resp.Events = append(resp.Events, event)
+	}
+	return resp, nil
+}
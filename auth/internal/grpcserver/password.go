@@ -0,0 +1,48 @@
+package grpcserver
+
+import (
+	"auth/internal/domain"
+	pb "auth/internal/pb/auth/v1"
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *AuthServer) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*emptypb.Empty, error) {
+	if req.GetToken() == "" || req.GetOldPassword() == "" || req.GetNewPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token, old_password and new_password are required")
+	}
+	err := s.authService.ChangePassword(req.GetToken(), req.GetOldPassword(), req.GetNewPassword())
+	if err != nil {
+		return nil, tokenError("change password", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthServer) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*emptypb.Empty, error) {
+	if req.GetLogin() == "" {
+		return nil, status.Error(codes.InvalidArgument, "login is required")
+	}
+	err := s.authService.RequestPasswordReset(req.GetLogin())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "request password reset: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthServer) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*emptypb.Empty, error) {
+	if req.GetResetToken() == "" || req.GetNewPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "reset_token and new_password are required")
+	}
+	err := s.authService.ResetPassword(req.GetResetToken(), req.GetNewPassword())
+	if errors.Is(err, domain.ErrResetTokenInvalid) {
+		return nil, status.Errorf(codes.Unauthenticated, "reset password: %v", err)
+	}
+	if err != nil {
+		return nil, tokenError("reset password", err)
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,146 @@
+package grpcserver
+
+import (
+	"auth/internal/domain"
+	pb "auth/internal/pb/auth/v1"
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *AuthServer) CreateSpace(ctx context.Context, req *pb.CreateSpaceRequest) (*pb.Space, error) {
+	if req.GetToken() == "" || req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token and name are required")
+	}
+	space, err := s.authService.CreateSpace(req.GetToken(), req.GetName())
+	if err != nil {
+		return nil, tokenError("create space", err)
+	}
+	return spaceToPb(space), nil
+}
+
+func (s *AuthServer) ListSpaces(ctx context.Context, req *pb.ListSpacesRequest) (*pb.ListSpacesResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	spaces, err := s.authService.ListSpaces(req.GetToken())
+	if err != nil {
+		return nil, tokenError("list spaces", err)
+	}
+	resp := &pb.ListSpacesResponse{Spaces: make([]*pb.Space, 0, len(spaces))}
+	for i := range spaces {
+		resp.Spaces = append(resp.Spaces, spaceToPb(&spaces[i]))
+	}
+	return resp, nil
+}
+
+func (s *AuthServer) ListSpaceMembers(ctx context.Context, req *pb.ListSpaceMembersRequest) (*pb.ListSpaceMembersResponse, error) {
+	if req.GetToken() == "" || req.GetSpaceId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token and space_id are required")
+	}
+	members, err := s.authService.ListSpaceMembers(req.GetToken(), req.GetSpaceId())
+	if err != nil {
+		return nil, tokenError("list space members", err)
+	}
+	resp := &pb.ListSpaceMembersResponse{Members: make([]*pb.SpaceMember, 0, len(members))}
+	for _, m := range members {
+		resp.Members = append(resp.Members, &pb.SpaceMember{
+			UserId:   m.UserID,
+			Login:    m.Login,
+			JoinedAt: m.JoinedAt.Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
+
+func (s *AuthServer) RemoveSpaceMember(ctx context.Context, req *pb.RemoveSpaceMemberRequest) (*emptypb.Empty, error) {
+	if req.GetToken() == "" || req.GetSpaceId() == "" || req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token, space_id and user_id are required")
+	}
+	err := s.authService.RemoveSpaceMember(req.GetToken(), req.GetSpaceId(), req.GetUserId())
+	if err != nil {
+		return nil, tokenError("remove space member", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthServer) SwitchSpace(ctx context.Context, req *pb.SwitchSpaceRequest) (*pb.SwitchSpaceResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	token, expiresIn, err := s.authService.SwitchSpace(req.GetToken(), req.GetSpaceId())
+	if err != nil {
+		return nil, tokenError("switch space", err)
+	}
+	return &pb.SwitchSpaceResponse{Token: token, ExpiresIn: int64(expiresIn.Seconds())}, nil
+}
+
+func (s *AuthServer) InviteToSpace(ctx context.Context, req *pb.InviteToSpaceRequest) (*pb.Invitation, error) {
+	if req.GetToken() == "" || req.GetSpaceId() == "" || req.GetLogin() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token, space_id and login are required")
+	}
+	invitation, err := s.authService.InviteToSpace(req.GetToken(), req.GetSpaceId(), req.GetLogin())
+	if err != nil {
+		return nil, tokenError("invite to space", err)
+	}
+	return invitationToPb(invitation), nil
+}
+
+func (s *AuthServer) ListInvitations(ctx context.Context, req *pb.ListInvitationsRequest) (*pb.ListInvitationsResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	invitations, err := s.authService.ListInvitations(req.GetToken())
+	if err != nil {
+		return nil, tokenError("list invitations", err)
+	}
+	resp := &pb.ListInvitationsResponse{Invitations: make([]*pb.Invitation, 0, len(invitations))}
+	for i := range invitations {
+		resp.Invitations = append(resp.Invitations, invitationToPb(&invitations[i]))
+	}
+	return resp, nil
+}
+
+func (s *AuthServer) AcceptInvitation(ctx context.Context, req *pb.InvitationRequest) (*pb.Space, error) {
+	if req.GetToken() == "" || req.GetInvitationId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token and invitation_id are required")
+	}
+	space, err := s.authService.AcceptInvitation(req.GetToken(), req.GetInvitationId())
+	if err != nil {
+		return nil, tokenError("accept invitation", err)
+	}
+	return spaceToPb(space), nil
+}
+
+func (s *AuthServer) DeclineInvitation(ctx context.Context, req *pb.InvitationRequest) (*emptypb.Empty, error) {
+	if req.GetToken() == "" || req.GetInvitationId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token and invitation_id are required")
+	}
+	err := s.authService.DeclineInvitation(req.GetToken(), req.GetInvitationId())
+	if err != nil {
+		return nil, tokenError("decline invitation", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func spaceToPb(space *domain.Space) *pb.Space {
+	return &pb.Space{
+		Id:        space.ID,
+		Name:      space.Name,
+		OwnerId:   space.OwnerID,
+		CreatedAt: space.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func invitationToPb(inv *domain.Invitation) *pb.Invitation {
+	return &pb.Invitation{
+		Id:        inv.ID,
+		SpaceId:   inv.SpaceID,
+		SpaceName: inv.SpaceName,
+		InvitedBy: inv.InviterLogin,
+		CreatedAt: inv.CreatedAt.Format(time.RFC3339),
+	}
+}
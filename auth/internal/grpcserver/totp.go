@@ -0,0 +1,64 @@
+package grpcserver
+
+import (
+	"auth/internal/domain"
+	pb "auth/internal/pb/auth/v1"
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *AuthServer) VerifyLogin(ctx context.Context, req *pb.VerifyLoginRequest) (*pb.LoginResponse, error) {
+	if req.GetChallengeToken() == "" || req.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "challenge_token and code are required")
+	}
+
+	tokens, err := s.authService.VerifyLogin(req.GetChallengeToken(), req.GetCode(), clientIP(ctx))
+	if errors.Is(err, domain.ErrLoginLocked) {
+		return nil, status.Errorf(codes.ResourceExhausted, "verify login: %v", err)
+	}
+	if errors.Is(err, domain.ErrChallengeInvalid) || errors.Is(err, domain.ErrInvalidCode) {
+		return nil, status.Errorf(codes.Unauthenticated, "verify login: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "verify login: %v", err)
+	}
+
+	return loginResponse(tokens), nil
+}
+
+func (s *AuthServer) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	enrollment, err := s.authService.EnrollTOTP(req.GetToken())
+	if err != nil {
+		return nil, tokenError("enroll totp", err)
+	}
+	return &pb.EnrollTOTPResponse{Secret: enrollment.Secret, Uri: enrollment.URI}, nil
+}
+
+func (s *AuthServer) ActivateTOTP(ctx context.Context, req *pb.ActivateTOTPRequest) (*pb.ActivateTOTPResponse, error) {
+	if req.GetToken() == "" || req.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token and code are required")
+	}
+	recoveryCodes, err := s.authService.ActivateTOTP(req.GetToken(), req.GetCode())
+	if err != nil {
+		return nil, tokenError("activate totp", err)
+	}
+	return &pb.ActivateTOTPResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (s *AuthServer) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*emptypb.Empty, error) {
+	if req.GetToken() == "" || req.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "token and code are required")
+	}
+	err := s.authService.DisableTOTP(req.GetToken(), req.GetCode())
+	if err != nil {
+		return nil, tokenError("disable totp", err)
+	}
+	return &emptypb.Empty{}, nil
+}
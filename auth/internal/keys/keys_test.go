@@ -0,0 +1,106 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKey(t *testing.T, dir, kid string, private any) {
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, kid+".pem"), data, 0o600))
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	writeKey(t, dir, "2024-01", rsaKey)
+	writeKey(t, dir, "2025-01", edKey)
+
+	t.Run("last kid signs", func(t *testing.T) {
+		set, err := Load(dir, "")
+		require.NoError(t, err)
+		assert.Equal(t, "2025-01", set.Signing().ID)
+		assert.Equal(t, jwt.SigningMethodEdDSA, set.Signing().Method)
+	})
+
+	t.Run("active kid signs", func(t *testing.T) {
+		set, err := Load(dir, "2024-01")
+		require.NoError(t, err)
+		assert.Equal(t, "2024-01", set.Signing().ID)
+		assert.Equal(t, jwt.SigningMethodRS256, set.Signing().Method)
+	})
+
+	t.Run("unknown active kid", func(t *testing.T) {
+		_, err := Load(dir, "2023-01")
+		assert.Error(t, err)
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		_, err := Load(t.TempDir(), "")
+		assert.Error(t, err)
+	})
+
+	t.Run("jwks round-trip", func(t *testing.T) {
+		set, err := Load(dir, "")
+		require.NoError(t, err)
+		jwks := set.JWKS()
+		require.Len(t, jwks, 2)
+
+		assert.Equal(t, "2024-01", jwks[0].Kid)
+		assert.Equal(t, "RSA", jwks[0].Kty)
+		assert.Equal(t, "RS256", jwks[0].Alg)
+		assert.Equal(t, "sig", jwks[0].Use)
+		n, err := base64.RawURLEncoding.DecodeString(jwks[0].N)
+		require.NoError(t, err)
+		e, err := base64.RawURLEncoding.DecodeString(jwks[0].E)
+		require.NoError(t, err)
+		assert.Equal(t, 0, rsaKey.N.Cmp(new(big.Int).SetBytes(n)))
+		assert.Equal(t, int64(rsaKey.E), new(big.Int).SetBytes(e).Int64())
+
+		assert.Equal(t, "2025-01", jwks[1].Kid)
+		assert.Equal(t, "OKP", jwks[1].Kty)
+		assert.Equal(t, "Ed25519", jwks[1].Crv)
+		assert.Equal(t, "EdDSA", jwks[1].Alg)
+		x, err := base64.RawURLEncoding.DecodeString(jwks[1].X)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(edPub), x)
+	})
+
+	t.Run("get verifies what signing signed", func(t *testing.T) {
+		set, err := Load(dir, "")
+		require.NoError(t, err)
+		signing := set.Signing()
+		token := jwt.NewWithClaims(signing.Method, jwt.RegisteredClaims{Subject: "u1"})
+		token.Header["kid"] = signing.ID
+		signed, err := token.SignedString(signing.Private)
+		require.NoError(t, err)
+
+		parsed, err := jwt.Parse(signed, func(token *jwt.Token) (any, error) {
+			key, ok := set.Get(token.Header["kid"].(string))
+			require.True(t, ok)
+			return key.Private.Public(), nil
+		})
+		require.NoError(t, err)
+		assert.True(t, parsed.Valid)
+
+		_, ok := set.Get("2023-01")
+		assert.False(t, ok)
+	})
+}
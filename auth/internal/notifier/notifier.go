@@ -0,0 +1,64 @@
+package notifier
+
+import (
+	"auth/internal/domain"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// New returns the notifier of the given kind: "log" writes to the service
+// log and "file" appends JSON lines to path. Both are meant for local use;
+// users have no address to deliver to yet.
+func New(kind, path string) (domain.Notifier, error) {
+	switch kind {
+	case "", "log":
+		return LogNotifier{}, nil
+	case "file":
+		if path == "" {
+			return nil, fmt.Errorf("file notifier needs a path")
+		}
+		return &FileNotifier{path: path}, nil
+	}
+	return nil, fmt.Errorf("unknown notifier %q", kind)
+}
+
+type LogNotifier struct{}
+
+func (LogNotifier) PasswordReset(login, token string, expiresAt time.Time) error {
+	log.Printf("password reset for %s: token %s, valid until %s", login, token, expiresAt.Format(time.RFC3339))
+	return nil
+}
+
+type FileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+type message struct {
+	Type      string    `json:"type"`
+	Login     string    `json:"login"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (n *FileNotifier) PasswordReset(login, token string, expiresAt time.Time) error {
+	line, err := json.Marshal(message{Type: "password_reset", Login: login, Token: token, ExpiresAt: expiresAt})
+	if err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	f, err := os.OpenFile(n.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,60 @@
+package password
+
+import (
+	"auth/internal/domain"
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// maxLength is the most bcrypt can hash.
+const maxLength = 72
+
+// Policy is checked for every new password: at Register, ChangePassword and
+// ResetPassword.
+type Policy struct {
+	minLength int
+	breached  map[string]struct{}
+}
+
+// NewPolicy loads the breached passwords from breachedFile, one per line,
+// if it is set. They are matched case-insensitively.
+func NewPolicy(minLength int, breachedFile string) (*Policy, error) {
+	p := &Policy{minLength: minLength, breached: map[string]struct{}{}}
+	if breachedFile == "" {
+		return p, nil
+	}
+	f, err := os.Open(breachedFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			p.breached[strings.ToLower(line)] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", breachedFile, err)
+	}
+	return p, nil
+}
+
+// Check returns an error wrapping domain.ErrWeakPassword with the reason.
+func (p *Policy) Check(login, password string) error {
+	if len([]rune(password)) < p.minLength {
+		return fmt.Errorf("%w: must be at least %d characters", domain.ErrWeakPassword, p.minLength)
+	}
+	if len(password) > maxLength {
+		return fmt.Errorf("%w: must be at most %d bytes", domain.ErrWeakPassword, maxLength)
+	}
+	if strings.EqualFold(password, login) {
+		return fmt.Errorf("%w: must not be the login", domain.ErrWeakPassword)
+	}
+	if _, ok := p.breached[strings.ToLower(password)]; ok {
+		return fmt.Errorf("%w: appears in a list of breached passwords", domain.ErrWeakPassword)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package pg
+
+import (
+	"auth/internal/domain"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+type APIKeyPgRepository struct {
+	db *sql.DB
+}
+
+func NewAPIKeyPgRepository(db *sql.DB) domain.APIKeyRepository {
+	return &APIKeyPgRepository{db: db}
+}
+
+const apiKeyColumns = "id, user_id, space_id, name, prefix, key_hash, scopes, expires_at, last_used_at, created_at"
+
+func (r *APIKeyPgRepository) CreateAPIKey(key *domain.APIKey) error {
+	var expiresAt sql.NullTime
+	if !key.ExpiresAt.IsZero() {
+		expiresAt = sql.NullTime{Time: key.ExpiresAt, Valid: true}
+	}
+	return r.db.QueryRow(
+		"INSERT INTO api_keys (user_id, space_id, name, prefix, key_hash, scopes, expires_at) VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5, $6, $7) RETURNING id, created_at",
+		key.UserID, key.SpaceID, key.Name, key.Prefix, key.KeyHash, pq.Array(key.Scopes), expiresAt,
+	).Scan(&key.ID, &key.CreatedAt)
+}
+
+func (r *APIKeyPgRepository) ListAPIKeys(userID string) ([]domain.APIKey, error) {
+	rows, err := r.db.Query(
+		"SELECT "+apiKeyColumns+" FROM api_keys WHERE user_id = $1 AND revoked_at IS NULL ORDER BY created_at DESC",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []domain.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+	return keys, rows.Err()
+}
+
+func (r *APIKeyPgRepository) RevokeAPIKey(id, userID string) error {
+	res, err := r.db.Exec(
+		"UPDATE api_keys SET revoked_at = now() WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL",
+		id, userID,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *APIKeyPgRepository) UseAPIKey(keyHash string) (*domain.APIKey, error) {
+	key, err := scanAPIKey(r.db.QueryRow(
+		"UPDATE api_keys SET last_used_at = now() WHERE key_hash = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > now()) RETURNING "+apiKeyColumns,
+		keyHash,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+	return key, err
+}
+
+func scanAPIKey(row interface{ Scan(...any) error }) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var spaceID sql.NullString
+	var expiresAt, lastUsedAt sql.NullTime
+	err := row.Scan(&key.ID, &key.UserID, &spaceID, &key.Name, &key.Prefix, &key.KeyHash,
+		pq.Array(&key.Scopes), &expiresAt, &lastUsedAt, &key.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	key.SpaceID = spaceID.String
+	key.ExpiresAt = expiresAt.Time
+	key.LastUsedAt = lastUsedAt.Time
+	return &key, nil
+}
@@ -0,0 +1,51 @@
+package pg
+
+import (
+	"testing"
+	"time"
+
+	"auth/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyPgRepository_UseAPIKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAPIKeyPgRepository(db)
+
+	query := `UPDATE api_keys SET last_used_at = now\(\) WHERE key_hash = \$1 AND revoked_at IS NULL AND \(expires_at IS NULL OR expires_at > now\(\)\) RETURNING id, user_id, space_id, name, prefix, key_hash, scopes, expires_at, last_used_at, created_at`
+	columns := []string{"id", "user_id", "space_id", "name", "prefix", "key_hash", "scopes", "expires_at", "last_used_at", "created_at"}
+	now := time.Now()
+
+	t.Run("active key", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs("hash").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("k1", "u1", "s1", "ci", "fk_abcd", "hash", "{read,write}", nil, now, now))
+
+		key, err := repo.UseAPIKey("hash")
+
+		require.NoError(t, err)
+		assert.Equal(t, &domain.APIKey{
+			ID: "k1", UserID: "u1", SpaceID: "s1", Name: "ci", Prefix: "fk_abcd", KeyHash: "hash",
+			Scopes: []string{"read", "write"}, LastUsedAt: now, CreatedAt: now,
+		}, key)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("expired, revoked or unknown key", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WithArgs("hash").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		key, err := repo.UseAPIKey("hash")
+
+		assert.ErrorIs(t, err, domain.ErrAPIKeyInvalid)
+		assert.Nil(t, key)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
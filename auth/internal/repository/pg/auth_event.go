@@ -0,0 +1,72 @@
+package pg
+
+import (
+	"auth/internal/domain"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type AuthEventPgRepository struct {
+	db *sql.DB
+}
+
+func NewAuthEventPgRepository(db *sql.DB) domain.AuthEventRepository {
+	return &AuthEventPgRepository{db: db}
+}
+
+func (r *AuthEventPgRepository) RecordEvent(event *domain.AuthEvent) error {
+	var lockedUntil sql.NullTime
+	if !event.LockedUntil.IsZero() {
+		lockedUntil = sql.NullTime{Time: event.LockedUntil, Valid: true}
+	}
+	return r.db.QueryRow(
+		"INSERT INTO auth_events (event_type, login, ip, actor_user_id, failures, locked_until) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at",
+		event.Type, event.Login, event.IP, event.ActorUserID, event.Failures, lockedUntil,
+	).Scan(&event.ID, &event.CreatedAt)
+}
+
+func (r *AuthEventPgRepository) ListEvents(filter domain.AuthEventFilter) ([]domain.AuthEvent, error) {
+	var where []string
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	if filter.Login != "" {
+		add("login = $%d", filter.Login)
+	}
+	if filter.IP != "" {
+		add("ip = $%d", filter.IP)
+	}
+	if filter.Type != "" {
+		add("event_type = $%d", filter.Type)
+	}
+	if filter.BeforeID > 0 {
+		add("id < $%d", filter.BeforeID)
+	}
+	query := "SELECT id, event_type, login, ip, actor_user_id, failures, locked_until, created_at FROM auth_events"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.AuthEvent
+	for rows.Next() {
+		var event domain.AuthEvent
+		var lockedUntil sql.NullTime
+		if err := rows.Scan(&event.ID, &event.Type, &event.Login, &event.IP, &event.ActorUserID, &event.Failures, &lockedUntil, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		event.LockedUntil = lockedUntil.Time
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,61 @@
+package pg
+
+import (
+	"auth/internal/domain"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type LoginAttemptPgRepository struct {
+	db *sql.DB
+}
+
+func NewLoginAttemptPgRepository(db *sql.DB) domain.LoginAttemptRepository {
+	return &LoginAttemptPgRepository{db: db}
+}
+
+func (r *LoginAttemptPgRepository) LockedUntil(scope, key string) (time.Time, error) {
+	var until sql.NullTime
+	err := r.db.QueryRow(
+		"SELECT locked_until FROM login_failures WHERE scope = $1 AND key = $2 AND locked_until > now()",
+		scope, key,
+	).Scan(&until)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	return until.Time, nil
+}
+
+func (r *LoginAttemptPgRepository) RecordFailure(scope, key string, window time.Duration) (int, error) {
+	var failures int
+	err := r.db.QueryRow(
+		`INSERT INTO login_failures (scope, key, failures) VALUES ($1, $2, 1)
+		ON CONFLICT (scope, key) DO UPDATE SET
+			failures = CASE WHEN login_failures.last_failure_at < now() - make_interval(secs => $3) THEN 1 ELSE login_failures.failures + 1 END,
+			last_failure_at = now()
+		RETURNING failures`,
+		scope, key, window.Seconds(),
+	).Scan(&failures)
+	return failures, err
+}
+
+func (r *LoginAttemptPgRepository) Lock(scope, key string, until time.Time) error {
+	_, err := r.db.Exec(
+		"UPDATE login_failures SET locked_until = $3 WHERE scope = $1 AND key = $2",
+		scope, key, until,
+	)
+	return err
+}
+
+func (r *LoginAttemptPgRepository) Reset(scope, key string) (bool, error) {
+	res, err := r.db.Exec("DELETE FROM login_failures WHERE scope = $1 AND key = $2", scope, key)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
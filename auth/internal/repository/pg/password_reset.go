@@ -0,0 +1,78 @@
+package pg
+
+import (
+	"auth/internal/domain"
+	"database/sql"
+	"errors"
+)
+
+type PasswordResetPgRepository struct {
+	db *sql.DB
+}
+
+func NewPasswordResetPgRepository(db *sql.DB) domain.PasswordResetRepository {
+	return &PasswordResetPgRepository{db: db}
+}
+
+func (r *PasswordResetPgRepository) CreatePasswordReset(reset *domain.PasswordReset) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec("DELETE FROM password_reset_tokens WHERE user_id = $1 OR expires_at < now()", reset.UserID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		"INSERT INTO password_reset_tokens (token_hash, user_id, expires_at) VALUES ($1, $2, $3)",
+		reset.TokenHash, reset.UserID, reset.ExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PasswordResetPgRepository) PasswordResetUser(tokenHash string) (string, error) {
+	var userID string
+	err := r.db.QueryRow(
+		"SELECT user_id FROM password_reset_tokens WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now()",
+		tokenHash,
+	).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrResetTokenInvalid
+		}
+		return "", err
+	}
+	return userID, nil
+}
+
+func (r *PasswordResetPgRepository) ResetPassword(tokenHash, passwordHash string) (string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var userID string
+	err = tx.QueryRow(
+		"UPDATE password_reset_tokens SET used_at = now() WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now() RETURNING user_id",
+		tokenHash,
+	).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrResetTokenInvalid
+		}
+		return "", err
+	}
+	res, err := tx.Exec("UPDATE users SET password = $2 WHERE id = $1", userID, passwordHash)
+	if err != nil {
+		return "", err
+	}
+	if err = userAffected(res); err != nil {
+		return "", err
+	}
+	return userID, tx.Commit()
+}
@@ -0,0 +1,83 @@
+package pg
+
+import (
+	"testing"
+
+	"auth/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordResetPgRepository_ResetPassword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPasswordResetPgRepository(db)
+
+	useQuery := `UPDATE password_reset_tokens SET used_at = now\(\) WHERE token_hash = \$1 AND used_at IS NULL AND expires_at > now\(\) RETURNING user_id`
+	setQuery := `UPDATE users SET password = \$2 WHERE id = \$1`
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expectedErr error
+	}{
+		{
+			name: "first use",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(useQuery).
+					WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("u1"))
+				mock.ExpectExec(setQuery).
+					WithArgs("u1", "new-password-hash").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "used, expired or unknown token",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(useQuery).
+					WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+				mock.ExpectRollback()
+			},
+			expectedErr: domain.ErrResetTokenInvalid,
+		},
+		{
+			name: "user deleted",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(useQuery).
+					WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("u1"))
+				mock.ExpectExec(setQuery).
+					WithArgs("u1", "new-password-hash").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectedErr: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			userID, err := repo.ResetPassword("hash", "new-password-hash")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "u1", userID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
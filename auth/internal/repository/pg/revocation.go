@@ -0,0 +1,109 @@
+package pg
+
+import (
+	"auth/internal/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Negative denylist lookups and token versions are cached briefly; revoking
+// or bumping overwrites the cached value right away.
+const revocationCacheTTL = 30 * time.Second
+
+type RevocationPgRepository struct {
+	db    *sql.DB
+	cache *redis.Client
+}
+
+func NewRevocationPgRepository(db *sql.DB, cache *redis.Client) domain.RevocationRepository {
+	return &RevocationPgRepository{db: db, cache: cache}
+}
+
+func (r *RevocationPgRepository) RevokeToken(jti string, userID string, expiresAt time.Time) error {
+	_, err := r.db.Exec(
+		"INSERT INTO revoked_tokens (jti, user_id, expires_at) VALUES ($1, $2, $3) ON CONFLICT (jti) DO NOTHING",
+		jti, userID, expiresAt,
+	)
+	if err != nil {
+		return err
+	}
+	if ttl := time.Until(expiresAt); ttl > 0 {
+		r.cache.Set(context.Background(), revokedKey(jti), "1", ttl)
+	}
+	return nil
+}
+
+func (r *RevocationPgRepository) IsTokenRevoked(jti string) (bool, error) {
+	ctx := context.Background()
+	val, err := r.cache.Get(ctx, revokedKey(jti)).Result()
+	if err == nil {
+		return val == "1", nil
+	}
+	var expiresAt time.Time
+	err = r.db.QueryRow("SELECT expires_at FROM revoked_tokens WHERE jti = $1", jti).Scan(&expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		r.cache.Set(ctx, revokedKey(jti), "0", revocationCacheTTL)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if ttl := time.Until(expiresAt); ttl > 0 {
+		r.cache.Set(ctx, revokedKey(jti), "1", ttl)
+	}
+	return true, nil
+}
+
+func (r *RevocationPgRepository) GetTokenVersion(userID string) (int, error) {
+	ctx := context.Background()
+	val, err := r.cache.Get(ctx, tokenVersionKey(userID)).Result()
+	if err == nil {
+		if version, err := strconv.Atoi(val); err == nil {
+			return version, nil
+		}
+	}
+	var version int
+	err = r.db.QueryRow("SELECT token_version FROM users WHERE id = $1", userID).Scan(&version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrUserNotFound
+		}
+		return 0, err
+	}
+	r.cache.Set(ctx, tokenVersionKey(userID), version, revocationCacheTTL)
+	return version, nil
+}
+
+func (r *RevocationPgRepository) BumpTokenVersion(userID string) (int, error) {
+	var version int
+	err := r.db.QueryRow("UPDATE users SET token_version = token_version + 1 WHERE id = $1 RETURNING token_version", userID).Scan(&version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrUserNotFound
+		}
+		return 0, err
+	}
+	r.cache.Set(context.Background(), tokenVersionKey(userID), version, revocationCacheTTL)
+	return version, nil
+}
+
+func (r *RevocationPgRepository) DeleteExpiredRevocations(before time.Time) (int64, error) {
+	res, err := r.db.Exec("DELETE FROM revoked_tokens WHERE expires_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func revokedKey(jti string) string {
+	return "auth:revoked:" + jti
+}
+
+func tokenVersionKey(userID string) string {
+	return "auth:token_version:" + userID
+}
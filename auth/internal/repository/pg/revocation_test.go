@@ -0,0 +1,29 @@
+package pg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevocationPgRepository_DeleteExpiredRevocations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRevocationPgRepository(db, nil)
+	before := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`DELETE FROM revoked_tokens WHERE expires_at < \$1`).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	deleted, err := repo.DeleteExpiredRevocations(before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
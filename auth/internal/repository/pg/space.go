@@ -0,0 +1,213 @@
+package pg
+
+import (
+	"auth/internal/domain"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+type SpacePgRepository struct {
+	db *sql.DB
+}
+
+func NewSpacePgRepository(db *sql.DB) domain.SpaceRepository {
+	return &SpacePgRepository{db: db}
+}
+
+func (r *SpacePgRepository) CreateSpace(space *domain.Space) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(
+		"INSERT INTO spaces (name, owner_id) VALUES ($1, $2) RETURNING id, created_at",
+		space.Name, space.OwnerID,
+	).Scan(&space.ID, &space.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec("INSERT INTO space_members (space_id, user_id) VALUES ($1, $2)", space.ID, space.OwnerID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *SpacePgRepository) GetSpace(id string) (*domain.Space, error) {
+	var space domain.Space
+	err := r.db.QueryRow(
+		"SELECT id, name, owner_id, created_at FROM spaces WHERE id = $1",
+		id,
+	).Scan(&space.ID, &space.Name, &space.OwnerID, &space.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSpaceNotFound
+		}
+		return nil, err
+	}
+	return &space, nil
+}
+
+func (r *SpacePgRepository) ListSpaces(userID string) ([]domain.Space, error) {
+	rows, err := r.db.Query(
+		"SELECT s.id, s.name, s.owner_id, s.created_at FROM spaces s JOIN space_members m ON m.space_id = s.id WHERE m.user_id = $1 ORDER BY s.name",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var spaces []domain.Space
+	for rows.Next() {
+		var space domain.Space
+		if err := rows.Scan(&space.ID, &space.Name, &space.OwnerID, &space.CreatedAt); err != nil {
+			return nil, err
+		}
+		spaces = append(spaces, space)
+	}
+	return spaces, rows.Err()
+}
+
+func (r *SpacePgRepository) IsMember(spaceID, userID string) (bool, error) {
+	var member bool
+	err := r.db.QueryRow(
+		"SELECT EXISTS (SELECT 1 FROM space_members WHERE space_id = $1 AND user_id = $2)",
+		spaceID, userID,
+	).Scan(&member)
+	return member, err
+}
+
+func (r *SpacePgRepository) ListMembers(spaceID string) ([]domain.SpaceMember, error) {
+	rows, err := r.db.Query(
+		"SELECT u.id, u.login, m.joined_at FROM space_members m JOIN users u ON u.id = m.user_id WHERE m.space_id = $1 ORDER BY m.joined_at, u.login",
+		spaceID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []domain.SpaceMember
+	for rows.Next() {
+		var member domain.SpaceMember
+		if err := rows.Scan(&member.UserID, &member.Login, &member.JoinedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+func (r *SpacePgRepository) RemoveMember(spaceID, userID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("DELETE FROM space_members WHERE space_id = $1 AND user_id = $2", spaceID, userID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrUserNotFound
+	}
+	if _, err = tx.Exec("UPDATE users SET active_space_id = NULL WHERE id = $1 AND active_space_id = $2", userID, spaceID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *SpacePgRepository) CreateInvitation(invitation *domain.Invitation) error {
+	err := r.db.QueryRow(
+		"INSERT INTO space_invitations (space_id, invitee_id, invited_by) VALUES ($1, $2, $3) RETURNING id, created_at",
+		invitation.SpaceID, invitation.InviteeID, invitation.InvitedBy,
+	).Scan(&invitation.ID, &invitation.CreatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return domain.ErrAlreadyMember
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *SpacePgRepository) ListInvitations(inviteeID string) ([]domain.Invitation, error) {
+	rows, err := r.db.Query(
+		"SELECT i.id, i.space_id, s.name, i.invitee_id, i.invited_by, u.login, i.created_at FROM space_invitations i JOIN spaces s ON s.id = i.space_id JOIN users u ON u.id = i.invited_by WHERE i.invitee_id = $1 ORDER BY i.created_at",
+		inviteeID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []domain.Invitation
+	for rows.Next() {
+		var inv domain.Invitation
+		if err := rows.Scan(&inv.ID, &inv.SpaceID, &inv.SpaceName, &inv.InviteeID, &inv.InvitedBy, &inv.InviterLogin, &inv.CreatedAt); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, inv)
+	}
+	return invitations, rows.Err()
+}
+
+func (r *SpacePgRepository) AcceptInvitation(id, inviteeID string) (string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var spaceID string
+	err = tx.QueryRow(
+		"DELETE FROM space_invitations WHERE id = $1 AND invitee_id = $2 RETURNING space_id",
+		id, inviteeID,
+	).Scan(&spaceID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrInvitationNotFound
+		}
+		return "", err
+	}
+	_, err = tx.Exec(
+		"INSERT INTO space_members (space_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		spaceID, inviteeID,
+	)
+	if err != nil {
+		return "", err
+	}
+	return spaceID, tx.Commit()
+}
+
+func (r *SpacePgRepository) DeclineInvitation(id, inviteeID string) error {
+	res, err := r.db.Exec("DELETE FROM space_invitations WHERE id = $1 AND invitee_id = $2", id, inviteeID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrInvitationNotFound
+	}
+	return nil
+}
+
+func (r *SpacePgRepository) SetActiveSpace(userID, spaceID string) error {
+	res, err := r.db.Exec("UPDATE users SET active_space_id = NULLIF($2, '')::uuid WHERE id = $1", userID, spaceID)
+	if err != nil {
+		return err
+	}
+	return userAffected(res)
+}
@@ -0,0 +1,94 @@
+package pg
+
+import (
+	"auth/internal/domain"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type RefreshTokenPgRepository struct {
+	db *sql.DB
+}
+
+func NewRefreshTokenPgRepository(db *sql.DB) domain.RefreshTokenRepository {
+	return &RefreshTokenPgRepository{db: db}
+}
+
+func (r *RefreshTokenPgRepository) CreateRefreshToken(token *domain.RefreshToken) error {
+	return r.db.QueryRow(
+		"INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at) VALUES ($1, COALESCE(NULLIF($2, '')::uuid, gen_random_uuid()), $3, $4) RETURNING id, family_id",
+		token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt,
+	).Scan(&token.ID, &token.FamilyID)
+}
+
+func (r *RefreshTokenPgRepository) RotateRefreshToken(tokenHash string, next *domain.RefreshToken) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var id string
+	var expiresAt time.Time
+	var used, revoked bool
+	err = tx.QueryRow(
+		"SELECT id, user_id, family_id, expires_at, used_at IS NOT NULL, revoked_at IS NOT NULL FROM refresh_tokens WHERE token_hash = $1 FOR UPDATE",
+		tokenHash,
+	).Scan(&id, &next.UserID, &next.FamilyID, &expiresAt, &used, &revoked)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrRefreshTokenInvalid
+		}
+		return err
+	}
+	if used {
+		_, err = tx.Exec("UPDATE refresh_tokens SET revoked_at = now() WHERE family_id = $1 AND revoked_at IS NULL", next.FamilyID)
+		if err != nil {
+			return err
+		}
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+		return domain.ErrRefreshTokenReused
+	}
+	if revoked || !expiresAt.After(time.Now()) {
+		return domain.ErrRefreshTokenInvalid
+	}
+
+	if _, err = tx.Exec("UPDATE refresh_tokens SET used_at = now() WHERE id = $1", id); err != nil {
+		return err
+	}
+	err = tx.QueryRow(
+		"INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at) VALUES ($1, $2, $3, $4) RETURNING id",
+		next.UserID, next.FamilyID, next.TokenHash, next.ExpiresAt,
+	).Scan(&next.ID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *RefreshTokenPgRepository) RevokeRefreshToken(tokenHash string, userID string) error {
+	_, err := r.db.Exec(
+		"UPDATE refresh_tokens SET revoked_at = now() WHERE family_id = (SELECT family_id FROM refresh_tokens WHERE token_hash = $1 AND user_id = $2) AND revoked_at IS NULL",
+		tokenHash, userID,
+	)
+	return err
+}
+
+func (r *RefreshTokenPgRepository) RevokeUserRefreshTokens(userID string) error {
+	_, err := r.db.Exec("UPDATE refresh_tokens SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL", userID)
+	return err
+}
+
+func (r *RefreshTokenPgRepository) DeleteExpiredRefreshTokens(before time.Time) (int64, error) {
+	res, err := r.db.Exec(
+		"DELETE FROM refresh_tokens t WHERE t.expires_at < $1 AND NOT EXISTS (SELECT 1 FROM refresh_tokens f WHERE f.family_id = t.family_id AND f.expires_at >= $1)",
+		before,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,135 @@
+package pg
+
+import (
+	"testing"
+	"time"
+
+	"auth/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshTokenPgRepository_RotateRefreshToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRefreshTokenPgRepository(db)
+
+	selectQuery := `SELECT id, user_id, family_id, expires_at, used_at IS NOT NULL, revoked_at IS NOT NULL FROM refresh_tokens WHERE token_hash = \$1 FOR UPDATE`
+	columns := []string{"id", "user_id", "family_id", "expires_at", "used", "revoked"}
+	future := time.Now().Add(time.Hour)
+	nextExpiry := time.Now().Add(30 * 24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expectedErr error
+		expectedID  string
+	}{
+		{
+			name: "successful rotation",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectQuery).
+					WithArgs("old").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("t1", "u1", "f1", future, false, false))
+				mock.ExpectExec(`UPDATE refresh_tokens SET used_at = now\(\) WHERE id = \$1`).
+					WithArgs("t1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(`INSERT INTO refresh_tokens \(user_id, family_id, token_hash, expires_at\) VALUES \(\$1, \$2, \$3, \$4\) RETURNING id`).
+					WithArgs("u1", "f1", "new", nextExpiry).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t2"))
+				mock.ExpectCommit()
+			},
+			expectedID: "t2",
+		},
+		{
+			name: "reuse revokes the family",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectQuery).
+					WithArgs("old").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("t1", "u1", "f1", future, true, false))
+				mock.ExpectExec(`UPDATE refresh_tokens SET revoked_at = now\(\) WHERE family_id = \$1 AND revoked_at IS NULL`).
+					WithArgs("f1").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			expectedErr: domain.ErrRefreshTokenReused,
+		},
+		{
+			name: "expired token",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectQuery).
+					WithArgs("old").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("t1", "u1", "f1", time.Now().Add(-time.Minute), false, false))
+				mock.ExpectRollback()
+			},
+			expectedErr: domain.ErrRefreshTokenInvalid,
+		},
+		{
+			name: "revoked token",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectQuery).
+					WithArgs("old").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("t1", "u1", "f1", future, false, true))
+				mock.ExpectRollback()
+			},
+			expectedErr: domain.ErrRefreshTokenInvalid,
+		},
+		{
+			name: "unknown token",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectQuery).
+					WithArgs("old").
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectRollback()
+			},
+			expectedErr: domain.ErrRefreshTokenInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			next := &domain.RefreshToken{TokenHash: "new", ExpiresAt: nextExpiry}
+			err := repo.RotateRefreshToken("old", next)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedID, next.ID)
+				assert.Equal(t, "u1", next.UserID)
+				assert.Equal(t, "f1", next.FamilyID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRefreshTokenPgRepository_DeleteExpiredRefreshTokens(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRefreshTokenPgRepository(db)
+	before := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`DELETE FROM refresh_tokens t WHERE t.expires_at < \$1 AND NOT EXISTS \(SELECT 1 FROM refresh_tokens f WHERE f.family_id = t.family_id AND f.expires_at >= \$1\)`).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	deleted, err := repo.DeleteExpiredRefreshTokens(before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
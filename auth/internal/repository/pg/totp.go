@@ -0,0 +1,167 @@
+package pg
+
+import (
+	"auth/internal/domain"
+	"database/sql"
+	"errors"
+)
+
+// maxChallengeAttempts bounds how many codes can be tried per login
+// challenge, so guessing a code needs the password again.
+const maxChallengeAttempts = 5
+
+type TOTPPgRepository struct {
+	db *sql.DB
+}
+
+func NewTOTPPgRepository(db *sql.DB) domain.TOTPRepository {
+	return &TOTPPgRepository{db: db}
+}
+
+func (r *TOTPPgRepository) SetTOTPSecret(userID, secret string) error {
+	res, err := r.db.Exec(
+		"UPDATE users SET totp_secret = $2 WHERE id = $1 AND NOT totp_enabled",
+		userID, secret,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		if _, _, err := r.GetTOTPSecret(userID); err != nil && !errors.Is(err, domain.ErrTOTPNotEnrolled) {
+			return err
+		}
+		return domain.ErrTOTPAlreadyEnabled
+	}
+	return nil
+}
+
+func (r *TOTPPgRepository) GetTOTPSecret(userID string) (string, bool, error) {
+	var secret sql.NullString
+	var enabled bool
+	err := r.db.QueryRow(
+		"SELECT totp_secret, totp_enabled FROM users WHERE id = $1",
+		userID,
+	).Scan(&secret, &enabled)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, domain.ErrUserNotFound
+		}
+		return "", false, err
+	}
+	if !secret.Valid {
+		return "", false, domain.ErrTOTPNotEnrolled
+	}
+	return secret.String, enabled, nil
+}
+
+func (r *TOTPPgRepository) EnableTOTP(userID string, recoveryCodeHashes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("UPDATE users SET totp_enabled = true WHERE id = $1 AND totp_secret IS NOT NULL", userID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrTOTPNotEnrolled
+	}
+	if _, err = tx.Exec("DELETE FROM recovery_codes WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+	for _, hash := range recoveryCodeHashes {
+		if _, err = tx.Exec("INSERT INTO recovery_codes (user_id, code_hash) VALUES ($1, $2)", userID, hash); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *TOTPPgRepository) DisableTOTP(userID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("UPDATE users SET totp_secret = NULL, totp_enabled = false, totp_last_step = 0 WHERE id = $1", userID)
+	if err != nil {
+		return err
+	}
+	if err = userAffected(res); err != nil {
+		return err
+	}
+	if _, err = tx.Exec("DELETE FROM recovery_codes WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+	if _, err = tx.Exec("DELETE FROM login_challenges WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *TOTPPgRepository) UseTOTPStep(userID string, step int64) (bool, error) {
+	res, err := r.db.Exec(
+		"UPDATE users SET totp_last_step = $2 WHERE id = $1 AND totp_last_step < $2",
+		userID, step,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r *TOTPPgRepository) UseRecoveryCode(userID, codeHash string) (bool, error) {
+	res, err := r.db.Exec(
+		"UPDATE recovery_codes SET used_at = now() WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL",
+		userID, codeHash,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r *TOTPPgRepository) CreateChallenge(challenge *domain.LoginChallenge) error {
+	_, err := r.db.Exec(
+		"INSERT INTO login_challenges (token_hash, user_id, expires_at) VALUES ($1, $2, $3)",
+		challenge.TokenHash, challenge.UserID, challenge.ExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec("DELETE FROM login_challenges WHERE expires_at < now()")
+	return err
+}
+
+func (r *TOTPPgRepository) ChallengeAttempt(tokenHash string) (string, error) {
+	var userID string
+	err := r.db.QueryRow(
+		"UPDATE login_challenges SET attempts = attempts + 1 WHERE token_hash = $1 AND expires_at > now() AND attempts < $2 RETURNING user_id",
+		tokenHash, maxChallengeAttempts,
+	).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrChallengeInvalid
+		}
+		return "", err
+	}
+	return userID, nil
+}
+
+func (r *TOTPPgRepository) DeleteChallenge(tokenHash string) error {
+	_, err := r.db.Exec("DELETE FROM login_challenges WHERE token_hash = $1", tokenHash)
+	return err
+}
@@ -19,13 +19,30 @@ func NewUserPgRepository(db *sql.DB) domain.UserRepository {
 func (r *UserPgRepository) GetUserByLogin(login string) (*domain.User, error) {
 	var user domain.User
 	err := r.db.QueryRow(
-		"SELECT id, login, password FROM users WHERE login = $1",
+		"SELECT id, login, password, role, COALESCE(active_space_id::text, ''), totp_enabled FROM users WHERE login = $1",
 		login,
-	).Scan(&user.ID, &user.Login, &user.Password)
+	).Scan(&user.ID, &user.Login, &user.Password, &user.Role, &user.ActiveSpaceID, &user.TOTPEnabled)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *UserPgRepository) GetUserByID(id string) (*domain.User, error) {
+	var user domain.User
+	err := r.db.QueryRow(
+		"SELECT id, login, password, role, COALESCE(active_space_id::text, ''), totp_enabled FROM users WHERE id = $1",
+		id,
+	).Scan(&user.ID, &user.Login, &user.Password, &user.Role, &user.ActiveSpaceID, &user.TOTPEnabled)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -48,3 +65,56 @@ func (r *UserPgRepository) CreateUser(user *domain.User) error {
 
 	return nil
 }
+
+func (r *UserPgRepository) ListUsers() ([]domain.User, error) {
+	rows, err := r.db.Query("SELECT id, login, role, created_at FROM users ORDER BY login")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.ID, &user.Login, &user.Role, &user.CreatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (r *UserPgRepository) SetUserRole(id, role string) error {
+	res, err := r.db.Exec("UPDATE users SET role = $2 WHERE id = $1", id, role)
+	if err != nil {
+		return err
+	}
+	return userAffected(res)
+}
+
+func (r *UserPgRepository) SetPassword(id, passwordHash string) error {
+	res, err := r.db.Exec("UPDATE users SET password = $2 WHERE id = $1", id, passwordHash)
+	if err != nil {
+		return err
+	}
+	return userAffected(res)
+}
+
+func (r *UserPgRepository) DeleteUser(id string) error {
+	res, err := r.db.Exec("DELETE FROM users WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	return userAffected(res)
+}
+
+func userAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"auth/internal/domain"
+	"errors"
+	"time"
+)
+
+// APIKeyIdentity is what a valid API key acts as: its user with the user's
+// current role, in the space the key was created in, limited to Scopes.
+type APIKeyIdentity struct {
+	KeyID   string
+	UserID  string
+	Role    string
+	SpaceID string
+	Scopes  []string
+}
+
+// CreateAPIKey returns the new key along with its plaintext, which is not
+// shown again. The key acts in the space of the token it was created with;
+// a zero expiresAt never expires.
+func (s *AuthService) CreateAPIKey(tokenString, name string, scopes []string, expiresAt time.Time) (*domain.APIKey, string, error) {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(scopes) == 0 {
+		return nil, "", domain.ErrInvalidScope
+	}
+	unique := make([]string, 0, len(scopes))
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		if !domain.ValidScope(scope) {
+			return nil, "", domain.ErrInvalidScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+	if !expiresAt.IsZero() && !expiresAt.After(time.Now()) {
+		return nil, "", domain.ErrInvalidExpiry
+	}
+
+	plaintext, prefix, err := domain.NewAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+	key := &domain.APIKey{
+		UserID:    claims.UserID,
+		SpaceID:   claims.SpaceID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   domain.HashRefreshToken(plaintext),
+		Scopes:    unique,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.apiKeys.CreateAPIKey(key); err != nil {
+		return nil, "", err
+	}
+	return key, plaintext, nil
+}
+
+func (s *AuthService) ListAPIKeys(tokenString string) ([]domain.APIKey, error) {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	return s.apiKeys.ListAPIKeys(claims.UserID)
+}
+
+func (s *AuthService) RevokeAPIKey(tokenString, id string) error {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return err
+	}
+	return s.apiKeys.RevokeAPIKey(id, claims.UserID)
+}
+
+// ValidateAPIKey also records the use of the key. A key created in a space
+// stops working once its user leaves the space.
+func (s *AuthService) ValidateAPIKey(plaintext string) (*APIKeyIdentity, error) {
+	key, err := s.apiKeys.UseAPIKey(domain.HashRefreshToken(plaintext))
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetUserByID(key.UserID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	if key.SpaceID != "" {
+		member, err := s.spaceRepo.IsMember(key.SpaceID, key.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !member {
+			return nil, domain.ErrAPIKeyInvalid
+		}
+	}
+	return &APIKeyIdentity{
+		KeyID:   key.ID,
+		UserID:  key.UserID,
+		Role:    user.Role,
+		SpaceID: key.SpaceID,
+		Scopes:  key.Scopes,
+	}, nil
+}
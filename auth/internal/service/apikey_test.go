@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+
+	"auth/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthService_ValidateAPIKey(t *testing.T) {
+	keyHash := domain.HashRefreshToken("fk_secret")
+
+	tests := []struct {
+		name      string
+		mockSetup func(r testRepos)
+		expected  *APIKeyIdentity
+		expectErr error
+	}{
+		{
+			name: "personal key",
+			mockSetup: func(r testRepos) {
+				r.apiKeys.On("UseAPIKey", keyHash).Return(&domain.APIKey{ID: "k1", UserID: "u1", Scopes: []string{"read"}}, nil)
+				r.users.On("GetUserByID", "u1").Return(&domain.User{ID: "u1", Role: domain.RoleMember}, nil)
+			},
+			expected: &APIKeyIdentity{KeyID: "k1", UserID: "u1", Role: domain.RoleMember, Scopes: []string{"read"}},
+		},
+		{
+			name: "space key of a member",
+			mockSetup: func(r testRepos) {
+				r.apiKeys.On("UseAPIKey", keyHash).Return(&domain.APIKey{ID: "k1", UserID: "u1", SpaceID: "s1", Scopes: []string{"read"}}, nil)
+				r.users.On("GetUserByID", "u1").Return(&domain.User{ID: "u1", Role: domain.RoleViewer}, nil)
+				r.spaces.On("IsMember", "s1", "u1").Return(true, nil)
+			},
+			expected: &APIKeyIdentity{KeyID: "k1", UserID: "u1", Role: domain.RoleViewer, SpaceID: "s1", Scopes: []string{"read"}},
+		},
+		{
+			name: "expired or revoked key",
+			mockSetup: func(r testRepos) {
+				r.apiKeys.On("UseAPIKey", keyHash).Return(nil, domain.ErrAPIKeyInvalid)
+			},
+			expectErr: domain.ErrAPIKeyInvalid,
+		},
+		{
+			name: "user left the space",
+			mockSetup: func(r testRepos) {
+				r.apiKeys.On("UseAPIKey", keyHash).Return(&domain.APIKey{ID: "k1", UserID: "u1", SpaceID: "s1"}, nil)
+				r.users.On("GetUserByID", "u1").Return(&domain.User{ID: "u1", Role: domain.RoleMember}, nil)
+				r.spaces.On("IsMember", "s1", "u1").Return(false, nil)
+			},
+			expectErr: domain.ErrAPIKeyInvalid,
+		},
+		{
+			name: "user deleted",
+			mockSetup: func(r testRepos) {
+				r.apiKeys.On("UseAPIKey", keyHash).Return(&domain.APIKey{ID: "k1", UserID: "u1"}, nil)
+				r.users.On("GetUserByID", "u1").Return(nil, domain.ErrUserNotFound)
+			},
+			expectErr: domain.ErrAPIKeyInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, r := newTestService(t)
+			tt.mockSetup(r)
+
+			identity, err := s.ValidateAPIKey("fk_secret")
+
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				assert.Nil(t, identity)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, identity)
+			}
+			r.apiKeys.AssertExpectations(t)
+			r.users.AssertExpectations(t)
+			r.spaces.AssertExpectations(t)
+		})
+	}
+}
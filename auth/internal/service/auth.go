@@ -2,51 +2,203 @@ package service
 
 import (
 	"auth/internal/domain"
+	"auth/internal/keys"
+	"auth/internal/password"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+var (
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrSelfManagement   = errors.New("admins cannot change their own role or account")
+)
+
 type AuthService struct {
-	userRepo    domain.UserRepository
-	jwtSecret   []byte
-	tokenExpiry time.Duration
+	userRepo        domain.UserRepository
+	spaceRepo       domain.SpaceRepository
+	refreshRepo     domain.RefreshTokenRepository
+	revocations     domain.RevocationRepository
+	totpRepo        domain.TOTPRepository
+	attempts        domain.LoginAttemptRepository
+	authEvents      domain.AuthEventRepository
+	resets          domain.PasswordResetRepository
+	apiKeys         domain.APIKeyRepository
+	notifier        domain.Notifier
+	policy          *password.Policy
+	jwtSecret       []byte
+	keys            *keys.KeySet
+	tokenExpiry     time.Duration
+	refreshExpiry   time.Duration
+	challengeExpiry time.Duration
+	resetExpiry     time.Duration
+}
+
+// Tokens hold only ChallengeToken, valid for ExpiresIn, when the login needs
+// a second factor.
+type Tokens struct {
+	AccessToken    string
+	RefreshToken   string
+	ChallengeToken string
+	ExpiresIn      time.Duration
 }
 
+// Claims carry the jti (RegisteredClaims.ID) used to revoke a single token
+// and the user's token version at issue time; bumping the version revokes
+// every token issued before. Role is the user's role at issue time, so a role
+// change bumps the version too. SpaceID is the shared ledger the token acts
+// in, empty for the user's own.
 type Claims struct {
-	UserID string `json:"user_id"`
+	UserID       string `json:"user_id"`
+	Role         string `json:"role"`
+	SpaceID      string `json:"space_id,omitempty"`
+	TokenVersion int    `json:"ver"`
 	jwt.RegisteredClaims
 }
 
-func NewAuthService(userRepo domain.UserRepository, jwtSecret string) *AuthService {
+// NewAuthService signs with the active key of keySet, or with HS256 and
+// jwtSecret when keySet is nil. A non-empty jwtSecret keeps HS256 tokens
+// valid next to a key set while moving over to it.
+func NewAuthService(userRepo domain.UserRepository, spaceRepo domain.SpaceRepository, refreshRepo domain.RefreshTokenRepository, revocations domain.RevocationRepository, totpRepo domain.TOTPRepository, attempts domain.LoginAttemptRepository, authEvents domain.AuthEventRepository, resets domain.PasswordResetRepository, apiKeys domain.APIKeyRepository, notifier domain.Notifier, policy *password.Policy, jwtSecret string, keySet *keys.KeySet) *AuthService {
 	return &AuthService{
-		userRepo:    userRepo,
-		jwtSecret:   []byte(jwtSecret),
-		tokenExpiry: 24 * time.Hour,
+		userRepo:        userRepo,
+		spaceRepo:       spaceRepo,
+		refreshRepo:     refreshRepo,
+		revocations:     revocations,
+		totpRepo:        totpRepo,
+		attempts:        attempts,
+		authEvents:      authEvents,
+		resets:          resets,
+		apiKeys:         apiKeys,
+		notifier:        notifier,
+		policy:          policy,
+		jwtSecret:       []byte(jwtSecret),
+		keys:            keySet,
+		tokenExpiry:     15 * time.Minute,
+		refreshExpiry:   30 * 24 * time.Hour,
+		challengeExpiry: 5 * time.Minute,
+		resetExpiry:     time.Hour,
 	}
 }
 
-func (s *AuthService) Login(login, password string) (string, error) {
+// Login of a user with 2FA on only returns a challenge token, see VerifyLogin.
+// ip is the client address failed attempts are counted against, if known.
+func (s *AuthService) Login(login, password, ip string) (*Tokens, error) {
+	if err := s.checkLockout(login, ip); err != nil {
+		return nil, err
+	}
+
 	user, err := s.userRepo.GetUserByLogin(login)
+	if err != nil || !user.CheckPassword(password) {
+		if err := s.recordFailure(login, ip); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("invalid credentials")
+	}
+
+	if user.TOTPEnabled {
+		return s.loginChallenge(user.ID)
+	}
+	return s.completeLogin(user)
+}
+
+// completeLogin starts the session of a user who passed every factor; only
+// then is the count of failed attempts of the login cleared.
+func (s *AuthService) completeLogin(user *domain.User) (*Tokens, error) {
+	if _, err := s.attempts.Reset(domain.AttemptScopeLogin, user.Login); err != nil {
+		return nil, err
+	}
+	return s.startSession(user)
+}
+
+func (s *AuthService) startSession(user *domain.User) (*Tokens, error) {
+	refreshToken, next, err := s.newRefreshToken()
 	if err != nil {
-		return "", errors.New("invalid credentials")
+		return nil, err
+	}
+	next.UserID = user.ID
+	if err := s.refreshRepo.CreateRefreshToken(next); err != nil {
+		return nil, err
 	}
+	return s.tokens(user, refreshToken)
+}
 
-	if !user.CheckPassword(password) {
-		return "", errors.New("invalid credentials")
+// Refresh exchanges a refresh token for a new pair. The presented token
+// cannot be used again.
+func (s *AuthService) Refresh(refreshToken string) (*Tokens, error) {
+	newToken, next, err := s.newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refreshRepo.RotateRefreshToken(domain.HashRefreshToken(refreshToken), next); err != nil {
+		return nil, err
 	}
+	user, err := s.userRepo.GetUserByID(next.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.tokens(user, newToken)
+}
 
+func (s *AuthService) newRefreshToken() (string, *domain.RefreshToken, error) {
+	token, err := domain.NewRefreshToken()
+	if err != nil {
+		return "", nil, err
+	}
+	return token, &domain.RefreshToken{
+		TokenHash: domain.HashRefreshToken(token),
+		ExpiresAt: time.Now().Add(s.refreshExpiry),
+	}, nil
+}
+
+func (s *AuthService) tokens(user *domain.User, refreshToken string) (*Tokens, error) {
+	accessToken, err := s.accessToken(user)
+	if err != nil {
+		return nil, err
+	}
+	return &Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    s.tokenExpiry,
+	}, nil
+}
+
+func (s *AuthService) accessToken(user *domain.User) (string, error) {
+	version, err := s.revocations.GetTokenVersion(user.ID)
+	if err != nil {
+		return "", err
+	}
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
 	claims := Claims{
-		UserID: user.ID,
+		UserID:       user.ID,
+		Role:         user.Role,
+		SpaceID:      user.ActiveSpaceID,
+		TokenVersion: version,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        hex.EncodeToString(jti),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.tokenExpiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(s.jwtSecret)
+	var tokenString string
+	if s.keys != nil {
+		key := s.keys.Signing()
+		token := jwt.NewWithClaims(key.Method, claims)
+		token.Header["kid"] = key.ID
+		tokenString, err = token.SignedString(key.Private)
+	} else {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		tokenString, err = token.SignedString(s.jwtSecret)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -54,23 +206,97 @@ func (s *AuthService) Login(login, password string) (string, error) {
 	return tokenString, nil
 }
 
-func (s *AuthService) ValidateToken(tokenString string) (string, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return s.jwtSecret, nil
-	})
+func (s *AuthService) ValidateToken(tokenString string) (*Claims, error) {
+	return s.parseToken(tokenString)
+}
 
+// Logout revokes the access token and, when given, the refresh token family
+// it was issued with.
+func (s *AuthService) Logout(tokenString string, refreshToken string) error {
+	claims, err := s.parseToken(tokenString)
 	if err != nil {
-		return "", err
+		return err
+	}
+	if err := s.revocations.RevokeToken(claims.ID, claims.UserID, claims.ExpiresAt.Time); err != nil {
+		return err
+	}
+	if refreshToken == "" {
+		return nil
 	}
+	return s.refreshRepo.RevokeRefreshToken(domain.HashRefreshToken(refreshToken), claims.UserID)
+}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims.UserID, nil
+// LogoutAll ends every session of the token's user.
+func (s *AuthService) LogoutAll(tokenString string) error {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return err
+	}
+	if _, err := s.revocations.BumpTokenVersion(claims.UserID); err != nil {
+		return err
+	}
+	return s.refreshRepo.RevokeUserRefreshTokens(claims.UserID)
+}
+
+func (s *AuthService) parseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, s.verificationKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithExpirationRequired())
+	if err != nil || !token.Valid || claims.ID == "" {
+		return nil, ErrInvalidToken
 	}
 
-	return "", errors.New("invalid token")
+	revoked, err := s.revocations.IsTokenRevoked(claims.ID)
+	if err != nil {
+		return nil, fmt.Errorf("check revocation: %w", err)
+	}
+	if revoked {
+		return nil, ErrInvalidToken
+	}
+	version, err := s.revocations.GetTokenVersion(claims.UserID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("check token version: %w", err)
+	}
+	if claims.TokenVersion != version {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func (s *AuthService) verificationKey(token *jwt.Token) (interface{}, error) {
+	if token.Method == jwt.SigningMethodHS256 {
+		if len(s.jwtSecret) == 0 {
+			return nil, ErrInvalidToken
+		}
+		return s.jwtSecret, nil
+	}
+	if s.keys == nil {
+		return nil, ErrInvalidToken
+	}
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.keys.Get(kid)
+	if !ok || key.Method != token.Method {
+		return nil, ErrInvalidToken
+	}
+	return key.Private.Public(), nil
+}
+
+// JWKS lists the public keys that verify access tokens.
+func (s *AuthService) JWKS() []keys.JWK {
+	if s.keys == nil {
+		return nil
+	}
+	return s.keys.JWKS()
 }
 
 func (s *AuthService) Register(login, password string) error {
+	if err := s.policy.Check(login, password); err != nil {
+		return err
+	}
 	user := &domain.User{
 		Login:    login,
 		Password: password,
@@ -82,3 +308,56 @@ func (s *AuthService) Register(login, password string) error {
 
 	return s.userRepo.CreateUser(user)
 }
+
+// ListUsers, SetUserRole and DeleteUser are limited to admins.
+func (s *AuthService) ListUsers(tokenString string) ([]domain.User, error) {
+	if _, err := s.requireAdmin(tokenString); err != nil {
+		return nil, err
+	}
+	return s.userRepo.ListUsers()
+}
+
+// SetUserRole bumps the user's token version so that tokens carrying the old
+// role stop working; the next refresh picks up the new one.
+func (s *AuthService) SetUserRole(tokenString, userID, role string) error {
+	claims, err := s.requireAdmin(tokenString)
+	if err != nil {
+		return err
+	}
+	if !domain.ValidRole(role) {
+		return domain.ErrInvalidRole
+	}
+	if claims.UserID == userID {
+		return ErrSelfManagement
+	}
+	if err := s.userRepo.SetUserRole(userID, role); err != nil {
+		return err
+	}
+	_, err = s.revocations.BumpTokenVersion(userID)
+	return err
+}
+
+func (s *AuthService) DeleteUser(tokenString, userID string) error {
+	claims, err := s.requireAdmin(tokenString)
+	if err != nil {
+		return err
+	}
+	if claims.UserID == userID {
+		return ErrSelfManagement
+	}
+	if _, err := s.revocations.BumpTokenVersion(userID); err != nil {
+		return err
+	}
+	return s.userRepo.DeleteUser(userID)
+}
+
+func (s *AuthService) requireAdmin(tokenString string) (*Claims, error) {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Role != domain.RoleAdmin {
+		return nil, ErrPermissionDenied
+	}
+	return claims, nil
+}
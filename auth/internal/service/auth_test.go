@@ -0,0 +1,399 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"auth/internal/domain"
+	"auth/internal/password"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetUserByLogin(login string) (*domain.User, error) {
+	args := m.Called(login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByID(id string) (*domain.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateUser(user *domain.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ListUsers() ([]domain.User, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) SetUserRole(id, role string) error {
+	args := m.Called(id, role)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetPassword(id, passwordHash string) error {
+	args := m.Called(id, passwordHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) DeleteUser(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type MockSpaceRepository struct {
+	mock.Mock
+}
+
+func (m *MockSpaceRepository) CreateSpace(space *domain.Space) error {
+	args := m.Called(space)
+	return args.Error(0)
+}
+
+func (m *MockSpaceRepository) GetSpace(id string) (*domain.Space, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Space), args.Error(1)
+}
+
+func (m *MockSpaceRepository) ListSpaces(userID string) ([]domain.Space, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]domain.Space), args.Error(1)
+}
+
+func (m *MockSpaceRepository) IsMember(spaceID, userID string) (bool, error) {
+	args := m.Called(spaceID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSpaceRepository) ListMembers(spaceID string) ([]domain.SpaceMember, error) {
+	args := m.Called(spaceID)
+	return args.Get(0).([]domain.SpaceMember), args.Error(1)
+}
+
+func (m *MockSpaceRepository) RemoveMember(spaceID, userID string) error {
+	args := m.Called(spaceID, userID)
+	return args.Error(0)
+}
+
+func (m *MockSpaceRepository) CreateInvitation(invitation *domain.Invitation) error {
+	args := m.Called(invitation)
+	return args.Error(0)
+}
+
+func (m *MockSpaceRepository) ListInvitations(inviteeID string) ([]domain.Invitation, error) {
+	args := m.Called(inviteeID)
+	return args.Get(0).([]domain.Invitation), args.Error(1)
+}
+
+func (m *MockSpaceRepository) AcceptInvitation(id, inviteeID string) (string, error) {
+	args := m.Called(id, inviteeID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSpaceRepository) DeclineInvitation(id, inviteeID string) error {
+	args := m.Called(id, inviteeID)
+	return args.Error(0)
+}
+
+func (m *MockSpaceRepository) SetActiveSpace(userID, spaceID string) error {
+	args := m.Called(userID, spaceID)
+	return args.Error(0)
+}
+
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenRepository) CreateRefreshToken(token *domain.RefreshToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) RotateRefreshToken(tokenHash string, next *domain.RefreshToken) error {
+	args := m.Called(tokenHash, next)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) RevokeRefreshToken(tokenHash string, userID string) error {
+	args := m.Called(tokenHash, userID)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) RevokeUserRefreshTokens(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) DeleteExpiredRefreshTokens(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockRevocationRepository struct {
+	mock.Mock
+}
+
+func (m *MockRevocationRepository) RevokeToken(jti string, userID string, expiresAt time.Time) error {
+	args := m.Called(jti, userID, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockRevocationRepository) IsTokenRevoked(jti string) (bool, error) {
+	args := m.Called(jti)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRevocationRepository) GetTokenVersion(userID string) (int, error) {
+	args := m.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRevocationRepository) BumpTokenVersion(userID string) (int, error) {
+	args := m.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRevocationRepository) DeleteExpiredRevocations(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockLoginAttemptRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoginAttemptRepository) LockedUntil(scope, key string) (time.Time, error) {
+	args := m.Called(scope, key)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockLoginAttemptRepository) RecordFailure(scope, key string, window time.Duration) (int, error) {
+	args := m.Called(scope, key, window)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockLoginAttemptRepository) Lock(scope, key string, until time.Time) error {
+	args := m.Called(scope, key, until)
+	return args.Error(0)
+}
+
+func (m *MockLoginAttemptRepository) Reset(scope, key string) (bool, error) {
+	args := m.Called(scope, key)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockAuthEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuthEventRepository) RecordEvent(event *domain.AuthEvent) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+func (m *MockAuthEventRepository) ListEvents(filter domain.AuthEventFilter) ([]domain.AuthEvent, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]domain.AuthEvent), args.Error(1)
+}
+
+type MockPasswordResetRepository struct {
+	mock.Mock
+}
+
+func (m *MockPasswordResetRepository) CreatePasswordReset(reset *domain.PasswordReset) error {
+	args := m.Called(reset)
+	return args.Error(0)
+}
+
+func (m *MockPasswordResetRepository) PasswordResetUser(tokenHash string) (string, error) {
+	args := m.Called(tokenHash)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPasswordResetRepository) ResetPassword(tokenHash, passwordHash string) (string, error) {
+	args := m.Called(tokenHash, passwordHash)
+	return args.String(0), args.Error(1)
+}
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) CreateAPIKey(key *domain.APIKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) ListAPIKeys(userID string) ([]domain.APIKey, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) RevokeAPIKey(id, userID string) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) UseAPIKey(keyHash string) (*domain.APIKey, error) {
+	args := m.Called(keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+const testSecret = "test-secret"
+
+type testRepos struct {
+	users       *MockUserRepository
+	spaces      *MockSpaceRepository
+	refresh     *MockRefreshTokenRepository
+	revocations *MockRevocationRepository
+	attempts    *MockLoginAttemptRepository
+	events      *MockAuthEventRepository
+	resets      *MockPasswordResetRepository
+	apiKeys     *MockAPIKeyRepository
+}
+
+func newTestService(t *testing.T) (*AuthService, testRepos) {
+	policy, err := password.NewPolicy(8, "")
+	require.NoError(t, err)
+	r := testRepos{
+		users:       &MockUserRepository{},
+		spaces:      &MockSpaceRepository{},
+		refresh:     &MockRefreshTokenRepository{},
+		revocations: &MockRevocationRepository{},
+		attempts:    &MockLoginAttemptRepository{},
+		events:      &MockAuthEventRepository{},
+		resets:      &MockPasswordResetRepository{},
+		apiKeys:     &MockAPIKeyRepository{},
+	}
+	s := NewAuthService(r.users, r.spaces, r.refresh, r.revocations, nil, r.attempts, r.events, r.resets, r.apiKeys, nil, policy, testSecret, nil)
+	return s, r
+}
+
+func TestAuthService_parseToken(t *testing.T) {
+	user := &domain.User{ID: "u1", Login: "alice", Role: domain.RoleOwner}
+
+	tests := []struct {
+		name      string
+		mockSetup func(r testRepos)
+		token     func(t *testing.T, s *AuthService) string
+		expectErr error
+	}{
+		{
+			name: "valid token",
+			mockSetup: func(r testRepos) {
+				r.revocations.On("IsTokenRevoked", mock.Anything).Return(false, nil)
+			},
+		},
+		{
+			name: "revoked jti",
+			mockSetup: func(r testRepos) {
+				r.revocations.On("IsTokenRevoked", mock.Anything).Return(true, nil)
+			},
+			expectErr: ErrInvalidToken,
+		},
+		{
+			name: "token version bumped",
+			mockSetup: func(r testRepos) {
+				r.revocations.On("IsTokenRevoked", mock.Anything).Return(false, nil)
+				r.revocations.On("GetTokenVersion", "u1").Return(4, nil).Once()
+			},
+			expectErr: ErrInvalidToken,
+		},
+		{
+			name: "user deleted",
+			mockSetup: func(r testRepos) {
+				r.revocations.On("IsTokenRevoked", mock.Anything).Return(false, nil)
+				r.revocations.On("GetTokenVersion", "u1").Return(0, domain.ErrUserNotFound).Once()
+			},
+			expectErr: ErrInvalidToken,
+		},
+		{
+			name: "denylist unavailable",
+			mockSetup: func(r testRepos) {
+				r.revocations.On("IsTokenRevoked", mock.Anything).Return(false, errors.New("connection refused"))
+			},
+			expectErr: errors.New("check revocation: connection refused"),
+		},
+		{
+			name:      "signed with another secret",
+			mockSetup: func(r testRepos) {},
+			token: func(t *testing.T, s *AuthService) string {
+				claims := Claims{UserID: "u1", TokenVersion: 3, RegisteredClaims: jwt.RegisteredClaims{
+					ID:        "jti-1",
+					ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+				}}
+				token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("other-secret"))
+				require.NoError(t, err)
+				return token
+			},
+			expectErr: ErrInvalidToken,
+		},
+		{
+			name:      "expired",
+			mockSetup: func(r testRepos) {},
+			token: func(t *testing.T, s *AuthService) string {
+				claims := Claims{UserID: "u1", TokenVersion: 3, RegisteredClaims: jwt.RegisteredClaims{
+					ID:        "jti-1",
+					ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+				}}
+				token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+				require.NoError(t, err)
+				return token
+			},
+			expectErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, r := newTestService(t)
+			var token string
+			if tt.token != nil {
+				token = tt.token(t, s)
+			} else {
+				r.revocations.On("GetTokenVersion", "u1").Return(3, nil).Once()
+				var err error
+				token, err = s.accessToken(user)
+				require.NoError(t, err)
+			}
+			tt.mockSetup(r)
+			r.revocations.On("GetTokenVersion", "u1").Return(3, nil)
+
+			claims, err := s.parseToken(token)
+
+			if tt.expectErr != nil {
+				assert.EqualError(t, err, tt.expectErr.Error())
+				assert.Nil(t, claims)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "u1", claims.UserID)
+				assert.Equal(t, domain.RoleOwner, claims.Role)
+				assert.Equal(t, 3, claims.TokenVersion)
+			}
+		})
+	}
+}
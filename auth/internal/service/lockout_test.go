@@ -0,0 +1,27 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockoutDuration(t *testing.T) {
+	tests := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{failures: 0, expected: 0},
+		{failures: 5, expected: 0},
+		{failures: 6, expected: baseLockout},
+		{failures: 7, expected: 2 * baseLockout},
+		{failures: 8, expected: 4 * baseLockout},
+		{failures: 100, expected: maxLockout},
+		{failures: 1 << 30, expected: maxLockout},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, lockoutDuration(tt.failures, 5), "failures %d", tt.failures)
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"auth/internal/domain"
+	"errors"
+	"time"
+)
+
+// ChangePassword ends every session of the user, the caller's included.
+func (s *AuthService) ChangePassword(tokenString, oldPassword, newPassword string) error {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return err
+	}
+	user, err := s.userRepo.GetUserByID(claims.UserID)
+	if err != nil {
+		return err
+	}
+	if !user.CheckPassword(oldPassword) {
+		return domain.ErrWrongPassword
+	}
+	if err := s.policy.Check(user.Login, newPassword); err != nil {
+		return err
+	}
+	hash, err := domain.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := s.userRepo.SetPassword(user.ID, hash); err != nil {
+		return err
+	}
+	return s.endSessions(user, domain.EventPasswordChanged)
+}
+
+// RequestPasswordReset sends a reset token through the notifier. It succeeds
+// for unknown logins too, so that it cannot be used to probe for accounts.
+func (s *AuthService) RequestPasswordReset(login string) error {
+	user, err := s.userRepo.GetUserByLogin(login)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	token, err := domain.NewRefreshToken()
+	if err != nil {
+		return err
+	}
+	reset := &domain.PasswordReset{
+		UserID:    user.ID,
+		TokenHash: domain.HashRefreshToken(token),
+		ExpiresAt: time.Now().Add(s.resetExpiry),
+	}
+	if err := s.resets.CreatePasswordReset(reset); err != nil {
+		return err
+	}
+	return s.notifier.PasswordReset(user.Login, token, reset.ExpiresAt)
+}
+
+// ResetPassword sets a new password with a token from RequestPasswordReset.
+// It also lifts a lockout of the login and ends every session.
+func (s *AuthService) ResetPassword(resetToken, newPassword string) error {
+	tokenHash := domain.HashRefreshToken(resetToken)
+	userID, err := s.resets.PasswordResetUser(tokenHash)
+	if err != nil {
+		return err
+	}
+	user, err := s.userRepo.GetUserByID(userID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.ErrResetTokenInvalid
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.policy.Check(user.Login, newPassword); err != nil {
+		return err
+	}
+	hash, err := domain.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	if _, err := s.resets.ResetPassword(tokenHash, hash); err != nil {
+		return err
+	}
+	if _, err := s.attempts.Reset(domain.AttemptScopeLogin, user.Login); err != nil {
+		return err
+	}
+	return s.endSessions(user, domain.EventPasswordReset)
+}
+
+func (s *AuthService) endSessions(user *domain.User, eventType string) error {
+	if _, err := s.revocations.BumpTokenVersion(user.ID); err != nil {
+		return err
+	}
+	if err := s.refreshRepo.RevokeUserRefreshTokens(user.ID); err != nil {
+		return err
+	}
+	return s.authEvents.RecordEvent(&domain.AuthEvent{Type: eventType, Login: user.Login, ActorUserID: user.ID})
+}
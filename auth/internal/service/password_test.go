@@ -0,0 +1,83 @@
+package service
+
+import (
+	"testing"
+
+	"auth/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthService_ResetPassword(t *testing.T) {
+	tokenHash := domain.HashRefreshToken("reset-token")
+	user := &domain.User{ID: "u1", Login: "alice"}
+
+	tests := []struct {
+		name      string
+		mockSetup func(r testRepos)
+		expectErr error
+	}{
+		{
+			name: "first use",
+			mockSetup: func(r testRepos) {
+				r.resets.On("PasswordResetUser", tokenHash).Return("u1", nil)
+				r.users.On("GetUserByID", "u1").Return(user, nil)
+				r.resets.On("ResetPassword", tokenHash, mock.AnythingOfType("string")).Return("u1", nil)
+				r.attempts.On("Reset", domain.AttemptScopeLogin, "alice").Return(true, nil)
+				r.revocations.On("BumpTokenVersion", "u1").Return(2, nil)
+				r.refresh.On("RevokeUserRefreshTokens", "u1").Return(nil)
+				r.events.On("RecordEvent", mock.MatchedBy(func(e *domain.AuthEvent) bool {
+					return e.Type == domain.EventPasswordReset && e.Login == "alice" && e.ActorUserID == "u1"
+				})).Return(nil)
+			},
+		},
+		{
+			name: "token already used",
+			mockSetup: func(r testRepos) {
+				r.resets.On("PasswordResetUser", tokenHash).Return("", domain.ErrResetTokenInvalid)
+			},
+			expectErr: domain.ErrResetTokenInvalid,
+		},
+		{
+			name: "token used concurrently",
+			mockSetup: func(r testRepos) {
+				r.resets.On("PasswordResetUser", tokenHash).Return("u1", nil)
+				r.users.On("GetUserByID", "u1").Return(user, nil)
+				r.resets.On("ResetPassword", tokenHash, mock.AnythingOfType("string")).Return("", domain.ErrResetTokenInvalid)
+			},
+			expectErr: domain.ErrResetTokenInvalid,
+		},
+		{
+			name: "user deleted",
+			mockSetup: func(r testRepos) {
+				r.resets.On("PasswordResetUser", tokenHash).Return("u1", nil)
+				r.users.On("GetUserByID", "u1").Return(nil, domain.ErrUserNotFound)
+			},
+			expectErr: domain.ErrResetTokenInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, r := newTestService(t)
+			tt.mockSetup(r)
+
+			err := s.ResetPassword("reset-token", "correct horse battery")
+
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				r.revocations.AssertNotCalled(t, "BumpTokenVersion", mock.Anything)
+				r.refresh.AssertNotCalled(t, "RevokeUserRefreshTokens", mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			r.resets.AssertExpectations(t)
+			r.users.AssertExpectations(t)
+			r.attempts.AssertExpectations(t)
+			r.revocations.AssertExpectations(t)
+			r.refresh.AssertExpectations(t)
+			r.events.AssertExpectations(t)
+		})
+	}
+}
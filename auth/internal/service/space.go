@@ -0,0 +1,173 @@
+package service
+
+import (
+	"auth/internal/domain"
+	"time"
+)
+
+func (s *AuthService) CreateSpace(tokenString, name string) (*domain.Space, error) {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	space := &domain.Space{Name: name, OwnerID: claims.UserID}
+	if err := s.spaceRepo.CreateSpace(space); err != nil {
+		return nil, err
+	}
+	return space, nil
+}
+
+func (s *AuthService) ListSpaces(tokenString string) ([]domain.Space, error) {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	return s.spaceRepo.ListSpaces(claims.UserID)
+}
+
+// ListSpaceMembers lets members map the user IDs transactions are attributed
+// to onto logins.
+func (s *AuthService) ListSpaceMembers(tokenString, spaceID string) ([]domain.SpaceMember, error) {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.memberSpace(spaceID, claims.UserID); err != nil {
+		return nil, err
+	}
+	return s.spaceRepo.ListMembers(spaceID)
+}
+
+// InviteToSpace is limited to the space owner.
+func (s *AuthService) InviteToSpace(tokenString, spaceID, login string) (*domain.Invitation, error) {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	space, err := s.memberSpace(spaceID, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if space.OwnerID != claims.UserID {
+		return nil, ErrPermissionDenied
+	}
+	invitee, err := s.userRepo.GetUserByLogin(login)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.spaceRepo.IsMember(spaceID, invitee.ID)
+	if err != nil {
+		return nil, err
+	}
+	if member {
+		return nil, domain.ErrAlreadyMember
+	}
+	inviter, err := s.userRepo.GetUserByID(claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	invitation := &domain.Invitation{
+		SpaceID:      spaceID,
+		SpaceName:    space.Name,
+		InviteeID:    invitee.ID,
+		InvitedBy:    inviter.ID,
+		InviterLogin: inviter.Login,
+	}
+	if err := s.spaceRepo.CreateInvitation(invitation); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func (s *AuthService) ListInvitations(tokenString string) ([]domain.Invitation, error) {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	return s.spaceRepo.ListInvitations(claims.UserID)
+}
+
+// AcceptInvitation returns the space joined; it does not switch to it.
+func (s *AuthService) AcceptInvitation(tokenString, invitationID string) (*domain.Space, error) {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	spaceID, err := s.spaceRepo.AcceptInvitation(invitationID, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.spaceRepo.GetSpace(spaceID)
+}
+
+func (s *AuthService) DeclineInvitation(tokenString, invitationID string) error {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return err
+	}
+	return s.spaceRepo.DeclineInvitation(invitationID, claims.UserID)
+}
+
+// SwitchSpace makes spaceID the active space of the user, or the personal
+// ledger when it is empty, and returns an access token for it. The choice is
+// kept, so refreshed tokens stay in the same space.
+func (s *AuthService) SwitchSpace(tokenString, spaceID string) (string, time.Duration, error) {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return "", 0, err
+	}
+	if spaceID != "" {
+		if _, err := s.memberSpace(spaceID, claims.UserID); err != nil {
+			return "", 0, err
+		}
+	}
+	if err := s.spaceRepo.SetActiveSpace(claims.UserID, spaceID); err != nil {
+		return "", 0, err
+	}
+	user, err := s.userRepo.GetUserByID(claims.UserID)
+	if err != nil {
+		return "", 0, err
+	}
+	accessToken, err := s.accessToken(user)
+	if err != nil {
+		return "", 0, err
+	}
+	return accessToken, s.tokenExpiry, nil
+}
+
+// RemoveSpaceMember lets the owner remove a member and any other member
+// leave. The removed user's token version is bumped so that tokens still
+// scoped to the space stop working.
+func (s *AuthService) RemoveSpaceMember(tokenString, spaceID, userID string) error {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return err
+	}
+	space, err := s.memberSpace(spaceID, claims.UserID)
+	if err != nil {
+		return err
+	}
+	if userID == space.OwnerID {
+		return domain.ErrOwnerCannotLeave
+	}
+	if claims.UserID != userID && claims.UserID != space.OwnerID {
+		return ErrPermissionDenied
+	}
+	if err := s.spaceRepo.RemoveMember(spaceID, userID); err != nil {
+		return err
+	}
+	_, err = s.revocations.BumpTokenVersion(userID)
+	return err
+}
+
+// memberSpace hides spaces the user is not a member of.
+func (s *AuthService) memberSpace(spaceID, userID string) (*domain.Space, error) {
+	member, err := s.spaceRepo.IsMember(spaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !member {
+		return nil, domain.ErrSpaceNotFound
+	}
+	return s.spaceRepo.GetSpace(spaceID)
+}
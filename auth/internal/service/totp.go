@@ -0,0 +1,181 @@
+package service
+
+import (
+	"auth/internal/domain"
+	"auth/internal/totp"
+	"errors"
+	"strings"
+	"time"
+)
+
+const totpIssuer = "ledger"
+
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// EnrollTOTP generates a new secret for the user. 2FA stays off until
+// ActivateTOTP confirms the authenticator app has it.
+func (s *AuthService) EnrollTOTP(tokenString string) (*TOTPEnrollment, error) {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetUserByID(claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.totpRepo.SetTOTPSecret(user.ID, secret); err != nil {
+		return nil, err
+	}
+	return &TOTPEnrollment{Secret: secret, URI: totp.URI(totpIssuer, user.Login, secret)}, nil
+}
+
+// ActivateTOTP turns 2FA on and returns the recovery codes, which are not
+// shown again.
+func (s *AuthService) ActivateTOTP(tokenString, code string) ([]string, error) {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	secret, enabled, err := s.totpRepo.GetTOTPSecret(claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, domain.ErrTOTPAlreadyEnabled
+	}
+	if err := s.checkTOTP(claims.UserID, secret, code); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, domain.RecoveryCodeCount)
+	hashes := make([]string, 0, domain.RecoveryCodeCount)
+	for range domain.RecoveryCodeCount {
+		code, err := domain.NewRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, domain.HashRecoveryCode(code))
+	}
+	if err := s.totpRepo.EnableTOTP(claims.UserID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTOTP takes a TOTP or recovery code, so a stolen access token alone
+// cannot turn 2FA off.
+func (s *AuthService) DisableTOTP(tokenString, code string) error {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return err
+	}
+	secret, enabled, err := s.totpRepo.GetTOTPSecret(claims.UserID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return domain.ErrTOTPNotEnrolled
+	}
+	if err := s.checkCode(claims.UserID, secret, code); err != nil {
+		return err
+	}
+	return s.totpRepo.DisableTOTP(claims.UserID)
+}
+
+// VerifyLogin completes a login of a user with 2FA: it exchanges the
+// challenge token from Login and a TOTP or recovery code for the tokens.
+// Wrong codes count as failed logins of the user's login and of ip, like
+// wrong passwords.
+func (s *AuthService) VerifyLogin(challengeToken, code, ip string) (*Tokens, error) {
+	tokenHash := domain.HashRefreshToken(challengeToken)
+	userID, err := s.totpRepo.ChallengeAttempt(tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	secret, enabled, err := s.totpRepo.GetTOTPSecret(userID)
+	if errors.Is(err, domain.ErrTOTPNotEnrolled) || errors.Is(err, domain.ErrUserNotFound) || (err == nil && !enabled) {
+		return nil, domain.ErrChallengeInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetUserByID(userID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, domain.ErrChallengeInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkLockout(user.Login, ip); err != nil {
+		return nil, err
+	}
+	if err := s.checkCode(userID, secret, code); err != nil {
+		if errors.Is(err, domain.ErrInvalidCode) {
+			if err := s.recordFailure(user.Login, ip); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+	if err := s.totpRepo.DeleteChallenge(tokenHash); err != nil {
+		return nil, err
+	}
+	return s.completeLogin(user)
+}
+
+// loginChallenge uses tokens of the same form as refresh tokens.
+func (s *AuthService) loginChallenge(userID string) (*Tokens, error) {
+	token, err := domain.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	err = s.totpRepo.CreateChallenge(&domain.LoginChallenge{
+		UserID:    userID,
+		TokenHash: domain.HashRefreshToken(token),
+		ExpiresAt: time.Now().Add(s.challengeExpiry),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Tokens{ChallengeToken: token, ExpiresIn: s.challengeExpiry}, nil
+}
+
+// checkCode accepts a TOTP code or an unused recovery code.
+func (s *AuthService) checkCode(userID, secret, code string) error {
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) == totp.Digits {
+		return s.checkTOTP(userID, secret, code)
+	}
+	ok, err := s.totpRepo.UseRecoveryCode(userID, domain.HashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrInvalidCode
+	}
+	return nil
+}
+
+// checkTOTP rejects a code whose time step was already used.
+func (s *AuthService) checkTOTP(userID, secret, code string) error {
+	step, ok := totp.Validate(secret, strings.ReplaceAll(code, " ", ""), time.Now())
+	if !ok {
+		return domain.ErrInvalidCode
+	}
+	fresh, err := s.totpRepo.UseTOTPStep(userID, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return domain.ErrInvalidCode
+	}
+	return nil
+}
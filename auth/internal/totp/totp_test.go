@@ -0,0 +1,111 @@
+package totp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfc6238Secret is the SHA1 key of the RFC 6238 test vectors,
+// "12345678901234567890", in base32.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	key := []byte("12345678901234567890")
+
+	// RFC 6238 appendix B, SHA1, truncated to six digits.
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{unix: 59, expected: "287082"},
+		{unix: 1111111109, expected: "081804"},
+		{unix: 1111111111, expected: "050471"},
+		{unix: 1234567890, expected: "005924"},
+		{unix: 2000000000, expected: "279037"},
+		{unix: 20000000000, expected: "353130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Code(key, Step(time.Unix(tt.unix, 0))))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		secret       string
+		code         string
+		at           int64
+		expectedStep int64
+		expectedOK   bool
+	}{
+		{
+			name:         "current step",
+			secret:       rfc6238Secret,
+			code:         "081804",
+			at:           1111111109,
+			expectedStep: 37037036,
+			expectedOK:   true,
+		},
+		{
+			name:         "lowercase secret",
+			secret:       "gezdgnbvgy3tqojqgezdgnbvgy3tqojq",
+			code:         "081804",
+			at:           1111111109,
+			expectedStep: 37037036,
+			expectedOK:   true,
+		},
+		{
+			name:         "previous step within skew",
+			secret:       rfc6238Secret,
+			code:         "081804",
+			at:           1111111109 + 30,
+			expectedStep: 37037036,
+			expectedOK:   true,
+		},
+		{
+			name:         "next step within skew",
+			secret:       rfc6238Secret,
+			code:         "081804",
+			at:           1111111109 - 30,
+			expectedStep: 37037036,
+			expectedOK:   true,
+		},
+		{
+			name:   "outside skew",
+			secret: rfc6238Secret,
+			code:   "081804",
+			at:     1111111109 + 60,
+		},
+		{
+			name:   "wrong code",
+			secret: rfc6238Secret,
+			code:   "000000",
+			at:     1111111109,
+		},
+		{
+			name:   "wrong length",
+			secret: rfc6238Secret,
+			code:   "81804",
+			at:     1111111109,
+		},
+		{
+			name:   "invalid secret",
+			secret: "not base32!",
+			code:   "081804",
+			at:     1111111109,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := Validate(tt.secret, tt.code, time.Unix(tt.at, 0))
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedStep, step)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE login_failures (
+    scope TEXT NOT NULL CHECK (scope IN ('login', 'ip')),
+    key TEXT NOT NULL,
+    failures INT NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    locked_until TIMESTAMPTZ,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE TABLE auth_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    login TEXT NOT NULL DEFAULT '',
+    ip TEXT NOT NULL DEFAULT '',
+    actor_user_id TEXT NOT NULL DEFAULT '',
+    failures INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX auth_events_login_idx ON auth_events(login, id);
+CREATE INDEX auth_events_ip_idx ON auth_events(ip, id);
+
+CREATE FUNCTION auth_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'auth_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER auth_events_no_update_delete BEFORE UPDATE OR DELETE ON auth_events
+    FOR EACH ROW EXECUTE FUNCTION auth_events_append_only();
+CREATE TRIGGER auth_events_no_truncate BEFORE TRUNCATE ON auth_events
+    FOR EACH STATEMENT EXECUTE FUNCTION auth_events_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE auth_events;
+DROP FUNCTION auth_events_append_only();
+DROP TABLE login_failures;
+-- +goose StatementEnd
@@ -2,7 +2,11 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"gateway/internal/auth"
 	"gateway/internal/config"
 	"gateway/internal/handler"
 	authv1 "gateway/internal/pb/auth/v1"
@@ -21,6 +25,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 func ping(c *gin.Context) {
@@ -36,46 +41,97 @@ func loggingMiddleware() gin.HandlerFunc {
 	}
 }
 
+// requestIDMiddleware keeps the caller's X-Request-ID or issues a new one and
+// forwards it to the backends, where it ends up in the audit log.
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader("X-Request-ID")
+		if requestID == "" || len(requestID) > 128 {
+			b := make([]byte, 16)
+			_, _ = rand.Read(b)
+			requestID = hex.EncodeToString(b)
+		}
+		c.Header("X-Request-ID", requestID)
+		c.Request = c.Request.WithContext(metadata.AppendToOutgoingContext(c.Request.Context(), "x-request-id", requestID))
+		c.Next()
+	}
+}
+
+// clientIPMiddleware passes the client address on to the backends, which
+// otherwise only see the gateway; auth counts failed logins per IP, so
+// X-Forwarded-For only counts from the engine's trusted proxies.
+func clientIPMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(metadata.AppendToOutgoingContext(c.Request.Context(), "x-real-ip", c.ClientIP()))
+		c.Next()
+	}
+}
+
 func timeoutResponse(c *gin.Context) {
 	c.String(http.StatusRequestTimeout, "timeout")
 }
 
-func timeoutMiddleware() gin.HandlerFunc {
-	return timeout.New(
-		timeout.WithTimeout(2*time.Second),
+// timeoutMiddleware buffers the whole response, so the event stream is
+// exempt from it. Attachments get their own limit, and the connection read
+// and write deadlines are pushed out to match it.
+func timeoutMiddleware(requestTimeout, attachmentTimeout time.Duration) gin.HandlerFunc {
+	withTimeout := timeout.New(
+		timeout.WithTimeout(requestTimeout),
+		timeout.WithResponse(timeoutResponse),
+	)
+	withAttachmentTimeout := timeout.New(
+		timeout.WithTimeout(attachmentTimeout),
 		timeout.WithResponse(timeoutResponse),
 	)
+	return func(c *gin.Context) {
+		switch {
+		case c.Request.URL.Path == "/api/events":
+			c.Next()
+		case strings.HasPrefix(c.FullPath(), "/api/transactions/:id/attachments"):
+			deadline := time.Now().Add(attachmentTimeout)
+			rc := http.NewResponseController(c.Writer)
+			_ = rc.SetReadDeadline(deadline)
+			_ = rc.SetWriteDeadline(deadline)
+			withAttachmentTimeout(c)
+		default:
+			withTimeout(c)
+		}
+	}
 }
 
-func authMiddleware(authConn authv1.AuthServiceClient) gin.HandlerFunc {
+func authMiddleware(verifier *auth.Verifier, permissions auth.RoutePermissions) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if c.Request.URL.Path == "/ping" {
+		if p, _ := permissions.Permission(c); p == auth.PermPublic {
 			c.Next()
 			return
 		}
-		token := c.GetHeader("Authorization")
-		if token == "" {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Invalid auth token"})
-			c.Abort()
-			return
+		header := c.GetHeader("Authorization")
+		var identity auth.Identity
+		var err error
+		switch {
+		case strings.HasPrefix(header, "Bearer "):
+			identity, err = verifier.Verify(c.Request.Context(), header[len("Bearer "):])
+		case strings.HasPrefix(header, "ApiKey "):
+			identity, err = verifier.VerifyAPIKey(c.Request.Context(), header[len("ApiKey "):])
+		default:
+			err = auth.ErrInvalidToken
 		}
-		has := strings.HasPrefix(token, "Bearer")
-		if !has {
+		if errors.Is(err, auth.ErrInvalidToken) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Invalid auth token"})
 			c.Abort()
 			return
 		}
-		resp, err := authConn.ValidateToken(c, &authv1.ValidateTokenRequest{Token: token[7:]})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Auth server down"})
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Auth server down"})
 			c.Abort()
 			return
 		}
-		if !resp.Valid {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Invalid auth token"})
-			c.Abort()
-			return
+		auth.SetIdentity(c, identity)
+		md := []string{"x-user-id", identity.UserID}
+		if identity.SpaceID != "" {
+			md = append(md, "x-space-id", identity.SpaceID)
 		}
+		c.Request = c.Request.WithContext(metadata.AppendToOutgoingContext(c.Request.Context(), md...))
 		c.Next()
 	}
 }
@@ -103,9 +159,18 @@ func main() {
 
 	ledgerService := service.NewLedgerGatewayService(ledgerv1.NewLedgerServiceClient(conn))
 	ledgerHandler := handler.NewLedgerHandler(ledgerService)
+	authClient := authv1.NewAuthServiceClient(authConn)
+	authHandler := handler.NewAuthHandler(service.NewAuthGatewayService(authClient))
+	verifier := auth.NewVerifier(authClient, cfg.Auth.JWTSecret, cfg.Auth.CacheTTL, cfg.Auth.RecheckInterval)
+	go verifier.Run(ctx, cfg.Auth.KeysInterval)
 	engine := gin.New()
-	engine.Use(gin.Logger(), gin.Recovery(), loggingMiddleware(), timeoutMiddleware(), authMiddleware(authv1.NewAuthServiceClient(authConn)))
+	if err := engine.SetTrustedProxies(cfg.HTTP.TrustedProxies); err != nil {
+		log.Fatalf("invalid TRUSTED_PROXIES: %v", err)
+	}
+	engine.Use(gin.Logger(), gin.Recovery(), loggingMiddleware(), requestIDMiddleware(), clientIPMiddleware(), timeoutMiddleware(cfg.HTTP.RequestTimeout, cfg.HTTP.AttachmentTimeout),
+		authMiddleware(verifier, handler.RoutePermissions), auth.Authorize(handler.RoutePermissions))
 	engine.GET("/ping", ping)
+	engine.GET("/.well-known/jwks.json", authHandler.JWKS)
 	engine.NoRoute(func(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{
 			"code":    http.StatusNotFound,
@@ -114,6 +179,7 @@ func main() {
 	})
 	api := engine.Group("/api")
 	ledgerHandler.Register(api)
+	authHandler.Register(api)
 
 	server := httpserver.New(cfg.HTTP, engine)
 
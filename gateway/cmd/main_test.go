@@ -0,0 +1,109 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestClientIPMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		expected       string
+	}{
+		{
+			name:       "spoofed header without trusted proxies",
+			remoteAddr: "203.0.113.7:41000",
+			expected:   "203.0.113.7",
+		},
+		{
+			name:           "spoofed header from untrusted peer",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "203.0.113.7:41000",
+			expected:       "203.0.113.7",
+		},
+		{
+			name:           "header from trusted proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.1.2.3:41000",
+			expected:       "198.51.100.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			require.NoError(t, engine.SetTrustedProxies(tt.trustedProxies))
+			var got []string
+			engine.Use(clientIPMiddleware())
+			engine.GET("/ip", func(c *gin.Context) {
+				md, _ := metadata.FromOutgoingContext(c.Request.Context())
+				got = md.Get("x-real-ip")
+				c.Status(http.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Forwarded-For", "198.51.100.1")
+			engine.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, []string{tt.expected}, got)
+		})
+	}
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		route    string
+		path     string
+		expected int
+	}{
+		{
+			name:     "regular route",
+			route:    "/api/transactions/:id",
+			path:     "/api/transactions/4",
+			expected: http.StatusRequestTimeout,
+		},
+		{
+			name:     "attachment upload",
+			route:    "/api/transactions/:id/attachments",
+			path:     "/api/transactions/4/attachments",
+			expected: http.StatusOK,
+		},
+		{
+			name:     "attachment download",
+			route:    "/api/transactions/:id/attachments/:attachment_id",
+			path:     "/api/transactions/4/attachments/1",
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.Use(timeoutMiddleware(20*time.Millisecond, time.Second))
+			engine.GET(tt.route, func(c *gin.Context) {
+				time.Sleep(100 * time.Millisecond)
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
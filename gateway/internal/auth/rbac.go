@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	RoleOwner  = "owner"
+	RoleMember = "member"
+	RoleViewer = "viewer"
+	RoleAdmin  = "admin"
+)
+
+type Permission string
+
+const (
+	// PermPublic routes skip authentication.
+	PermPublic Permission = "public"
+	// PermAuthenticated routes only need a valid token, whatever the role.
+	PermAuthenticated Permission = "authenticated"
+	PermRead          Permission = "read"
+	PermWrite         Permission = "write"
+	PermManageUsers   Permission = "manage_users"
+)
+
+var rolePermissions = map[string][]Permission{
+	RoleOwner:  {PermRead, PermWrite},
+	RoleMember: {PermRead, PermWrite},
+	RoleViewer: {PermRead},
+	RoleAdmin:  {PermRead, PermWrite, PermManageUsers},
+}
+
+// Identity is who a verified token belongs to and, for shared ledgers, the
+// space it acts in. Identities of API keys carry the key's ID and scopes.
+type Identity struct {
+	UserID   string
+	Role     string
+	SpaceID  string
+	APIKeyID string
+	Scopes   []string
+}
+
+// RouteScope is the API key scope a route needs: "<resource>:read" or
+// "<resource>:write" after the first path segment under /api and the
+// route's permission. Other routes have no scope and are closed to keys.
+func RouteScope(path string, p Permission) string {
+	var access string
+	switch p {
+	case PermRead:
+		access = "read"
+	case PermWrite:
+		access = "write"
+	default:
+		return ""
+	}
+	rest, ok := strings.CutPrefix(path, "/api/")
+	if !ok {
+		return ""
+	}
+	resource, _, _ := strings.Cut(rest, "/")
+	if resource == "" {
+		return ""
+	}
+	return resource + ":" + access
+}
+
+// HasScope reports whether an API key identity was granted scope.
+func (id Identity) HasScope(scope string) bool {
+	for _, s := range id.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Allowed reports whether role grants p. Unknown roles, including tokens
+// issued before roles existed, only get PermPublic and PermAuthenticated.
+func Allowed(role string, p Permission) bool {
+	if p == PermPublic || p == PermAuthenticated {
+		return true
+	}
+	for _, granted := range rolePermissions[role] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
+
+// RoutePermissions maps "METHOD /full/path", with the path as registered
+// (e.g. "GET /api/transactions/:id/attachments"), to the permission it needs.
+type RoutePermissions map[string]Permission
+
+func RouteKey(method, path string) string {
+	return method + " " + path
+}
+
+// Permission returns what the matched route of c needs. Routes missing from
+// the table are denied.
+func (t RoutePermissions) Permission(c *gin.Context) (Permission, bool) {
+	p, ok := t[RouteKey(c.Request.Method, c.FullPath())]
+	return p, ok
+}
+
+const identityKey = "auth.identity"
+
+func SetIdentity(c *gin.Context, id Identity) {
+	c.Set(identityKey, id)
+}
+
+func GetIdentity(c *gin.Context) (Identity, bool) {
+	v, ok := c.Get(identityKey)
+	if !ok {
+		return Identity{}, false
+	}
+	id, ok := v.(Identity)
+	return id, ok
+}
+
+// Authorize enforces the table on routes that run after authentication, and
+// for API keys also the route's scope. Unmatched requests fall through to
+// NoRoute.
+func Authorize(table RoutePermissions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.FullPath() == "" {
+			c.Next()
+			return
+		}
+		p, ok := table.Permission(c)
+		if !ok {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			c.Abort()
+			return
+		}
+		if p == PermPublic {
+			c.Next()
+			return
+		}
+		id, ok := GetIdentity(c)
+		if !ok || !Allowed(id.Role, p) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			c.Abort()
+			return
+		}
+		if id.APIKeyID != "" {
+			scope := RouteScope(c.FullPath(), p)
+			if scope == "" || !id.HasScope(scope) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
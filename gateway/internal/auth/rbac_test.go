@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	table := RoutePermissions{
+		"GET /ping":                   PermPublic,
+		"POST /api/auth/logout":       PermAuthenticated,
+		"GET /api/budget/":            PermRead,
+		"POST /api/budget/":           PermWrite,
+		"POST /api/transactions/bulk": PermWrite,
+		"GET /api/admin/users/":       PermManageUsers,
+		"DELETE /api/admin/users/:id": PermManageUsers,
+	}
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		if role := c.GetHeader("X-Test-Role"); role != "" {
+			SetIdentity(c, Identity{UserID: "u1", Role: role})
+		}
+	}, Authorize(table))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	engine.GET("/ping", ok)
+	engine.POST("/api/auth/logout", ok)
+	engine.GET("/api/budget/", ok)
+	engine.POST("/api/budget/", ok)
+	engine.POST("/api/transactions/bulk", ok)
+	engine.GET("/api/admin/users/", ok)
+	engine.DELETE("/api/admin/users/:id", ok)
+	engine.GET("/api/unlisted", ok)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		role           string
+		expectedStatus int
+	}{
+		{"public without identity", http.MethodGet, "/ping", "", http.StatusOK},
+		{"viewer reads", http.MethodGet, "/api/budget/", RoleViewer, http.StatusOK},
+		{"viewer cannot set budget", http.MethodPost, "/api/budget/", RoleViewer, http.StatusForbidden},
+		{"viewer cannot bulk import", http.MethodPost, "/api/transactions/bulk", RoleViewer, http.StatusForbidden},
+		{"viewer logs out", http.MethodPost, "/api/auth/logout", RoleViewer, http.StatusOK},
+		{"member sets budget", http.MethodPost, "/api/budget/", RoleMember, http.StatusOK},
+		{"owner bulk imports", http.MethodPost, "/api/transactions/bulk", RoleOwner, http.StatusOK},
+		{"member cannot manage users", http.MethodGet, "/api/admin/users/", RoleMember, http.StatusForbidden},
+		{"admin manages users", http.MethodDelete, "/api/admin/users/u2", RoleAdmin, http.StatusOK},
+		{"token without role", http.MethodGet, "/api/budget/", "none", http.StatusForbidden},
+		{"no identity", http.MethodGet, "/api/budget/", "", http.StatusForbidden},
+		{"route missing from table", http.MethodGet, "/api/unlisted", RoleAdmin, http.StatusForbidden},
+		{"unknown path falls through", http.MethodGet, "/api/nothing", RoleViewer, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			if tt.role != "" {
+				req.Header.Set("X-Test-Role", tt.role)
+			}
+
+			engine.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestAuthorize_APIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	table := RoutePermissions{
+		"POST /api/auth/logout":                  PermAuthenticated,
+		"GET /api/transactions/list":             PermRead,
+		"POST /api/transactions/:id/attachments": PermWrite,
+		"GET /api/reports/summary":               PermRead,
+	}
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		SetIdentity(c, Identity{UserID: "u1", Role: c.GetHeader("X-Test-Role"), APIKeyID: "k1", Scopes: []string{"transactions:write", "reports:read"}})
+	}, Authorize(table))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	engine.POST("/api/auth/logout", ok)
+	engine.GET("/api/transactions/list", ok)
+	engine.POST("/api/transactions/:id/attachments", ok)
+	engine.GET("/api/reports/summary", ok)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		role           string
+		expectedStatus int
+	}{
+		{"scope granted", http.MethodPost, "/api/transactions/t1/attachments", RoleMember, http.StatusOK},
+		{"read scope granted", http.MethodGet, "/api/reports/summary", RoleMember, http.StatusOK},
+		{"write does not imply read", http.MethodGet, "/api/transactions/list", RoleMember, http.StatusForbidden},
+		{"role still applies", http.MethodPost, "/api/transactions/t1/attachments", RoleViewer, http.StatusForbidden},
+		{"routes without scope are closed", http.MethodPost, "/api/auth/logout", RoleMember, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("X-Test-Role", tt.role)
+
+			engine.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestRouteScope(t *testing.T) {
+	assert.Equal(t, "transactions:read", RouteScope("/api/transactions/:id/attachments", PermRead))
+	assert.Equal(t, "budget:write", RouteScope("/api/budget/", PermWrite))
+	assert.Equal(t, "events:read", RouteScope("/api/events", PermRead))
+	assert.Equal(t, "", RouteScope("/api/spaces/", PermAuthenticated))
+	assert.Equal(t, "", RouteScope("/api/admin/users/", PermManageUsers))
+	assert.Equal(t, "", RouteScope("/ping", PermRead))
+}
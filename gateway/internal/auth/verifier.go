@@ -0,0 +1,335 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	authv1 "gateway/internal/pb/auth/v1"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrUnavailable means the token could not be checked locally and the
+	// auth service did not answer either.
+	ErrUnavailable = errors.New("auth server unavailable")
+	errNoKey       = errors.New("no key to verify token")
+)
+
+const (
+	keyRefreshMinInterval = 30 * time.Second
+	remoteCheckTimeout    = time.Second
+	maxCachedTokens       = 10000
+)
+
+type claims struct {
+	UserID  string `json:"user_id"`
+	Role    string `json:"role"`
+	SpaceID string `json:"space_id"`
+	jwt.RegisteredClaims
+}
+
+type publicKey struct {
+	method jwt.SigningMethod
+	key    crypto.PublicKey
+}
+
+type entry struct {
+	identity      Identity
+	invalid       bool
+	validUntil    time.Time
+	expiresAt     time.Time
+	remoteChecked time.Time
+}
+
+// Verifier checks access tokens in the gateway with the keys the auth
+// service signs with: the shared HS256 secret and the public keys from its
+// JWKS. Results are cached for cacheTTL. With recheck set, a token is also
+// sent to ValidateToken once per recheck interval, which catches revoked
+// tokens; if auth does not answer, the local result stands.
+type Verifier struct {
+	client   authv1.AuthServiceClient
+	secret   []byte
+	cacheTTL time.Duration
+	recheck  time.Duration
+	now      func() time.Time
+
+	mu          sync.Mutex
+	keys        map[string]publicKey
+	keysFetched time.Time
+	keysErr     error
+	cache       map[string]*entry
+}
+
+func NewVerifier(client authv1.AuthServiceClient, secret string, cacheTTL time.Duration, recheck time.Duration) *Verifier {
+	return &Verifier{
+		client:   client,
+		secret:   []byte(secret),
+		cacheTTL: cacheTTL,
+		recheck:  recheck,
+		now:      time.Now,
+		keys:     make(map[string]publicKey),
+		cache:    make(map[string]*entry),
+	}
+}
+
+// Verify returns the user, role and space of a valid token.
+func (v *Verifier) Verify(ctx context.Context, token string) (Identity, error) {
+	now := v.now()
+	v.mu.Lock()
+	e, ok := v.cache[token]
+	var cached entry
+	if ok {
+		cached = *e
+	}
+	v.mu.Unlock()
+
+	if !ok || !now.Before(cached.validUntil) {
+		local, err := v.verifyLocal(ctx, token)
+		switch {
+		case errors.Is(err, errNoKey):
+			return v.verifyRemote(ctx, token, now)
+		case err != nil:
+			local = entry{invalid: true, expiresAt: now.Add(v.cacheTTL)}
+		}
+		local.remoteChecked = cached.remoteChecked
+		local.validUntil = now.Add(v.cacheTTL)
+		if local.validUntil.After(local.expiresAt) {
+			local.validUntil = local.expiresAt
+		}
+		cached = local
+		v.store(token, cached)
+	}
+	if cached.invalid {
+		return Identity{}, ErrInvalidToken
+	}
+	if v.recheck > 0 && now.Sub(cached.remoteChecked) >= v.recheck {
+		return v.recheckRemote(ctx, token, cached, now)
+	}
+	return cached.identity, nil
+}
+
+func (v *Verifier) verifyLocal(ctx context.Context, token string) (entry, error) {
+	c := &claims{}
+	_, err := jwt.ParseWithClaims(token, c, func(t *jwt.Token) (interface{}, error) {
+		return v.key(ctx, t)
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithExpirationRequired(), jwt.WithTimeFunc(v.now))
+	if errors.Is(err, errNoKey) {
+		return entry{}, errNoKey
+	}
+	if err != nil || c.UserID == "" {
+		return entry{}, ErrInvalidToken
+	}
+	return entry{identity: Identity{UserID: c.UserID, Role: c.Role, SpaceID: c.SpaceID}, expiresAt: c.ExpiresAt.Time}, nil
+}
+
+func (v *Verifier) key(ctx context.Context, t *jwt.Token) (interface{}, error) {
+	if t.Method == jwt.SigningMethodHS256 {
+		if len(v.secret) == 0 {
+			return nil, errNoKey
+		}
+		return v.secret, nil
+	}
+	kid, _ := t.Header["kid"].(string)
+	v.mu.Lock()
+	k, ok := v.keys[kid]
+	stale := v.now().Sub(v.keysFetched) >= keyRefreshMinInterval
+	keysErr := v.keysErr
+	v.mu.Unlock()
+	if !ok && !stale && keysErr != nil {
+		return nil, errNoKey
+	}
+	if !ok && stale {
+		if err := v.RefreshKeys(ctx); err != nil {
+			return nil, errNoKey
+		}
+		v.mu.Lock()
+		k, ok = v.keys[kid]
+		v.mu.Unlock()
+	}
+	if !ok || k.method != t.Method {
+		return nil, ErrInvalidToken
+	}
+	return k.key, nil
+}
+
+// verifyRemote is the fallback for tokens the gateway has no key for.
+func (v *Verifier) verifyRemote(ctx context.Context, token string, now time.Time) (Identity, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteCheckTimeout)
+	defer cancel()
+	resp, err := v.client.ValidateToken(ctx, &authv1.ValidateTokenRequest{Token: token})
+	if err != nil {
+		return Identity{}, ErrUnavailable
+	}
+	e := entry{identity: Identity{UserID: resp.UserId, Role: resp.Role, SpaceID: resp.SpaceId}, invalid: !resp.Valid, validUntil: now.Add(v.cacheTTL), expiresAt: now.Add(v.cacheTTL), remoteChecked: now}
+	v.store(token, e)
+	if e.invalid {
+		return Identity{}, ErrInvalidToken
+	}
+	return e.identity, nil
+}
+
+func (v *Verifier) recheckRemote(ctx context.Context, token string, e entry, now time.Time) (Identity, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteCheckTimeout)
+	defer cancel()
+	resp, err := v.client.ValidateToken(ctx, &authv1.ValidateTokenRequest{Token: token})
+	e.remoteChecked = now
+	if err != nil {
+		log.Printf("recheck token: %v", err)
+	} else if !resp.Valid {
+		e.invalid = true
+		e.validUntil = e.expiresAt
+	}
+	v.store(token, e)
+	if e.invalid {
+		return Identity{}, ErrInvalidToken
+	}
+	return e.identity, nil
+}
+
+// VerifyAPIKey checks an API key with the auth service, which records its
+// use. Results are cached for cacheTTL, so a revoked key can keep working
+// that long.
+func (v *Verifier) VerifyAPIKey(ctx context.Context, key string) (Identity, error) {
+	if key == "" {
+		return Identity{}, ErrInvalidToken
+	}
+	// Keys share the cache with tokens; the scheme keeps them apart.
+	cacheKey := "ApiKey " + key
+	now := v.now()
+	v.mu.Lock()
+	e, ok := v.cache[cacheKey]
+	var cached entry
+	if ok {
+		cached = *e
+	}
+	v.mu.Unlock()
+
+	if !ok || !now.Before(cached.validUntil) {
+		ctx, cancel := context.WithTimeout(ctx, remoteCheckTimeout)
+		defer cancel()
+		resp, err := v.client.ValidateAPIKey(ctx, &authv1.ValidateAPIKeyRequest{Key: key})
+		if err != nil {
+			return Identity{}, ErrUnavailable
+		}
+		cached = entry{
+			identity: Identity{
+				UserID:   resp.UserId,
+				Role:     resp.Role,
+				SpaceID:  resp.SpaceId,
+				APIKeyID: resp.KeyId,
+				Scopes:   resp.Scopes,
+			},
+			invalid:    !resp.Valid,
+			validUntil: now.Add(v.cacheTTL),
+			expiresAt:  now.Add(v.cacheTTL),
+		}
+		v.store(cacheKey, cached)
+	}
+	if cached.invalid {
+		return Identity{}, ErrInvalidToken
+	}
+	return cached.identity, nil
+}
+
+func (v *Verifier) store(token string, e entry) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if _, ok := v.cache[token]; !ok && len(v.cache) >= maxCachedTokens {
+		now := v.now()
+		for t, cached := range v.cache {
+			if !now.Before(cached.expiresAt) {
+				delete(v.cache, t)
+			}
+		}
+		if len(v.cache) >= maxCachedTokens {
+			v.cache = make(map[string]*entry)
+		}
+	}
+	v.cache[token] = &e
+}
+
+// RefreshKeys replaces the public keys with the auth service's JWKS.
+func (v *Verifier) RefreshKeys(ctx context.Context) error {
+	v.mu.Lock()
+	v.keysFetched = v.now()
+	v.mu.Unlock()
+	resp, err := v.client.GetJWKS(ctx, &emptypb.Empty{})
+	if err != nil {
+		v.mu.Lock()
+		v.keysErr = err
+		v.mu.Unlock()
+		return err
+	}
+	keys := make(map[string]publicKey, len(resp.GetKeys()))
+	for _, k := range resp.GetKeys() {
+		key, err := parseJWK(k)
+		if err != nil {
+			log.Printf("skip key %q: %v", k.Kid, err)
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	v.mu.Lock()
+	v.keys = keys
+	v.keysErr = nil
+	v.mu.Unlock()
+	return nil
+}
+
+func (v *Verifier) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := v.RefreshKeys(ctx); err != nil {
+			log.Printf("refresh auth keys: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func parseJWK(k *authv1.JWK) (publicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return publicKey{}, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return publicKey{}, err
+		}
+		return publicKey{
+			method: jwt.SigningMethodRS256,
+			key:    &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())},
+		}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return publicKey{}, errors.New("unsupported curve " + k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return publicKey{}, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return publicKey{}, errors.New("bad Ed25519 key size")
+		}
+		return publicKey{method: jwt.SigningMethodEdDSA, key: ed25519.PublicKey(x)}, nil
+	}
+	return publicKey{}, errors.New("unsupported key type " + k.Kty)
+}
@@ -0,0 +1,213 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	authv1 "gateway/internal/pb/auth/v1"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type MockAuthServiceClient struct {
+	authv1.AuthServiceClient
+	mock.Mock
+}
+
+func (m *MockAuthServiceClient) ValidateToken(ctx context.Context, in *authv1.ValidateTokenRequest, opts ...grpc.CallOption) (*authv1.ValidateTokenResponse, error) {
+	args := m.Called(in.Token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.ValidateTokenResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) GetJWKS(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*authv1.JWKSResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.JWKSResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ValidateAPIKey(ctx context.Context, in *authv1.ValidateAPIKeyRequest, opts ...grpc.CallOption) (*authv1.ValidateAPIKeyResponse, error) {
+	args := m.Called(in.Key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.ValidateAPIKeyResponse), args.Error(1)
+}
+
+var testNow = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, userID string, exp time.Time) string {
+	token := jwt.NewWithClaims(method, claims{
+		UserID:           userID,
+		Role:             RoleMember,
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(exp)},
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+func newTestVerifier(client *MockAuthServiceClient, secret string, recheck time.Duration) (*Verifier, *time.Time) {
+	now := testNow
+	v := NewVerifier(client, secret, 30*time.Second, recheck)
+	v.now = func() time.Time { return now }
+	return v, &now
+}
+
+func TestVerifier_HS256(t *testing.T) {
+	client := &MockAuthServiceClient{}
+	v, _ := newTestVerifier(client, "secret", 0)
+	ctx := context.Background()
+
+	token := sign(t, jwt.SigningMethodHS256, "", []byte("secret"), "u1", testNow.Add(15*time.Minute))
+	identity, err := v.Verify(ctx, token)
+	assert.NoError(t, err)
+	assert.Equal(t, Identity{UserID: "u1", Role: RoleMember}, identity)
+
+	forged := sign(t, jwt.SigningMethodHS256, "", []byte("other"), "u1", testNow.Add(15*time.Minute))
+	_, err = v.Verify(ctx, forged)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	expired := sign(t, jwt.SigningMethodHS256, "", []byte("secret"), "u1", testNow.Add(-time.Minute))
+	_, err = v.Verify(ctx, expired)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	client.AssertExpectations(t)
+}
+
+func TestVerifier_EdDSA(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	client := &MockAuthServiceClient{}
+	client.On("GetJWKS").Return(&authv1.JWKSResponse{Keys: []*authv1.JWK{
+		{Kty: "OKP", Kid: "k1", Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(pub)},
+	}}, nil).Once()
+	v, now := newTestVerifier(client, "", 0)
+	ctx := context.Background()
+
+	identity, err := v.Verify(ctx, sign(t, jwt.SigningMethodEdDSA, "k1", priv, "u1", testNow.Add(15*time.Minute)))
+	assert.NoError(t, err)
+	assert.Equal(t, Identity{UserID: "u1", Role: RoleMember}, identity)
+
+	identity, err = v.Verify(ctx, sign(t, jwt.SigningMethodEdDSA, "k1", priv, "u2", testNow.Add(15*time.Minute)))
+	assert.NoError(t, err)
+	assert.Equal(t, Identity{UserID: "u2", Role: RoleMember}, identity)
+
+	_, err = v.Verify(ctx, sign(t, jwt.SigningMethodEdDSA, "unknown", priv, "u1", testNow.Add(15*time.Minute)))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	client.On("GetJWKS").Return(nil, status.Error(codes.Unavailable, "down")).Once()
+	client.On("ValidateToken", mock.Anything).Return(nil, status.Error(codes.Unavailable, "down")).Once()
+	*now = now.Add(time.Minute)
+	_, err = v.Verify(ctx, sign(t, jwt.SigningMethodEdDSA, "k2", priv, "u1", testNow.Add(15*time.Minute)))
+	assert.ErrorIs(t, err, ErrUnavailable)
+
+	client.AssertExpectations(t)
+}
+
+func TestVerifier_Recheck(t *testing.T) {
+	client := &MockAuthServiceClient{}
+	v, now := newTestVerifier(client, "secret", time.Minute)
+	ctx := context.Background()
+	token := sign(t, jwt.SigningMethodHS256, "", []byte("secret"), "u1", testNow.Add(15*time.Minute))
+
+	client.On("ValidateToken", token).Return(&authv1.ValidateTokenResponse{UserId: "u1", Valid: true}, nil).Once()
+	identity, err := v.Verify(ctx, token)
+	assert.NoError(t, err)
+	assert.Equal(t, Identity{UserID: "u1", Role: RoleMember}, identity)
+
+	*now = now.Add(40 * time.Second)
+	_, err = v.Verify(ctx, token)
+	assert.NoError(t, err)
+
+	client.On("ValidateToken", token).Return(nil, status.Error(codes.Unavailable, "down")).Once()
+	*now = now.Add(30 * time.Second)
+	_, err = v.Verify(ctx, token)
+	assert.NoError(t, err, "auth being down keeps the local result")
+
+	client.On("ValidateToken", token).Return(&authv1.ValidateTokenResponse{Valid: false}, nil).Once()
+	*now = now.Add(time.Minute)
+	_, err = v.Verify(ctx, token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	*now = now.Add(time.Minute)
+	_, err = v.Verify(ctx, token)
+	assert.ErrorIs(t, err, ErrInvalidToken, "a revoked token stays rejected")
+
+	client.AssertExpectations(t)
+}
+
+func TestVerifier_RemoteFallback(t *testing.T) {
+	client := &MockAuthServiceClient{}
+	v, _ := newTestVerifier(client, "", 0)
+	ctx := context.Background()
+	token := sign(t, jwt.SigningMethodHS256, "", []byte("secret"), "u1", testNow.Add(15*time.Minute))
+	other := sign(t, jwt.SigningMethodHS256, "", []byte("secret"), "u2", testNow.Add(15*time.Minute))
+
+	client.On("ValidateToken", token).Return(&authv1.ValidateTokenResponse{UserId: "u1", Valid: true, Role: RoleViewer, SpaceId: "s1"}, nil).Once()
+	client.On("ValidateToken", other).Return(nil, status.Error(codes.Unavailable, "down")).Once()
+
+	identity, err := v.Verify(ctx, token)
+	assert.NoError(t, err)
+	assert.Equal(t, Identity{UserID: "u1", Role: RoleViewer, SpaceID: "s1"}, identity)
+	identity, err = v.Verify(ctx, token)
+	assert.NoError(t, err)
+	assert.Equal(t, Identity{UserID: "u1", Role: RoleViewer, SpaceID: "s1"}, identity)
+
+	_, err = v.Verify(ctx, other)
+	assert.ErrorIs(t, err, ErrUnavailable)
+
+	client.AssertExpectations(t)
+}
+
+func TestVerifier_APIKey(t *testing.T) {
+	client := &MockAuthServiceClient{}
+	v, now := newTestVerifier(client, "secret", 0)
+	ctx := context.Background()
+
+	client.On("ValidateAPIKey", "lk_good").Return(&authv1.ValidateAPIKeyResponse{
+		Valid: true, UserId: "u1", Role: RoleMember, SpaceId: "s1", Scopes: []string{"reports:read"}, KeyId: "k1",
+	}, nil).Once()
+	client.On("ValidateAPIKey", "lk_revoked").Return(&authv1.ValidateAPIKeyResponse{Valid: false}, nil).Once()
+
+	expected := Identity{UserID: "u1", Role: RoleMember, SpaceID: "s1", APIKeyID: "k1", Scopes: []string{"reports:read"}}
+	identity, err := v.VerifyAPIKey(ctx, "lk_good")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, identity)
+	identity, err = v.VerifyAPIKey(ctx, "lk_good")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, identity, "served from the cache")
+
+	_, err = v.VerifyAPIKey(ctx, "lk_revoked")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, err = v.VerifyAPIKey(ctx, "lk_revoked")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = v.Verify(ctx, "lk_good")
+	assert.ErrorIs(t, err, ErrInvalidToken, "a key is not a bearer token")
+
+	client.On("ValidateAPIKey", "lk_good").Return(nil, status.Error(codes.Unavailable, "down")).Once()
+	*now = now.Add(time.Minute)
+	_, err = v.VerifyAPIKey(ctx, "lk_good")
+	assert.ErrorIs(t, err, ErrUnavailable)
+
+	client.AssertExpectations(t)
+}
@@ -2,14 +2,22 @@ package config
 
 import (
 	"os"
+	"strings"
 	"time"
 )
 
+// HTTPConfig.TrustedProxies are the addresses or CIDRs whose
+// X-Forwarded-For is believed; with none the client address is the peer.
+// AttachmentTimeout replaces RequestTimeout and the connection timeouts on
+// the attachment routes, which move whole files.
 type HTTPConfig struct {
-	Address         string
-	ReadTimeout     time.Duration
-	WriteTimeout    time.Duration
-	ShutdownTimeout time.Duration
+	Address           string
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	ShutdownTimeout   time.Duration
+	RequestTimeout    time.Duration
+	AttachmentTimeout time.Duration
+	TrustedProxies    []string
 }
 
 type GRPCConfig struct {
@@ -17,23 +25,42 @@ type GRPCConfig struct {
 	AuthAddress   string
 }
 
+// AuthConfig is for verifying tokens in the gateway. JWTSecret is only
+// needed for HS256 tokens; a zero RecheckInterval never asks auth again.
+type AuthConfig struct {
+	JWTSecret       string
+	CacheTTL        time.Duration
+	RecheckInterval time.Duration
+	KeysInterval    time.Duration
+}
+
 type Config struct {
 	HTTP HTTPConfig
 	GRPC GRPCConfig
+	Auth AuthConfig
 }
 
 func Load() Config {
 	return Config{
 		HTTP: HTTPConfig{
-			Address:         getEnv("HTTP_ADDRESS", ":8081"),
-			ReadTimeout:     5 * time.Second,
-			WriteTimeout:    10 * time.Second,
-			ShutdownTimeout: 5 * time.Second,
+			Address:           getEnv("HTTP_ADDRESS", ":8081"),
+			ReadTimeout:       5 * time.Second,
+			WriteTimeout:      10 * time.Second,
+			ShutdownTimeout:   5 * time.Second,
+			RequestTimeout:    2 * time.Second,
+			AttachmentTimeout: getDuration("ATTACHMENT_TIMEOUT", time.Minute),
+			TrustedProxies:    getList("TRUSTED_PROXIES"),
 		},
 		GRPC: GRPCConfig{
 			LedgerAddress: getEnv("GRPC_ADDRESS", "127.0.0.1:9090"),
 			AuthAddress:   getEnv("AUTH_GRPC_ADDR", "127.0.0.1:50002"),
 		},
+		Auth: AuthConfig{
+			JWTSecret:       os.Getenv("JWT_SECRET"),
+			CacheTTL:        getDuration("AUTH_CACHE_TTL", 30*time.Second),
+			RecheckInterval: getDuration("AUTH_RECHECK_INTERVAL", time.Minute),
+			KeysInterval:    5 * time.Minute,
+		},
 	}
 }
 
@@ -43,3 +70,22 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getDuration(key string, fallback time.Duration) time.Duration {
+	if val := os.Getenv(key); val != "" {
+		if d, err := time.ParseDuration(val); err == nil && d >= 0 {
+			return d
+		}
+	}
+	return fallback
+}
+
+func getList(key string) []string {
+	var list []string
+	for _, val := range strings.Split(os.Getenv(key), ",") {
+		if val = strings.TrimSpace(val); val != "" {
+			list = append(list, val)
+		}
+	}
+	return list
+}
@@ -0,0 +1,499 @@
+package handler
+
+import (
+	"gateway/internal/model"
+	"gateway/internal/service"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/status"
+)
+
+type AuthHandler struct {
+	service service.AuthGatewayService
+}
+
+func NewAuthHandler(s service.AuthGatewayService) *AuthHandler {
+	if s == nil {
+		panic("AuthHandler requires service")
+	}
+	return &AuthHandler{service: s}
+}
+
+func (a *AuthHandler) Register(r *gin.RouterGroup) {
+	auth := r.Group("/auth")
+	{
+		auth.POST("/login", a.Login)
+		auth.POST("/refresh", a.Refresh)
+		auth.POST("/logout", a.Logout)
+		auth.POST("/logout-all", a.LogoutAll)
+		auth.POST("/password", a.PasswordChange)
+		auth.POST("/password/reset-request", a.PasswordResetRequest)
+		auth.POST("/password/reset", a.PasswordReset)
+		auth.POST("/2fa/verify", a.TOTPVerify)
+		auth.POST("/2fa/enroll", a.TOTPEnroll)
+		auth.POST("/2fa/activate", a.TOTPActivate)
+		auth.POST("/2fa/disable", a.TOTPDisable)
+	}
+	users := r.Group("/admin/users")
+	{
+		users.GET("/", a.UserList)
+		users.PUT("/:id/role", a.UserSetRole)
+		users.DELETE("/:id", a.UserDelete)
+	}
+	admin := r.Group("/admin")
+	{
+		admin.POST("/unlock", a.LoginUnlock)
+		admin.GET("/auth-events", a.AuthEventList)
+	}
+	spaces := r.Group("/spaces")
+	{
+		spaces.POST("/", a.SpaceCreate)
+		spaces.GET("/", a.SpaceList)
+		spaces.POST("/switch", a.SpaceSwitch)
+		spaces.GET("/:id/members", a.SpaceMembers)
+		spaces.DELETE("/:id/members/:user_id", a.SpaceRemoveMember)
+		spaces.POST("/:id/invitations", a.SpaceInvite)
+	}
+	invitations := r.Group("/invitations")
+	{
+		invitations.GET("/", a.InvitationList)
+		invitations.POST("/:id/accept", a.InvitationAccept)
+		invitations.POST("/:id/decline", a.InvitationDecline)
+	}
+	apiKeys := r.Group("/api-keys")
+	{
+		apiKeys.POST("/", a.APIKeyCreate)
+		apiKeys.GET("/", a.APIKeyList)
+		apiKeys.DELETE("/:id", a.APIKeyRevoke)
+	}
+}
+
+// Login answers 429 while the login or the client IP is locked out after
+// failed attempts.
+func (a *AuthHandler) Login(c *gin.Context) {
+	var req model.AuthLogin
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Login == "" || req.Password == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "login and password must not be empty"})
+		return
+	}
+	resp, err := a.service.Login(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (a *AuthHandler) Refresh(c *gin.Context) {
+	var req model.AuthRefresh
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.RefreshToken == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "refresh_token must not be empty"})
+		return
+	}
+	resp, err := a.service.Refresh(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (a *AuthHandler) Logout(c *gin.Context) {
+	var req model.AuthLogout
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	err := a.service.Logout(c.Request.Context(), bearerToken(c), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (a *AuthHandler) LogoutAll(c *gin.Context) {
+	err := a.service.LogoutAll(c.Request.Context(), bearerToken(c))
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// PasswordChange ends every session of the user; the client has to log in
+// again.
+func (a *AuthHandler) PasswordChange(c *gin.Context) {
+	var req model.PasswordChange
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.OldPassword == "" || req.NewPassword == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "old_password and new_password must not be empty"})
+		return
+	}
+	err := a.service.ChangePassword(c.Request.Context(), bearerToken(c), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// PasswordResetRequest succeeds whether or not the login exists.
+func (a *AuthHandler) PasswordResetRequest(c *gin.Context) {
+	var req model.PasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Login == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "login must not be empty"})
+		return
+	}
+	err := a.service.RequestPasswordReset(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (a *AuthHandler) PasswordReset(c *gin.Context) {
+	var req model.PasswordReset
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.ResetToken == "" || req.NewPassword == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reset_token and new_password must not be empty"})
+		return
+	}
+	err := a.service.ResetPassword(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (a *AuthHandler) TOTPVerify(c *gin.Context) {
+	var req model.TOTPVerify
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.ChallengeToken == "" || req.Code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "challenge_token and code must not be empty"})
+		return
+	}
+	resp, err := a.service.VerifyLogin(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (a *AuthHandler) TOTPEnroll(c *gin.Context) {
+	resp, err := a.service.EnrollTOTP(c.Request.Context(), bearerToken(c))
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (a *AuthHandler) TOTPActivate(c *gin.Context) {
+	var req model.TOTPCode
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code must not be empty"})
+		return
+	}
+	resp, err := a.service.ActivateTOTP(c.Request.Context(), bearerToken(c), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (a *AuthHandler) TOTPDisable(c *gin.Context) {
+	var req model.TOTPCode
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code must not be empty"})
+		return
+	}
+	err := a.service.DisableTOTP(c.Request.Context(), bearerToken(c), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (a *AuthHandler) UserList(c *gin.Context) {
+	users, err := a.service.ListUsers(c.Request.Context(), bearerToken(c))
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, users)
+}
+
+func (a *AuthHandler) UserSetRole(c *gin.Context) {
+	var req model.UserRole
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Role == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "role must not be empty"})
+		return
+	}
+	err := a.service.SetUserRole(c.Request.Context(), bearerToken(c), c.Param("id"), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (a *AuthHandler) UserDelete(c *gin.Context) {
+	err := a.service.DeleteUser(c.Request.Context(), bearerToken(c), c.Param("id"))
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (a *AuthHandler) LoginUnlock(c *gin.Context) {
+	var req model.LoginUnlock
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Login == "" && req.Ip == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "login or ip must be set"})
+		return
+	}
+	err := a.service.UnlockLogin(c.Request.Context(), bearerToken(c), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (a *AuthHandler) AuthEventList(c *gin.Context) {
+	req := model.AuthEventList{
+		Login: c.Query("login"),
+		Ip:    c.Query("ip"),
+		Type:  c.Query("type"),
+	}
+	var ok bool
+	if req.BeforeId, ok = optionalQueryID(c, "before_id"); !ok {
+		return
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive int32"})
+			return
+		}
+		req.Limit = int32(limit)
+	}
+	events, err := a.service.ListAuthEvents(c.Request.Context(), bearerToken(c), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, events)
+}
+
+func (a *AuthHandler) SpaceCreate(c *gin.Context) {
+	var req model.SpaceCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name must not be empty"})
+		return
+	}
+	resp, err := a.service.CreateSpace(c.Request.Context(), bearerToken(c), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (a *AuthHandler) SpaceList(c *gin.Context) {
+	spaces, err := a.service.ListSpaces(c.Request.Context(), bearerToken(c))
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, spaces)
+}
+
+// SpaceSwitch returns an access token for the chosen space. Later refreshes
+// stay in it until the next switch.
+func (a *AuthHandler) SpaceSwitch(c *gin.Context) {
+	var req model.SpaceSwitch
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	resp, err := a.service.SwitchSpace(c.Request.Context(), bearerToken(c), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (a *AuthHandler) SpaceMembers(c *gin.Context) {
+	members, err := a.service.ListSpaceMembers(c.Request.Context(), bearerToken(c), c.Param("id"))
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, members)
+}
+
+func (a *AuthHandler) SpaceRemoveMember(c *gin.Context) {
+	err := a.service.RemoveSpaceMember(c.Request.Context(), bearerToken(c), c.Param("id"), c.Param("user_id"))
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (a *AuthHandler) SpaceInvite(c *gin.Context) {
+	var req model.SpaceInvite
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Login == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "login must not be empty"})
+		return
+	}
+	resp, err := a.service.InviteToSpace(c.Request.Context(), bearerToken(c), c.Param("id"), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (a *AuthHandler) InvitationList(c *gin.Context) {
+	invitations, err := a.service.ListInvitations(c.Request.Context(), bearerToken(c))
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, invitations)
+}
+
+func (a *AuthHandler) InvitationAccept(c *gin.Context) {
+	resp, err := a.service.AcceptInvitation(c.Request.Context(), bearerToken(c), c.Param("id"))
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (a *AuthHandler) InvitationDecline(c *gin.Context) {
+	err := a.service.DeclineInvitation(c.Request.Context(), bearerToken(c), c.Param("id"))
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// APIKeyCreate returns the key in full only in this response.
+func (a *AuthHandler) APIKeyCreate(c *gin.Context) {
+	var req model.APIKeyCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name must not be empty"})
+		return
+	}
+	if len(req.Scopes) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "scopes must not be empty"})
+		return
+	}
+	resp, err := a.service.CreateAPIKey(c.Request.Context(), bearerToken(c), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (a *AuthHandler) APIKeyList(c *gin.Context) {
+	keys, err := a.service.ListAPIKeys(c.Request.Context(), bearerToken(c))
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, keys)
+}
+
+func (a *AuthHandler) APIKeyRevoke(c *gin.Context) {
+	err := a.service.RevokeAPIKey(c.Request.Context(), bearerToken(c), c.Param("id"))
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// JWKS serves the public keys for verifying access tokens locally.
+func (a *AuthHandler) JWKS(c *gin.Context) {
+	resp, err := a.service.JWKS(c.Request.Context())
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, resp)
+}
+
+func bearerToken(c *gin.Context) string {
+	return strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+}
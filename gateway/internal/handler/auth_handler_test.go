@@ -0,0 +1,1131 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gateway/internal/model"
+	"gateway/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MockAuthGatewayService struct {
+	mock.Mock
+}
+
+var _ service.AuthGatewayService = (*MockAuthGatewayService)(nil)
+
+func (m *MockAuthGatewayService) Login(ctx context.Context, req model.AuthLogin) (*model.AuthTokens, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AuthTokens), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) UnlockLogin(ctx context.Context, token string, req model.LoginUnlock) error {
+	args := m.Called(ctx, token, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthGatewayService) ListAuthEvents(ctx context.Context, token string, req model.AuthEventList) ([]model.AuthEvent, error) {
+	args := m.Called(ctx, token, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.AuthEvent), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) ChangePassword(ctx context.Context, token string, req model.PasswordChange) error {
+	args := m.Called(ctx, token, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthGatewayService) RequestPasswordReset(ctx context.Context, req model.PasswordResetRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthGatewayService) ResetPassword(ctx context.Context, req model.PasswordReset) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthGatewayService) Refresh(ctx context.Context, req model.AuthRefresh) (*model.AuthTokens, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AuthTokens), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) Logout(ctx context.Context, token string, req model.AuthLogout) error {
+	args := m.Called(ctx, token, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthGatewayService) LogoutAll(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockAuthGatewayService) JWKS(ctx context.Context) (*model.JWKS, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.JWKS), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) ListUsers(ctx context.Context, token string) ([]model.User, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.User), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) SetUserRole(ctx context.Context, token string, userID string, req model.UserRole) error {
+	args := m.Called(ctx, token, userID, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthGatewayService) DeleteUser(ctx context.Context, token string, userID string) error {
+	args := m.Called(ctx, token, userID)
+	return args.Error(0)
+}
+
+func (m *MockAuthGatewayService) CreateSpace(ctx context.Context, token string, req model.SpaceCreate) (*model.Space, error) {
+	args := m.Called(ctx, token, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Space), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) ListSpaces(ctx context.Context, token string) ([]model.Space, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Space), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) ListSpaceMembers(ctx context.Context, token string, spaceID string) ([]model.SpaceMember, error) {
+	args := m.Called(ctx, token, spaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SpaceMember), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) RemoveSpaceMember(ctx context.Context, token string, spaceID string, userID string) error {
+	args := m.Called(ctx, token, spaceID, userID)
+	return args.Error(0)
+}
+
+func (m *MockAuthGatewayService) SwitchSpace(ctx context.Context, token string, req model.SpaceSwitch) (*model.SpaceToken, error) {
+	args := m.Called(ctx, token, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SpaceToken), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) InviteToSpace(ctx context.Context, token string, spaceID string, req model.SpaceInvite) (*model.Invitation, error) {
+	args := m.Called(ctx, token, spaceID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Invitation), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) ListInvitations(ctx context.Context, token string) ([]model.Invitation, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Invitation), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) AcceptInvitation(ctx context.Context, token string, invitationID string) (*model.Space, error) {
+	args := m.Called(ctx, token, invitationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Space), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) DeclineInvitation(ctx context.Context, token string, invitationID string) error {
+	args := m.Called(ctx, token, invitationID)
+	return args.Error(0)
+}
+
+func (m *MockAuthGatewayService) VerifyLogin(ctx context.Context, req model.TOTPVerify) (*model.AuthTokens, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AuthTokens), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) EnrollTOTP(ctx context.Context, token string) (*model.TOTPEnrollment, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TOTPEnrollment), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) ActivateTOTP(ctx context.Context, token string, req model.TOTPCode) (*model.RecoveryCodes, error) {
+	args := m.Called(ctx, token, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RecoveryCodes), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) DisableTOTP(ctx context.Context, token string, req model.TOTPCode) error {
+	args := m.Called(ctx, token, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthGatewayService) CreateAPIKey(ctx context.Context, token string, req model.APIKeyCreate) (*model.APIKeyCreated, error) {
+	args := m.Called(ctx, token, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIKeyCreated), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) ListAPIKeys(ctx context.Context, token string) ([]model.APIKey, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.APIKey), args.Error(1)
+}
+
+func (m *MockAuthGatewayService) RevokeAPIKey(ctx context.Context, token string, id string) error {
+	args := m.Called(ctx, token, id)
+	return args.Error(0)
+}
+
+func TestAuthHandler_Login(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockAuthGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "successful login",
+			body: `{"login":"alice","password":"secret"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("Login", mock.Anything, model.AuthLogin{Login: "alice", Password: "secret"}).Return(&model.AuthTokens{
+					Token:        "access",
+					RefreshToken: "refresh",
+					ExpiresIn:    900,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"token":"access","refresh_token":"refresh","expires_in":900}`,
+		},
+		{
+			name: "second factor required",
+			body: `{"login":"alice","password":"secret"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("Login", mock.Anything, model.AuthLogin{Login: "alice", Password: "secret"}).
+					Return(&model.AuthTokens{ChallengeToken: "challenge", ExpiresIn: 300}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"challenge_token":"challenge","expires_in":300}`,
+		},
+		{
+			name:           "missing password",
+			body:           `{"login":"alice"}`,
+			mockSetup:      func(m *MockAuthGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"login and password must not be empty"}`,
+		},
+		{
+			name: "locked out",
+			body: `{"login":"bob","password":"guess"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("Login", mock.Anything, model.AuthLogin{Login: "bob", Password: "guess"}).
+					Return(nil, status.Error(codes.ResourceExhausted, "login failed: too many failed login attempts, try again in 4s"))
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedBody:   `{"error":"login failed: too many failed login attempts, try again in 4s"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAuthGatewayService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.Login(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_Refresh(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockAuthGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "successful refresh",
+			body: `{"refresh_token":"old"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("Refresh", mock.Anything, model.AuthRefresh{RefreshToken: "old"}).Return(&model.AuthTokens{
+					Token:        "access",
+					RefreshToken: "new",
+					ExpiresIn:    900,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"token":"access","refresh_token":"new","expires_in":900}`,
+		},
+		{
+			name:           "missing refresh token",
+			body:           `{}`,
+			mockSetup:      func(m *MockAuthGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"refresh_token must not be empty"}`,
+		},
+		{
+			name: "reused refresh token",
+			body: `{"refresh_token":"rotated"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("Refresh", mock.Anything, model.AuthRefresh{RefreshToken: "rotated"}).
+					Return(nil, status.Error(codes.Unauthenticated, "refresh failed: refresh token reused"))
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"refresh failed: refresh token reused"}`,
+		},
+		{
+			name: "auth service error",
+			body: `{"refresh_token":"old"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("Refresh", mock.Anything, model.AuthRefresh{RefreshToken: "old"}).Return(nil, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"assert.AnError general error for testing"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAuthGatewayService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.Refresh(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockAuthGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "access token only",
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("Logout", mock.Anything, "access", model.AuthLogout{}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "with refresh token",
+			body: `{"refresh_token":"refresh"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("Logout", mock.Anything, "access", model.AuthLogout{RefreshToken: "refresh"}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid body",
+			body:           `{`,
+			mockSetup:      func(m *MockAuthGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "revoked token",
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("Logout", mock.Anything, "access", model.AuthLogout{}).
+					Return(status.Error(codes.Unauthenticated, "logout: invalid token"))
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"logout: invalid token"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAuthGatewayService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/auth/logout", strings.NewReader(tt.body))
+			c.Request.Header.Set("Authorization", "Bearer access")
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.Logout(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_LogoutAll(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockAuthGatewayService{}
+	mockService.On("LogoutAll", mock.Anything, "access").Return(nil)
+	handler := NewAuthHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/auth/logout-all", nil)
+	c.Request.Header.Set("Authorization", "Bearer access")
+
+	handler.LogoutAll(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_PasswordChange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockAuthGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "successful change",
+			body: `{"old_password":"testpass","new_password":"correct horse battery"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("ChangePassword", mock.Anything, "access", model.PasswordChange{OldPassword: "testpass", NewPassword: "correct horse battery"}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing new password",
+			body:           `{"old_password":"testpass"}`,
+			mockSetup:      func(m *MockAuthGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"old_password and new_password must not be empty"}`,
+		},
+		{
+			name: "wrong old password",
+			body: `{"old_password":"guess","new_password":"correct horse battery"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("ChangePassword", mock.Anything, "access", model.PasswordChange{OldPassword: "guess", NewPassword: "correct horse battery"}).
+					Return(status.Error(codes.PermissionDenied, "change password: wrong password"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"change password: wrong password"}`,
+		},
+		{
+			name: "weak new password",
+			body: `{"old_password":"testpass","new_password":"short"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("ChangePassword", mock.Anything, "access", model.PasswordChange{OldPassword: "testpass", NewPassword: "short"}).
+					Return(status.Error(codes.InvalidArgument, "change password: password does not meet the policy: must be at least 8 characters"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"change password: password does not meet the policy: must be at least 8 characters"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAuthGatewayService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/auth/password", strings.NewReader(tt.body))
+			c.Request.Header.Set("Authorization", "Bearer access")
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.PasswordChange(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_PasswordReset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockAuthGatewayService{}
+	mockService.On("RequestPasswordReset", mock.Anything, model.PasswordResetRequest{Login: "bob"}).Return(nil)
+	mockService.On("ResetPassword", mock.Anything, model.PasswordReset{ResetToken: "used", NewPassword: "correct horse battery"}).
+		Return(status.Error(codes.Unauthenticated, "reset password: invalid or expired reset token"))
+	handler := NewAuthHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/auth/password/reset-request", strings.NewReader(`{"login":"bob"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	handler.PasswordResetRequest(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/auth/password/reset", strings.NewReader(`{"reset_token":"used","new_password":"correct horse battery"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	handler.PasswordReset(c)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.JSONEq(t, `{"error":"reset password: invalid or expired reset token"}`, w.Body.String())
+
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_TOTPVerify(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockAuthGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "successful verification",
+			body: `{"challenge_token":"challenge","code":"123456"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("VerifyLogin", mock.Anything, model.TOTPVerify{ChallengeToken: "challenge", Code: "123456"}).Return(&model.AuthTokens{
+					Token:        "access",
+					RefreshToken: "refresh",
+					ExpiresIn:    900,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"token":"access","refresh_token":"refresh","expires_in":900}`,
+		},
+		{
+			name:           "missing code",
+			body:           `{"challenge_token":"challenge"}`,
+			mockSetup:      func(m *MockAuthGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"challenge_token and code must not be empty"}`,
+		},
+		{
+			name: "wrong code",
+			body: `{"challenge_token":"challenge","code":"000000"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("VerifyLogin", mock.Anything, model.TOTPVerify{ChallengeToken: "challenge", Code: "000000"}).
+					Return(nil, status.Error(codes.Unauthenticated, "verify login: invalid code"))
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"verify login: invalid code"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAuthGatewayService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/auth/2fa/verify", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.TOTPVerify(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_TOTPActivate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockAuthGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "successful activation",
+			body: `{"code":"123456"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("ActivateTOTP", mock.Anything, "access", model.TOTPCode{Code: "123456"}).
+					Return(&model.RecoveryCodes{RecoveryCodes: []string{"k3x7q-m2f7a", "p6w2d-c4n5t"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"recovery_codes":["k3x7q-m2f7a","p6w2d-c4n5t"]}`,
+		},
+		{
+			name:           "missing code",
+			body:           `{}`,
+			mockSetup:      func(m *MockAuthGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"code must not be empty"}`,
+		},
+		{
+			name: "not enrolled",
+			body: `{"code":"123456"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("ActivateTOTP", mock.Anything, "access", model.TOTPCode{Code: "123456"}).
+					Return(nil, status.Error(codes.FailedPrecondition, "activate totp: two-factor authentication is not enrolled"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"activate totp: two-factor authentication is not enrolled"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAuthGatewayService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/auth/2fa/activate", strings.NewReader(tt.body))
+			c.Request.Header.Set("Authorization", "Bearer access")
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.TOTPActivate(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_JWKS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockAuthGatewayService{}
+	mockService.On("JWKS", mock.Anything).Return(&model.JWKS{Keys: []model.JWK{
+		{Kty: "OKP", Kid: "2026-10-01", Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: "E_NSMUvu"},
+		{Kty: "RSA", Kid: "2026-10-18", Use: "sig", Alg: "RS256", N: "rCK_YOpF", E: "AQAB"},
+	}}, nil)
+	handler := NewAuthHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+
+	handler.JWKS(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"keys":[{"kty":"OKP","kid":"2026-10-01","use":"sig","alg":"EdDSA","crv":"Ed25519","x":"E_NSMUvu"},{"kty":"RSA","kid":"2026-10-18","use":"sig","alg":"RS256","n":"rCK_YOpF","e":"AQAB"}]}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_UserList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockAuthGatewayService{}
+	mockService.On("ListUsers", mock.Anything, "access").Return([]model.User{
+		{Id: "u1", Login: "alice", Role: "admin", CreatedAt: "2026-10-18T12:00:00Z"},
+	}, nil)
+	handler := NewAuthHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/admin/users/", nil)
+	c.Request.Header.Set("Authorization", "Bearer access")
+
+	handler.UserList(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id":"u1","login":"alice","role":"admin","created_at":"2026-10-18T12:00:00Z"}]`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_UserSetRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockAuthGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "successful update",
+			body: `{"role":"viewer"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("SetUserRole", mock.Anything, "access", "u2", model.UserRole{Role: "viewer"}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing role",
+			body:           `{}`,
+			mockSetup:      func(m *MockAuthGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"role must not be empty"}`,
+		},
+		{
+			name: "caller is not an admin",
+			body: `{"role":"admin"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("SetUserRole", mock.Anything, "access", "u2", model.UserRole{Role: "admin"}).
+					Return(status.Error(codes.PermissionDenied, "set user role: permission denied"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"set user role: permission denied"}`,
+		},
+		{
+			name: "unknown role",
+			body: `{"role":"root"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("SetUserRole", mock.Anything, "access", "u2", model.UserRole{Role: "root"}).
+					Return(status.Error(codes.InvalidArgument, "set user role: invalid role"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"set user role: invalid role"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAuthGatewayService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPut, "/admin/users/u2/role", strings.NewReader(tt.body))
+			c.Request.Header.Set("Authorization", "Bearer access")
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: "u2"}}
+
+			handler.UserSetRole(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_UserDelete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockAuthGatewayService{}
+	mockService.On("DeleteUser", mock.Anything, "access", "u2").Return(status.Error(codes.NotFound, "delete user: user not found"))
+	handler := NewAuthHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/admin/users/u2", nil)
+	c.Request.Header.Set("Authorization", "Bearer access")
+	c.Params = gin.Params{{Key: "id", Value: "u2"}}
+
+	handler.UserDelete(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_LoginUnlock(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockAuthGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "unlock login and ip",
+			body: `{"login":"bob","ip":"203.0.113.7"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("UnlockLogin", mock.Anything, "access", model.LoginUnlock{Login: "bob", Ip: "203.0.113.7"}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "nothing to unlock",
+			body:           `{}`,
+			mockSetup:      func(m *MockAuthGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"login or ip must be set"}`,
+		},
+		{
+			name: "not an admin",
+			body: `{"login":"bob"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("UnlockLogin", mock.Anything, "access", model.LoginUnlock{Login: "bob"}).
+					Return(status.Error(codes.PermissionDenied, "unlock login: permission denied"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"unlock login: permission denied"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAuthGatewayService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/admin/unlock", strings.NewReader(tt.body))
+			c.Request.Header.Set("Authorization", "Bearer access")
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.LoginUnlock(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_AuthEventList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockAuthGatewayService{}
+	mockService.On("ListAuthEvents", mock.Anything, "access", model.AuthEventList{Login: "bob", Type: "login.locked", BeforeId: 10, Limit: 5}).Return([]model.AuthEvent{
+		{Id: 3, Type: "login.locked", Login: "bob", Failures: 6, LockedUntil: "2026-10-18T12:00:01Z", CreatedAt: "2026-10-18T12:00:00Z"},
+	}, nil)
+	handler := NewAuthHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/admin/auth-events?login=bob&type=login.locked&before_id=10&limit=5", nil)
+	c.Request.Header.Set("Authorization", "Bearer access")
+
+	handler.AuthEventList(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id":3,"type":"login.locked","login":"bob","failures":6,"locked_until":"2026-10-18T12:00:01Z","created_at":"2026-10-18T12:00:00Z"}]`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_SpaceSwitch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockAuthGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "switch to a space",
+			body: `{"space_id":"s1"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("SwitchSpace", mock.Anything, "access", model.SpaceSwitch{SpaceId: "s1"}).Return(&model.SpaceToken{Token: "spaced", ExpiresIn: 900}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"token":"spaced","expires_in":900}`,
+		},
+		{
+			name: "back to the personal ledger",
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("SwitchSpace", mock.Anything, "access", model.SpaceSwitch{}).Return(&model.SpaceToken{Token: "personal", ExpiresIn: 900}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"token":"personal","expires_in":900}`,
+		},
+		{
+			name: "not a member",
+			body: `{"space_id":"s2"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("SwitchSpace", mock.Anything, "access", model.SpaceSwitch{SpaceId: "s2"}).
+					Return(nil, status.Error(codes.NotFound, "switch space: space not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"switch space: space not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAuthGatewayService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/spaces/switch", strings.NewReader(tt.body))
+			c.Request.Header.Set("Authorization", "Bearer access")
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.SpaceSwitch(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_SpaceInvite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockAuthGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "successful invite",
+			body: `{"login":"bob"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("InviteToSpace", mock.Anything, "access", "s1", model.SpaceInvite{Login: "bob"}).
+					Return(&model.Invitation{Id: "i1", SpaceId: "s1", SpaceName: "Household", InvitedBy: "alice", CreatedAt: "2026-10-18T12:00:00Z"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"i1","space_id":"s1","space_name":"Household","invited_by":"alice","created_at":"2026-10-18T12:00:00Z"}`,
+		},
+		{
+			name:           "missing login",
+			body:           `{}`,
+			mockSetup:      func(m *MockAuthGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"login must not be empty"}`,
+		},
+		{
+			name: "caller is not the owner",
+			body: `{"login":"bob"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("InviteToSpace", mock.Anything, "access", "s1", model.SpaceInvite{Login: "bob"}).
+					Return(nil, status.Error(codes.PermissionDenied, "invite to space: permission denied"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"invite to space: permission denied"}`,
+		},
+		{
+			name: "already invited",
+			body: `{"login":"bob"}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("InviteToSpace", mock.Anything, "access", "s1", model.SpaceInvite{Login: "bob"}).
+					Return(nil, status.Error(codes.AlreadyExists, "invite to space: user is already a member or invited"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"invite to space: user is already a member or invited"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAuthGatewayService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/spaces/s1/invitations", strings.NewReader(tt.body))
+			c.Request.Header.Set("Authorization", "Bearer access")
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: "s1"}}
+
+			handler.SpaceInvite(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_InvitationAccept(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockAuthGatewayService{}
+	mockService.On("AcceptInvitation", mock.Anything, "access", "i1").Return(&model.Space{Id: "s1", Name: "Household", OwnerId: "u1", CreatedAt: "2026-10-18T12:00:00Z"}, nil)
+	handler := NewAuthHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/invitations/i1/accept", nil)
+	c.Request.Header.Set("Authorization", "Bearer access")
+	c.Params = gin.Params{{Key: "id", Value: "i1"}}
+
+	handler.InvitationAccept(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"s1","name":"Household","owner_id":"u1","created_at":"2026-10-18T12:00:00Z"}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_APIKeyCreate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	create := model.APIKeyCreate{Name: "import", Scopes: []string{"transactions:write", "reports:read"}}
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockAuthGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "successful create",
+			body: `{"name":"import","scopes":["transactions:write","reports:read"]}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("CreateAPIKey", mock.Anything, "access", create).Return(&model.APIKeyCreated{
+					APIKey: model.APIKey{Id: "k1", Name: "import", Prefix: "lk_abcdefgh", Scopes: create.Scopes, CreatedAt: "2026-10-18T12:00:00Z"},
+					Key:    "lk_abcdefghsecret",
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"k1","name":"import","prefix":"lk_abcdefgh","scopes":["transactions:write","reports:read"],"created_at":"2026-10-18T12:00:00Z","key":"lk_abcdefghsecret"}`,
+		},
+		{
+			name:           "missing name",
+			body:           `{"scopes":["reports:read"]}`,
+			mockSetup:      func(m *MockAuthGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"name must not be empty"}`,
+		},
+		{
+			name:           "missing scopes",
+			body:           `{"name":"import"}`,
+			mockSetup:      func(m *MockAuthGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"scopes must not be empty"}`,
+		},
+		{
+			name: "unknown scope",
+			body: `{"name":"import","scopes":["users:write"]}`,
+			mockSetup: func(m *MockAuthGatewayService) {
+				m.On("CreateAPIKey", mock.Anything, "access", model.APIKeyCreate{Name: "import", Scopes: []string{"users:write"}}).
+					Return(nil, status.Error(codes.InvalidArgument, "create api key: invalid scope"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"create api key: invalid scope"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAuthGatewayService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api-keys/", strings.NewReader(tt.body))
+			c.Request.Header.Set("Authorization", "Bearer access")
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handler.APIKeyCreate(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_APIKeyRevoke(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockAuthGatewayService{}
+	mockService.On("RevokeAPIKey", mock.Anything, "access", "k1").Return(status.Error(codes.NotFound, "revoke api key: api key not found"))
+	handler := NewAuthHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/api-keys/k1", nil)
+	c.Request.Header.Set("Authorization", "Bearer access")
+	c.Params = gin.Params{{Key: "id", Value: "k1"}}
+
+	handler.APIKeyRevoke(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error":"revoke api key: api key not found"}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
@@ -1,17 +1,26 @@
 package handler
 
 import (
+	"context"
 	"encoding/csv"
+	"encoding/json"
+	"fmt"
 	"gateway/internal/model"
 	"gateway/internal/service"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+const eventsHeartbeat = 15 * time.Second
+
 type LedgerHandler struct {
 	service service.LedgerGatewayService
 }
@@ -36,12 +45,51 @@ func (l *LedgerHandler) Register(r *gin.RouterGroup) {
 		transactions.POST("/bulk", l.TransactionBulkAdd)
 		transactions.GET("/", l.TransactionGet)
 		transactions.GET("/list", l.TransactionList)
+		transactions.GET("/search", l.TransactionSearch)
 		transactions.GET("/export.csv", l.TransactionExportCSV)
+		transactions.POST("/:id/attachments", l.AttachmentUpload)
+		transactions.GET("/:id/attachments", l.AttachmentList)
+		transactions.GET("/:id/attachments/:attachment_id", l.AttachmentDownload)
+		transactions.DELETE("/:id/attachments/:attachment_id", l.AttachmentDelete)
+	}
+	categories := r.Group("/categories")
+	{
+		categories.POST("/", l.CategoryAdd)
+		categories.PUT("/", l.CategoryUpdate)
+		categories.GET("/list", l.CategoryList)
+	}
+	rules := r.Group("/rules")
+	{
+		rules.POST("/", l.RuleAdd)
+		rules.PUT("/", l.RuleUpdate)
+		rules.DELETE("/", l.RuleDelete)
+		rules.GET("/list", l.RuleList)
+		rules.POST("/test", l.RuleTest)
+	}
+	goals := r.Group("/goals")
+	{
+		goals.POST("/", l.GoalAdd)
+		goals.PUT("/", l.GoalUpdate)
+		goals.DELETE("/", l.GoalDelete)
+		goals.GET("/", l.GoalGet)
+		goals.GET("/list", l.GoalList)
+		goals.POST("/contribute", l.GoalContribute)
+	}
+	webhooks := r.Group("/webhooks")
+	{
+		webhooks.POST("/", l.WebhookAdd)
+		webhooks.DELETE("/", l.WebhookDelete)
+		webhooks.GET("/list", l.WebhookList)
+		webhooks.GET("/deliveries", l.WebhookDeliveries)
 	}
 	reports := r.Group("/reports")
 	{
 		reports.GET("/summary", l.ReportSummary)
+		reports.GET("/forecast", l.ReportForecast)
+		reports.GET("/anomalies", l.ReportAnomalies)
 	}
+	r.GET("/events", l.Events)
+	r.GET("/audit", l.AuditList)
 }
 
 func (l *LedgerHandler) BudgetAdd(c *gin.Context) {
@@ -50,7 +98,7 @@ func (l *LedgerHandler) BudgetAdd(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	if req.Category == "" {
+	if req.Category == "" && req.CategoryID == 0 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "category must not be empty"})
 		return
 	}
@@ -70,18 +118,19 @@ func (l *LedgerHandler) BudgetGet(c *gin.Context) {
 		return
 	}
 	req.Category = category
+	req.AsOf = c.Query("as_of")
 	resp, err := l.service.BudgetGet(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
 		return
 	}
 	c.JSON(http.StatusOK, resp)
 }
 
 func (l *LedgerHandler) BudgetList(c *gin.Context) {
-	resp, err := l.service.BudgetList(c.Request.Context())
+	resp, err := l.service.BudgetList(c.Request.Context(), c.Query("as_of"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
 		return
 	}
 	c.JSON(http.StatusOK, resp)
@@ -97,10 +146,6 @@ func (l *LedgerHandler) TransactionAdd(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "amount must not be empty"})
 		return
 	}
-	if req.Category == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "category must not be empty"})
-		return
-	}
 	if req.Date == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "date must not be empty"})
 		return
@@ -109,11 +154,29 @@ func (l *LedgerHandler) TransactionAdd(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "category must not be empty"})
 		return
 	}
+	req.IdempotencyKey = c.GetHeader("Idempotency-Key")
+	if len(req.IdempotencyKey) > 255 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key is too long"})
+		return
+	}
 	resp, err := l.service.TransactionAdd(c.Request.Context(), req)
 	if err != nil && err.Error() == "rpc error: code = Internal desc = add transaction: budget exceeded" {
 		c.JSON(http.StatusConflict, gin.H{"error": "budget exceeded"})
 		return
 	}
+	if status.Code(err) == codes.AlreadyExists {
+		c.JSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	if status.Code(err) == codes.FailedPrecondition {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	if status.Code(err) == codes.Aborted {
+		c.Header("Retry-After", "1")
+		c.JSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		return
+	}
 	if err != nil && strings.Contains(err.Error(), "parsing time") {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Date invalid"})
 		return
@@ -147,7 +210,52 @@ func (l *LedgerHandler) TransactionGet(c *gin.Context) {
 }
 
 func (l *LedgerHandler) TransactionList(c *gin.Context) {
-	resp, err := l.service.TransactionList(c.Request.Context())
+	resp, err := l.service.TransactionList(c.Request.Context(), model.TransactionList{Tag: c.Query("tag")})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (l *LedgerHandler) TransactionSearch(c *gin.Context) {
+	req := model.TransactionSearch{
+		Query:    c.Query("q"),
+		Language: c.Query("lang"),
+		From:     c.Query("from"),
+		To:       c.Query("to"),
+	}
+	if req.Query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q must not be empty"})
+		return
+	}
+	for _, date := range []string{req.From, req.To} {
+		if _, err := time.Parse("2006-01-02", date); date != "" && err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Date invalid"})
+			return
+		}
+	}
+	if categoryID := c.Query("category_id"); categoryID != "" {
+		id, err := strconv.ParseInt(categoryID, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "category_id must be int64"})
+			return
+		}
+		req.CategoryID = id
+	}
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.ParseInt(limit, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be int32"})
+			return
+		}
+		req.Limit = int32(n)
+	}
+	resp, err := l.service.TransactionSearch(c.Request.Context(), req)
+	if status.Code(err) == codes.InvalidArgument {
+		c.JSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -156,7 +264,7 @@ func (l *LedgerHandler) TransactionList(c *gin.Context) {
 }
 
 func (l *LedgerHandler) TransactionExportCSV(c *gin.Context) {
-	transactions, err := l.service.TransactionList(c.Request.Context())
+	transactions, err := l.service.TransactionList(c.Request.Context(), model.TransactionList{Tag: c.Query("tag")})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -199,9 +307,54 @@ func (l *LedgerHandler) ReportSummary(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to"})
 		return
 	}
+	groupBy := c.Query("group_by")
+	if groupBy != "" && groupBy != "category" && groupBy != "tag" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group_by"})
+		return
+	}
 	req.From = from
 	req.To = to
+	req.GroupBy = groupBy
+	req.AsOf = c.Query("as_of")
 	resp, err := l.service.ReportSummary(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (l *LedgerHandler) ReportForecast(c *gin.Context) {
+	var historyPeriods int64
+	if history := c.Query("history"); history != "" {
+		var err error
+		historyPeriods, err = strconv.ParseInt(history, 10, 32)
+		if err != nil || historyPeriods < 1 || historyPeriods > 24 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "history must be between 1 and 24"})
+			return
+		}
+	}
+	resp, err := l.service.ReportForecast(c.Request.Context(), int32(historyPeriods))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (l *LedgerHandler) ReportAnomalies(c *gin.Context) {
+	layout := "2006-01-02"
+	from := c.Query("from")
+	if _, err := time.Parse(layout, from); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from"})
+		return
+	}
+	to := c.Query("to")
+	if _, err := time.Parse(layout, to); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to"})
+		return
+	}
+	resp, err := l.service.ReportAnomalies(c.Request.Context(), from, to)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -222,3 +375,561 @@ func (l *LedgerHandler) TransactionBulkAdd(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, resp)
 }
+
+func (l *LedgerHandler) CategoryAdd(c *gin.Context) {
+	var req model.CategoryAdd
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name must not be empty"})
+		return
+	}
+	resp, err := l.service.CategoryAdd(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (l *LedgerHandler) CategoryUpdate(c *gin.Context) {
+	var req model.CategoryUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id must not be empty"})
+		return
+	}
+	if req.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name must not be empty"})
+		return
+	}
+	err := l.service.CategoryUpdate(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (l *LedgerHandler) CategoryList(c *gin.Context) {
+	resp, err := l.service.CategoryList(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (l *LedgerHandler) RuleAdd(c *gin.Context) {
+	var req model.Rule
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Category == "" && req.CategoryID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "category must not be empty"})
+		return
+	}
+	resp, err := l.service.RuleAdd(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (l *LedgerHandler) RuleUpdate(c *gin.Context) {
+	var req model.Rule
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id must not be empty"})
+		return
+	}
+	if req.Category == "" && req.CategoryID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "category must not be empty"})
+		return
+	}
+	err := l.service.RuleUpdate(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (l *LedgerHandler) RuleDelete(c *gin.Context) {
+	idStr := c.Query("id")
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id must not be empty"})
+		return
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id must be int64"})
+		return
+	}
+	err = l.service.RuleDelete(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (l *LedgerHandler) RuleList(c *gin.Context) {
+	resp, err := l.service.RuleList(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (l *LedgerHandler) RuleTest(c *gin.Context) {
+	var req model.RuleTest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Last < 0 || req.Last > 1000 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "last must be between 1 and 1000, or omitted for 100"})
+		return
+	}
+	resp, err := l.service.RuleTest(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (l *LedgerHandler) AttachmentUpload(c *gin.Context) {
+	transactionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id must be int64"})
+		return
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file must not be empty"})
+		return
+	}
+	data, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer data.Close()
+	resp, err := l.service.AttachmentUpload(c.Request.Context(), transactionID, file.Filename, data)
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (l *LedgerHandler) AttachmentList(c *gin.Context) {
+	transactionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id must be int64"})
+		return
+	}
+	resp, err := l.service.AttachmentList(c.Request.Context(), transactionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (l *LedgerHandler) AttachmentDownload(c *gin.Context) {
+	transactionID, id, ok := attachmentParams(c)
+	if !ok {
+		return
+	}
+	attachment, data, err := l.service.AttachmentDownload(c.Request.Context(), transactionID, id)
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, data, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+	})
+}
+
+func (l *LedgerHandler) AttachmentDelete(c *gin.Context) {
+	transactionID, id, ok := attachmentParams(c)
+	if !ok {
+		return
+	}
+	err := l.service.AttachmentDelete(c.Request.Context(), transactionID, id)
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func attachmentParams(c *gin.Context) (int64, int64, bool) {
+	transactionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id must be int64"})
+		return 0, 0, false
+	}
+	id, err := strconv.ParseInt(c.Param("attachment_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "attachment_id must be int64"})
+		return 0, 0, false
+	}
+	return transactionID, id, true
+}
+
+func attachmentErrorStatus(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.ResourceExhausted:
+		return http.StatusRequestEntityTooLarge
+	case codes.InvalidArgument:
+		return http.StatusUnsupportedMediaType
+	}
+	return http.StatusInternalServerError
+}
+
+func (l *LedgerHandler) GoalAdd(c *gin.Context) {
+	var req model.Goal
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !validGoal(c, req) {
+		return
+	}
+	resp, err := l.service.GoalAdd(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (l *LedgerHandler) GoalUpdate(c *gin.Context) {
+	var req model.Goal
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id must not be empty"})
+		return
+	}
+	if !validGoal(c, req) {
+		return
+	}
+	err := l.service.GoalUpdate(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (l *LedgerHandler) GoalDelete(c *gin.Context) {
+	id, ok := queryID(c, "id")
+	if !ok {
+		return
+	}
+	err := l.service.GoalDelete(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (l *LedgerHandler) GoalGet(c *gin.Context) {
+	id, ok := queryID(c, "id")
+	if !ok {
+		return
+	}
+	resp, err := l.service.GoalGet(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (l *LedgerHandler) GoalList(c *gin.Context) {
+	resp, err := l.service.GoalList(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (l *LedgerHandler) GoalContribute(c *gin.Context) {
+	var req model.GoalContribute
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.GoalId == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "goal_id must not be empty"})
+		return
+	}
+	if req.Amount <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "amount must be positive"})
+		return
+	}
+	if req.Date != "" {
+		if _, err := time.Parse("2006-01-02", req.Date); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date must be YYYY-MM-DD"})
+			return
+		}
+	}
+	resp, err := l.service.GoalContribute(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func validGoal(c *gin.Context, req model.Goal) bool {
+	if req.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name must not be empty"})
+		return false
+	}
+	if req.TargetAmount <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "target_amount must be positive"})
+		return false
+	}
+	if _, err := time.Parse("2006-01-02", req.Deadline); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "deadline must be YYYY-MM-DD"})
+		return false
+	}
+	return true
+}
+
+func queryID(c *gin.Context, key string) (int64, bool) {
+	idStr := c.Query(key)
+	if idStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": key + " must not be empty"})
+		return 0, false
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": key + " must be int64"})
+		return 0, false
+	}
+	return id, true
+}
+
+func optionalQueryID(c *gin.Context, key string) (int64, bool) {
+	if c.Query(key) == "" {
+		return 0, true
+	}
+	return queryID(c, key)
+}
+
+func grpcErrorStatus(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.FailedPrecondition, codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	}
+	return http.StatusInternalServerError
+}
+
+func (l *LedgerHandler) WebhookAdd(c *gin.Context) {
+	var req model.Webhook
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Url == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "url must not be empty"})
+		return
+	}
+	if req.Secret == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "secret must not be empty"})
+		return
+	}
+	if len(req.Events) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "events must not be empty"})
+		return
+	}
+	resp, err := l.service.WebhookAdd(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (l *LedgerHandler) WebhookDelete(c *gin.Context) {
+	id, ok := queryID(c, "id")
+	if !ok {
+		return
+	}
+	err := l.service.WebhookDelete(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (l *LedgerHandler) WebhookList(c *gin.Context) {
+	resp, err := l.service.WebhookList(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (l *LedgerHandler) WebhookDeliveries(c *gin.Context) {
+	webhookID, ok := queryID(c, "webhook_id")
+	if !ok {
+		return
+	}
+	var limit int64
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || limit < 1 || limit > 500 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 500"})
+			return
+		}
+	}
+	resp, err := l.service.WebhookDeliveries(c.Request.Context(), webhookID, int32(limit))
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Events streams ledger changes as Server-Sent Events. A reconnecting client
+// resumes after the id in Last-Event-ID (or ?from=) without losing events.
+// A client that reads too slowly is disconnected with an error event and is
+// expected to reconnect the same way.
+func (l *LedgerHandler) Events(c *gin.Context) {
+	from := c.GetHeader("Last-Event-ID")
+	if from == "" {
+		from = c.Query("from")
+	}
+	var fromEventID int64
+	if from != "" {
+		var err error
+		fromEventID, err = strconv.ParseInt(from, 10, 64)
+		if err != nil || fromEventID < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "event id must be a non-negative int64"})
+			return
+		}
+	}
+	var types []string
+	if t := c.Query("types"); t != "" {
+		types = strings.Split(t, ",")
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	events := make(chan model.LedgerEvent)
+	done := make(chan error, 1)
+	go func() {
+		done <- l.service.Subscribe(ctx, fromEventID, types, func(event model.LedgerEvent) error {
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			data, err := json.Marshal(event)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data)
+		case <-heartbeat.C:
+			_, _ = io.WriteString(c.Writer, ": ping\n\n")
+		case err := <-done:
+			if err != nil && ctx.Err() == nil {
+				data, _ := json.Marshal(gin.H{"error": status.Convert(err).Message(), "code": status.Code(err).String()})
+				fmt.Fprintf(c.Writer, "event: error\ndata: %s\n\n", data)
+				c.Writer.Flush()
+			}
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
+func (l *LedgerHandler) AuditList(c *gin.Context) {
+	req := model.AuditList{
+		EntityType:  c.Query("entity_type"),
+		ActorUserId: c.Query("actor_user_id"),
+		RequestId:   c.Query("request_id"),
+		Action:      c.Query("action"),
+		From:        c.Query("from"),
+		To:          c.Query("to"),
+	}
+	var ok bool
+	if req.EntityId, ok = optionalQueryID(c, "entity_id"); !ok {
+		return
+	}
+	if req.BeforeId, ok = optionalQueryID(c, "before_id"); !ok {
+		return
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive int32"})
+			return
+		}
+		req.Limit = int32(limit)
+	}
+	resp, err := l.service.AuditList(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(grpcErrorStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
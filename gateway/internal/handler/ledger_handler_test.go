@@ -5,8 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"gateway/internal/model"
@@ -15,6 +18,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type MockLedgerGatewayService struct {
@@ -33,8 +38,8 @@ func (m *MockLedgerGatewayService) BudgetGet(ctx context.Context, req model.Budg
 	return args.Get(0).(*model.BudgetGetResponse), args.Error(1)
 }
 
-func (m *MockLedgerGatewayService) BudgetList(ctx context.Context) ([]model.BudgetGetResponse, error) {
-	args := m.Called(ctx)
+func (m *MockLedgerGatewayService) BudgetList(ctx context.Context, asOf string) ([]model.BudgetGetResponse, error) {
+	args := m.Called(ctx, asOf)
 	return args.Get(0).([]model.BudgetGetResponse), args.Error(1)
 }
 
@@ -48,8 +53,8 @@ func (m *MockLedgerGatewayService) TransactionGet(ctx context.Context, req model
 	return args.Get(0).(*model.TransactionGetResponse), args.Error(1)
 }
 
-func (m *MockLedgerGatewayService) TransactionList(ctx context.Context) ([]model.TransactionGetResponse, error) {
-	args := m.Called(ctx)
+func (m *MockLedgerGatewayService) TransactionList(ctx context.Context, req model.TransactionList) ([]model.TransactionGetResponse, error) {
+	args := m.Called(ctx, req)
 	return args.Get(0).([]model.TransactionGetResponse), args.Error(1)
 }
 
@@ -63,6 +68,179 @@ func (m *MockLedgerGatewayService) ReportSummary(ctx context.Context, req model.
 	return args.Get(0).(*model.ReportSummaryResponse), args.Error(1)
 }
 
+func (m *MockLedgerGatewayService) CategoryAdd(ctx context.Context, req model.CategoryAdd) (*model.CategoryAddResponse, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*model.CategoryAddResponse), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) CategoryUpdate(ctx context.Context, req model.CategoryUpdate) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockLedgerGatewayService) CategoryList(ctx context.Context) ([]model.CategoryGetResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.CategoryGetResponse), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) RuleAdd(ctx context.Context, req model.Rule) (*model.RuleAddResponse, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*model.RuleAddResponse), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) RuleUpdate(ctx context.Context, req model.Rule) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockLedgerGatewayService) RuleDelete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockLedgerGatewayService) RuleList(ctx context.Context) ([]model.Rule, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.Rule), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) RuleTest(ctx context.Context, req model.RuleTest) ([]model.RuleMatch, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).([]model.RuleMatch), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) AttachmentUpload(ctx context.Context, transactionID int64, fileName string, data io.Reader) (*model.Attachment, error) {
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return nil, err
+	}
+	args := m.Called(ctx, transactionID, fileName, content)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Attachment), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) AttachmentDownload(ctx context.Context, transactionID int64, id int64) (*model.Attachment, io.Reader, error) {
+	args := m.Called(ctx, transactionID, id)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*model.Attachment), args.Get(1).(io.Reader), args.Error(2)
+}
+
+func (m *MockLedgerGatewayService) AttachmentDelete(ctx context.Context, transactionID int64, id int64) error {
+	args := m.Called(ctx, transactionID, id)
+	return args.Error(0)
+}
+
+func (m *MockLedgerGatewayService) AttachmentList(ctx context.Context, transactionID int64) ([]model.Attachment, error) {
+	args := m.Called(ctx, transactionID)
+	return args.Get(0).([]model.Attachment), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) TransactionSearch(ctx context.Context, req model.TransactionSearch) ([]model.TransactionSearchResult, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).([]model.TransactionSearchResult), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) GoalAdd(ctx context.Context, req model.Goal) (*model.GoalAddResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.GoalAddResponse), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) GoalUpdate(ctx context.Context, req model.Goal) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockLedgerGatewayService) GoalDelete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockLedgerGatewayService) GoalGet(ctx context.Context, id int64) (*model.GoalProgress, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.GoalProgress), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) GoalList(ctx context.Context) ([]model.GoalProgress, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.GoalProgress), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) GoalContribute(ctx context.Context, req model.GoalContribute) (*model.TransactionAddResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TransactionAddResponse), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) ReportForecast(ctx context.Context, historyPeriods int32) (*model.ReportForecastResponse, error) {
+	args := m.Called(ctx, historyPeriods)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReportForecastResponse), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) ReportAnomalies(ctx context.Context, from string, to string) ([]model.ReportAnomaly, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).([]model.ReportAnomaly), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) WebhookAdd(ctx context.Context, req model.Webhook) (*model.WebhookAddResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.WebhookAddResponse), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) WebhookDelete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockLedgerGatewayService) WebhookList(ctx context.Context) ([]model.Webhook, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.Webhook), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) WebhookDeliveries(ctx context.Context, webhookID int64, limit int32) ([]model.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) Subscribe(ctx context.Context, fromEventID int64, types []string, send func(model.LedgerEvent) error) error {
+	args := m.Called(ctx, fromEventID, types, send)
+	if events, ok := args.Get(0).([]model.LedgerEvent); ok {
+		for _, event := range events {
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockLedgerGatewayService) AuditList(ctx context.Context, req model.AuditList) ([]model.AuditEntry, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.AuditEntry), args.Error(1)
+}
+
 func TestLedgerHandler_BudgetAdd(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -149,18 +327,18 @@ func TestLedgerHandler_BudgetList(t *testing.T) {
 		{
 			name: "successful budget list",
 			mockSetup: func(m *MockLedgerGatewayService) {
-				m.On("BudgetList", mock.Anything).Return([]model.BudgetGetResponse{
-					{Category: "Food", Limit: 1000.0},
-					{Category: "Transport", Limit: 500.0},
+				m.On("BudgetList", mock.Anything, "").Return([]model.BudgetGetResponse{
+					{CategoryID: 1, Category: "Food", Limit: 1000.0},
+					{CategoryID: 2, Category: "Transport", Limit: 500.0},
 				}, nil)
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `[{"category":"Food","limit":1000},{"category":"Transport","limit":500}]`,
+			expectedBody:   `[{"category_id":1,"category":"Food","limit":1000},{"category_id":2,"category":"Transport","limit":500}]`,
 		},
 		{
 			name: "service error",
 			mockSetup: func(m *MockLedgerGatewayService) {
-				m.On("BudgetList", mock.Anything).Return([]model.BudgetGetResponse(nil), assert.AnError)
+				m.On("BudgetList", mock.Anything, "").Return([]model.BudgetGetResponse(nil), assert.AnError)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   `{"error":"assert.AnError general error for testing"}`,
@@ -191,6 +369,34 @@ func TestLedgerHandler_BudgetList(t *testing.T) {
 	}
 }
 
+func TestLedgerHandler_BudgetListAsOf(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockLedgerGatewayService{}
+	mockService.On("BudgetList", mock.Anything, "2025-12-15").Return([]model.BudgetGetResponse{
+		{CategoryID: 1, Category: "Food", Limit: 800.0, Spent: 430.0},
+	}, nil)
+	mockService.On("BudgetList", mock.Anything, "yesterday").Return([]model.BudgetGetResponse(nil),
+		status.Error(codes.InvalidArgument, "as_of must be YYYY-MM-DD or RFC3339"))
+	handler := NewLedgerHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/budget/list?as_of=2025-12-15", nil)
+	handler.BudgetList(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"category_id":1,"category":"Food","limit":800,"spent":430}]`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/budget/list?as_of=yesterday", nil)
+	handler.BudgetList(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"as_of must be YYYY-MM-DD or RFC3339"}`, w.Body.String())
+
+	mockService.AssertExpectations(t)
+}
+
 func TestLedgerHandler_BudgetGet(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -206,12 +412,13 @@ func TestLedgerHandler_BudgetGet(t *testing.T) {
 			query: "category=Food",
 			mockSetup: func(m *MockLedgerGatewayService) {
 				m.On("BudgetGet", mock.Anything, model.BudgetGet{Category: "Food"}).Return(&model.BudgetGetResponse{
-					Category: "Food",
-					Limit:    1000.0,
+					CategoryID: 1,
+					Category:   "Food",
+					Limit:      1000.0,
 				}, nil)
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"category":"Food","limit":1000}`,
+			expectedBody:   `{"category_id":1,"category":"Food","limit":1000}`,
 		},
 		{
 			name:           "empty category",
@@ -261,9 +468,11 @@ func TestLedgerHandler_TransactionAdd(t *testing.T) {
 	tests := []struct {
 		name           string
 		requestBody    model.TrasnactionAdd
+		idempotencyKey string
 		mockSetup      func(*MockLedgerGatewayService)
 		expectedStatus int
 		expectedBody   string
+		expectedRetry  string
 	}{
 		{
 			name: "successful transaction add",
@@ -295,6 +504,86 @@ func TestLedgerHandler_TransactionAdd(t *testing.T) {
 			expectedStatus: http.StatusConflict,
 			expectedBody:   `{"error":"budget exceeded"}`,
 		},
+		{
+			name: "duplicate transaction",
+			requestBody: model.TrasnactionAdd{
+				Amount:      100.0,
+				Category:    "Food",
+				Description: "Lunch",
+				Date:        "2025-12-01",
+				ExternalID:  "bank-1",
+			},
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("TransactionAdd", mock.Anything, mock.Anything).Return((*model.TransactionAddResponse)(nil), status.Error(codes.AlreadyExists, "add transaction: duplicate transaction: matches transaction 9"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"add transaction: duplicate transaction: matches transaction 9"}`,
+		},
+		{
+			name: "forced duplicate",
+			requestBody: model.TrasnactionAdd{
+				Amount:      100.0,
+				Category:    "Food",
+				Description: "Lunch",
+				Date:        "2025-12-01",
+				Force:       true,
+			},
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("TransactionAdd", mock.Anything, mock.MatchedBy(func(req model.TrasnactionAdd) bool {
+					return req.Force
+				})).Return(&model.TransactionAddResponse{Id: 10}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":10}`,
+		},
+		{
+			name: "idempotency key forwarded",
+			requestBody: model.TrasnactionAdd{
+				Amount:      100.0,
+				Category:    "Food",
+				Description: "Lunch",
+				Date:        "2025-12-01",
+			},
+			idempotencyKey: "key-1",
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("TransactionAdd", mock.Anything, mock.MatchedBy(func(req model.TrasnactionAdd) bool {
+					return req.IdempotencyKey == "key-1"
+				})).Return(&model.TransactionAddResponse{Id: 7}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":7}`,
+		},
+		{
+			name: "idempotency key reused with different body",
+			requestBody: model.TrasnactionAdd{
+				Amount:      150.0,
+				Category:    "Food",
+				Description: "Lunch",
+				Date:        "2025-12-01",
+			},
+			idempotencyKey: "key-1",
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("TransactionAdd", mock.Anything, mock.Anything).Return((*model.TransactionAddResponse)(nil), status.Error(codes.FailedPrecondition, "add transaction: idempotency key reused with different request"))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"add transaction: idempotency key reused with different request"}`,
+		},
+		{
+			name: "idempotency key stored concurrently",
+			requestBody: model.TrasnactionAdd{
+				Amount:      100.0,
+				Category:    "Food",
+				Description: "Lunch",
+				Date:        "2025-12-01",
+			},
+			idempotencyKey: "key-1",
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("TransactionAdd", mock.Anything, mock.Anything).Return((*model.TransactionAddResponse)(nil), status.Error(codes.Aborted, "add transaction: idempotency key already stored"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"add transaction: idempotency key already stored"}`,
+			expectedRetry:  "1",
+		},
 		{
 			name: "zero amount",
 			requestBody: model.TrasnactionAdd{
@@ -308,16 +597,20 @@ func TestLedgerHandler_TransactionAdd(t *testing.T) {
 			expectedBody:   `{"error":"amount must not be empty"}`,
 		},
 		{
-			name: "empty category",
+			name: "empty category left to rules",
 			requestBody: model.TrasnactionAdd{
 				Amount:      100.0,
 				Category:    "",
-				Description: "Lunch",
+				Description: "UBER *TRIP",
 				Date:        "2025-12-01",
 			},
-			mockSetup:      func(m *MockLedgerGatewayService) {},
-			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"error":"category must not be empty"}`,
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("TransactionAdd", mock.Anything, mock.MatchedBy(func(req model.TrasnactionAdd) bool {
+					return req.Category == "" && req.CategoryID == 0
+				})).Return(&model.TransactionAddResponse{Id: 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":5}`,
 		},
 		{
 			name: "empty date",
@@ -369,6 +662,9 @@ func TestLedgerHandler_TransactionAdd(t *testing.T) {
 			body, _ := json.Marshal(tt.requestBody)
 			req, _ := http.NewRequest(http.MethodPost, "/transactions/", bytes.NewBuffer(body))
 			req.Header.Set("Content-Type", "application/json")
+			if tt.idempotencyKey != "" {
+				req.Header.Set("Idempotency-Key", tt.idempotencyKey)
+			}
 
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
@@ -377,6 +673,7 @@ func TestLedgerHandler_TransactionAdd(t *testing.T) {
 			handler.TransactionAdd(c)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedRetry, w.Header().Get("Retry-After"))
 			if tt.expectedBody != "" {
 				assert.JSONEq(t, tt.expectedBody, w.Body.String())
 			}
@@ -402,13 +699,14 @@ func TestLedgerHandler_TransactionGet(t *testing.T) {
 				m.On("TransactionGet", mock.Anything, model.TransactionGet{Id: 1}).Return(&model.TransactionGetResponse{
 					Id:          1,
 					Amount:      100.0,
+					CategoryID:  1,
 					Category:    "Food",
 					Description: "Lunch",
 					Date:        "2025-12-01",
 				}, nil)
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"id":1,"amount":100,"category":"Food","description":"Lunch","Date":"2025-12-01"}`,
+			expectedBody:   `{"id":1,"amount":100,"category_id":1,"category":"Food","description":"Lunch","Date":"2025-12-01"}`,
 		},
 		{
 			name:           "empty id",
@@ -471,10 +769,11 @@ func TestLedgerHandler_TransactionList(t *testing.T) {
 		{
 			name: "successful transaction list",
 			mockSetup: func(m *MockLedgerGatewayService) {
-				m.On("TransactionList", mock.Anything).Return([]model.TransactionGetResponse{
+				m.On("TransactionList", mock.Anything, model.TransactionList{}).Return([]model.TransactionGetResponse{
 					{
 						Id:          1,
 						Amount:      100.0,
+						CategoryID:  1,
 						Category:    "Food",
 						Description: "Lunch",
 						Date:        "2025-12-01",
@@ -482,6 +781,7 @@ func TestLedgerHandler_TransactionList(t *testing.T) {
 					{
 						Id:          2,
 						Amount:      50.0,
+						CategoryID:  2,
 						Category:    "Transport",
 						Description: "Bus",
 						Date:        "2025-12-02",
@@ -489,12 +789,12 @@ func TestLedgerHandler_TransactionList(t *testing.T) {
 				}, nil)
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `[{"id":1,"amount":100,"category":"Food","description":"Lunch","Date":"2025-12-01"},{"id":2,"amount":50,"category":"Transport","description":"Bus","Date":"2025-12-02"}]`,
+			expectedBody:   `[{"id":1,"amount":100,"category_id":1,"category":"Food","description":"Lunch","Date":"2025-12-01"},{"id":2,"amount":50,"category_id":2,"category":"Transport","description":"Bus","Date":"2025-12-02"}]`,
 		},
 		{
 			name: "service error",
 			mockSetup: func(m *MockLedgerGatewayService) {
-				m.On("TransactionList", mock.Anything).Return([]model.TransactionGetResponse(nil), assert.AnError)
+				m.On("TransactionList", mock.Anything, model.TransactionList{}).Return([]model.TransactionGetResponse(nil), assert.AnError)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   `{"error":"assert.AnError general error for testing"}`,
@@ -525,6 +825,37 @@ func TestLedgerHandler_TransactionList(t *testing.T) {
 	}
 }
 
+func TestLedgerHandler_TransactionListByTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockLedgerGatewayService{}
+	mockService.On("TransactionList", mock.Anything, model.TransactionList{Tag: "vacation-2025"}).Return([]model.TransactionGetResponse{
+		{
+			Id:          1,
+			Amount:      100.0,
+			CategoryID:  1,
+			Category:    "Food",
+			Description: "Lunch",
+			Date:        "2025-12-01",
+			Tags:        []string{"vacation-2025"},
+		},
+	}, nil)
+
+	handler := NewLedgerHandler(mockService)
+
+	req, _ := http.NewRequest(http.MethodGet, "/transactions/list?tag=vacation-2025", nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.TransactionList(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id":1,"amount":100,"category_id":1,"category":"Food","description":"Lunch","Date":"2025-12-01","tags":["vacation-2025"]}]`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
 func TestLedgerHandler_TransactionExportCSV(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -538,7 +869,7 @@ func TestLedgerHandler_TransactionExportCSV(t *testing.T) {
 		{
 			name: "successful export",
 			mockSetup: func(m *MockLedgerGatewayService) {
-				m.On("TransactionList", mock.Anything).Return([]model.TransactionGetResponse{
+				m.On("TransactionList", mock.Anything, model.TransactionList{}).Return([]model.TransactionGetResponse{
 					{
 						Id:          1,
 						Amount:      100.0,
@@ -558,7 +889,7 @@ func TestLedgerHandler_TransactionExportCSV(t *testing.T) {
 		{
 			name: "service error",
 			mockSetup: func(m *MockLedgerGatewayService) {
-				m.On("TransactionList", mock.Anything).Return([]model.TransactionGetResponse(nil), assert.AnError)
+				m.On("TransactionList", mock.Anything, model.TransactionList{}).Return([]model.TransactionGetResponse(nil), assert.AnError)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedHeader: nil,
@@ -621,6 +952,25 @@ func TestLedgerHandler_ReportSummary(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedBody:   `{"report":{"Food":500},"cache_result":true}`,
 		},
+		{
+			name:  "report grouped by tag",
+			query: "from=2025-12-01&to=2025-12-31&group_by=tag",
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("ReportSummary", mock.Anything, model.ReportSummary{From: "2025-12-01", To: "2025-12-31", GroupBy: "tag"}).Return(&model.ReportSummaryResponse{
+					Report:      map[string]float64{"vacation-2025": 300.0},
+					CacheResult: false,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"report":{"vacation-2025":300},"cache_result":false}`,
+		},
+		{
+			name:           "invalid group_by",
+			query:          "from=2025-12-01&to=2025-12-31&group_by=month",
+			mockSetup:      func(m *MockLedgerGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid group_by"}`,
+		},
 		{
 			name:           "invalid from date",
 			query:          "from=invalid&to=2025-12-31",
@@ -708,7 +1058,7 @@ func TestLedgerHandler_TransactionBulkAdd(t *testing.T) {
 				}, nil)
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"Accepted":2,"Rejected":0,"Errors":null}`,
+			expectedBody:   `{"Accepted":2,"Rejected":0,"Errors":null,"Duplicates":null,"BatchDuplicates":null}`,
 		},
 		{
 			name: "partial success",
@@ -730,7 +1080,7 @@ func TestLedgerHandler_TransactionBulkAdd(t *testing.T) {
 				}, nil)
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"Accepted":0,"Rejected":1,"Errors":{"0":"budget exceeded"}}`,
+			expectedBody:   `{"Accepted":0,"Rejected":1,"Errors":{"0":"budget exceeded"},"Duplicates":null,"BatchDuplicates":null}`,
 		},
 		{
 			name: "service error",
@@ -777,3 +1127,927 @@ func TestLedgerHandler_TransactionBulkAdd(t *testing.T) {
 		})
 	}
 }
+
+func TestLedgerHandler_CategoryAdd(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    model.CategoryAdd
+		mockSetup      func(*MockLedgerGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "successful category add",
+			requestBody: model.CategoryAdd{Name: "Groceries", ParentID: 1},
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("CategoryAdd", mock.Anything, model.CategoryAdd{Name: "Groceries", ParentID: 1}).
+					Return(&model.CategoryAddResponse{Id: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":2}`,
+		},
+		{
+			name:           "empty name",
+			requestBody:    model.CategoryAdd{ParentID: 1},
+			mockSetup:      func(m *MockLedgerGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"name must not be empty"}`,
+		},
+		{
+			name:        "service error",
+			requestBody: model.CategoryAdd{Name: "Groceries", ParentID: 99},
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("CategoryAdd", mock.Anything, model.CategoryAdd{Name: "Groceries", ParentID: 99}).
+					Return((*model.CategoryAddResponse)(nil), errors.New("parent category not found"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"parent category not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerGatewayService{}
+			tt.mockSetup(mockService)
+
+			handler := NewLedgerHandler(mockService)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest(http.MethodPost, "/categories/", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.CategoryAdd(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerHandler_CategoryUpdate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    model.CategoryUpdate
+		mockSetup      func(*MockLedgerGatewayService)
+		expectedStatus int
+	}{
+		{
+			name:        "successful rename",
+			requestBody: model.CategoryUpdate{Id: 2, Name: "Supermarkets", ParentID: 1},
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("CategoryUpdate", mock.Anything, model.CategoryUpdate{Id: 2, Name: "Supermarkets", ParentID: 1}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing id",
+			requestBody:    model.CategoryUpdate{Name: "Supermarkets"},
+			mockSetup:      func(m *MockLedgerGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "service error",
+			requestBody: model.CategoryUpdate{Id: 1, Name: "Food", ParentID: 2},
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("CategoryUpdate", mock.Anything, model.CategoryUpdate{Id: 1, Name: "Food", ParentID: 2}).
+					Return(errors.New("category can not be moved under its own descendant"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerGatewayService{}
+			tt.mockSetup(mockService)
+
+			handler := NewLedgerHandler(mockService)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest(http.MethodPut, "/categories/", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.CategoryUpdate(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerHandler_CategoryList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockLedgerGatewayService{}
+	mockService.On("CategoryList", mock.Anything).Return([]model.CategoryGetResponse{
+		{Id: 1, Name: "Food"},
+		{Id: 2, Name: "Groceries", ParentID: 1},
+	}, nil)
+
+	handler := NewLedgerHandler(mockService)
+
+	req, _ := http.NewRequest(http.MethodGet, "/categories/list", nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.CategoryList(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id":1,"name":"Food","parent_id":0},{"id":2,"name":"Groceries","parent_id":1}]`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerHandler_RuleAdd(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    model.Rule
+		mockSetup      func(*MockLedgerGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "successful rule add",
+			requestBody: model.Rule{Priority: 1, DescriptionContains: "uber", Category: "Taxi", Description: "Uber"},
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("RuleAdd", mock.Anything, model.Rule{Priority: 1, DescriptionContains: "uber", Category: "Taxi", Description: "Uber"}).
+					Return(&model.RuleAddResponse{Id: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":1}`,
+		},
+		{
+			name:           "empty category",
+			requestBody:    model.Rule{DescriptionContains: "uber"},
+			mockSetup:      func(m *MockLedgerGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"category must not be empty"}`,
+		},
+		{
+			name:        "service error",
+			requestBody: model.Rule{DescriptionRegex: "(", CategoryID: 3},
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("RuleAdd", mock.Anything, mock.Anything).
+					Return((*model.RuleAddResponse)(nil), errors.New("invalid description regex"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"invalid description regex"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerGatewayService{}
+			tt.mockSetup(mockService)
+
+			handler := NewLedgerHandler(mockService)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest(http.MethodPost, "/rules/", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.RuleAdd(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerHandler_RuleDelete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*MockLedgerGatewayService)
+		expectedStatus int
+	}{
+		{
+			name:  "successful delete",
+			query: "id=1",
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("RuleDelete", mock.Anything, int64(1)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid id",
+			query:          "id=abc",
+			mockSetup:      func(m *MockLedgerGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerGatewayService{}
+			tt.mockSetup(mockService)
+
+			handler := NewLedgerHandler(mockService)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/rules/?"+tt.query, nil)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.RuleDelete(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerHandler_RuleTest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockLedgerGatewayService{}
+	mockService.On("RuleTest", mock.Anything, model.RuleTest{Rule: model.Rule{Id: 4}, Last: 50}).Return([]model.RuleMatch{
+		{
+			Transaction: model.TransactionGetResponse{Id: 1, Amount: 10, CategoryID: 9, Category: "Misc", Description: "Coffee", Date: "2025-12-01"},
+			CategoryID:  1,
+			Category:    "Food",
+			Description: "Coffee",
+		},
+	}, nil)
+
+	handler := NewLedgerHandler(mockService)
+
+	req, _ := http.NewRequest(http.MethodPost, "/rules/test", bytes.NewBufferString(`{"rule":{"id":4},"last":50}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.RuleTest(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"transaction":{"id":1,"amount":10,"category_id":9,"category":"Misc","description":"Coffee","Date":"2025-12-01"},"category_id":1,"category":"Food","description":"Coffee"}]`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerHandler_RuleTestInvalidLast(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockLedgerGatewayService{}
+	handler := NewLedgerHandler(mockService)
+
+	req, _ := http.NewRequest(http.MethodPost, "/rules/test", bytes.NewBufferString(`{"rule":{"id":4},"last":5000}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.RuleTest(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"last must be between 1 and 1000, or omitted for 100"}`, w.Body.String())
+	mockService.AssertNotCalled(t, "RuleTest", mock.Anything, mock.Anything)
+}
+
+func TestLedgerHandler_AttachmentUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		path           string
+		content        string
+		mockSetup      func(*MockLedgerGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:    "successful upload",
+			path:    "/api/transactions/3/attachments",
+			content: "%PDF-1.4",
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("AttachmentUpload", mock.Anything, int64(3), "receipt.pdf", []byte("%PDF-1.4")).
+					Return(&model.Attachment{Id: 5, TransactionId: 3, FileName: "receipt.pdf", ContentType: "application/pdf", Size: 8, CreatedAt: "2025-12-01T10:00:00Z"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":5,"transaction_id":3,"file_name":"receipt.pdf","content_type":"application/pdf","size":8,"created_at":"2025-12-01T10:00:00Z"}`,
+		},
+		{
+			name:    "unsupported type",
+			path:    "/api/transactions/3/attachments",
+			content: "hello",
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("AttachmentUpload", mock.Anything, int64(3), "receipt.pdf", []byte("hello")).
+					Return(nil, status.Error(codes.InvalidArgument, "upload attachment: unsupported attachment type"))
+			},
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedBody:   `{"error":"upload attachment: unsupported attachment type"}`,
+		},
+		{
+			name:    "transaction not found",
+			path:    "/api/transactions/9/attachments",
+			content: "%PDF-1.4",
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("AttachmentUpload", mock.Anything, int64(9), "receipt.pdf", []byte("%PDF-1.4")).
+					Return(nil, status.Error(codes.NotFound, "transaction not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"transaction not found"}`,
+		},
+		{
+			name:    "too large",
+			path:    "/api/transactions/3/attachments",
+			content: "%PDF-1.4 large",
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("AttachmentUpload", mock.Anything, int64(3), "receipt.pdf", []byte("%PDF-1.4 large")).
+					Return(nil, status.Error(codes.ResourceExhausted, "upload attachment: attachment too large"))
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   `{"error":"upload attachment: attachment too large"}`,
+		},
+		{
+			name:           "invalid id",
+			path:           "/api/transactions/abc/attachments",
+			content:        "%PDF-1.4",
+			mockSetup:      func(m *MockLedgerGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"id must be int64"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerGatewayService{}
+			tt.mockSetup(mockService)
+
+			engine := gin.New()
+			NewLedgerHandler(mockService).Register(engine.Group("/api"))
+
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			part, _ := writer.CreateFormFile("file", "receipt.pdf")
+			_, _ = part.Write([]byte(tt.content))
+			_ = writer.Close()
+			req, _ := http.NewRequest(http.MethodPost, tt.path, &body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerHandler_AttachmentDownload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockLedgerGatewayService{}
+	mockService.On("AttachmentDownload", mock.Anything, int64(3), int64(5)).
+		Return(&model.Attachment{Id: 5, TransactionId: 3, FileName: "receipt.png", ContentType: "image/png", Size: 4}, strings.NewReader("\x89PNG"), nil)
+	mockService.On("AttachmentDownload", mock.Anything, int64(3), int64(6)).
+		Return(nil, nil, status.Error(codes.NotFound, "attachment not found"))
+
+	engine := gin.New()
+	NewLedgerHandler(mockService).Register(engine.Group("/api"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/transactions/3/attachments/5", nil)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=receipt.png`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "\x89PNG", w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/transactions/3/attachments/6", nil)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error":"attachment not found"}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerHandler_AttachmentDelete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockLedgerGatewayService{}
+	mockService.On("AttachmentDelete", mock.Anything, int64(3), int64(5)).Return(nil)
+
+	engine := gin.New()
+	NewLedgerHandler(mockService).Register(engine.Group("/api"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/transactions/3/attachments/5", nil)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodDelete, "/api/transactions/3/attachments/x", nil)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"attachment_id must be int64"}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerHandler_TransactionSearch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*MockLedgerGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "successful search",
+			query: "q=pharm&lang=english&from=2025-03-01&to=2025-03-31&category_id=2&limit=10",
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("TransactionSearch", mock.Anything, model.TransactionSearch{Query: "pharm", Language: "english", From: "2025-03-01", To: "2025-03-31", CategoryID: 2, Limit: 10}).
+					Return([]model.TransactionSearchResult{{Transaction: model.TransactionGetResponse{Id: 4, Amount: 12.5, CategoryID: 2, Category: "Health", Description: "Pharmacy", Date: "2025-03-14"}, Rank: 0.5}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[{"transaction":{"id":4,"amount":12.5,"category_id":2,"category":"Health","description":"Pharmacy","Date":"2025-03-14"},"rank":0.5}]`,
+		},
+		{
+			name:           "empty query",
+			query:          "lang=english",
+			mockSetup:      func(m *MockLedgerGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"q must not be empty"}`,
+		},
+		{
+			name:           "invalid date",
+			query:          "q=pharm&from=March",
+			mockSetup:      func(m *MockLedgerGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"Date invalid"}`,
+		},
+		{
+			name:  "unsupported language",
+			query: "q=pharm&lang=klingon",
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("TransactionSearch", mock.Anything, mock.Anything).
+					Return([]model.TransactionSearchResult(nil), status.Error(codes.InvalidArgument, "invalid search: unsupported search language"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid search: unsupported search language"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerGatewayService{}
+			tt.mockSetup(mockService)
+
+			engine := gin.New()
+			NewLedgerHandler(mockService).Register(engine.Group("/api"))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/api/transactions/search?"+tt.query, nil)
+			engine.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerHandler_GoalAdd(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockLedgerGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "successful add",
+			body: `{"name":"Vacation","target_amount":1200,"deadline":"2026-06-30"}`,
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("GoalAdd", mock.Anything, model.Goal{Name: "Vacation", TargetAmount: 1200, Deadline: "2026-06-30"}).
+					Return(&model.GoalAddResponse{Id: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":1}`,
+		},
+		{
+			name:           "invalid deadline",
+			body:           `{"name":"Vacation","target_amount":1200,"deadline":"30.06.2026"}`,
+			mockSetup:      func(m *MockLedgerGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"deadline must be YYYY-MM-DD"}`,
+		},
+		{
+			name:           "non-positive target",
+			body:           `{"name":"Vacation","target_amount":0,"deadline":"2026-06-30"}`,
+			mockSetup:      func(m *MockLedgerGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"target_amount must be positive"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerGatewayService{}
+			tt.mockSetup(mockService)
+
+			engine := gin.New()
+			NewLedgerHandler(mockService).Register(engine.Group("/api"))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/api/goals/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			engine.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerHandler_GoalGet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockLedgerGatewayService{}
+	mockService.On("GoalGet", mock.Anything, int64(1)).Return(&model.GoalProgress{
+		Goal:            model.Goal{Id: 1, Name: "Vacation", TargetAmount: 1200, Deadline: "2026-06-30"},
+		Saved:           500,
+		Remaining:       700,
+		Percent:         41.5,
+		MonthsLeft:      7,
+		MonthlyRequired: 100,
+	}, nil)
+	mockService.On("GoalGet", mock.Anything, int64(2)).Return(nil, status.Error(codes.NotFound, "goal not found"))
+
+	engine := gin.New()
+	NewLedgerHandler(mockService).Register(engine.Group("/api"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/goals/?id=1", nil)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"goal":{"id":1,"name":"Vacation","target_amount":1200,"deadline":"2026-06-30"},"saved":500,"remaining":700,"percent":41.5,"months_left":7,"monthly_required":100}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/goals/?id=2", nil)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error":"goal not found"}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerHandler_GoalDelete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockLedgerGatewayService{}
+	mockService.On("GoalDelete", mock.Anything, int64(1)).Return(status.Error(codes.FailedPrecondition, "delete goal: goal has contributions"))
+
+	engine := gin.New()
+	NewLedgerHandler(mockService).Register(engine.Group("/api"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/goals/?id=1", nil)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.JSONEq(t, `{"error":"delete goal: goal has contributions"}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerHandler_GoalContribute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockLedgerGatewayService{}
+	mockService.On("GoalContribute", mock.Anything, model.GoalContribute{GoalId: 1, Amount: 150, Date: "2025-12-11"}).
+		Return(&model.TransactionAddResponse{Id: 42}, nil)
+
+	engine := gin.New()
+	NewLedgerHandler(mockService).Register(engine.Group("/api"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/goals/contribute", strings.NewReader(`{"goal_id":1,"amount":150,"date":"2025-12-11"}`))
+	req.Header.Set("Content-Type", "application/json")
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":42}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/api/goals/contribute", strings.NewReader(`{"goal_id":1,"amount":-5}`))
+	req.Header.Set("Content-Type", "application/json")
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"amount must be positive"}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerHandler_ReportForecast(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockLedgerGatewayService{}
+	mockService.On("ReportForecast", mock.Anything, int32(6)).Return(&model.ReportForecastResponse{
+		PeriodStart:  "2025-12-01",
+		PeriodEnd:    "2025-12-31",
+		DaysElapsed:  10,
+		DaysInPeriod: 31,
+		Categories:   []model.CategoryForecast{{Category: "Food", Spent: 100, HistoricalAverage: 310, Projected: 310}},
+		Budgets:      []model.BudgetForecast{{CategoryID: 1, Category: "Food", Limit: 300, Projected: 310, LikelyExceeded: true}},
+	}, nil)
+
+	engine := gin.New()
+	NewLedgerHandler(mockService).Register(engine.Group("/api"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/reports/forecast?history=6", nil)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"period_start":"2025-12-01","period_end":"2025-12-31","days_elapsed":10,"days_in_period":31,"categories":[{"category":"Food","spent":100,"scheduled":0,"historical_average":310,"projected":310}],"budgets":[{"category_id":1,"category":"Food","limit":300,"projected":310,"likely_exceeded":true}]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/reports/forecast?history=0", nil)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"history must be between 1 and 24"}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerHandler_ReportAnomalies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockLedgerGatewayService{}
+	mockService.On("ReportAnomalies", mock.Anything, "2025-12-01", "2025-12-31").Return([]model.ReportAnomaly{{
+		Transaction: model.TransactionGetResponse{Id: 7, Amount: 900, CategoryID: 1, Category: "Food", Description: "Banquet", Date: "2025-12-01"},
+		Score:       40.5,
+		Ratio:       9,
+		Median:      100,
+		MAD:         13.5,
+	}}, nil)
+
+	engine := gin.New()
+	NewLedgerHandler(mockService).Register(engine.Group("/api"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/reports/anomalies?from=2025-12-01&to=2025-12-31", nil)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"transaction":{"id":7,"amount":900,"category_id":1,"category":"Food","description":"Banquet","Date":"2025-12-01"},"score":40.5,"ratio":9,"median":100,"mad":13.5}]`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/reports/anomalies?from=2025-12-01", nil)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"invalid to"}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerHandler_WebhookAdd(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockLedgerGatewayService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "successful add",
+			body: `{"url":"https://bot.example.com/ledger","secret":"s3cret","events":["budget.exceeded"]}`,
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("WebhookAdd", mock.Anything, model.Webhook{Url: "https://bot.example.com/ledger", Secret: "s3cret", Events: []string{"budget.exceeded"}}).
+					Return(&model.WebhookAddResponse{Id: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":1}`,
+		},
+		{
+			name:           "missing secret",
+			body:           `{"url":"https://bot.example.com/ledger","events":["budget.exceeded"]}`,
+			mockSetup:      func(m *MockLedgerGatewayService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"secret must not be empty"}`,
+		},
+		{
+			name: "unknown event",
+			body: `{"url":"https://bot.example.com/ledger","secret":"s3cret","events":["budget.spent"]}`,
+			mockSetup: func(m *MockLedgerGatewayService) {
+				m.On("WebhookAdd", mock.Anything, mock.Anything).
+					Return(nil, status.Error(codes.InvalidArgument, `unknown event "budget.spent"`))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"unknown event \"budget.spent\""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerGatewayService{}
+			tt.mockSetup(mockService)
+
+			engine := gin.New()
+			NewLedgerHandler(mockService).Register(engine.Group("/api"))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/api/webhooks/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			engine.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerHandler_WebhookDeliveries(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockLedgerGatewayService{}
+	mockService.On("WebhookDeliveries", mock.Anything, int64(1), int32(10)).Return([]model.WebhookDelivery{
+		{
+			Id:             7,
+			WebhookId:      1,
+			Event:          "budget.exceeded",
+			Payload:        json.RawMessage(`{"category":"Food"}`),
+			Status:         "pending",
+			Attempts:       1,
+			LastStatusCode: 500,
+			LastError:      "unexpected status 500",
+			NextAttemptAt:  "2025-12-19T10:00:05Z",
+			CreatedAt:      "2025-12-19T10:00:00Z",
+		},
+	}, nil)
+	mockService.On("WebhookDeliveries", mock.Anything, int64(2), int32(0)).Return(nil, status.Error(codes.NotFound, "webhook not found"))
+
+	engine := gin.New()
+	NewLedgerHandler(mockService).Register(engine.Group("/api"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/webhooks/deliveries?webhook_id=1&limit=10", nil)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id":7,"webhook_id":1,"event":"budget.exceeded","payload":{"category":"Food"},"status":"pending","attempts":1,"last_status_code":500,"last_error":"unexpected status 500","next_attempt_at":"2025-12-19T10:00:05Z","created_at":"2025-12-19T10:00:00Z"}]`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/webhooks/deliveries?webhook_id=2", nil)
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/webhooks/deliveries?webhook_id=1&limit=0", nil)
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/webhooks/deliveries", nil)
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"webhook_id must not be empty"}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerHandler_Events(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockLedgerGatewayService{}
+	mockService.On("Subscribe", mock.Anything, int64(41), []string{"transaction.created", "budget.set"}, mock.Anything).Return([]model.LedgerEvent{
+		{Id: 42, Type: "transaction.created", AggregateId: 15, Payload: json.RawMessage(`{"id":15}`), CreatedAt: "2025-12-19T10:00:00Z"},
+	}, status.Error(codes.ResourceExhausted, "subscriber is too slow"))
+	mockService.On("Subscribe", mock.Anything, int64(7), []string(nil), mock.Anything).Return(nil, nil)
+
+	engine := gin.New()
+	NewLedgerHandler(mockService).Register(engine.Group("/api"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/events?types=transaction.created,budget.set", nil)
+	req.Header.Set("Last-Event-ID", "41")
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, "id: 42\nevent: transaction.created\n"+
+		`data: {"id":42,"type":"transaction.created","aggregate_id":15,"payload":{"id":15},"created_at":"2025-12-19T10:00:00Z"}`+"\n\n"+
+		"event: error\n"+
+		`data: {"code":"ResourceExhausted","error":"subscriber is too slow"}`+"\n\n", w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/events?from=7", nil)
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/events?from=-1", nil)
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerHandler_EventsClientDisconnect(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	subscribed := make(chan struct{})
+	mockService := &MockLedgerGatewayService{}
+	mockService.On("Subscribe", mock.Anything, int64(0), []string(nil), mock.Anything).Return(nil, nil).Run(func(args mock.Arguments) {
+		close(subscribed)
+		<-args.Get(0).(context.Context).Done()
+	})
+
+	engine := gin.New()
+	NewLedgerHandler(mockService).Register(engine.Group("/api"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/api/events", nil)
+	finished := make(chan struct{})
+	go func() {
+		engine.ServeHTTP(httptest.NewRecorder(), req)
+		close(finished)
+	}()
+
+	<-subscribed
+	cancel()
+	<-finished
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerHandler_AuditList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockLedgerGatewayService{}
+	mockService.On("AuditList", mock.Anything, model.AuditList{EntityType: "budget", EntityId: 1, From: "2025-12-01", Limit: 20}).Return([]model.AuditEntry{
+		{
+			Id:          9,
+			Action:      "budget.set",
+			EntityType:  "budget",
+			EntityId:    1,
+			ActorUserId: "98ac2ffe",
+			RequestId:   "req-1",
+			Method:      "/ledger.v1.LedgerService/BudgetAdd",
+			After:       json.RawMessage(`{"limit":1000}`),
+			CreatedAt:   "2025-12-10T12:00:00Z",
+		},
+	}, nil)
+	mockService.On("AuditList", mock.Anything, model.AuditList{From: "yesterday"}).Return(nil, status.Error(codes.InvalidArgument, "from must be YYYY-MM-DD or RFC3339"))
+
+	engine := gin.New()
+	NewLedgerHandler(mockService).Register(engine.Group("/api"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/audit?entity_type=budget&entity_id=1&from=2025-12-01&limit=20", nil)
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"id":9,"action":"budget.set","entity_type":"budget","entity_id":1,"actor_user_id":"98ac2ffe","request_id":"req-1","method":"/ledger.v1.LedgerService/BudgetAdd","after":{"limit":1000},"created_at":"2025-12-10T12:00:00Z"}]`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/audit?from=yesterday", nil)
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"from must be YYYY-MM-DD or RFC3339"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/audit?entity_id=x", nil)
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"entity_id must be int64"}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
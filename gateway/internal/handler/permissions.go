@@ -0,0 +1,91 @@
+package handler
+
+import "gateway/internal/auth"
+
+// RoutePermissions lists every gateway route with the permission it needs;
+// a route that is missing here is denied.
+var RoutePermissions = auth.RoutePermissions{
+	"GET /ping":                  auth.PermPublic,
+	"GET /.well-known/jwks.json": auth.PermPublic,
+
+	"POST /api/auth/login":                  auth.PermPublic,
+	"POST /api/auth/refresh":                auth.PermPublic,
+	"POST /api/auth/logout":                 auth.PermAuthenticated,
+	"POST /api/auth/logout-all":             auth.PermAuthenticated,
+	"POST /api/auth/password":               auth.PermAuthenticated,
+	"POST /api/auth/password/reset-request": auth.PermPublic,
+	"POST /api/auth/password/reset":         auth.PermPublic,
+	"POST /api/auth/2fa/verify":             auth.PermPublic,
+	"POST /api/auth/2fa/enroll":             auth.PermAuthenticated,
+	"POST /api/auth/2fa/activate":           auth.PermAuthenticated,
+	"POST /api/auth/2fa/disable":            auth.PermAuthenticated,
+
+	"GET /api/admin/users/":         auth.PermManageUsers,
+	"PUT /api/admin/users/:id/role": auth.PermManageUsers,
+	"DELETE /api/admin/users/:id":   auth.PermManageUsers,
+	"POST /api/admin/unlock":        auth.PermManageUsers,
+	"GET /api/admin/auth-events":    auth.PermManageUsers,
+
+	// Membership is checked by the auth service; leaving a space is open
+	// to every role.
+	"POST /api/spaces/":                       auth.PermWrite,
+	"GET /api/spaces/":                        auth.PermAuthenticated,
+	"POST /api/spaces/switch":                 auth.PermAuthenticated,
+	"GET /api/spaces/:id/members":             auth.PermAuthenticated,
+	"DELETE /api/spaces/:id/members/:user_id": auth.PermAuthenticated,
+	"POST /api/spaces/:id/invitations":        auth.PermWrite,
+	"GET /api/invitations/":                   auth.PermAuthenticated,
+	"POST /api/invitations/:id/accept":        auth.PermAuthenticated,
+	"POST /api/invitations/:id/decline":       auth.PermAuthenticated,
+
+	// Keys are managed with access tokens only; RouteScope gives these
+	// routes no scope.
+	"POST /api/api-keys/":      auth.PermAuthenticated,
+	"GET /api/api-keys/":       auth.PermAuthenticated,
+	"DELETE /api/api-keys/:id": auth.PermAuthenticated,
+
+	"POST /api/budget/":    auth.PermWrite,
+	"GET /api/budget/":     auth.PermRead,
+	"GET /api/budget/list": auth.PermRead,
+
+	"POST /api/transactions/":                                 auth.PermWrite,
+	"POST /api/transactions/bulk":                             auth.PermWrite,
+	"GET /api/transactions/":                                  auth.PermRead,
+	"GET /api/transactions/list":                              auth.PermRead,
+	"GET /api/transactions/search":                            auth.PermRead,
+	"GET /api/transactions/export.csv":                        auth.PermRead,
+	"POST /api/transactions/:id/attachments":                  auth.PermWrite,
+	"GET /api/transactions/:id/attachments":                   auth.PermRead,
+	"GET /api/transactions/:id/attachments/:attachment_id":    auth.PermRead,
+	"DELETE /api/transactions/:id/attachments/:attachment_id": auth.PermWrite,
+
+	"POST /api/categories/":    auth.PermWrite,
+	"PUT /api/categories/":     auth.PermWrite,
+	"GET /api/categories/list": auth.PermRead,
+
+	"POST /api/rules/":    auth.PermWrite,
+	"PUT /api/rules/":     auth.PermWrite,
+	"DELETE /api/rules/":  auth.PermWrite,
+	"GET /api/rules/list": auth.PermRead,
+	// A dry run over past transactions; nothing is saved.
+	"POST /api/rules/test": auth.PermRead,
+
+	"POST /api/goals/":           auth.PermWrite,
+	"PUT /api/goals/":            auth.PermWrite,
+	"DELETE /api/goals/":         auth.PermWrite,
+	"GET /api/goals/":            auth.PermRead,
+	"GET /api/goals/list":        auth.PermRead,
+	"POST /api/goals/contribute": auth.PermWrite,
+
+	"POST /api/webhooks/":          auth.PermWrite,
+	"DELETE /api/webhooks/":        auth.PermWrite,
+	"GET /api/webhooks/list":       auth.PermRead,
+	"GET /api/webhooks/deliveries": auth.PermRead,
+
+	"GET /api/reports/summary":   auth.PermRead,
+	"GET /api/reports/forecast":  auth.PermRead,
+	"GET /api/reports/anomalies": auth.PermRead,
+
+	"GET /api/events": auth.PermRead,
+	"GET /api/audit":  auth.PermRead,
+}
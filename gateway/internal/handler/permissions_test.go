@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"gateway/internal/auth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoutePermissions_CoverRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.GET("/ping", func(c *gin.Context) {})
+	engine.GET("/.well-known/jwks.json", func(c *gin.Context) {})
+	api := engine.Group("/api")
+	NewLedgerHandler(&MockLedgerGatewayService{}).Register(api)
+	NewAuthHandler(&MockAuthGatewayService{}).Register(api)
+
+	routes := make(map[string]bool)
+	for _, r := range engine.Routes() {
+		key := auth.RouteKey(r.Method, r.Path)
+		routes[key] = true
+		assert.Contains(t, RoutePermissions, key, "route has no permission")
+	}
+	for key := range RoutePermissions {
+		assert.True(t, routes[key], "permission for unknown route %s", key)
+	}
+
+	assert.Equal(t, auth.PermWrite, RoutePermissions[auth.RouteKey(http.MethodPost, "/api/budget/")])
+	assert.Equal(t, auth.PermWrite, RoutePermissions[auth.RouteKey(http.MethodPost, "/api/transactions/bulk")])
+}
+
+func TestRoutePermissions_Scopes(t *testing.T) {
+	for key, p := range RoutePermissions {
+		_, path, _ := strings.Cut(key, " ")
+		if p == auth.PermRead || p == auth.PermWrite {
+			assert.NotEmpty(t, auth.RouteScope(path, p), "no scope for %s", key)
+		}
+	}
+	assert.Equal(t, "transactions:write", auth.RouteScope("/api/transactions/bulk", RoutePermissions["POST /api/transactions/bulk"]))
+	assert.Equal(t, "reports:read", auth.RouteScope("/api/reports/summary", RoutePermissions["GET /api/reports/summary"]))
+	assert.Empty(t, auth.RouteScope("/api/api-keys/", RoutePermissions["POST /api/api-keys/"]))
+}
@@ -0,0 +1,172 @@
+package model
+
+type AuthLogin struct {
+	Login    string `json:"login" example:"testuser"`
+	Password string `json:"password" example:"testpass"`
+}
+
+type PasswordChange struct {
+	OldPassword string `json:"old_password" example:"testpass"`
+	NewPassword string `json:"new_password" example:"correct horse battery"`
+}
+
+type PasswordResetRequest struct {
+	Login string `json:"login" example:"testuser"`
+}
+
+type PasswordReset struct {
+	ResetToken  string `json:"reset_token" example:"Xc4mN8..."`
+	NewPassword string `json:"new_password" example:"correct horse battery"`
+}
+
+type AuthRefresh struct {
+	RefreshToken string `json:"refresh_token" example:"q3Jb9x..."`
+}
+
+// AuthTokens hold only ChallengeToken when the login needs a second factor,
+// see TOTPVerify.
+type AuthTokens struct {
+	Token          string `json:"token,omitempty"`
+	RefreshToken   string `json:"refresh_token,omitempty"`
+	ChallengeToken string `json:"challenge_token,omitempty"`
+	ExpiresIn      int64  `json:"expires_in" example:"900"`
+}
+
+// TOTPVerify completes a login with 2FA: Code is a TOTP code or one of the
+// recovery codes.
+type TOTPVerify struct {
+	ChallengeToken string `json:"challenge_token" example:"Jd8sK2..."`
+	Code           string `json:"code" example:"492039"`
+}
+
+type TOTPCode struct {
+	Code string `json:"code" example:"492039"`
+}
+
+type TOTPEnrollment struct {
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	URI    string `json:"uri" example:"otpauth://totp/ledger:bob?secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP&issuer=ledger"`
+}
+
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k3x7q-m2f7a"`
+}
+
+type AuthLogout struct {
+	RefreshToken string `json:"refresh_token,omitempty" example:"q3Jb9x..."`
+}
+
+type User struct {
+	Id        string `json:"id"`
+	Login     string `json:"login"`
+	Role      string `json:"role" example:"member"`
+	CreatedAt string `json:"created_at" example:"2026-10-18T12:00:00Z"`
+}
+
+type UserRole struct {
+	Role string `json:"role" example:"viewer"`
+}
+
+// LoginUnlock clears the failed attempts of a login, an IP or both.
+type LoginUnlock struct {
+	Login string `json:"login,omitempty" example:"bob"`
+	Ip    string `json:"ip,omitempty" example:"203.0.113.7"`
+}
+
+type AuthEventList struct {
+	Login    string
+	Ip       string
+	Type     string
+	BeforeId int64
+	Limit    int32
+}
+
+type AuthEvent struct {
+	Id          int64  `json:"id" example:"3"`
+	Type        string `json:"type" example:"login.locked"`
+	Login       string `json:"login,omitempty" example:"bob"`
+	Ip          string `json:"ip,omitempty" example:"203.0.113.7"`
+	ActorUserId string `json:"actor_user_id,omitempty"`
+	Failures    int32  `json:"failures,omitempty" example:"6"`
+	LockedUntil string `json:"locked_until,omitempty" example:"2026-10-18T12:00:01Z"`
+	CreatedAt   string `json:"created_at" example:"2026-10-18T12:00:00Z"`
+}
+
+type Space struct {
+	Id        string `json:"id"`
+	Name      string `json:"name" example:"Household"`
+	OwnerId   string `json:"owner_id"`
+	CreatedAt string `json:"created_at" example:"2026-10-18T12:00:00Z"`
+}
+
+type SpaceCreate struct {
+	Name string `json:"name" example:"Household"`
+}
+
+type SpaceMember struct {
+	UserId   string `json:"user_id"`
+	Login    string `json:"login"`
+	JoinedAt string `json:"joined_at" example:"2026-10-18T12:00:00Z"`
+}
+
+// SpaceSwitch selects the active space; an empty SpaceId switches back to
+// the personal ledger.
+type SpaceSwitch struct {
+	SpaceId string `json:"space_id"`
+}
+
+type SpaceToken struct {
+	Token     string `json:"token"`
+	ExpiresIn int64  `json:"expires_in" example:"900"`
+}
+
+type SpaceInvite struct {
+	Login string `json:"login" example:"bob"`
+}
+
+type Invitation struct {
+	Id        string `json:"id"`
+	SpaceId   string `json:"space_id"`
+	SpaceName string `json:"space_name" example:"Household"`
+	InvitedBy string `json:"invited_by" example:"alice"`
+	CreatedAt string `json:"created_at" example:"2026-10-18T12:00:00Z"`
+}
+
+type APIKey struct {
+	Id         string   `json:"id"`
+	Name       string   `json:"name" example:"nightly import"`
+	Prefix     string   `json:"prefix" example:"lk_Q2hhcmxp"`
+	Scopes     []string `json:"scopes" example:"transactions:write,reports:read"`
+	SpaceId    string   `json:"space_id,omitempty"`
+	ExpiresAt  string   `json:"expires_at,omitempty" example:"2027-10-18T12:00:00Z"`
+	LastUsedAt string   `json:"last_used_at,omitempty" example:"2026-10-18T12:00:00Z"`
+	CreatedAt  string   `json:"created_at" example:"2026-10-18T12:00:00Z"`
+}
+
+// APIKeyCreate leaves ExpiresAt empty for a key that does not expire.
+type APIKeyCreate struct {
+	Name      string   `json:"name" example:"nightly import"`
+	Scopes    []string `json:"scopes" example:"transactions:write,reports:read"`
+	ExpiresAt string   `json:"expires_at,omitempty" example:"2027-10-18T12:00:00Z"`
+}
+
+// APIKeyCreated holds the only copy of the key the caller gets.
+type APIKeyCreated struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
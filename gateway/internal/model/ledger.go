@@ -1,28 +1,41 @@
 package model
 
+import "encoding/json"
+
 type BudgetAdd struct {
-	Category string  `json:"category" example:"Продукты"`
-	Limit    float64 `json:"limit" example:"1250.50"`
+	CategoryID int64   `json:"category_id" example:"1"`
+	Category   string  `json:"category" example:"Продукты"`
+	Limit      float64 `json:"limit" example:"1250.50"`
 }
 
 type BudgetGet struct {
 	Category string `json:"category" example:"Продукты"`
+	AsOf     string `json:"as_of,omitempty" example:"2025-12-15"`
 }
 
 type BudgetGetResponse struct {
-	Category string  `json:"category" example:"Продукты"`
-	Limit    float64 `json:"limit" example:"1250.50"`
+	CategoryID int64   `json:"category_id" example:"1"`
+	Category   string  `json:"category" example:"Продукты"`
+	Limit      float64 `json:"limit" example:"1250.50"`
+	Spent      float64 `json:"spent,omitempty" example:"430.00"`
 }
 
 type TrasnactionAdd struct {
-	Amount      float64 `json:"amount" example:"123.50"`
-	Category    string  `json:"category" example:"Продукты"`
-	Description string  `json:"description" example:"Тест"`
-	Date        string  `json:"Date" example:"2025-12-19"`
+	Amount      float64  `json:"amount" example:"123.50"`
+	CategoryID  int64    `json:"category_id" example:"1"`
+	Category    string   `json:"category" example:"Продукты"`
+	Description string   `json:"description" example:"Тест"`
+	Date        string   `json:"Date" example:"2025-12-19"`
+	Tags        []string `json:"tags,omitempty" example:"vacation-2025"`
+	ExternalID  string   `json:"external_id,omitempty" example:"bank-7781"`
+	Force       bool     `json:"force,omitempty" example:"false"`
+
+	IdempotencyKey string `json:"-"`
 }
 
 type TransactionAddResponse struct {
-	Id int64 `json:"id" example:"1"`
+	Id      int64 `json:"id" example:"1"`
+	Anomaly bool  `json:"anomaly,omitempty" example:"true"`
 }
 
 type TransactionGet struct {
@@ -30,29 +43,240 @@ type TransactionGet struct {
 }
 
 type TransactionGetResponse struct {
-	Id          int64   `json:"id" example:"1"`
-	Amount      float64 `json:"amount" example:"123.50"`
-	Category    string  `json:"category" example:"Продукты"`
-	Description string  `json:"description" example:"Тест"`
-	Date        string  `json:"Date" example:"2025-12-19"`
+	Id          int64    `json:"id" example:"1"`
+	Amount      float64  `json:"amount" example:"123.50"`
+	CategoryID  int64    `json:"category_id" example:"1"`
+	Category    string   `json:"category" example:"Продукты"`
+	Description string   `json:"description" example:"Тест"`
+	Date        string   `json:"Date" example:"2025-12-19"`
+	Tags        []string `json:"tags,omitempty" example:"vacation-2025"`
+	CreatedBy   string   `json:"created_by,omitempty"`
+	Kind        string   `json:"kind,omitempty" example:"expense"`
+}
+
+type TransactionList struct {
+	Tag string `json:"tag" example:"vacation-2025"`
+}
+
+type TransactionSearch struct {
+	Query      string `json:"q" example:"аптека"`
+	Language   string `json:"lang" example:"russian"`
+	From       string `json:"from" example:"2025-03-01"`
+	To         string `json:"to" example:"2025-03-31"`
+	CategoryID int64  `json:"category_id" example:"2"`
+	Limit      int32  `json:"limit" example:"50"`
+}
+
+type TransactionSearchResult struct {
+	Transaction TransactionGetResponse `json:"transaction"`
+	Rank        float64                `json:"rank" example:"0.6"`
 }
 
 type ReportSummary struct {
-	From string `json:"from" exaple:"2025-12-10"`
-	To   string `json:"to" example:"2025-12-12"`
+	From    string `json:"from" exaple:"2025-12-10"`
+	To      string `json:"to" example:"2025-12-12"`
+	GroupBy string `json:"group_by" example:"tag"`
+	AsOf    string `json:"as_of,omitempty" example:"2025-12-15"`
 }
 
 type ReportSummaryResponse struct {
 	Report      map[string]float64 `json:"report"`
+	Tree        []CategoryNode     `json:"tree,omitempty"`
 	CacheResult bool               `json:"cache_result"`
 }
 
+type ReportAnomaly struct {
+	Transaction TransactionGetResponse `json:"transaction"`
+	Score       float64                `json:"score" example:"40.47"`
+	Ratio       float64                `json:"ratio" example:"9"`
+	Median      float64                `json:"median" example:"100"`
+	MAD         float64                `json:"mad" example:"13.33"`
+}
+
+type ReportForecastResponse struct {
+	PeriodStart  string             `json:"period_start" example:"2025-12-01"`
+	PeriodEnd    string             `json:"period_end" example:"2025-12-31"`
+	DaysElapsed  int32              `json:"days_elapsed" example:"10"`
+	DaysInPeriod int32              `json:"days_in_period" example:"31"`
+	Categories   []CategoryForecast `json:"categories"`
+	Budgets      []BudgetForecast   `json:"budgets"`
+}
+
+type CategoryForecast struct {
+	Category          string  `json:"category" example:"Продукты"`
+	Spent             float64 `json:"spent" example:"100"`
+	Scheduled         float64 `json:"scheduled" example:"0"`
+	HistoricalAverage float64 `json:"historical_average" example:"310"`
+	Projected         float64 `json:"projected" example:"310"`
+}
+
+type BudgetForecast struct {
+	CategoryID     int64   `json:"category_id" example:"1"`
+	Category       string  `json:"category" example:"Продукты"`
+	Limit          float64 `json:"limit" example:"300"`
+	Projected      float64 `json:"projected" example:"310"`
+	LikelyExceeded bool    `json:"likely_exceeded" example:"true"`
+}
+
+type CategoryNode struct {
+	Id       int64          `json:"id" example:"1"`
+	Name     string         `json:"name" example:"Продукты"`
+	Amount   float64        `json:"amount" example:"100.50"`
+	Total    float64        `json:"total" example:"250.00"`
+	Children []CategoryNode `json:"children,omitempty"`
+}
+
+type CategoryAdd struct {
+	Name     string `json:"name" example:"Рестораны"`
+	ParentID int64  `json:"parent_id" example:"1"`
+}
+
+type CategoryAddResponse struct {
+	Id int64 `json:"id" example:"2"`
+}
+
+type CategoryUpdate struct {
+	Id       int64  `json:"id" example:"2"`
+	Name     string `json:"name" example:"Кафе"`
+	ParentID int64  `json:"parent_id" example:"1"`
+}
+
+type CategoryGetResponse struct {
+	Id       int64  `json:"id" example:"2"`
+	Name     string `json:"name" example:"Кафе"`
+	ParentID int64  `json:"parent_id" example:"1"`
+}
+
 type TransactionBulkAdd struct {
 	Transactions []TrasnactionAdd `json:"transactions"`
 }
 
 type TransactionBulkAddResponse struct {
-	Accepted int64
-	Rejected int64
-	Errors   map[int64]string
+	Accepted        int64
+	Rejected        int64
+	Errors          map[int64]string
+	Duplicates      map[int64]int64
+	BatchDuplicates map[int64]int64
+}
+
+type Rule struct {
+	Id                  int64   `json:"id" example:"1"`
+	Priority            int32   `json:"priority" example:"10"`
+	DescriptionRegex    string  `json:"description_regex" example:"\\*UBER (\\w+)"`
+	DescriptionContains string  `json:"description_contains" example:"uber"`
+	MinAmount           float64 `json:"min_amount" example:"0"`
+	MaxAmount           float64 `json:"max_amount" example:"100"`
+	CategoryID          int64   `json:"category_id" example:"3"`
+	Category            string  `json:"category" example:"Такси"`
+	Description         string  `json:"description" example:"Uber $1"`
+}
+
+type RuleAddResponse struct {
+	Id int64 `json:"id" example:"1"`
+}
+
+type RuleTest struct {
+	Rule Rule  `json:"rule"`
+	Last int32 `json:"last" example:"100"`
+}
+
+type RuleMatch struct {
+	Transaction TransactionGetResponse `json:"transaction"`
+	CategoryID  int64                  `json:"category_id" example:"3"`
+	Category    string                 `json:"category" example:"Такси"`
+	Description string                 `json:"description" example:"Uber TRIP"`
+}
+
+type Attachment struct {
+	Id            int64  `json:"id" example:"1"`
+	TransactionId int64  `json:"transaction_id" example:"3"`
+	FileName      string `json:"file_name" example:"receipt.pdf"`
+	ContentType   string `json:"content_type" example:"application/pdf"`
+	Size          int64  `json:"size" example:"48213"`
+	CreatedAt     string `json:"created_at" example:"2025-12-19T10:00:00Z"`
+}
+
+type Goal struct {
+	Id           int64   `json:"id" example:"1"`
+	Name         string  `json:"name" example:"Отпуск"`
+	TargetAmount float64 `json:"target_amount" example:"1200"`
+	Deadline     string  `json:"deadline" example:"2026-06-30"`
+}
+
+type GoalAddResponse struct {
+	Id int64 `json:"id" example:"1"`
+}
+
+type GoalProgress struct {
+	Goal            Goal    `json:"goal"`
+	Saved           float64 `json:"saved" example:"500"`
+	Remaining       float64 `json:"remaining" example:"700"`
+	Percent         float64 `json:"percent" example:"41.67"`
+	MonthsLeft      int32   `json:"months_left" example:"7"`
+	MonthlyRequired float64 `json:"monthly_required" example:"100"`
+}
+
+type GoalContribute struct {
+	GoalId      int64   `json:"goal_id" example:"1"`
+	Amount      float64 `json:"amount" example:"150"`
+	Date        string  `json:"date" example:"2025-12-11"`
+	Description string  `json:"description" example:"Откладываю с зарплаты"`
+}
+
+type Webhook struct {
+	Id     int64    `json:"id" example:"1"`
+	Url    string   `json:"url" example:"https://bot.example.com/ledger"`
+	Secret string   `json:"secret,omitempty" example:"s3cret"`
+	Events []string `json:"events" example:"budget.threshold_reached,budget.exceeded"`
+}
+
+type WebhookAddResponse struct {
+	Id int64 `json:"id" example:"1"`
+}
+
+type WebhookDelivery struct {
+	Id             int64           `json:"id" example:"7"`
+	WebhookId      int64           `json:"webhook_id" example:"1"`
+	Event          string          `json:"event" example:"budget.exceeded"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status" example:"delivered"`
+	Attempts       int32           `json:"attempts" example:"2"`
+	LastStatusCode int32           `json:"last_status_code,omitempty" example:"200"`
+	LastError      string          `json:"last_error,omitempty" example:"unexpected status 500"`
+	NextAttemptAt  string          `json:"next_attempt_at" example:"2025-12-19T10:00:05Z"`
+	CreatedAt      string          `json:"created_at" example:"2025-12-19T10:00:00Z"`
+	DeliveredAt    string          `json:"delivered_at,omitempty" example:"2025-12-19T10:00:05Z"`
+}
+
+type LedgerEvent struct {
+	Id          int64           `json:"id" example:"42"`
+	Type        string          `json:"type" example:"transaction.created"`
+	AggregateId int64           `json:"aggregate_id" example:"15"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedAt   string          `json:"created_at" example:"2025-12-19T10:00:00.123456Z"`
+}
+
+type AuditList struct {
+	EntityType  string
+	EntityId    int64
+	ActorUserId string
+	RequestId   string
+	Action      string
+	From        string
+	To          string
+	BeforeId    int64
+	Limit       int32
+}
+
+type AuditEntry struct {
+	Id          int64           `json:"id" example:"9"`
+	Action      string          `json:"action" example:"budget.set"`
+	EntityType  string          `json:"entity_type" example:"budget"`
+	EntityId    int64           `json:"entity_id" example:"1"`
+	ActorUserId string          `json:"actor_user_id" example:"98ac2ffe-5749-4ade-9053-6a7555d2bf04"`
+	RequestId   string          `json:"request_id" example:"6f1c2a9e0b7d4e58"`
+	Method      string          `json:"method" example:"/ledger.v1.LedgerService/BudgetAdd"`
+	Before      json.RawMessage `json:"before,omitempty"`
+	After       json.RawMessage `json:"after,omitempty"`
+	CreatedAt   string          `json:"created_at" example:"2025-12-19T10:00:00.123456Z"`
 }
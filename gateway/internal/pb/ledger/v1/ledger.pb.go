@@ -26,6 +26,7 @@ type BudgetAddRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
 	Limit         float32                `protobuf:"fixed32,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	CategoryId    int64                  `protobuf:"varint,3,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,9 +75,17 @@ func (x *BudgetAddRequest) GetLimit() float32 {
 	return 0
 }
 
+func (x *BudgetAddRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
 type BudgetGetRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	AsOf          string                 `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -118,17 +127,70 @@ func (x *BudgetGetRequest) GetCategory() string {
 	return ""
 }
 
+func (x *BudgetGetRequest) GetAsOf() string {
+	if x != nil {
+		return x.AsOf
+	}
+	return ""
+}
+
+type BudgetsListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AsOf          string                 `protobuf:"bytes,1,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BudgetsListRequest) Reset() {
+	*x = BudgetsListRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BudgetsListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BudgetsListRequest) ProtoMessage() {}
+
+func (x *BudgetsListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BudgetsListRequest.ProtoReflect.Descriptor instead.
+func (*BudgetsListRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BudgetsListRequest) GetAsOf() string {
+	if x != nil {
+		return x.AsOf
+	}
+	return ""
+}
+
 type BudgetGetResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
 	Limit         float32                `protobuf:"fixed32,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	CategoryId    int64                  `protobuf:"varint,3,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Spent         float32                `protobuf:"fixed32,4,opt,name=spent,proto3" json:"spent,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BudgetGetResponse) Reset() {
 	*x = BudgetGetResponse{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[2]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -140,7 +202,7 @@ func (x *BudgetGetResponse) String() string {
 func (*BudgetGetResponse) ProtoMessage() {}
 
 func (x *BudgetGetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[2]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -153,7 +215,7 @@ func (x *BudgetGetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BudgetGetResponse.ProtoReflect.Descriptor instead.
 func (*BudgetGetResponse) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{2}
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *BudgetGetResponse) GetCategory() string {
@@ -170,6 +232,20 @@ func (x *BudgetGetResponse) GetLimit() float32 {
 	return 0
 }
 
+func (x *BudgetGetResponse) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *BudgetGetResponse) GetSpent() float32 {
+	if x != nil {
+		return x.Spent
+	}
+	return 0
+}
+
 type BudgetGetListResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Budgets       []*BudgetGetResponse   `protobuf:"bytes,1,rep,name=budgets,proto3" json:"budgets,omitempty"`
@@ -179,7 +255,7 @@ type BudgetGetListResponse struct {
 
 func (x *BudgetGetListResponse) Reset() {
 	*x = BudgetGetListResponse{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[3]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -191,7 +267,7 @@ func (x *BudgetGetListResponse) String() string {
 func (*BudgetGetListResponse) ProtoMessage() {}
 
 func (x *BudgetGetListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[3]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -204,7 +280,7 @@ func (x *BudgetGetListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BudgetGetListResponse.ProtoReflect.Descriptor instead.
 func (*BudgetGetListResponse) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{3}
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *BudgetGetListResponse) GetBudgets() []*BudgetGetResponse {
@@ -215,18 +291,23 @@ func (x *BudgetGetListResponse) GetBudgets() []*BudgetGetResponse {
 }
 
 type TransactionAddRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Amount        float32                `protobuf:"fixed32,1,opt,name=amount,proto3" json:"amount,omitempty"`
-	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Date          string                 `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Amount         float32                `protobuf:"fixed32,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Category       string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Date           string                 `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
+	Tags           []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	CategoryId     int64                  `protobuf:"varint,6,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	ExternalId     string                 `protobuf:"bytes,7,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Force          bool                   `protobuf:"varint,8,opt,name=force,proto3" json:"force,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,9,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *TransactionAddRequest) Reset() {
 	*x = TransactionAddRequest{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[4]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -238,7 +319,7 @@ func (x *TransactionAddRequest) String() string {
 func (*TransactionAddRequest) ProtoMessage() {}
 
 func (x *TransactionAddRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[4]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -251,7 +332,7 @@ func (x *TransactionAddRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransactionAddRequest.ProtoReflect.Descriptor instead.
 func (*TransactionAddRequest) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{4}
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *TransactionAddRequest) GetAmount() float32 {
@@ -282,6 +363,41 @@ func (x *TransactionAddRequest) GetDate() string {
 	return ""
 }
 
+func (x *TransactionAddRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TransactionAddRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *TransactionAddRequest) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *TransactionAddRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *TransactionAddRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type TransactionBulkAddRequest struct {
 	state         protoimpl.MessageState   `protogen:"open.v1"`
 	Transactions  []*TransactionAddRequest `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
@@ -291,7 +407,7 @@ type TransactionBulkAddRequest struct {
 
 func (x *TransactionBulkAddRequest) Reset() {
 	*x = TransactionBulkAddRequest{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[5]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -303,7 +419,7 @@ func (x *TransactionBulkAddRequest) String() string {
 func (*TransactionBulkAddRequest) ProtoMessage() {}
 
 func (x *TransactionBulkAddRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[5]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -316,7 +432,7 @@ func (x *TransactionBulkAddRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransactionBulkAddRequest.ProtoReflect.Descriptor instead.
 func (*TransactionBulkAddRequest) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{5}
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *TransactionBulkAddRequest) GetTransactions() []*TransactionAddRequest {
@@ -327,17 +443,19 @@ func (x *TransactionBulkAddRequest) GetTransactions() []*TransactionAddRequest {
 }
 
 type TransactionBulkAddResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Accepted      int64                  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
-	Rejected      int64                  `protobuf:"varint,2,opt,name=rejected,proto3" json:"rejected,omitempty"`
-	Errors        map[int64]string       `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Accepted        int64                  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected        int64                  `protobuf:"varint,2,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Errors          map[int64]string       `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Duplicates      map[int64]int64        `protobuf:"bytes,4,rep,name=duplicates,proto3" json:"duplicates,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	BatchDuplicates map[int64]int64        `protobuf:"bytes,5,rep,name=batch_duplicates,json=batchDuplicates,proto3" json:"batch_duplicates,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *TransactionBulkAddResponse) Reset() {
 	*x = TransactionBulkAddResponse{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[6]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -349,7 +467,7 @@ func (x *TransactionBulkAddResponse) String() string {
 func (*TransactionBulkAddResponse) ProtoMessage() {}
 
 func (x *TransactionBulkAddResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[6]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -362,7 +480,7 @@ func (x *TransactionBulkAddResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransactionBulkAddResponse.ProtoReflect.Descriptor instead.
 func (*TransactionBulkAddResponse) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{6}
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *TransactionBulkAddResponse) GetAccepted() int64 {
@@ -386,16 +504,31 @@ func (x *TransactionBulkAddResponse) GetErrors() map[int64]string {
 	return nil
 }
 
+func (x *TransactionBulkAddResponse) GetDuplicates() map[int64]int64 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return nil
+}
+
+func (x *TransactionBulkAddResponse) GetBatchDuplicates() map[int64]int64 {
+	if x != nil {
+		return x.BatchDuplicates
+	}
+	return nil
+}
+
 type TransactionAddResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Anomaly       bool                   `protobuf:"varint,2,opt,name=anomaly,proto3" json:"anomaly,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TransactionAddResponse) Reset() {
 	*x = TransactionAddResponse{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[7]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -407,7 +540,7 @@ func (x *TransactionAddResponse) String() string {
 func (*TransactionAddResponse) ProtoMessage() {}
 
 func (x *TransactionAddResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[7]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -420,7 +553,7 @@ func (x *TransactionAddResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransactionAddResponse.ProtoReflect.Descriptor instead.
 func (*TransactionAddResponse) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{7}
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *TransactionAddResponse) GetId() int64 {
@@ -430,6 +563,13 @@ func (x *TransactionAddResponse) GetId() int64 {
 	return 0
 }
 
+func (x *TransactionAddResponse) GetAnomaly() bool {
+	if x != nil {
+		return x.Anomaly
+	}
+	return false
+}
+
 type TransactionGetRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -439,7 +579,7 @@ type TransactionGetRequest struct {
 
 func (x *TransactionGetRequest) Reset() {
 	*x = TransactionGetRequest{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[8]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -451,7 +591,7 @@ func (x *TransactionGetRequest) String() string {
 func (*TransactionGetRequest) ProtoMessage() {}
 
 func (x *TransactionGetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[8]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -464,7 +604,7 @@ func (x *TransactionGetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransactionGetRequest.ProtoReflect.Descriptor instead.
 func (*TransactionGetRequest) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{8}
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *TransactionGetRequest) GetId() int64 {
@@ -481,13 +621,17 @@ type TransactionGetResponse struct {
 	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
 	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
 	Date          string                 `protobuf:"bytes,5,opt,name=date,proto3" json:"date,omitempty"`
+	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	CategoryId    int64                  `protobuf:"varint,7,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Kind          string                 `protobuf:"bytes,9,opt,name=kind,proto3" json:"kind,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TransactionGetResponse) Reset() {
 	*x = TransactionGetResponse{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[9]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -499,7 +643,7 @@ func (x *TransactionGetResponse) String() string {
 func (*TransactionGetResponse) ProtoMessage() {}
 
 func (x *TransactionGetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[9]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -512,7 +656,7 @@ func (x *TransactionGetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransactionGetResponse.ProtoReflect.Descriptor instead.
 func (*TransactionGetResponse) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{9}
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *TransactionGetResponse) GetId() int64 {
@@ -550,6 +694,78 @@ func (x *TransactionGetResponse) GetDate() string {
 	return ""
 }
 
+func (x *TransactionGetResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TransactionGetResponse) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *TransactionGetResponse) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *TransactionGetResponse) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+type TransactionListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionListRequest) Reset() {
+	*x = TransactionListRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionListRequest) ProtoMessage() {}
+
+func (x *TransactionListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionListRequest.ProtoReflect.Descriptor instead.
+func (*TransactionListRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TransactionListRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
 type TransactionGetListResponse struct {
 	state         protoimpl.MessageState    `protogen:"open.v1"`
 	Transactions  []*TransactionGetResponse `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
@@ -559,7 +775,7 @@ type TransactionGetListResponse struct {
 
 func (x *TransactionGetListResponse) Reset() {
 	*x = TransactionGetListResponse{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[10]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -571,7 +787,7 @@ func (x *TransactionGetListResponse) String() string {
 func (*TransactionGetListResponse) ProtoMessage() {}
 
 func (x *TransactionGetListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[10]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -584,7 +800,7 @@ func (x *TransactionGetListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransactionGetListResponse.ProtoReflect.Descriptor instead.
 func (*TransactionGetListResponse) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{10}
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *TransactionGetListResponse) GetTransactions() []*TransactionGetResponse {
@@ -598,13 +814,15 @@ type SummaryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
 	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	GroupBy       string                 `protobuf:"bytes,3,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	AsOf          string                 `protobuf:"bytes,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SummaryRequest) Reset() {
 	*x = SummaryRequest{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[11]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -616,7 +834,7 @@ func (x *SummaryRequest) String() string {
 func (*SummaryRequest) ProtoMessage() {}
 
 func (x *SummaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[11]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -629,7 +847,7 @@ func (x *SummaryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SummaryRequest.ProtoReflect.Descriptor instead.
 func (*SummaryRequest) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{11}
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *SummaryRequest) GetFrom() string {
@@ -646,17 +864,32 @@ func (x *SummaryRequest) GetTo() string {
 	return ""
 }
 
+func (x *SummaryRequest) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+func (x *SummaryRequest) GetAsOf() string {
+	if x != nil {
+		return x.AsOf
+	}
+	return ""
+}
+
 type SummaryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Report        map[string]float64     `protobuf:"bytes,1,rep,name=report,proto3" json:"report,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
 	CacheResult   bool                   `protobuf:"varint,2,opt,name=cache_result,json=cacheResult,proto3" json:"cache_result,omitempty"`
+	Tree          []*CategoryNode        `protobuf:"bytes,3,rep,name=tree,proto3" json:"tree,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SummaryResponse) Reset() {
 	*x = SummaryResponse{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[12]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -668,7 +901,7 @@ func (x *SummaryResponse) String() string {
 func (*SummaryResponse) ProtoMessage() {}
 
 func (x *SummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[12]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -681,7 +914,7 @@ func (x *SummaryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SummaryResponse.ProtoReflect.Descriptor instead.
 func (*SummaryResponse) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{12}
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *SummaryResponse) GetReport() map[string]float64 {
@@ -698,65 +931,3294 @@ func (x *SummaryResponse) GetCacheResult() bool {
 	return false
 }
 
-var File_ledger_v1_ledger_proto protoreflect.FileDescriptor
+func (x *SummaryResponse) GetTree() []*CategoryNode {
+	if x != nil {
+		return x.Tree
+	}
+	return nil
+}
 
-const file_ledger_v1_ledger_proto_rawDesc = "" +
-	"\n" +
-	"\x16ledger/v1/ledger.proto\x12\tledger.v1\x1a\x1bgoogle/protobuf/empty.proto\"D\n" +
-	"\x10BudgetAddRequest\x12\x1a\n" +
-	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x02R\x05limit\".\n" +
-	"\x10BudgetGetRequest\x12\x1a\n" +
-	"\bcategory\x18\x01 \x01(\tR\bcategory\"E\n" +
-	"\x11BudgetGetResponse\x12\x1a\n" +
-	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x02R\x05limit\"O\n" +
-	"\x15BudgetGetListResponse\x126\n" +
-	"\abudgets\x18\x01 \x03(\v2\x1c.ledger.v1.BudgetGetResponseR\abudgets\"\x81\x01\n" +
-	"\x15TransactionAddRequest\x12\x16\n" +
-	"\x06amount\x18\x01 \x01(\x02R\x06amount\x12\x1a\n" +
-	"\bcategory\x18\x02 \x01(\tR\bcategory\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
-	"\x04date\x18\x04 \x01(\tR\x04date\"a\n" +
-	"\x19TransactionBulkAddRequest\x12D\n" +
-	"\ftransactions\x18\x01 \x03(\v2 .ledger.v1.TransactionAddRequestR\ftransactions\"\xda\x01\n" +
-	"\x1aTransactionBulkAddResponse\x12\x1a\n" +
-	"\baccepted\x18\x01 \x01(\x03R\baccepted\x12\x1a\n" +
-	"\brejected\x18\x02 \x01(\x03R\brejected\x12I\n" +
-	"\x06errors\x18\x03 \x03(\v21.ledger.v1.TransactionBulkAddResponse.ErrorsEntryR\x06errors\x1a9\n" +
-	"\vErrorsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"(\n" +
-	"\x16TransactionAddResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"'\n" +
-	"\x15TransactionGetRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"\x92\x01\n" +
-	"\x16TransactionGetResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x02R\x06amount\x12\x1a\n" +
-	"\bcategory\x18\x03 \x01(\tR\bcategory\x12 \n" +
-	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x12\n" +
-	"\x04date\x18\x05 \x01(\tR\x04date\"c\n" +
-	"\x1aTransactionGetListResponse\x12E\n" +
-	"\ftransactions\x18\x01 \x03(\v2!.ledger.v1.TransactionGetResponseR\ftransactions\"4\n" +
-	"\x0eSummaryRequest\x12\x12\n" +
-	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
-	"\x02to\x18\x02 \x01(\tR\x02to\"\xaf\x01\n" +
-	"\x0fSummaryResponse\x12>\n" +
+type CategoryNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Total         float64                `protobuf:"fixed64,4,opt,name=total,proto3" json:"total,omitempty"`
+	Children      []*CategoryNode        `protobuf:"bytes,5,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryNode) Reset() {
+	*x = CategoryNode{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryNode) ProtoMessage() {}
+
+func (x *CategoryNode) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryNode.ProtoReflect.Descriptor instead.
+func (*CategoryNode) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CategoryNode) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CategoryNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CategoryNode) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CategoryNode) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *CategoryNode) GetChildren() []*CategoryNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type CategoryAddRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      int64                  `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryAddRequest) Reset() {
+	*x = CategoryAddRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryAddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryAddRequest) ProtoMessage() {}
+
+func (x *CategoryAddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryAddRequest.ProtoReflect.Descriptor instead.
+func (*CategoryAddRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CategoryAddRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CategoryAddRequest) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+type CategoryAddResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryAddResponse) Reset() {
+	*x = CategoryAddResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryAddResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryAddResponse) ProtoMessage() {}
+
+func (x *CategoryAddResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryAddResponse.ProtoReflect.Descriptor instead.
+func (*CategoryAddResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CategoryAddResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CategoryUpdateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      int64                  `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryUpdateRequest) Reset() {
+	*x = CategoryUpdateRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryUpdateRequest) ProtoMessage() {}
+
+func (x *CategoryUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryUpdateRequest.ProtoReflect.Descriptor instead.
+func (*CategoryUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CategoryUpdateRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CategoryUpdateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CategoryUpdateRequest) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+type CategoryGetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      int64                  `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryGetResponse) Reset() {
+	*x = CategoryGetResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryGetResponse) ProtoMessage() {}
+
+func (x *CategoryGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryGetResponse.ProtoReflect.Descriptor instead.
+func (*CategoryGetResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CategoryGetResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CategoryGetResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CategoryGetResponse) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+type CategoryGetListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*CategoryGetResponse `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryGetListResponse) Reset() {
+	*x = CategoryGetListResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryGetListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryGetListResponse) ProtoMessage() {}
+
+func (x *CategoryGetListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryGetListResponse.ProtoReflect.Descriptor instead.
+func (*CategoryGetListResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CategoryGetListResponse) GetCategories() []*CategoryGetResponse {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type Rule struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Priority            int32                  `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	DescriptionRegex    string                 `protobuf:"bytes,3,opt,name=description_regex,json=descriptionRegex,proto3" json:"description_regex,omitempty"`
+	DescriptionContains string                 `protobuf:"bytes,4,opt,name=description_contains,json=descriptionContains,proto3" json:"description_contains,omitempty"`
+	MinAmount           float32                `protobuf:"fixed32,5,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	MaxAmount           float32                `protobuf:"fixed32,6,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	CategoryId          int64                  `protobuf:"varint,7,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Category            string                 `protobuf:"bytes,8,opt,name=category,proto3" json:"category,omitempty"`
+	Description         string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Rule) Reset() {
+	*x = Rule{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rule) ProtoMessage() {}
+
+func (x *Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rule.ProtoReflect.Descriptor instead.
+func (*Rule) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Rule) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Rule) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Rule) GetDescriptionRegex() string {
+	if x != nil {
+		return x.DescriptionRegex
+	}
+	return ""
+}
+
+func (x *Rule) GetDescriptionContains() string {
+	if x != nil {
+		return x.DescriptionContains
+	}
+	return ""
+}
+
+func (x *Rule) GetMinAmount() float32 {
+	if x != nil {
+		return x.MinAmount
+	}
+	return 0
+}
+
+func (x *Rule) GetMaxAmount() float32 {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return 0
+}
+
+func (x *Rule) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *Rule) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Rule) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type RuleAddResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleAddResponse) Reset() {
+	*x = RuleAddResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleAddResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleAddResponse) ProtoMessage() {}
+
+func (x *RuleAddResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleAddResponse.ProtoReflect.Descriptor instead.
+func (*RuleAddResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RuleAddResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RuleDeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleDeleteRequest) Reset() {
+	*x = RuleDeleteRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleDeleteRequest) ProtoMessage() {}
+
+func (x *RuleDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleDeleteRequest.ProtoReflect.Descriptor instead.
+func (*RuleDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RuleDeleteRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RuleListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*Rule                `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleListResponse) Reset() {
+	*x = RuleListResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleListResponse) ProtoMessage() {}
+
+func (x *RuleListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleListResponse.ProtoReflect.Descriptor instead.
+func (*RuleListResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RuleListResponse) GetRules() []*Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type RuleTestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *Rule                  `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Last          int32                  `protobuf:"varint,2,opt,name=last,proto3" json:"last,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleTestRequest) Reset() {
+	*x = RuleTestRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleTestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleTestRequest) ProtoMessage() {}
+
+func (x *RuleTestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleTestRequest.ProtoReflect.Descriptor instead.
+func (*RuleTestRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RuleTestRequest) GetRule() *Rule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *RuleTestRequest) GetLast() int32 {
+	if x != nil {
+		return x.Last
+	}
+	return 0
+}
+
+type RuleMatch struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Transaction   *TransactionGetResponse `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	CategoryId    int64                   `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Category      string                  `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Description   string                  `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleMatch) Reset() {
+	*x = RuleMatch{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleMatch) ProtoMessage() {}
+
+func (x *RuleMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleMatch.ProtoReflect.Descriptor instead.
+func (*RuleMatch) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RuleMatch) GetTransaction() *TransactionGetResponse {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *RuleMatch) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *RuleMatch) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *RuleMatch) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type RuleTestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*RuleMatch           `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleTestResponse) Reset() {
+	*x = RuleTestResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleTestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleTestResponse) ProtoMessage() {}
+
+func (x *RuleTestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleTestResponse.ProtoReflect.Descriptor instead.
+func (*RuleTestResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RuleTestResponse) GetMatches() []*RuleMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TransactionId int64                  `protobuf:"varint,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FileName      string                 `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Attachment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Attachment) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *Attachment) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type AttachmentUploadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*AttachmentUploadRequest_Info
+	//	*AttachmentUploadRequest_Chunk
+	Payload       isAttachmentUploadRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentUploadRequest) Reset() {
+	*x = AttachmentUploadRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentUploadRequest) ProtoMessage() {}
+
+func (x *AttachmentUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentUploadRequest.ProtoReflect.Descriptor instead.
+func (*AttachmentUploadRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AttachmentUploadRequest) GetPayload() isAttachmentUploadRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *AttachmentUploadRequest) GetInfo() *Attachment {
+	if x != nil {
+		if x, ok := x.Payload.(*AttachmentUploadRequest_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *AttachmentUploadRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*AttachmentUploadRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isAttachmentUploadRequest_Payload interface {
+	isAttachmentUploadRequest_Payload()
+}
+
+type AttachmentUploadRequest_Info struct {
+	Info *Attachment `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type AttachmentUploadRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*AttachmentUploadRequest_Info) isAttachmentUploadRequest_Payload() {}
+
+func (*AttachmentUploadRequest_Chunk) isAttachmentUploadRequest_Payload() {}
+
+type AttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId int64                  `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentRequest) Reset() {
+	*x = AttachmentRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentRequest) ProtoMessage() {}
+
+func (x *AttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentRequest.ProtoReflect.Descriptor instead.
+func (*AttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AttachmentRequest) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *AttachmentRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type AttachmentChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*AttachmentChunk_Info
+	//	*AttachmentChunk_Chunk
+	Payload       isAttachmentChunk_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentChunk) Reset() {
+	*x = AttachmentChunk{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentChunk) ProtoMessage() {}
+
+func (x *AttachmentChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentChunk.ProtoReflect.Descriptor instead.
+func (*AttachmentChunk) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AttachmentChunk) GetPayload() isAttachmentChunk_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *AttachmentChunk) GetInfo() *Attachment {
+	if x != nil {
+		if x, ok := x.Payload.(*AttachmentChunk_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *AttachmentChunk) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*AttachmentChunk_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isAttachmentChunk_Payload interface {
+	isAttachmentChunk_Payload()
+}
+
+type AttachmentChunk_Info struct {
+	Info *Attachment `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type AttachmentChunk_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*AttachmentChunk_Info) isAttachmentChunk_Payload() {}
+
+func (*AttachmentChunk_Chunk) isAttachmentChunk_Payload() {}
+
+type AttachmentsListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId int64                  `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentsListRequest) Reset() {
+	*x = AttachmentsListRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentsListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentsListRequest) ProtoMessage() {}
+
+func (x *AttachmentsListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentsListRequest.ProtoReflect.Descriptor instead.
+func (*AttachmentsListRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AttachmentsListRequest) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+type AttachmentListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachments   []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentListResponse) Reset() {
+	*x = AttachmentListResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentListResponse) ProtoMessage() {}
+
+func (x *AttachmentListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentListResponse.ProtoReflect.Descriptor instead.
+func (*AttachmentListResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AttachmentListResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type TransactionSearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	CategoryId    int64                  `protobuf:"varint,5,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionSearchRequest) Reset() {
+	*x = TransactionSearchRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionSearchRequest) ProtoMessage() {}
+
+func (x *TransactionSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionSearchRequest.ProtoReflect.Descriptor instead.
+func (*TransactionSearchRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TransactionSearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *TransactionSearchRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *TransactionSearchRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *TransactionSearchRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *TransactionSearchRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *TransactionSearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TransactionSearchResult struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Transaction   *TransactionGetResponse `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Rank          float32                 `protobuf:"fixed32,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionSearchResult) Reset() {
+	*x = TransactionSearchResult{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionSearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionSearchResult) ProtoMessage() {}
+
+func (x *TransactionSearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionSearchResult.ProtoReflect.Descriptor instead.
+func (*TransactionSearchResult) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TransactionSearchResult) GetTransaction() *TransactionGetResponse {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *TransactionSearchResult) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+type TransactionSearchResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Results       []*TransactionSearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionSearchResponse) Reset() {
+	*x = TransactionSearchResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionSearchResponse) ProtoMessage() {}
+
+func (x *TransactionSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionSearchResponse.ProtoReflect.Descriptor instead.
+func (*TransactionSearchResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TransactionSearchResponse) GetResults() []*TransactionSearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type Goal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TargetAmount  float32                `protobuf:"fixed32,3,opt,name=target_amount,json=targetAmount,proto3" json:"target_amount,omitempty"`
+	Deadline      string                 `protobuf:"bytes,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Goal) Reset() {
+	*x = Goal{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Goal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Goal) ProtoMessage() {}
+
+func (x *Goal) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Goal.ProtoReflect.Descriptor instead.
+func (*Goal) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Goal) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Goal) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Goal) GetTargetAmount() float32 {
+	if x != nil {
+		return x.TargetAmount
+	}
+	return 0
+}
+
+func (x *Goal) GetDeadline() string {
+	if x != nil {
+		return x.Deadline
+	}
+	return ""
+}
+
+type GoalAddResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoalAddResponse) Reset() {
+	*x = GoalAddResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoalAddResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoalAddResponse) ProtoMessage() {}
+
+func (x *GoalAddResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoalAddResponse.ProtoReflect.Descriptor instead.
+func (*GoalAddResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GoalAddResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GoalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoalRequest) Reset() {
+	*x = GoalRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoalRequest) ProtoMessage() {}
+
+func (x *GoalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoalRequest.ProtoReflect.Descriptor instead.
+func (*GoalRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GoalRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GoalProgress struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Goal            *Goal                  `protobuf:"bytes,1,opt,name=goal,proto3" json:"goal,omitempty"`
+	Saved           float32                `protobuf:"fixed32,2,opt,name=saved,proto3" json:"saved,omitempty"`
+	Remaining       float32                `protobuf:"fixed32,3,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	Percent         float32                `protobuf:"fixed32,4,opt,name=percent,proto3" json:"percent,omitempty"`
+	MonthsLeft      int32                  `protobuf:"varint,5,opt,name=months_left,json=monthsLeft,proto3" json:"months_left,omitempty"`
+	MonthlyRequired float32                `protobuf:"fixed32,6,opt,name=monthly_required,json=monthlyRequired,proto3" json:"monthly_required,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GoalProgress) Reset() {
+	*x = GoalProgress{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoalProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoalProgress) ProtoMessage() {}
+
+func (x *GoalProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoalProgress.ProtoReflect.Descriptor instead.
+func (*GoalProgress) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GoalProgress) GetGoal() *Goal {
+	if x != nil {
+		return x.Goal
+	}
+	return nil
+}
+
+func (x *GoalProgress) GetSaved() float32 {
+	if x != nil {
+		return x.Saved
+	}
+	return 0
+}
+
+func (x *GoalProgress) GetRemaining() float32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *GoalProgress) GetPercent() float32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *GoalProgress) GetMonthsLeft() int32 {
+	if x != nil {
+		return x.MonthsLeft
+	}
+	return 0
+}
+
+func (x *GoalProgress) GetMonthlyRequired() float32 {
+	if x != nil {
+		return x.MonthlyRequired
+	}
+	return 0
+}
+
+type GoalListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Goals         []*GoalProgress        `protobuf:"bytes,1,rep,name=goals,proto3" json:"goals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoalListResponse) Reset() {
+	*x = GoalListResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoalListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoalListResponse) ProtoMessage() {}
+
+func (x *GoalListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoalListResponse.ProtoReflect.Descriptor instead.
+func (*GoalListResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GoalListResponse) GetGoals() []*GoalProgress {
+	if x != nil {
+		return x.Goals
+	}
+	return nil
+}
+
+type GoalContributeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoalId        int64                  `protobuf:"varint,1,opt,name=goal_id,json=goalId,proto3" json:"goal_id,omitempty"`
+	Amount        float32                `protobuf:"fixed32,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Date          string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoalContributeRequest) Reset() {
+	*x = GoalContributeRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoalContributeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoalContributeRequest) ProtoMessage() {}
+
+func (x *GoalContributeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoalContributeRequest.ProtoReflect.Descriptor instead.
+func (*GoalContributeRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GoalContributeRequest) GetGoalId() int64 {
+	if x != nil {
+		return x.GoalId
+	}
+	return 0
+}
+
+func (x *GoalContributeRequest) GetAmount() float32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *GoalContributeRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *GoalContributeRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ForecastRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	HistoryPeriods int32                  `protobuf:"varint,1,opt,name=history_periods,json=historyPeriods,proto3" json:"history_periods,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ForecastRequest) Reset() {
+	*x = ForecastRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastRequest) ProtoMessage() {}
+
+func (x *ForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastRequest.ProtoReflect.Descriptor instead.
+func (*ForecastRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ForecastRequest) GetHistoryPeriods() int32 {
+	if x != nil {
+		return x.HistoryPeriods
+	}
+	return 0
+}
+
+type CategoryForecast struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Category          string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Spent             float32                `protobuf:"fixed32,2,opt,name=spent,proto3" json:"spent,omitempty"`
+	Scheduled         float32                `protobuf:"fixed32,3,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	HistoricalAverage float32                `protobuf:"fixed32,4,opt,name=historical_average,json=historicalAverage,proto3" json:"historical_average,omitempty"`
+	Projected         float32                `protobuf:"fixed32,5,opt,name=projected,proto3" json:"projected,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CategoryForecast) Reset() {
+	*x = CategoryForecast{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryForecast) ProtoMessage() {}
+
+func (x *CategoryForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryForecast.ProtoReflect.Descriptor instead.
+func (*CategoryForecast) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CategoryForecast) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CategoryForecast) GetSpent() float32 {
+	if x != nil {
+		return x.Spent
+	}
+	return 0
+}
+
+func (x *CategoryForecast) GetScheduled() float32 {
+	if x != nil {
+		return x.Scheduled
+	}
+	return 0
+}
+
+func (x *CategoryForecast) GetHistoricalAverage() float32 {
+	if x != nil {
+		return x.HistoricalAverage
+	}
+	return 0
+}
+
+func (x *CategoryForecast) GetProjected() float32 {
+	if x != nil {
+		return x.Projected
+	}
+	return 0
+}
+
+type BudgetForecast struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId     int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Category       string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Limit          float32                `protobuf:"fixed32,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Projected      float32                `protobuf:"fixed32,4,opt,name=projected,proto3" json:"projected,omitempty"`
+	LikelyExceeded bool                   `protobuf:"varint,5,opt,name=likely_exceeded,json=likelyExceeded,proto3" json:"likely_exceeded,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BudgetForecast) Reset() {
+	*x = BudgetForecast{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BudgetForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BudgetForecast) ProtoMessage() {}
+
+func (x *BudgetForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BudgetForecast.ProtoReflect.Descriptor instead.
+func (*BudgetForecast) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *BudgetForecast) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *BudgetForecast) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *BudgetForecast) GetLimit() float32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *BudgetForecast) GetProjected() float32 {
+	if x != nil {
+		return x.Projected
+	}
+	return 0
+}
+
+func (x *BudgetForecast) GetLikelyExceeded() bool {
+	if x != nil {
+		return x.LikelyExceeded
+	}
+	return false
+}
+
+type ForecastResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeriodStart   string                 `protobuf:"bytes,1,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	PeriodEnd     string                 `protobuf:"bytes,2,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
+	DaysElapsed   int32                  `protobuf:"varint,3,opt,name=days_elapsed,json=daysElapsed,proto3" json:"days_elapsed,omitempty"`
+	DaysInPeriod  int32                  `protobuf:"varint,4,opt,name=days_in_period,json=daysInPeriod,proto3" json:"days_in_period,omitempty"`
+	Categories    []*CategoryForecast    `protobuf:"bytes,5,rep,name=categories,proto3" json:"categories,omitempty"`
+	Budgets       []*BudgetForecast      `protobuf:"bytes,6,rep,name=budgets,proto3" json:"budgets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForecastResponse) Reset() {
+	*x = ForecastResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastResponse) ProtoMessage() {}
+
+func (x *ForecastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastResponse.ProtoReflect.Descriptor instead.
+func (*ForecastResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ForecastResponse) GetPeriodStart() string {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return ""
+}
+
+func (x *ForecastResponse) GetPeriodEnd() string {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return ""
+}
+
+func (x *ForecastResponse) GetDaysElapsed() int32 {
+	if x != nil {
+		return x.DaysElapsed
+	}
+	return 0
+}
+
+func (x *ForecastResponse) GetDaysInPeriod() int32 {
+	if x != nil {
+		return x.DaysInPeriod
+	}
+	return 0
+}
+
+func (x *ForecastResponse) GetCategories() []*CategoryForecast {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *ForecastResponse) GetBudgets() []*BudgetForecast {
+	if x != nil {
+		return x.Budgets
+	}
+	return nil
+}
+
+type AnomaliesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnomaliesRequest) Reset() {
+	*x = AnomaliesRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnomaliesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnomaliesRequest) ProtoMessage() {}
+
+func (x *AnomaliesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnomaliesRequest.ProtoReflect.Descriptor instead.
+func (*AnomaliesRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *AnomaliesRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *AnomaliesRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type Anomaly struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Transaction   *TransactionGetResponse `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Score         float32                 `protobuf:"fixed32,2,opt,name=score,proto3" json:"score,omitempty"`
+	Ratio         float32                 `protobuf:"fixed32,3,opt,name=ratio,proto3" json:"ratio,omitempty"`
+	Median        float32                 `protobuf:"fixed32,4,opt,name=median,proto3" json:"median,omitempty"`
+	Mad           float32                 `protobuf:"fixed32,5,opt,name=mad,proto3" json:"mad,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Anomaly) Reset() {
+	*x = Anomaly{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Anomaly) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Anomaly) ProtoMessage() {}
+
+func (x *Anomaly) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Anomaly.ProtoReflect.Descriptor instead.
+func (*Anomaly) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Anomaly) GetTransaction() *TransactionGetResponse {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *Anomaly) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Anomaly) GetRatio() float32 {
+	if x != nil {
+		return x.Ratio
+	}
+	return 0
+}
+
+func (x *Anomaly) GetMedian() float32 {
+	if x != nil {
+		return x.Median
+	}
+	return 0
+}
+
+func (x *Anomaly) GetMad() float32 {
+	if x != nil {
+		return x.Mad
+	}
+	return 0
+}
+
+type AnomaliesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Anomalies     []*Anomaly             `protobuf:"bytes,1,rep,name=anomalies,proto3" json:"anomalies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnomaliesResponse) Reset() {
+	*x = AnomaliesResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnomaliesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnomaliesResponse) ProtoMessage() {}
+
+func (x *AnomaliesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnomaliesResponse.ProtoReflect.Descriptor instead.
+func (*AnomaliesResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *AnomaliesResponse) GetAnomalies() []*Anomaly {
+	if x != nil {
+		return x.Anomalies
+	}
+	return nil
+}
+
+type Webhook struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Secret        string                 `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	Events        []string               `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *Webhook) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Webhook) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type WebhookAddResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookAddResponse) Reset() {
+	*x = WebhookAddResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookAddResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookAddResponse) ProtoMessage() {}
+
+func (x *WebhookAddResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookAddResponse.ProtoReflect.Descriptor instead.
+func (*WebhookAddResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *WebhookAddResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type WebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookRequest) Reset() {
+	*x = WebhookRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookRequest) ProtoMessage() {}
+
+func (x *WebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookRequest.ProtoReflect.Descriptor instead.
+func (*WebhookRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *WebhookRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type WebhookListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookListResponse) Reset() {
+	*x = WebhookListResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookListResponse) ProtoMessage() {}
+
+func (x *WebhookListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookListResponse.ProtoReflect.Descriptor instead.
+func (*WebhookListResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *WebhookListResponse) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+type WebhookDeliveriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDeliveriesRequest) Reset() {
+	*x = WebhookDeliveriesRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *WebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*WebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *WebhookDeliveriesRequest) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *WebhookDeliveriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type WebhookDelivery struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId      int64                  `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Event          string                 `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	Payload        string                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts       int32                  `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastStatusCode int32                  `protobuf:"varint,7,opt,name=last_status_code,json=lastStatusCode,proto3" json:"last_status_code,omitempty"`
+	LastError      string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	NextAttemptAt  string                 `protobuf:"bytes,9,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeliveredAt    string                 `protobuf:"bytes,11,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *WebhookDelivery) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastStatusCode() int32 {
+	if x != nil {
+		return x.LastStatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() string {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetDeliveredAt() string {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return ""
+}
+
+type WebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDeliveriesResponse) Reset() {
+	*x = WebhookDeliveriesResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *WebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*WebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *WebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromEventId   int64                  `protobuf:"varint,1,opt,name=from_event_id,json=fromEventId,proto3" json:"from_event_id,omitempty"`
+	Types         []string               `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SubscribeRequest) GetFromEventId() int64 {
+	if x != nil {
+		return x.FromEventId
+	}
+	return 0
+}
+
+func (x *SubscribeRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type LedgerEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	AggregateId   int64                  `protobuf:"varint,3,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`
+	Payload       string                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerEvent) Reset() {
+	*x = LedgerEvent{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerEvent) ProtoMessage() {}
+
+func (x *LedgerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerEvent.ProtoReflect.Descriptor instead.
+func (*LedgerEvent) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *LedgerEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LedgerEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *LedgerEvent) GetAggregateId() int64 {
+	if x != nil {
+		return x.AggregateId
+	}
+	return 0
+}
+
+func (x *LedgerEvent) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *LedgerEvent) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type AuditListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntityType    string                 `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EntityId      int64                  `protobuf:"varint,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	ActorUserId   string                 `protobuf:"bytes,3,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RequestId     string                 `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Action        string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	From          string                 `protobuf:"bytes,6,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,7,opt,name=to,proto3" json:"to,omitempty"`
+	BeforeId      int64                  `protobuf:"varint,8,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditListRequest) Reset() {
+	*x = AuditListRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditListRequest) ProtoMessage() {}
+
+func (x *AuditListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditListRequest.ProtoReflect.Descriptor instead.
+func (*AuditListRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *AuditListRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *AuditListRequest) GetEntityId() int64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *AuditListRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *AuditListRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditListRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditListRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *AuditListRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *AuditListRequest) GetBeforeId() int64 {
+	if x != nil {
+		return x.BeforeId
+	}
+	return 0
+}
+
+func (x *AuditListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type AuditEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	EntityType    string                 `protobuf:"bytes,3,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EntityId      int64                  `protobuf:"varint,4,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	ActorUserId   string                 `protobuf:"bytes,5,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RequestId     string                 `protobuf:"bytes,6,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Method        string                 `protobuf:"bytes,7,opt,name=method,proto3" json:"method,omitempty"`
+	Before        string                 `protobuf:"bytes,8,opt,name=before,proto3" json:"before,omitempty"`
+	After         string                 `protobuf:"bytes,9,opt,name=after,proto3" json:"after,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *AuditEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetEntityId() int64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type AuditListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditListResponse) Reset() {
+	*x = AuditListResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditListResponse) ProtoMessage() {}
+
+func (x *AuditListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditListResponse.ProtoReflect.Descriptor instead.
+func (*AuditListResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *AuditListResponse) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+var File_ledger_v1_ledger_proto protoreflect.FileDescriptor
+
+const file_ledger_v1_ledger_proto_rawDesc = "" +
+	"\n" +
+	"\x16ledger/v1/ledger.proto\x12\tledger.v1\x1a\x1bgoogle/protobuf/empty.proto\"e\n" +
+	"\x10BudgetAddRequest\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x02R\x05limit\x12\x1f\n" +
+	"\vcategory_id\x18\x03 \x01(\x03R\n" +
+	"categoryId\"C\n" +
+	"\x10BudgetGetRequest\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x13\n" +
+	"\x05as_of\x18\x02 \x01(\tR\x04asOf\")\n" +
+	"\x12BudgetsListRequest\x12\x13\n" +
+	"\x05as_of\x18\x01 \x01(\tR\x04asOf\"|\n" +
+	"\x11BudgetGetResponse\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x02R\x05limit\x12\x1f\n" +
+	"\vcategory_id\x18\x03 \x01(\x03R\n" +
+	"categoryId\x12\x14\n" +
+	"\x05spent\x18\x04 \x01(\x02R\x05spent\"O\n" +
+	"\x15BudgetGetListResponse\x126\n" +
+	"\abudgets\x18\x01 \x03(\v2\x1c.ledger.v1.BudgetGetResponseR\abudgets\"\x96\x02\n" +
+	"\x15TransactionAddRequest\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x02R\x06amount\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04date\x18\x04 \x01(\tR\x04date\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1f\n" +
+	"\vcategory_id\x18\x06 \x01(\x03R\n" +
+	"categoryId\x12\x1f\n" +
+	"\vexternal_id\x18\a \x01(\tR\n" +
+	"externalId\x12\x14\n" +
+	"\x05force\x18\b \x01(\bR\x05force\x12'\n" +
+	"\x0fidempotency_key\x18\t \x01(\tR\x0eidempotencyKey\"a\n" +
+	"\x19TransactionBulkAddRequest\x12D\n" +
+	"\ftransactions\x18\x01 \x03(\v2 .ledger.v1.TransactionAddRequestR\ftransactions\"\x9b\x04\n" +
+	"\x1aTransactionBulkAddResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\x03R\baccepted\x12\x1a\n" +
+	"\brejected\x18\x02 \x01(\x03R\brejected\x12I\n" +
+	"\x06errors\x18\x03 \x03(\v21.ledger.v1.TransactionBulkAddResponse.ErrorsEntryR\x06errors\x12U\n" +
+	"\n" +
+	"duplicates\x18\x04 \x03(\v25.ledger.v1.TransactionBulkAddResponse.DuplicatesEntryR\n" +
+	"duplicates\x12e\n" +
+	"\x10batch_duplicates\x18\x05 \x03(\v2:.ledger.v1.TransactionBulkAddResponse.BatchDuplicatesEntryR\x0fbatchDuplicates\x1a9\n" +
+	"\vErrorsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a=\n" +
+	"\x0fDuplicatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1aB\n" +
+	"\x14BatchDuplicatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"B\n" +
+	"\x16TransactionAddResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\aanomaly\x18\x02 \x01(\bR\aanomaly\"'\n" +
+	"\x15TransactionGetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xfa\x01\n" +
+	"\x16TransactionGetResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x02R\x06amount\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04date\x18\x05 \x01(\tR\x04date\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x1f\n" +
+	"\vcategory_id\x18\a \x01(\x03R\n" +
+	"categoryId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\b \x01(\tR\tcreatedBy\x12\x12\n" +
+	"\x04kind\x18\t \x01(\tR\x04kind\"*\n" +
+	"\x16TransactionListRequest\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\"c\n" +
+	"\x1aTransactionGetListResponse\x12E\n" +
+	"\ftransactions\x18\x01 \x03(\v2!.ledger.v1.TransactionGetResponseR\ftransactions\"d\n" +
+	"\x0eSummaryRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x19\n" +
+	"\bgroup_by\x18\x03 \x01(\tR\agroupBy\x12\x13\n" +
+	"\x05as_of\x18\x04 \x01(\tR\x04asOf\"\xdc\x01\n" +
+	"\x0fSummaryResponse\x12>\n" +
 	"\x06report\x18\x01 \x03(\v2&.ledger.v1.SummaryResponse.ReportEntryR\x06report\x12!\n" +
-	"\fcache_result\x18\x02 \x01(\bR\vcacheResult\x1a9\n" +
+	"\fcache_result\x18\x02 \x01(\bR\vcacheResult\x12+\n" +
+	"\x04tree\x18\x03 \x03(\v2\x17.ledger.v1.CategoryNodeR\x04tree\x1a9\n" +
 	"\vReportEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x012\x8e\x05\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\x95\x01\n" +
+	"\fCategoryNode\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x01R\x05total\x123\n" +
+	"\bchildren\x18\x05 \x03(\v2\x17.ledger.v1.CategoryNodeR\bchildren\"E\n" +
+	"\x12CategoryAddRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\x03R\bparentId\"%\n" +
+	"\x13CategoryAddResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"X\n" +
+	"\x15CategoryUpdateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\x03R\bparentId\"V\n" +
+	"\x13CategoryGetResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\x03R\bparentId\"Y\n" +
+	"\x17CategoryGetListResponse\x12>\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x1e.ledger.v1.CategoryGetResponseR\n" +
+	"categories\"\xaf\x02\n" +
+	"\x04Rule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
+	"\bpriority\x18\x02 \x01(\x05R\bpriority\x12+\n" +
+	"\x11description_regex\x18\x03 \x01(\tR\x10descriptionRegex\x121\n" +
+	"\x14description_contains\x18\x04 \x01(\tR\x13descriptionContains\x12\x1d\n" +
+	"\n" +
+	"min_amount\x18\x05 \x01(\x02R\tminAmount\x12\x1d\n" +
+	"\n" +
+	"max_amount\x18\x06 \x01(\x02R\tmaxAmount\x12\x1f\n" +
+	"\vcategory_id\x18\a \x01(\x03R\n" +
+	"categoryId\x12\x1a\n" +
+	"\bcategory\x18\b \x01(\tR\bcategory\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\"!\n" +
+	"\x0fRuleAddResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"#\n" +
+	"\x11RuleDeleteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"9\n" +
+	"\x10RuleListResponse\x12%\n" +
+	"\x05rules\x18\x01 \x03(\v2\x0f.ledger.v1.RuleR\x05rules\"J\n" +
+	"\x0fRuleTestRequest\x12#\n" +
+	"\x04rule\x18\x01 \x01(\v2\x0f.ledger.v1.RuleR\x04rule\x12\x12\n" +
+	"\x04last\x18\x02 \x01(\x05R\x04last\"\xaf\x01\n" +
+	"\tRuleMatch\x12C\n" +
+	"\vtransaction\x18\x01 \x01(\v2!.ledger.v1.TransactionGetResponseR\vtransaction\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\x03R\n" +
+	"categoryId\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"B\n" +
+	"\x10RuleTestResponse\x12.\n" +
+	"\amatches\x18\x01 \x03(\v2\x14.ledger.v1.RuleMatchR\amatches\"\xb6\x01\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\x03R\rtransactionId\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\"i\n" +
+	"\x17AttachmentUploadRequest\x12+\n" +
+	"\x04info\x18\x01 \x01(\v2\x15.ledger.v1.AttachmentH\x00R\x04info\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"J\n" +
+	"\x11AttachmentRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\x03R\rtransactionId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\"a\n" +
+	"\x0fAttachmentChunk\x12+\n" +
+	"\x04info\x18\x01 \x01(\v2\x15.ledger.v1.AttachmentH\x00R\x04info\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"?\n" +
+	"\x16AttachmentsListRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\x03R\rtransactionId\"Q\n" +
+	"\x16AttachmentListResponse\x127\n" +
+	"\vattachments\x18\x01 \x03(\v2\x15.ledger.v1.AttachmentR\vattachments\"\xa7\x01\n" +
+	"\x18TransactionSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\x12\x1f\n" +
+	"\vcategory_id\x18\x05 \x01(\x03R\n" +
+	"categoryId\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\"r\n" +
+	"\x17TransactionSearchResult\x12C\n" +
+	"\vtransaction\x18\x01 \x01(\v2!.ledger.v1.TransactionGetResponseR\vtransaction\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x02R\x04rank\"Y\n" +
+	"\x19TransactionSearchResponse\x12<\n" +
+	"\aresults\x18\x01 \x03(\v2\".ledger.v1.TransactionSearchResultR\aresults\"k\n" +
+	"\x04Goal\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
+	"\rtarget_amount\x18\x03 \x01(\x02R\ftargetAmount\x12\x1a\n" +
+	"\bdeadline\x18\x04 \x01(\tR\bdeadline\"!\n" +
+	"\x0fGoalAddResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x1d\n" +
+	"\vGoalRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xcd\x01\n" +
+	"\fGoalProgress\x12#\n" +
+	"\x04goal\x18\x01 \x01(\v2\x0f.ledger.v1.GoalR\x04goal\x12\x14\n" +
+	"\x05saved\x18\x02 \x01(\x02R\x05saved\x12\x1c\n" +
+	"\tremaining\x18\x03 \x01(\x02R\tremaining\x12\x18\n" +
+	"\apercent\x18\x04 \x01(\x02R\apercent\x12\x1f\n" +
+	"\vmonths_left\x18\x05 \x01(\x05R\n" +
+	"monthsLeft\x12)\n" +
+	"\x10monthly_required\x18\x06 \x01(\x02R\x0fmonthlyRequired\"A\n" +
+	"\x10GoalListResponse\x12-\n" +
+	"\x05goals\x18\x01 \x03(\v2\x17.ledger.v1.GoalProgressR\x05goals\"~\n" +
+	"\x15GoalContributeRequest\x12\x17\n" +
+	"\agoal_id\x18\x01 \x01(\x03R\x06goalId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x02R\x06amount\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\":\n" +
+	"\x0fForecastRequest\x12'\n" +
+	"\x0fhistory_periods\x18\x01 \x01(\x05R\x0ehistoryPeriods\"\xaf\x01\n" +
+	"\x10CategoryForecast\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05spent\x18\x02 \x01(\x02R\x05spent\x12\x1c\n" +
+	"\tscheduled\x18\x03 \x01(\x02R\tscheduled\x12-\n" +
+	"\x12historical_average\x18\x04 \x01(\x02R\x11historicalAverage\x12\x1c\n" +
+	"\tprojected\x18\x05 \x01(\x02R\tprojected\"\xaa\x01\n" +
+	"\x0eBudgetForecast\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x02R\x05limit\x12\x1c\n" +
+	"\tprojected\x18\x04 \x01(\x02R\tprojected\x12'\n" +
+	"\x0flikely_exceeded\x18\x05 \x01(\bR\x0elikelyExceeded\"\x8f\x02\n" +
+	"\x10ForecastResponse\x12!\n" +
+	"\fperiod_start\x18\x01 \x01(\tR\vperiodStart\x12\x1d\n" +
+	"\n" +
+	"period_end\x18\x02 \x01(\tR\tperiodEnd\x12!\n" +
+	"\fdays_elapsed\x18\x03 \x01(\x05R\vdaysElapsed\x12$\n" +
+	"\x0edays_in_period\x18\x04 \x01(\x05R\fdaysInPeriod\x12;\n" +
+	"\n" +
+	"categories\x18\x05 \x03(\v2\x1b.ledger.v1.CategoryForecastR\n" +
+	"categories\x123\n" +
+	"\abudgets\x18\x06 \x03(\v2\x19.ledger.v1.BudgetForecastR\abudgets\"6\n" +
+	"\x10AnomaliesRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\"\xa4\x01\n" +
+	"\aAnomaly\x12C\n" +
+	"\vtransaction\x18\x01 \x01(\v2!.ledger.v1.TransactionGetResponseR\vtransaction\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x02R\x05score\x12\x14\n" +
+	"\x05ratio\x18\x03 \x01(\x02R\x05ratio\x12\x16\n" +
+	"\x06median\x18\x04 \x01(\x02R\x06median\x12\x10\n" +
+	"\x03mad\x18\x05 \x01(\x02R\x03mad\"E\n" +
+	"\x11AnomaliesResponse\x120\n" +
+	"\tanomalies\x18\x01 \x03(\v2\x12.ledger.v1.AnomalyR\tanomalies\"[\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\x12\x16\n" +
+	"\x06events\x18\x04 \x03(\tR\x06events\"$\n" +
+	"\x12WebhookAddResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\" \n" +
+	"\x0eWebhookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"E\n" +
+	"\x13WebhookListResponse\x12.\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\x12.ledger.v1.WebhookR\bwebhooks\"O\n" +
+	"\x18WebhookDeliveriesRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x03R\twebhookId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xd7\x02\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\x03R\twebhookId\x12\x14\n" +
+	"\x05event\x18\x03 \x01(\tR\x05event\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\tR\apayload\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x12(\n" +
+	"\x10last_status_code\x18\a \x01(\x05R\x0elastStatusCode\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x12&\n" +
+	"\x0fnext_attempt_at\x18\t \x01(\tR\rnextAttemptAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\x12!\n" +
+	"\fdelivered_at\x18\v \x01(\tR\vdeliveredAt\"W\n" +
+	"\x19WebhookDeliveriesResponse\x12:\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x1a.ledger.v1.WebhookDeliveryR\n" +
+	"deliveries\"L\n" +
+	"\x10SubscribeRequest\x12\"\n" +
+	"\rfrom_event_id\x18\x01 \x01(\x03R\vfromEventId\x12\x14\n" +
+	"\x05types\x18\x02 \x03(\tR\x05types\"\x8d\x01\n" +
+	"\vLedgerEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12!\n" +
+	"\faggregate_id\x18\x03 \x01(\x03R\vaggregateId\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\tR\apayload\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\"\x82\x02\n" +
+	"\x10AuditListRequest\x12\x1f\n" +
+	"\ventity_type\x18\x01 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x02 \x01(\x03R\bentityId\x12\"\n" +
+	"\ractor_user_id\x18\x03 \x01(\tR\vactorUserId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x12\n" +
+	"\x04from\x18\x06 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\a \x01(\tR\x02to\x12\x1b\n" +
+	"\tbefore_id\x18\b \x01(\x03R\bbeforeId\x12\x14\n" +
+	"\x05limit\x18\t \x01(\x05R\x05limit\"\x9a\x02\n" +
+	"\n" +
+	"AuditEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x1f\n" +
+	"\ventity_type\x18\x03 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x04 \x01(\x03R\bentityId\x12\"\n" +
+	"\ractor_user_id\x18\x05 \x01(\tR\vactorUserId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x06 \x01(\tR\trequestId\x12\x16\n" +
+	"\x06method\x18\a \x01(\tR\x06method\x12\x16\n" +
+	"\x06before\x18\b \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\t \x01(\tR\x05after\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\"D\n" +
+	"\x11AuditListResponse\x12/\n" +
+	"\aentries\x18\x01 \x03(\v2\x15.ledger.v1.AuditEntryR\aentries2\xc0\x14\n" +
 	"\rLedgerService\x12@\n" +
 	"\tBudgetAdd\x12\x1b.ledger.v1.BudgetAddRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
-	"\tBudgetGet\x12\x1b.ledger.v1.BudgetGetRequest\x1a\x1c.ledger.v1.BudgetGetResponse\x12G\n" +
-	"\vBudgetsList\x12\x16.google.protobuf.Empty\x1a .ledger.v1.BudgetGetListResponse\x12U\n" +
+	"\tBudgetGet\x12\x1b.ledger.v1.BudgetGetRequest\x1a\x1c.ledger.v1.BudgetGetResponse\x12N\n" +
+	"\vBudgetsList\x12\x1d.ledger.v1.BudgetsListRequest\x1a .ledger.v1.BudgetGetListResponse\x12U\n" +
 	"\x0eTransactionAdd\x12 .ledger.v1.TransactionAddRequest\x1a!.ledger.v1.TransactionAddResponse\x12U\n" +
-	"\x0eTransactionGet\x12 .ledger.v1.TransactionGetRequest\x1a!.ledger.v1.TransactionGetResponse\x12P\n" +
-	"\x0fTransactionList\x12\x16.google.protobuf.Empty\x1a%.ledger.v1.TransactionGetListResponse\x12F\n" +
-	"\rReportSummary\x12\x19.ledger.v1.SummaryRequest\x1a\x1a.ledger.v1.SummaryResponse\x12b\n" +
-	"\x13BulkAddTransactions\x12$.ledger.v1.TransactionBulkAddRequest\x1a%.ledger.v1.TransactionBulkAddResponseB\x1fZ\x1dgateway/internal/pb/ledger/v1b\x06proto3"
+	"\x0eTransactionGet\x12 .ledger.v1.TransactionGetRequest\x1a!.ledger.v1.TransactionGetResponse\x12[\n" +
+	"\x0fTransactionList\x12!.ledger.v1.TransactionListRequest\x1a%.ledger.v1.TransactionGetListResponse\x12^\n" +
+	"\x11TransactionSearch\x12#.ledger.v1.TransactionSearchRequest\x1a$.ledger.v1.TransactionSearchResponse\x12F\n" +
+	"\rReportSummary\x12\x19.ledger.v1.SummaryRequest\x1a\x1a.ledger.v1.SummaryResponse\x12I\n" +
+	"\x0eReportForecast\x12\x1a.ledger.v1.ForecastRequest\x1a\x1b.ledger.v1.ForecastResponse\x12L\n" +
+	"\x0fReportAnomalies\x12\x1b.ledger.v1.AnomaliesRequest\x1a\x1c.ledger.v1.AnomaliesResponse\x12b\n" +
+	"\x13BulkAddTransactions\x12$.ledger.v1.TransactionBulkAddRequest\x1a%.ledger.v1.TransactionBulkAddResponse\x12L\n" +
+	"\vCategoryAdd\x12\x1d.ledger.v1.CategoryAddRequest\x1a\x1e.ledger.v1.CategoryAddResponse\x12J\n" +
+	"\x0eCategoryUpdate\x12 .ledger.v1.CategoryUpdateRequest\x1a\x16.google.protobuf.Empty\x12L\n" +
+	"\x0eCategoriesList\x12\x16.google.protobuf.Empty\x1a\".ledger.v1.CategoryGetListResponse\x126\n" +
+	"\aRuleAdd\x12\x0f.ledger.v1.Rule\x1a\x1a.ledger.v1.RuleAddResponse\x125\n" +
+	"\n" +
+	"RuleUpdate\x12\x0f.ledger.v1.Rule\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\n" +
+	"RuleDelete\x12\x1c.ledger.v1.RuleDeleteRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\tRulesList\x12\x16.google.protobuf.Empty\x1a\x1b.ledger.v1.RuleListResponse\x12C\n" +
+	"\bRuleTest\x12\x1a.ledger.v1.RuleTestRequest\x1a\x1b.ledger.v1.RuleTestResponse\x12O\n" +
+	"\x10AttachmentUpload\x12\".ledger.v1.AttachmentUploadRequest\x1a\x15.ledger.v1.Attachment(\x01\x12P\n" +
+	"\x12AttachmentDownload\x12\x1c.ledger.v1.AttachmentRequest\x1a\x1a.ledger.v1.AttachmentChunk0\x01\x12H\n" +
+	"\x10AttachmentDelete\x12\x1c.ledger.v1.AttachmentRequest\x1a\x16.google.protobuf.Empty\x12W\n" +
+	"\x0fAttachmentsList\x12!.ledger.v1.AttachmentsListRequest\x1a!.ledger.v1.AttachmentListResponse\x126\n" +
+	"\aGoalAdd\x12\x0f.ledger.v1.Goal\x1a\x1a.ledger.v1.GoalAddResponse\x125\n" +
+	"\n" +
+	"GoalUpdate\x12\x0f.ledger.v1.Goal\x1a\x16.google.protobuf.Empty\x12<\n" +
+	"\n" +
+	"GoalDelete\x12\x16.ledger.v1.GoalRequest\x1a\x16.google.protobuf.Empty\x12:\n" +
+	"\aGoalGet\x12\x16.ledger.v1.GoalRequest\x1a\x17.ledger.v1.GoalProgress\x12@\n" +
+	"\tGoalsList\x12\x16.google.protobuf.Empty\x1a\x1b.ledger.v1.GoalListResponse\x12U\n" +
+	"\x0eGoalContribute\x12 .ledger.v1.GoalContributeRequest\x1a!.ledger.v1.TransactionAddResponse\x12?\n" +
+	"\n" +
+	"WebhookAdd\x12\x12.ledger.v1.Webhook\x1a\x1d.ledger.v1.WebhookAddResponse\x12B\n" +
+	"\rWebhookDelete\x12\x19.ledger.v1.WebhookRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
+	"\fWebhooksList\x12\x16.google.protobuf.Empty\x1a\x1e.ledger.v1.WebhookListResponse\x12b\n" +
+	"\x15WebhookDeliveriesList\x12#.ledger.v1.WebhookDeliveriesRequest\x1a$.ledger.v1.WebhookDeliveriesResponse\x12B\n" +
+	"\tSubscribe\x12\x1b.ledger.v1.SubscribeRequest\x1a\x16.ledger.v1.LedgerEvent0\x01\x12F\n" +
+	"\tAuditList\x12\x1b.ledger.v1.AuditListRequest\x1a\x1c.ledger.v1.AuditListResponseB\x1fZ\x1dgateway/internal/pb/ledger/v1b\x06proto3"
 
 var (
 	file_ledger_v1_ledger_proto_rawDescOnce sync.Once
@@ -770,52 +4232,180 @@ func file_ledger_v1_ledger_proto_rawDescGZIP() []byte {
 	return file_ledger_v1_ledger_proto_rawDescData
 }
 
-var file_ledger_v1_ledger_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_ledger_v1_ledger_proto_msgTypes = make([]protoimpl.MessageInfo, 66)
 var file_ledger_v1_ledger_proto_goTypes = []any{
 	(*BudgetAddRequest)(nil),           // 0: ledger.v1.BudgetAddRequest
 	(*BudgetGetRequest)(nil),           // 1: ledger.v1.BudgetGetRequest
-	(*BudgetGetResponse)(nil),          // 2: ledger.v1.BudgetGetResponse
-	(*BudgetGetListResponse)(nil),      // 3: ledger.v1.BudgetGetListResponse
-	(*TransactionAddRequest)(nil),      // 4: ledger.v1.TransactionAddRequest
-	(*TransactionBulkAddRequest)(nil),  // 5: ledger.v1.TransactionBulkAddRequest
-	(*TransactionBulkAddResponse)(nil), // 6: ledger.v1.TransactionBulkAddResponse
-	(*TransactionAddResponse)(nil),     // 7: ledger.v1.TransactionAddResponse
-	(*TransactionGetRequest)(nil),      // 8: ledger.v1.TransactionGetRequest
-	(*TransactionGetResponse)(nil),     // 9: ledger.v1.TransactionGetResponse
-	(*TransactionGetListResponse)(nil), // 10: ledger.v1.TransactionGetListResponse
-	(*SummaryRequest)(nil),             // 11: ledger.v1.SummaryRequest
-	(*SummaryResponse)(nil),            // 12: ledger.v1.SummaryResponse
-	nil,                                // 13: ledger.v1.TransactionBulkAddResponse.ErrorsEntry
-	nil,                                // 14: ledger.v1.SummaryResponse.ReportEntry
-	(*emptypb.Empty)(nil),              // 15: google.protobuf.Empty
+	(*BudgetsListRequest)(nil),         // 2: ledger.v1.BudgetsListRequest
+	(*BudgetGetResponse)(nil),          // 3: ledger.v1.BudgetGetResponse
+	(*BudgetGetListResponse)(nil),      // 4: ledger.v1.BudgetGetListResponse
+	(*TransactionAddRequest)(nil),      // 5: ledger.v1.TransactionAddRequest
+	(*TransactionBulkAddRequest)(nil),  // 6: ledger.v1.TransactionBulkAddRequest
+	(*TransactionBulkAddResponse)(nil), // 7: ledger.v1.TransactionBulkAddResponse
+	(*TransactionAddResponse)(nil),     // 8: ledger.v1.TransactionAddResponse
+	(*TransactionGetRequest)(nil),      // 9: ledger.v1.TransactionGetRequest
+	(*TransactionGetResponse)(nil),     // 10: ledger.v1.TransactionGetResponse
+	(*TransactionListRequest)(nil),     // 11: ledger.v1.TransactionListRequest
+	(*TransactionGetListResponse)(nil), // 12: ledger.v1.TransactionGetListResponse
+	(*SummaryRequest)(nil),             // 13: ledger.v1.SummaryRequest
+	(*SummaryResponse)(nil),            // 14: ledger.v1.SummaryResponse
+	(*CategoryNode)(nil),               // 15: ledger.v1.CategoryNode
+	(*CategoryAddRequest)(nil),         // 16: ledger.v1.CategoryAddRequest
+	(*CategoryAddResponse)(nil),        // 17: ledger.v1.CategoryAddResponse
+	(*CategoryUpdateRequest)(nil),      // 18: ledger.v1.CategoryUpdateRequest
+	(*CategoryGetResponse)(nil),        // 19: ledger.v1.CategoryGetResponse
+	(*CategoryGetListResponse)(nil),    // 20: ledger.v1.CategoryGetListResponse
+	(*Rule)(nil),                       // 21: ledger.v1.Rule
+	(*RuleAddResponse)(nil),            // 22: ledger.v1.RuleAddResponse
+	(*RuleDeleteRequest)(nil),          // 23: ledger.v1.RuleDeleteRequest
+	(*RuleListResponse)(nil),           // 24: ledger.v1.RuleListResponse
+	(*RuleTestRequest)(nil),            // 25: ledger.v1.RuleTestRequest
+	(*RuleMatch)(nil),                  // 26: ledger.v1.RuleMatch
+	(*RuleTestResponse)(nil),           // 27: ledger.v1.RuleTestResponse
+	(*Attachment)(nil),                 // 28: ledger.v1.Attachment
+	(*AttachmentUploadRequest)(nil),    // 29: ledger.v1.AttachmentUploadRequest
+	(*AttachmentRequest)(nil),          // 30: ledger.v1.AttachmentRequest
+	(*AttachmentChunk)(nil),            // 31: ledger.v1.AttachmentChunk
+	(*AttachmentsListRequest)(nil),     // 32: ledger.v1.AttachmentsListRequest
+	(*AttachmentListResponse)(nil),     // 33: ledger.v1.AttachmentListResponse
+	(*TransactionSearchRequest)(nil),   // 34: ledger.v1.TransactionSearchRequest
+	(*TransactionSearchResult)(nil),    // 35: ledger.v1.TransactionSearchResult
+	(*TransactionSearchResponse)(nil),  // 36: ledger.v1.TransactionSearchResponse
+	(*Goal)(nil),                       // 37: ledger.v1.Goal
+	(*GoalAddResponse)(nil),            // 38: ledger.v1.GoalAddResponse
+	(*GoalRequest)(nil),                // 39: ledger.v1.GoalRequest
+	(*GoalProgress)(nil),               // 40: ledger.v1.GoalProgress
+	(*GoalListResponse)(nil),           // 41: ledger.v1.GoalListResponse
+	(*GoalContributeRequest)(nil),      // 42: ledger.v1.GoalContributeRequest
+	(*ForecastRequest)(nil),            // 43: ledger.v1.ForecastRequest
+	(*CategoryForecast)(nil),           // 44: ledger.v1.CategoryForecast
+	(*BudgetForecast)(nil),             // 45: ledger.v1.BudgetForecast
+	(*ForecastResponse)(nil),           // 46: ledger.v1.ForecastResponse
+	(*AnomaliesRequest)(nil),           // 47: ledger.v1.AnomaliesRequest
+	(*Anomaly)(nil),                    // 48: ledger.v1.Anomaly
+	(*AnomaliesResponse)(nil),          // 49: ledger.v1.AnomaliesResponse
+	(*Webhook)(nil),                    // 50: ledger.v1.Webhook
+	(*WebhookAddResponse)(nil),         // 51: ledger.v1.WebhookAddResponse
+	(*WebhookRequest)(nil),             // 52: ledger.v1.WebhookRequest
+	(*WebhookListResponse)(nil),        // 53: ledger.v1.WebhookListResponse
+	(*WebhookDeliveriesRequest)(nil),   // 54: ledger.v1.WebhookDeliveriesRequest
+	(*WebhookDelivery)(nil),            // 55: ledger.v1.WebhookDelivery
+	(*WebhookDeliveriesResponse)(nil),  // 56: ledger.v1.WebhookDeliveriesResponse
+	(*SubscribeRequest)(nil),           // 57: ledger.v1.SubscribeRequest
+	(*LedgerEvent)(nil),                // 58: ledger.v1.LedgerEvent
+	(*AuditListRequest)(nil),           // 59: ledger.v1.AuditListRequest
+	(*AuditEntry)(nil),                 // 60: ledger.v1.AuditEntry
+	(*AuditListResponse)(nil),          // 61: ledger.v1.AuditListResponse
+	nil,                                // 62: ledger.v1.TransactionBulkAddResponse.ErrorsEntry
+	nil,                                // 63: ledger.v1.TransactionBulkAddResponse.DuplicatesEntry
+	nil,                                // 64: ledger.v1.TransactionBulkAddResponse.BatchDuplicatesEntry
+	nil,                                // 65: ledger.v1.SummaryResponse.ReportEntry
+	(*emptypb.Empty)(nil),              // 66: google.protobuf.Empty
 }
 var file_ledger_v1_ledger_proto_depIdxs = []int32{
-	2,  // 0: ledger.v1.BudgetGetListResponse.budgets:type_name -> ledger.v1.BudgetGetResponse
-	4,  // 1: ledger.v1.TransactionBulkAddRequest.transactions:type_name -> ledger.v1.TransactionAddRequest
-	13, // 2: ledger.v1.TransactionBulkAddResponse.errors:type_name -> ledger.v1.TransactionBulkAddResponse.ErrorsEntry
-	9,  // 3: ledger.v1.TransactionGetListResponse.transactions:type_name -> ledger.v1.TransactionGetResponse
-	14, // 4: ledger.v1.SummaryResponse.report:type_name -> ledger.v1.SummaryResponse.ReportEntry
-	0,  // 5: ledger.v1.LedgerService.BudgetAdd:input_type -> ledger.v1.BudgetAddRequest
-	1,  // 6: ledger.v1.LedgerService.BudgetGet:input_type -> ledger.v1.BudgetGetRequest
-	15, // 7: ledger.v1.LedgerService.BudgetsList:input_type -> google.protobuf.Empty
-	4,  // 8: ledger.v1.LedgerService.TransactionAdd:input_type -> ledger.v1.TransactionAddRequest
-	8,  // 9: ledger.v1.LedgerService.TransactionGet:input_type -> ledger.v1.TransactionGetRequest
-	15, // 10: ledger.v1.LedgerService.TransactionList:input_type -> google.protobuf.Empty
-	11, // 11: ledger.v1.LedgerService.ReportSummary:input_type -> ledger.v1.SummaryRequest
-	5,  // 12: ledger.v1.LedgerService.BulkAddTransactions:input_type -> ledger.v1.TransactionBulkAddRequest
-	15, // 13: ledger.v1.LedgerService.BudgetAdd:output_type -> google.protobuf.Empty
-	2,  // 14: ledger.v1.LedgerService.BudgetGet:output_type -> ledger.v1.BudgetGetResponse
-	3,  // 15: ledger.v1.LedgerService.BudgetsList:output_type -> ledger.v1.BudgetGetListResponse
-	7,  // 16: ledger.v1.LedgerService.TransactionAdd:output_type -> ledger.v1.TransactionAddResponse
-	9,  // 17: ledger.v1.LedgerService.TransactionGet:output_type -> ledger.v1.TransactionGetResponse
-	10, // 18: ledger.v1.LedgerService.TransactionList:output_type -> ledger.v1.TransactionGetListResponse
-	12, // 19: ledger.v1.LedgerService.ReportSummary:output_type -> ledger.v1.SummaryResponse
-	6,  // 20: ledger.v1.LedgerService.BulkAddTransactions:output_type -> ledger.v1.TransactionBulkAddResponse
-	13, // [13:21] is the sub-list for method output_type
-	5,  // [5:13] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	3,  // 0: ledger.v1.BudgetGetListResponse.budgets:type_name -> ledger.v1.BudgetGetResponse
+	5,  // 1: ledger.v1.TransactionBulkAddRequest.transactions:type_name -> ledger.v1.TransactionAddRequest
+	62, // 2: ledger.v1.TransactionBulkAddResponse.errors:type_name -> ledger.v1.TransactionBulkAddResponse.ErrorsEntry
+	63, // 3: ledger.v1.TransactionBulkAddResponse.duplicates:type_name -> ledger.v1.TransactionBulkAddResponse.DuplicatesEntry
+	64, // 4: ledger.v1.TransactionBulkAddResponse.batch_duplicates:type_name -> ledger.v1.TransactionBulkAddResponse.BatchDuplicatesEntry
+	10, // 5: ledger.v1.TransactionGetListResponse.transactions:type_name -> ledger.v1.TransactionGetResponse
+	65, // 6: ledger.v1.SummaryResponse.report:type_name -> ledger.v1.SummaryResponse.ReportEntry
+	15, // 7: ledger.v1.SummaryResponse.tree:type_name -> ledger.v1.CategoryNode
+	15, // 8: ledger.v1.CategoryNode.children:type_name -> ledger.v1.CategoryNode
+	19, // 9: ledger.v1.CategoryGetListResponse.categories:type_name -> ledger.v1.CategoryGetResponse
+	21, // 10: ledger.v1.RuleListResponse.rules:type_name -> ledger.v1.Rule
+	21, // 11: ledger.v1.RuleTestRequest.rule:type_name -> ledger.v1.Rule
+	10, // 12: ledger.v1.RuleMatch.transaction:type_name -> ledger.v1.TransactionGetResponse
+	26, // 13: ledger.v1.RuleTestResponse.matches:type_name -> ledger.v1.RuleMatch
+	28, // 14: ledger.v1.AttachmentUploadRequest.info:type_name -> ledger.v1.Attachment
+	28, // 15: ledger.v1.AttachmentChunk.info:type_name -> ledger.v1.Attachment
+	28, // 16: ledger.v1.AttachmentListResponse.attachments:type_name -> ledger.v1.Attachment
+	10, // 17: ledger.v1.TransactionSearchResult.transaction:type_name -> ledger.v1.TransactionGetResponse
+	35, // 18: ledger.v1.TransactionSearchResponse.results:type_name -> ledger.v1.TransactionSearchResult
+	37, // 19: ledger.v1.GoalProgress.goal:type_name -> ledger.v1.Goal
+	40, // 20: ledger.v1.GoalListResponse.goals:type_name -> ledger.v1.GoalProgress
+	44, // 21: ledger.v1.ForecastResponse.categories:type_name -> ledger.v1.CategoryForecast
+	45, // 22: ledger.v1.ForecastResponse.budgets:type_name -> ledger.v1.BudgetForecast
+	10, // 23: ledger.v1.Anomaly.transaction:type_name -> ledger.v1.TransactionGetResponse
+	48, // 24: ledger.v1.AnomaliesResponse.anomalies:type_name -> ledger.v1.Anomaly
+	50, // 25: ledger.v1.WebhookListResponse.webhooks:type_name -> ledger.v1.Webhook
+	55, // 26: ledger.v1.WebhookDeliveriesResponse.deliveries:type_name -> ledger.v1.WebhookDelivery
+	60, // 27: ledger.v1.AuditListResponse.entries:type_name -> ledger.v1.AuditEntry
+	0,  // 28: ledger.v1.LedgerService.BudgetAdd:input_type -> ledger.v1.BudgetAddRequest
+	1,  // 29: ledger.v1.LedgerService.BudgetGet:input_type -> ledger.v1.BudgetGetRequest
+	2,  // 30: ledger.v1.LedgerService.BudgetsList:input_type -> ledger.v1.BudgetsListRequest
+	5,  // 31: ledger.v1.LedgerService.TransactionAdd:input_type -> ledger.v1.TransactionAddRequest
+	9,  // 32: ledger.v1.LedgerService.TransactionGet:input_type -> ledger.v1.TransactionGetRequest
+	11, // 33: ledger.v1.LedgerService.TransactionList:input_type -> ledger.v1.TransactionListRequest
+	34, // 34: ledger.v1.LedgerService.TransactionSearch:input_type -> ledger.v1.TransactionSearchRequest
+	13, // 35: ledger.v1.LedgerService.ReportSummary:input_type -> ledger.v1.SummaryRequest
+	43, // 36: ledger.v1.LedgerService.ReportForecast:input_type -> ledger.v1.ForecastRequest
+	47, // 37: ledger.v1.LedgerService.ReportAnomalies:input_type -> ledger.v1.AnomaliesRequest
+	6,  // 38: ledger.v1.LedgerService.BulkAddTransactions:input_type -> ledger.v1.TransactionBulkAddRequest
+	16, // 39: ledger.v1.LedgerService.CategoryAdd:input_type -> ledger.v1.CategoryAddRequest
+	18, // 40: ledger.v1.LedgerService.CategoryUpdate:input_type -> ledger.v1.CategoryUpdateRequest
+	66, // 41: ledger.v1.LedgerService.CategoriesList:input_type -> google.protobuf.Empty
+	21, // 42: ledger.v1.LedgerService.RuleAdd:input_type -> ledger.v1.Rule
+	21, // 43: ledger.v1.LedgerService.RuleUpdate:input_type -> ledger.v1.Rule
+	23, // 44: ledger.v1.LedgerService.RuleDelete:input_type -> ledger.v1.RuleDeleteRequest
+	66, // 45: ledger.v1.LedgerService.RulesList:input_type -> google.protobuf.Empty
+	25, // 46: ledger.v1.LedgerService.RuleTest:input_type -> ledger.v1.RuleTestRequest
+	29, // 47: ledger.v1.LedgerService.AttachmentUpload:input_type -> ledger.v1.AttachmentUploadRequest
+	30, // 48: ledger.v1.LedgerService.AttachmentDownload:input_type -> ledger.v1.AttachmentRequest
+	30, // 49: ledger.v1.LedgerService.AttachmentDelete:input_type -> ledger.v1.AttachmentRequest
+	32, // 50: ledger.v1.LedgerService.AttachmentsList:input_type -> ledger.v1.AttachmentsListRequest
+	37, // 51: ledger.v1.LedgerService.GoalAdd:input_type -> ledger.v1.Goal
+	37, // 52: ledger.v1.LedgerService.GoalUpdate:input_type -> ledger.v1.Goal
+	39, // 53: ledger.v1.LedgerService.GoalDelete:input_type -> ledger.v1.GoalRequest
+	39, // 54: ledger.v1.LedgerService.GoalGet:input_type -> ledger.v1.GoalRequest
+	66, // 55: ledger.v1.LedgerService.GoalsList:input_type -> google.protobuf.Empty
+	42, // 56: ledger.v1.LedgerService.GoalContribute:input_type -> ledger.v1.GoalContributeRequest
+	50, // 57: ledger.v1.LedgerService.WebhookAdd:input_type -> ledger.v1.Webhook
+	52, // 58: ledger.v1.LedgerService.WebhookDelete:input_type -> ledger.v1.WebhookRequest
+	66, // 59: ledger.v1.LedgerService.WebhooksList:input_type -> google.protobuf.Empty
+	54, // 60: ledger.v1.LedgerService.WebhookDeliveriesList:input_type -> ledger.v1.WebhookDeliveriesRequest
+	57, // 61: ledger.v1.LedgerService.Subscribe:input_type -> ledger.v1.SubscribeRequest
+	59, // 62: ledger.v1.LedgerService.AuditList:input_type -> ledger.v1.AuditListRequest
+	66, // 63: ledger.v1.LedgerService.BudgetAdd:output_type -> google.protobuf.Empty
+	3,  // 64: ledger.v1.LedgerService.BudgetGet:output_type -> ledger.v1.BudgetGetResponse
+	4,  // 65: ledger.v1.LedgerService.BudgetsList:output_type -> ledger.v1.BudgetGetListResponse
+	8,  // 66: ledger.v1.LedgerService.TransactionAdd:output_type -> ledger.v1.TransactionAddResponse
+	10, // 67: ledger.v1.LedgerService.TransactionGet:output_type -> ledger.v1.TransactionGetResponse
+	12, // 68: ledger.v1.LedgerService.TransactionList:output_type -> ledger.v1.TransactionGetListResponse
+	36, // 69: ledger.v1.LedgerService.TransactionSearch:output_type -> ledger.v1.TransactionSearchResponse
+	14, // 70: ledger.v1.LedgerService.ReportSummary:output_type -> ledger.v1.SummaryResponse
+	46, // 71: ledger.v1.LedgerService.ReportForecast:output_type -> ledger.v1.ForecastResponse
+	49, // 72: ledger.v1.LedgerService.ReportAnomalies:output_type -> ledger.v1.AnomaliesResponse
+	7,  // 73: ledger.v1.LedgerService.BulkAddTransactions:output_type -> ledger.v1.TransactionBulkAddResponse
+	17, // 74: ledger.v1.LedgerService.CategoryAdd:output_type -> ledger.v1.CategoryAddResponse
+	66, // 75: ledger.v1.LedgerService.CategoryUpdate:output_type -> google.protobuf.Empty
+	20, // 76: ledger.v1.LedgerService.CategoriesList:output_type -> ledger.v1.CategoryGetListResponse
+	22, // 77: ledger.v1.LedgerService.RuleAdd:output_type -> ledger.v1.RuleAddResponse
+	66, // 78: ledger.v1.LedgerService.RuleUpdate:output_type -> google.protobuf.Empty
+	66, // 79: ledger.v1.LedgerService.RuleDelete:output_type -> google.protobuf.Empty
+	24, // 80: ledger.v1.LedgerService.RulesList:output_type -> ledger.v1.RuleListResponse
+	27, // 81: ledger.v1.LedgerService.RuleTest:output_type -> ledger.v1.RuleTestResponse
+	28, // 82: ledger.v1.LedgerService.AttachmentUpload:output_type -> ledger.v1.Attachment
+	31, // 83: ledger.v1.LedgerService.AttachmentDownload:output_type -> ledger.v1.AttachmentChunk
+	66, // 84: ledger.v1.LedgerService.AttachmentDelete:output_type -> google.protobuf.Empty
+	33, // 85: ledger.v1.LedgerService.AttachmentsList:output_type -> ledger.v1.AttachmentListResponse
+	38, // 86: ledger.v1.LedgerService.GoalAdd:output_type -> ledger.v1.GoalAddResponse
+	66, // 87: ledger.v1.LedgerService.GoalUpdate:output_type -> google.protobuf.Empty
+	66, // 88: ledger.v1.LedgerService.GoalDelete:output_type -> google.protobuf.Empty
+	40, // 89: ledger.v1.LedgerService.GoalGet:output_type -> ledger.v1.GoalProgress
+	41, // 90: ledger.v1.LedgerService.GoalsList:output_type -> ledger.v1.GoalListResponse
+	8,  // 91: ledger.v1.LedgerService.GoalContribute:output_type -> ledger.v1.TransactionAddResponse
+	51, // 92: ledger.v1.LedgerService.WebhookAdd:output_type -> ledger.v1.WebhookAddResponse
+	66, // 93: ledger.v1.LedgerService.WebhookDelete:output_type -> google.protobuf.Empty
+	53, // 94: ledger.v1.LedgerService.WebhooksList:output_type -> ledger.v1.WebhookListResponse
+	56, // 95: ledger.v1.LedgerService.WebhookDeliveriesList:output_type -> ledger.v1.WebhookDeliveriesResponse
+	58, // 96: ledger.v1.LedgerService.Subscribe:output_type -> ledger.v1.LedgerEvent
+	61, // 97: ledger.v1.LedgerService.AuditList:output_type -> ledger.v1.AuditListResponse
+	63, // [63:98] is the sub-list for method output_type
+	28, // [28:63] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_ledger_v1_ledger_proto_init() }
@@ -823,13 +4413,21 @@ func file_ledger_v1_ledger_proto_init() {
 	if File_ledger_v1_ledger_proto != nil {
 		return
 	}
+	file_ledger_v1_ledger_proto_msgTypes[29].OneofWrappers = []any{
+		(*AttachmentUploadRequest_Info)(nil),
+		(*AttachmentUploadRequest_Chunk)(nil),
+	}
+	file_ledger_v1_ledger_proto_msgTypes[31].OneofWrappers = []any{
+		(*AttachmentChunk_Info)(nil),
+		(*AttachmentChunk_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ledger_v1_ledger_proto_rawDesc), len(file_ledger_v1_ledger_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   66,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
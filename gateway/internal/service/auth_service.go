@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"gateway/internal/model"
+	authv1 "gateway/internal/pb/auth/v1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type AuthGatewayService interface {
+	Login(ctx context.Context, req model.AuthLogin) (*model.AuthTokens, error)
+	Refresh(ctx context.Context, req model.AuthRefresh) (*model.AuthTokens, error)
+	Logout(ctx context.Context, token string, req model.AuthLogout) error
+	LogoutAll(ctx context.Context, token string) error
+	ChangePassword(ctx context.Context, token string, req model.PasswordChange) error
+	RequestPasswordReset(ctx context.Context, req model.PasswordResetRequest) error
+	ResetPassword(ctx context.Context, req model.PasswordReset) error
+	VerifyLogin(ctx context.Context, req model.TOTPVerify) (*model.AuthTokens, error)
+	EnrollTOTP(ctx context.Context, token string) (*model.TOTPEnrollment, error)
+	ActivateTOTP(ctx context.Context, token string, req model.TOTPCode) (*model.RecoveryCodes, error)
+	DisableTOTP(ctx context.Context, token string, req model.TOTPCode) error
+	JWKS(ctx context.Context) (*model.JWKS, error)
+	ListUsers(ctx context.Context, token string) ([]model.User, error)
+	SetUserRole(ctx context.Context, token string, userID string, req model.UserRole) error
+	DeleteUser(ctx context.Context, token string, userID string) error
+	UnlockLogin(ctx context.Context, token string, req model.LoginUnlock) error
+	ListAuthEvents(ctx context.Context, token string, req model.AuthEventList) ([]model.AuthEvent, error)
+	CreateSpace(ctx context.Context, token string, req model.SpaceCreate) (*model.Space, error)
+	ListSpaces(ctx context.Context, token string) ([]model.Space, error)
+	ListSpaceMembers(ctx context.Context, token string, spaceID string) ([]model.SpaceMember, error)
+	RemoveSpaceMember(ctx context.Context, token string, spaceID string, userID string) error
+	SwitchSpace(ctx context.Context, token string, req model.SpaceSwitch) (*model.SpaceToken, error)
+	InviteToSpace(ctx context.Context, token string, spaceID string, req model.SpaceInvite) (*model.Invitation, error)
+	ListInvitations(ctx context.Context, token string) ([]model.Invitation, error)
+	AcceptInvitation(ctx context.Context, token string, invitationID string) (*model.Space, error)
+	DeclineInvitation(ctx context.Context, token string, invitationID string) error
+	CreateAPIKey(ctx context.Context, token string, req model.APIKeyCreate) (*model.APIKeyCreated, error)
+	ListAPIKeys(ctx context.Context, token string) ([]model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, token string, id string) error
+}
+
+type authGatewayService struct {
+	client authv1.AuthServiceClient
+}
+
+func NewAuthGatewayService(client authv1.AuthServiceClient) AuthGatewayService {
+	if client == nil {
+		panic("auth gateway service requires gRPC client")
+	}
+	return &authGatewayService{client: client}
+}
+
+func (a *authGatewayService) Login(ctx context.Context, req model.AuthLogin) (*model.AuthTokens, error) {
+	resp, err := a.client.Login(ctx, &authv1.LoginRequest{Login: req.Login, Password: req.Password})
+	if err != nil {
+		return nil, err
+	}
+	return authTokensFromPb(resp), nil
+}
+
+func (a *authGatewayService) Refresh(ctx context.Context, req model.AuthRefresh) (*model.AuthTokens, error) {
+	resp, err := a.client.Refresh(ctx, &authv1.RefreshRequest{RefreshToken: req.RefreshToken})
+	if err != nil {
+		return nil, err
+	}
+	return authTokensFromPb(resp), nil
+}
+
+func (a *authGatewayService) Logout(ctx context.Context, token string, req model.AuthLogout) error {
+	_, err := a.client.Logout(ctx, &authv1.LogoutRequest{Token: token, RefreshToken: req.RefreshToken})
+	return err
+}
+
+func (a *authGatewayService) LogoutAll(ctx context.Context, token string) error {
+	_, err := a.client.LogoutAll(ctx, &authv1.LogoutAllRequest{Token: token})
+	return err
+}
+
+func (a *authGatewayService) ChangePassword(ctx context.Context, token string, req model.PasswordChange) error {
+	_, err := a.client.ChangePassword(ctx, &authv1.ChangePasswordRequest{Token: token, OldPassword: req.OldPassword, NewPassword: req.NewPassword})
+	return err
+}
+
+func (a *authGatewayService) RequestPasswordReset(ctx context.Context, req model.PasswordResetRequest) error {
+	_, err := a.client.RequestPasswordReset(ctx, &authv1.RequestPasswordResetRequest{Login: req.Login})
+	return err
+}
+
+func (a *authGatewayService) ResetPassword(ctx context.Context, req model.PasswordReset) error {
+	_, err := a.client.ResetPassword(ctx, &authv1.ResetPasswordRequest{ResetToken: req.ResetToken, NewPassword: req.NewPassword})
+	return err
+}
+
+func (a *authGatewayService) VerifyLogin(ctx context.Context, req model.TOTPVerify) (*model.AuthTokens, error) {
+	resp, err := a.client.VerifyLogin(ctx, &authv1.VerifyLoginRequest{ChallengeToken: req.ChallengeToken, Code: req.Code})
+	if err != nil {
+		return nil, err
+	}
+	return authTokensFromPb(resp), nil
+}
+
+func (a *authGatewayService) EnrollTOTP(ctx context.Context, token string) (*model.TOTPEnrollment, error) {
+	resp, err := a.client.EnrollTOTP(ctx, &authv1.EnrollTOTPRequest{Token: token})
+	if err != nil {
+		return nil, err
+	}
+	return &model.TOTPEnrollment{Secret: resp.GetSecret(), URI: resp.GetUri()}, nil
+}
+
+func (a *authGatewayService) ActivateTOTP(ctx context.Context, token string, req model.TOTPCode) (*model.RecoveryCodes, error) {
+	resp, err := a.client.ActivateTOTP(ctx, &authv1.ActivateTOTPRequest{Token: token, Code: req.Code})
+	if err != nil {
+		return nil, err
+	}
+	return &model.RecoveryCodes{RecoveryCodes: resp.GetRecoveryCodes()}, nil
+}
+
+func (a *authGatewayService) DisableTOTP(ctx context.Context, token string, req model.TOTPCode) error {
+	_, err := a.client.DisableTOTP(ctx, &authv1.DisableTOTPRequest{Token: token, Code: req.Code})
+	return err
+}
+
+func (a *authGatewayService) JWKS(ctx context.Context) (*model.JWKS, error) {
+	resp, err := a.client.GetJWKS(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	out := &model.JWKS{Keys: make([]model.JWK, 0, len(resp.GetKeys()))}
+	for _, k := range resp.GetKeys() {
+		out.Keys = append(out.Keys, model.JWK{
+			Kty: k.Kty,
+			Kid: k.Kid,
+			Use: k.Use,
+			Alg: k.Alg,
+			N:   k.N,
+			E:   k.E,
+			Crv: k.Crv,
+			X:   k.X,
+		})
+	}
+	return out, nil
+}
+
+func (a *authGatewayService) ListUsers(ctx context.Context, token string) ([]model.User, error) {
+	resp, err := a.client.ListUsers(ctx, &authv1.ListUsersRequest{Token: token})
+	if err != nil {
+		return nil, err
+	}
+	users := make([]model.User, 0, len(resp.GetUsers()))
+	for _, u := range resp.GetUsers() {
+		users = append(users, model.User{
+			Id:        u.Id,
+			Login:     u.Login,
+			Role:      u.Role,
+			CreatedAt: u.CreatedAt,
+		})
+	}
+	return users, nil
+}
+
+func (a *authGatewayService) SetUserRole(ctx context.Context, token string, userID string, req model.UserRole) error {
+	_, err := a.client.SetUserRole(ctx, &authv1.SetUserRoleRequest{Token: token, UserId: userID, Role: req.Role})
+	return err
+}
+
+func (a *authGatewayService) DeleteUser(ctx context.Context, token string, userID string) error {
+	_, err := a.client.DeleteUser(ctx, &authv1.DeleteUserRequest{Token: token, UserId: userID})
+	return err
+}
+
+func (a *authGatewayService) UnlockLogin(ctx context.Context, token string, req model.LoginUnlock) error {
+	_, err := a.client.UnlockLogin(ctx, &authv1.UnlockLoginRequest{Token: token, Login: req.Login, Ip: req.Ip})
+	return err
+}
+
+func (a *authGatewayService) ListAuthEvents(ctx context.Context, token string, req model.AuthEventList) ([]model.AuthEvent, error) {
+	resp, err := a.client.ListAuthEvents(ctx, &authv1.ListAuthEventsRequest{
+		Token:    token,
+		Login:    req.Login,
+		Ip:       req.Ip,
+		Type:     req.Type,
+		BeforeId: req.BeforeId,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]model.AuthEvent, 0, len(resp.GetEvents()))
+	for _, e := range resp.GetEvents() {
+		events = append(events, model.AuthEvent{
+			Id:          e.GetId(),
+			Type:        e.GetType(),
+			Login:       e.GetLogin(),
+			Ip:          e.GetIp(),
+			ActorUserId: e.GetActorUserId(),
+			Failures:    e.GetFailures(),
+			LockedUntil: e.GetLockedUntil(),
+			CreatedAt:   e.GetCreatedAt(),
+		})
+	}
+	return events, nil
+}
+
+func (a *authGatewayService) CreateSpace(ctx context.Context, token string, req model.SpaceCreate) (*model.Space, error) {
+	resp, err := a.client.CreateSpace(ctx, &authv1.CreateSpaceRequest{Token: token, Name: req.Name})
+	if err != nil {
+		return nil, err
+	}
+	space := spaceFromPb(resp)
+	return &space, nil
+}
+
+func (a *authGatewayService) ListSpaces(ctx context.Context, token string) ([]model.Space, error) {
+	resp, err := a.client.ListSpaces(ctx, &authv1.ListSpacesRequest{Token: token})
+	if err != nil {
+		return nil, err
+	}
+	spaces := make([]model.Space, 0, len(resp.GetSpaces()))
+	for _, s := range resp.GetSpaces() {
+		spaces = append(spaces, spaceFromPb(s))
+	}
+	return spaces, nil
+}
+
+func (a *authGatewayService) ListSpaceMembers(ctx context.Context, token string, spaceID string) ([]model.SpaceMember, error) {
+	resp, err := a.client.ListSpaceMembers(ctx, &authv1.ListSpaceMembersRequest{Token: token, SpaceId: spaceID})
+	if err != nil {
+		return nil, err
+	}
+	members := make([]model.SpaceMember, 0, len(resp.GetMembers()))
+	for _, m := range resp.GetMembers() {
+		members = append(members, model.SpaceMember{
+			UserId:   m.UserId,
+			Login:    m.Login,
+			JoinedAt: m.JoinedAt,
+		})
+	}
+	return members, nil
+}
+
+func (a *authGatewayService) RemoveSpaceMember(ctx context.Context, token string, spaceID string, userID string) error {
+	_, err := a.client.RemoveSpaceMember(ctx, &authv1.RemoveSpaceMemberRequest{Token: token, SpaceId: spaceID, UserId: userID})
+	return err
+}
+
+func (a *authGatewayService) SwitchSpace(ctx context.Context, token string, req model.SpaceSwitch) (*model.SpaceToken, error) {
+	resp, err := a.client.SwitchSpace(ctx, &authv1.SwitchSpaceRequest{Token: token, SpaceId: req.SpaceId})
+	if err != nil {
+		return nil, err
+	}
+	return &model.SpaceToken{Token: resp.GetToken(), ExpiresIn: resp.GetExpiresIn()}, nil
+}
+
+func (a *authGatewayService) InviteToSpace(ctx context.Context, token string, spaceID string, req model.SpaceInvite) (*model.Invitation, error) {
+	resp, err := a.client.InviteToSpace(ctx, &authv1.InviteToSpaceRequest{Token: token, SpaceId: spaceID, Login: req.Login})
+	if err != nil {
+		return nil, err
+	}
+	invitation := invitationFromPb(resp)
+	return &invitation, nil
+}
+
+func (a *authGatewayService) ListInvitations(ctx context.Context, token string) ([]model.Invitation, error) {
+	resp, err := a.client.ListInvitations(ctx, &authv1.ListInvitationsRequest{Token: token})
+	if err != nil {
+		return nil, err
+	}
+	invitations := make([]model.Invitation, 0, len(resp.GetInvitations()))
+	for _, i := range resp.GetInvitations() {
+		invitations = append(invitations, invitationFromPb(i))
+	}
+	return invitations, nil
+}
+
+func (a *authGatewayService) AcceptInvitation(ctx context.Context, token string, invitationID string) (*model.Space, error) {
+	resp, err := a.client.AcceptInvitation(ctx, &authv1.InvitationRequest{Token: token, InvitationId: invitationID})
+	if err != nil {
+		return nil, err
+	}
+	space := spaceFromPb(resp)
+	return &space, nil
+}
+
+func (a *authGatewayService) DeclineInvitation(ctx context.Context, token string, invitationID string) error {
+	_, err := a.client.DeclineInvitation(ctx, &authv1.InvitationRequest{Token: token, InvitationId: invitationID})
+	return err
+}
+
+func (a *authGatewayService) CreateAPIKey(ctx context.Context, token string, req model.APIKeyCreate) (*model.APIKeyCreated, error) {
+	resp, err := a.client.CreateAPIKey(ctx, &authv1.CreateAPIKeyRequest{
+		Token:     token,
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.APIKeyCreated{APIKey: apiKeyFromPb(resp.GetApiKey()), Key: resp.GetKey()}, nil
+}
+
+func (a *authGatewayService) ListAPIKeys(ctx context.Context, token string) ([]model.APIKey, error) {
+	resp, err := a.client.ListAPIKeys(ctx, &authv1.ListAPIKeysRequest{Token: token})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]model.APIKey, 0, len(resp.GetApiKeys()))
+	for _, k := range resp.GetApiKeys() {
+		keys = append(keys, apiKeyFromPb(k))
+	}
+	return keys, nil
+}
+
+func (a *authGatewayService) RevokeAPIKey(ctx context.Context, token string, id string) error {
+	_, err := a.client.RevokeAPIKey(ctx, &authv1.RevokeAPIKeyRequest{Token: token, Id: id})
+	return err
+}
+
+func spaceFromPb(s *authv1.Space) model.Space {
+	return model.Space{
+		Id:        s.GetId(),
+		Name:      s.GetName(),
+		OwnerId:   s.GetOwnerId(),
+		CreatedAt: s.GetCreatedAt(),
+	}
+}
+
+func invitationFromPb(i *authv1.Invitation) model.Invitation {
+	return model.Invitation{
+		Id:        i.GetId(),
+		SpaceId:   i.GetSpaceId(),
+		SpaceName: i.GetSpaceName(),
+		InvitedBy: i.GetInvitedBy(),
+		CreatedAt: i.GetCreatedAt(),
+	}
+}
+
+func apiKeyFromPb(k *authv1.APIKey) model.APIKey {
+	return model.APIKey{
+		Id:         k.GetId(),
+		Name:       k.GetName(),
+		Prefix:     k.GetPrefix(),
+		Scopes:     k.GetScopes(),
+		SpaceId:    k.GetSpaceId(),
+		ExpiresAt:  k.GetExpiresAt(),
+		LastUsedAt: k.GetLastUsedAt(),
+		CreatedAt:  k.GetCreatedAt(),
+	}
+}
+
+func authTokensFromPb(resp *authv1.LoginResponse) *model.AuthTokens {
+	return &model.AuthTokens{
+		Token:          resp.GetToken(),
+		RefreshToken:   resp.GetRefreshToken(),
+		ChallengeToken: resp.GetChallengeToken(),
+		ExpiresIn:      resp.GetExpiresIn(),
+	}
+}
@@ -0,0 +1,511 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"gateway/internal/model"
+	authv1 "gateway/internal/pb/auth/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuthServiceClient struct {
+	mock.Mock
+}
+
+var _ authv1.AuthServiceClient = (*MockAuthServiceClient)(nil)
+
+func (m *MockAuthServiceClient) Login(ctx context.Context, in *authv1.LoginRequest, opts ...grpc.CallOption) (*authv1.LoginResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.LoginResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ValidateToken(ctx context.Context, in *authv1.ValidateTokenRequest, opts ...grpc.CallOption) (*authv1.ValidateTokenResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.ValidateTokenResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) Register(ctx context.Context, in *authv1.RegisterRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) Refresh(ctx context.Context, in *authv1.RefreshRequest, opts ...grpc.CallOption) (*authv1.LoginResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.LoginResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) Logout(ctx context.Context, in *authv1.LogoutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) LogoutAll(ctx context.Context, in *authv1.LogoutAllRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) GetJWKS(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*authv1.JWKSResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.JWKSResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ListUsers(ctx context.Context, in *authv1.ListUsersRequest, opts ...grpc.CallOption) (*authv1.ListUsersResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.ListUsersResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) SetUserRole(ctx context.Context, in *authv1.SetUserRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) DeleteUser(ctx context.Context, in *authv1.DeleteUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) CreateSpace(ctx context.Context, in *authv1.CreateSpaceRequest, opts ...grpc.CallOption) (*authv1.Space, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.Space), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ListSpaces(ctx context.Context, in *authv1.ListSpacesRequest, opts ...grpc.CallOption) (*authv1.ListSpacesResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.ListSpacesResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ListSpaceMembers(ctx context.Context, in *authv1.ListSpaceMembersRequest, opts ...grpc.CallOption) (*authv1.ListSpaceMembersResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.ListSpaceMembersResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) RemoveSpaceMember(ctx context.Context, in *authv1.RemoveSpaceMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) SwitchSpace(ctx context.Context, in *authv1.SwitchSpaceRequest, opts ...grpc.CallOption) (*authv1.SwitchSpaceResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.SwitchSpaceResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) InviteToSpace(ctx context.Context, in *authv1.InviteToSpaceRequest, opts ...grpc.CallOption) (*authv1.Invitation, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.Invitation), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ListInvitations(ctx context.Context, in *authv1.ListInvitationsRequest, opts ...grpc.CallOption) (*authv1.ListInvitationsResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.ListInvitationsResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) AcceptInvitation(ctx context.Context, in *authv1.InvitationRequest, opts ...grpc.CallOption) (*authv1.Space, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.Space), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) DeclineInvitation(ctx context.Context, in *authv1.InvitationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) VerifyLogin(ctx context.Context, in *authv1.VerifyLoginRequest, opts ...grpc.CallOption) (*authv1.LoginResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.LoginResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) EnrollTOTP(ctx context.Context, in *authv1.EnrollTOTPRequest, opts ...grpc.CallOption) (*authv1.EnrollTOTPResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.EnrollTOTPResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ActivateTOTP(ctx context.Context, in *authv1.ActivateTOTPRequest, opts ...grpc.CallOption) (*authv1.ActivateTOTPResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.ActivateTOTPResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) DisableTOTP(ctx context.Context, in *authv1.DisableTOTPRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) UnlockLogin(ctx context.Context, in *authv1.UnlockLoginRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ListAuthEvents(ctx context.Context, in *authv1.ListAuthEventsRequest, opts ...grpc.CallOption) (*authv1.ListAuthEventsResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.ListAuthEventsResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ChangePassword(ctx context.Context, in *authv1.ChangePasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) RequestPasswordReset(ctx context.Context, in *authv1.RequestPasswordResetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ResetPassword(ctx context.Context, in *authv1.ResetPasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) CreateAPIKey(ctx context.Context, in *authv1.CreateAPIKeyRequest, opts ...grpc.CallOption) (*authv1.CreateAPIKeyResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.CreateAPIKeyResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ListAPIKeys(ctx context.Context, in *authv1.ListAPIKeysRequest, opts ...grpc.CallOption) (*authv1.ListAPIKeysResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.ListAPIKeysResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) RevokeAPIKey(ctx context.Context, in *authv1.RevokeAPIKeyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ValidateAPIKey(ctx context.Context, in *authv1.ValidateAPIKeyRequest, opts ...grpc.CallOption) (*authv1.ValidateAPIKeyResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authv1.ValidateAPIKeyResponse), args.Error(1)
+}
+
+func TestAuthGatewayService_Login(t *testing.T) {
+	mockClient := &MockAuthServiceClient{}
+	service := NewAuthGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("Login", ctx, &authv1.LoginRequest{Login: "alice", Password: "secret"}, mock.Anything).Return(&authv1.LoginResponse{ChallengeToken: "challenge", ExpiresIn: 300}, nil)
+	mockClient.On("Login", ctx, &authv1.LoginRequest{Login: "bob", Password: "guess"}, mock.Anything).Return(nil, status.Error(codes.ResourceExhausted, "login failed: too many failed login attempts, try again in 4s"))
+
+	tokens, err := service.Login(ctx, model.AuthLogin{Login: "alice", Password: "secret"})
+	assert.NoError(t, err)
+	assert.Equal(t, &model.AuthTokens{ChallengeToken: "challenge", ExpiresIn: 300}, tokens)
+
+	_, err = service.Login(ctx, model.AuthLogin{Login: "bob", Password: "guess"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthGatewayService_Refresh(t *testing.T) {
+	mockClient := &MockAuthServiceClient{}
+	service := NewAuthGatewayService(mockClient)
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expected    *model.AuthTokens
+		expectedErr codes.Code
+	}{
+		{
+			name: "successful refresh",
+			mockSetup: func() {
+				mockClient.On("Refresh", ctx, &authv1.RefreshRequest{RefreshToken: "old"}, mock.Anything).Return(&authv1.LoginResponse{
+					Token:        "access",
+					RefreshToken: "new",
+					ExpiresIn:    900,
+				}, nil)
+			},
+			expected: &model.AuthTokens{Token: "access", RefreshToken: "new", ExpiresIn: 900},
+		},
+		{
+			name: "invalid refresh token",
+			mockSetup: func() {
+				mockClient.On("Refresh", ctx, &authv1.RefreshRequest{RefreshToken: "old"}, mock.Anything).
+					Return(nil, status.Error(codes.Unauthenticated, "refresh failed: invalid refresh token"))
+			},
+			expectedErr: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient.ExpectedCalls = nil
+			tt.mockSetup()
+
+			result, err := service.Refresh(ctx, model.AuthRefresh{RefreshToken: "old"})
+
+			if tt.expectedErr != codes.OK {
+				assert.Equal(t, tt.expectedErr, status.Code(err))
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthGatewayService_Logout(t *testing.T) {
+	mockClient := &MockAuthServiceClient{}
+	service := NewAuthGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("Logout", ctx, &authv1.LogoutRequest{Token: "access", RefreshToken: "refresh"}, mock.Anything).Return(&emptypb.Empty{}, nil)
+	mockClient.On("LogoutAll", ctx, &authv1.LogoutAllRequest{Token: "access"}, mock.Anything).Return(nil, status.Error(codes.Unauthenticated, "logout all: invalid token"))
+
+	assert.NoError(t, service.Logout(ctx, "access", model.AuthLogout{RefreshToken: "refresh"}))
+	assert.Equal(t, codes.Unauthenticated, status.Code(service.LogoutAll(ctx, "access")))
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthGatewayService_Password(t *testing.T) {
+	mockClient := &MockAuthServiceClient{}
+	service := NewAuthGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("ChangePassword", ctx, &authv1.ChangePasswordRequest{Token: "access", OldPassword: "old", NewPassword: "password"}, mock.Anything).
+		Return(nil, status.Error(codes.InvalidArgument, "change password: password does not meet the policy: appears in a list of breached passwords"))
+	mockClient.On("RequestPasswordReset", ctx, &authv1.RequestPasswordResetRequest{Login: "bob"}, mock.Anything).Return(&emptypb.Empty{}, nil)
+	mockClient.On("ResetPassword", ctx, &authv1.ResetPasswordRequest{ResetToken: "reset", NewPassword: "correct horse battery"}, mock.Anything).Return(&emptypb.Empty{}, nil)
+
+	err := service.ChangePassword(ctx, "access", model.PasswordChange{OldPassword: "old", NewPassword: "password"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, service.RequestPasswordReset(ctx, model.PasswordResetRequest{Login: "bob"}))
+	assert.NoError(t, service.ResetPassword(ctx, model.PasswordReset{ResetToken: "reset", NewPassword: "correct horse battery"}))
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthGatewayService_TOTP(t *testing.T) {
+	mockClient := &MockAuthServiceClient{}
+	service := NewAuthGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("EnrollTOTP", ctx, &authv1.EnrollTOTPRequest{Token: "access"}, mock.Anything).Return(&authv1.EnrollTOTPResponse{Secret: "JBSWY3DP", Uri: "otpauth://totp/ledger:bob?secret=JBSWY3DP"}, nil)
+	mockClient.On("ActivateTOTP", ctx, &authv1.ActivateTOTPRequest{Token: "access", Code: "123456"}, mock.Anything).Return(&authv1.ActivateTOTPResponse{RecoveryCodes: []string{"k3x7q-m2f7a"}}, nil)
+	mockClient.On("DisableTOTP", ctx, &authv1.DisableTOTPRequest{Token: "access", Code: "000000"}, mock.Anything).Return(nil, status.Error(codes.InvalidArgument, "disable totp: invalid code"))
+	mockClient.On("VerifyLogin", ctx, &authv1.VerifyLoginRequest{ChallengeToken: "challenge", Code: "k3x7q-m2f7a"}, mock.Anything).Return(&authv1.LoginResponse{Token: "access", RefreshToken: "refresh", ExpiresIn: 900}, nil)
+
+	enrollment, err := service.EnrollTOTP(ctx, "access")
+	assert.NoError(t, err)
+	assert.Equal(t, &model.TOTPEnrollment{Secret: "JBSWY3DP", URI: "otpauth://totp/ledger:bob?secret=JBSWY3DP"}, enrollment)
+
+	recoveryCodes, err := service.ActivateTOTP(ctx, "access", model.TOTPCode{Code: "123456"})
+	assert.NoError(t, err)
+	assert.Equal(t, &model.RecoveryCodes{RecoveryCodes: []string{"k3x7q-m2f7a"}}, recoveryCodes)
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(service.DisableTOTP(ctx, "access", model.TOTPCode{Code: "000000"})))
+
+	tokens, err := service.VerifyLogin(ctx, model.TOTPVerify{ChallengeToken: "challenge", Code: "k3x7q-m2f7a"})
+	assert.NoError(t, err)
+	assert.Equal(t, &model.AuthTokens{Token: "access", RefreshToken: "refresh", ExpiresIn: 900}, tokens)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthGatewayService_JWKS(t *testing.T) {
+	mockClient := &MockAuthServiceClient{}
+	service := NewAuthGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("GetJWKS", ctx, mock.AnythingOfType("*emptypb.Empty"), mock.Anything).Return(&authv1.JWKSResponse{
+		Keys: []*authv1.JWK{{Kty: "RSA", Kid: "2026-10-18", Use: "sig", Alg: "RS256", N: "rCK_YOpF", E: "AQAB"}},
+	}, nil)
+
+	result, err := service.JWKS(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.JWKS{Keys: []model.JWK{{Kty: "RSA", Kid: "2026-10-18", Use: "sig", Alg: "RS256", N: "rCK_YOpF", E: "AQAB"}}}, result)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthGatewayService_Users(t *testing.T) {
+	mockClient := &MockAuthServiceClient{}
+	service := NewAuthGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("ListUsers", ctx, &authv1.ListUsersRequest{Token: "access"}, mock.Anything).Return(&authv1.ListUsersResponse{
+		Users: []*authv1.User{{Id: "u1", Login: "alice", Role: "admin", CreatedAt: "2026-10-18T12:00:00Z"}},
+	}, nil)
+	mockClient.On("SetUserRole", ctx, &authv1.SetUserRoleRequest{Token: "access", UserId: "u2", Role: "viewer"}, mock.Anything).Return(&emptypb.Empty{}, nil)
+	mockClient.On("DeleteUser", ctx, &authv1.DeleteUserRequest{Token: "access", UserId: "u2"}, mock.Anything).Return(nil, status.Error(codes.PermissionDenied, "delete user: permission denied"))
+
+	users, err := service.ListUsers(ctx, "access")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.User{{Id: "u1", Login: "alice", Role: "admin", CreatedAt: "2026-10-18T12:00:00Z"}}, users)
+	assert.NoError(t, service.SetUserRole(ctx, "access", "u2", model.UserRole{Role: "viewer"}))
+	assert.Equal(t, codes.PermissionDenied, status.Code(service.DeleteUser(ctx, "access", "u2")))
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthGatewayService_Lockout(t *testing.T) {
+	mockClient := &MockAuthServiceClient{}
+	service := NewAuthGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("UnlockLogin", ctx, &authv1.UnlockLoginRequest{Token: "access", Login: "bob"}, mock.Anything).Return(&emptypb.Empty{}, nil)
+	mockClient.On("ListAuthEvents", ctx, &authv1.ListAuthEventsRequest{Token: "access", Login: "bob", Limit: 10}, mock.Anything).Return(&authv1.ListAuthEventsResponse{
+		Events: []*authv1.AuthEvent{
+			{Id: 4, Type: "login.unlocked", Login: "bob", ActorUserId: "u1", CreatedAt: "2026-10-18T12:05:00Z"},
+			{Id: 3, Type: "login.locked", Login: "bob", Ip: "203.0.113.7", Failures: 6, LockedUntil: "2026-10-18T12:00:01Z", CreatedAt: "2026-10-18T12:00:00Z"},
+		},
+	}, nil)
+
+	assert.NoError(t, service.UnlockLogin(ctx, "access", model.LoginUnlock{Login: "bob"}))
+	events, err := service.ListAuthEvents(ctx, "access", model.AuthEventList{Login: "bob", Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.AuthEvent{
+		{Id: 4, Type: "login.unlocked", Login: "bob", ActorUserId: "u1", CreatedAt: "2026-10-18T12:05:00Z"},
+		{Id: 3, Type: "login.locked", Login: "bob", Ip: "203.0.113.7", Failures: 6, LockedUntil: "2026-10-18T12:00:01Z", CreatedAt: "2026-10-18T12:00:00Z"},
+	}, events)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthGatewayService_Spaces(t *testing.T) {
+	mockClient := &MockAuthServiceClient{}
+	service := NewAuthGatewayService(mockClient)
+	ctx := context.Background()
+	space := &authv1.Space{Id: "s1", Name: "Household", OwnerId: "u1", CreatedAt: "2026-10-18T12:00:00Z"}
+
+	mockClient.On("CreateSpace", ctx, &authv1.CreateSpaceRequest{Token: "access", Name: "Household"}, mock.Anything).Return(space, nil)
+	mockClient.On("SwitchSpace", ctx, &authv1.SwitchSpaceRequest{Token: "access", SpaceId: "s1"}, mock.Anything).Return(&authv1.SwitchSpaceResponse{Token: "spaced", ExpiresIn: 900}, nil)
+	mockClient.On("InviteToSpace", ctx, &authv1.InviteToSpaceRequest{Token: "access", SpaceId: "s1", Login: "bob"}, mock.Anything).Return(nil, status.Error(codes.AlreadyExists, "invite to space: user is already a member or invited"))
+	mockClient.On("ListInvitations", ctx, &authv1.ListInvitationsRequest{Token: "access"}, mock.Anything).Return(&authv1.ListInvitationsResponse{
+		Invitations: []*authv1.Invitation{{Id: "i1", SpaceId: "s1", SpaceName: "Household", InvitedBy: "alice", CreatedAt: "2026-10-18T12:00:00Z"}},
+	}, nil)
+
+	created, err := service.CreateSpace(ctx, "access", model.SpaceCreate{Name: "Household"})
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Space{Id: "s1", Name: "Household", OwnerId: "u1", CreatedAt: "2026-10-18T12:00:00Z"}, created)
+	switched, err := service.SwitchSpace(ctx, "access", model.SpaceSwitch{SpaceId: "s1"})
+	assert.NoError(t, err)
+	assert.Equal(t, &model.SpaceToken{Token: "spaced", ExpiresIn: 900}, switched)
+	_, err = service.InviteToSpace(ctx, "access", "s1", model.SpaceInvite{Login: "bob"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	invitations, err := service.ListInvitations(ctx, "access")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Invitation{{Id: "i1", SpaceId: "s1", SpaceName: "Household", InvitedBy: "alice", CreatedAt: "2026-10-18T12:00:00Z"}}, invitations)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthGatewayService_APIKeys(t *testing.T) {
+	mockClient := &MockAuthServiceClient{}
+	service := NewAuthGatewayService(mockClient)
+	ctx := context.Background()
+	key := &authv1.APIKey{Id: "k1", Name: "import", Prefix: "lk_abcdefgh", Scopes: []string{"transactions:write"}, ExpiresAt: "2027-10-18T12:00:00Z", CreatedAt: "2026-10-18T12:00:00Z"}
+	expected := model.APIKey{Id: "k1", Name: "import", Prefix: "lk_abcdefgh", Scopes: []string{"transactions:write"}, ExpiresAt: "2027-10-18T12:00:00Z", CreatedAt: "2026-10-18T12:00:00Z"}
+
+	mockClient.On("CreateAPIKey", ctx, &authv1.CreateAPIKeyRequest{Token: "access", Name: "import", Scopes: []string{"transactions:write"}, ExpiresAt: "2027-10-18T12:00:00Z"}, mock.Anything).
+		Return(&authv1.CreateAPIKeyResponse{ApiKey: key, Key: "lk_abcdefghsecret"}, nil)
+	mockClient.On("ListAPIKeys", ctx, &authv1.ListAPIKeysRequest{Token: "access"}, mock.Anything).Return(&authv1.ListAPIKeysResponse{ApiKeys: []*authv1.APIKey{key}}, nil)
+	mockClient.On("RevokeAPIKey", ctx, &authv1.RevokeAPIKeyRequest{Token: "access", Id: "k2"}, mock.Anything).Return(nil, status.Error(codes.NotFound, "revoke api key: api key not found"))
+
+	created, err := service.CreateAPIKey(ctx, "access", model.APIKeyCreate{Name: "import", Scopes: []string{"transactions:write"}, ExpiresAt: "2027-10-18T12:00:00Z"})
+	assert.NoError(t, err)
+	assert.Equal(t, &model.APIKeyCreated{APIKey: expected, Key: "lk_abcdefghsecret"}, created)
+	keys, err := service.ListAPIKeys(ctx, "access")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.APIKey{expected}, keys)
+	err = service.RevokeAPIKey(ctx, "access", "k2")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockClient.AssertExpectations(t)
+}
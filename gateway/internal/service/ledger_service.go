@@ -2,19 +2,49 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"gateway/internal/model"
 	ledgerv1 "gateway/internal/pb/ledger/v1"
+	"io"
 )
 
 type LedgerGatewayService interface {
 	BudgetAdd(ctx context.Context, req model.BudgetAdd) error
 	BudgetGet(ctx context.Context, req model.BudgetGet) (*model.BudgetGetResponse, error)
-	BudgetList(ctx context.Context) ([]model.BudgetGetResponse, error)
+	BudgetList(ctx context.Context, asOf string) ([]model.BudgetGetResponse, error)
 	TransactionAdd(ctx context.Context, req model.TrasnactionAdd) (*model.TransactionAddResponse, error)
 	TransactionGet(ctx context.Context, req model.TransactionGet) (*model.TransactionGetResponse, error)
-	TransactionList(ctx context.Context) ([]model.TransactionGetResponse, error)
+	TransactionList(ctx context.Context, req model.TransactionList) ([]model.TransactionGetResponse, error)
+	TransactionSearch(ctx context.Context, req model.TransactionSearch) ([]model.TransactionSearchResult, error)
 	TransactionBulkAdd(ctx context.Context, req model.TransactionBulkAdd) (*model.TransactionBulkAddResponse, error)
 	ReportSummary(ctx context.Context, req model.ReportSummary) (*model.ReportSummaryResponse, error)
+	ReportForecast(ctx context.Context, historyPeriods int32) (*model.ReportForecastResponse, error)
+	ReportAnomalies(ctx context.Context, from string, to string) ([]model.ReportAnomaly, error)
+	CategoryAdd(ctx context.Context, req model.CategoryAdd) (*model.CategoryAddResponse, error)
+	CategoryUpdate(ctx context.Context, req model.CategoryUpdate) error
+	CategoryList(ctx context.Context) ([]model.CategoryGetResponse, error)
+	RuleAdd(ctx context.Context, req model.Rule) (*model.RuleAddResponse, error)
+	RuleUpdate(ctx context.Context, req model.Rule) error
+	RuleDelete(ctx context.Context, id int64) error
+	RuleList(ctx context.Context) ([]model.Rule, error)
+	RuleTest(ctx context.Context, req model.RuleTest) ([]model.RuleMatch, error)
+	AttachmentUpload(ctx context.Context, transactionID int64, fileName string, data io.Reader) (*model.Attachment, error)
+	AttachmentDownload(ctx context.Context, transactionID int64, id int64) (*model.Attachment, io.Reader, error)
+	AttachmentDelete(ctx context.Context, transactionID int64, id int64) error
+	AttachmentList(ctx context.Context, transactionID int64) ([]model.Attachment, error)
+	GoalAdd(ctx context.Context, req model.Goal) (*model.GoalAddResponse, error)
+	GoalUpdate(ctx context.Context, req model.Goal) error
+	GoalDelete(ctx context.Context, id int64) error
+	GoalGet(ctx context.Context, id int64) (*model.GoalProgress, error)
+	GoalList(ctx context.Context) ([]model.GoalProgress, error)
+	GoalContribute(ctx context.Context, req model.GoalContribute) (*model.TransactionAddResponse, error)
+	WebhookAdd(ctx context.Context, req model.Webhook) (*model.WebhookAddResponse, error)
+	WebhookDelete(ctx context.Context, id int64) error
+	WebhookList(ctx context.Context) ([]model.Webhook, error)
+	WebhookDeliveries(ctx context.Context, webhookID int64, limit int32) ([]model.WebhookDelivery, error)
+	Subscribe(ctx context.Context, fromEventID int64, types []string, send func(model.LedgerEvent) error) error
+	AuditList(ctx context.Context, req model.AuditList) ([]model.AuditEntry, error)
 }
 
 type ledgerGatewayService struct {
@@ -30,8 +60,9 @@ func NewLedgerGatewayService(client ledgerv1.LedgerServiceClient) LedgerGatewayS
 
 func (l *ledgerGatewayService) BudgetAdd(ctx context.Context, req model.BudgetAdd) error {
 	_, err := l.client.BudgetAdd(ctx, &ledgerv1.BudgetAddRequest{
-		Category: req.Category,
-		Limit:    float32(req.Limit),
+		Category:   req.Category,
+		Limit:      float32(req.Limit),
+		CategoryId: req.CategoryID,
 	})
 	return err
 }
@@ -39,18 +70,21 @@ func (l *ledgerGatewayService) BudgetAdd(ctx context.Context, req model.BudgetAd
 func (l *ledgerGatewayService) BudgetGet(ctx context.Context, req model.BudgetGet) (*model.BudgetGetResponse, error) {
 	resp, err := l.client.BudgetGet(ctx, &ledgerv1.BudgetGetRequest{
 		Category: req.Category,
+		AsOf:     req.AsOf,
 	})
 	if err != nil {
 		return nil, err
 	}
 	return &model.BudgetGetResponse{
-		Category: resp.Category,
-		Limit:    float64(resp.Limit),
+		CategoryID: resp.CategoryId,
+		Category:   resp.Category,
+		Limit:      float64(resp.Limit),
+		Spent:      float64(resp.Spent),
 	}, nil
 }
 
-func (l *ledgerGatewayService) BudgetList(ctx context.Context) ([]model.BudgetGetResponse, error) {
-	resp, err := l.client.BudgetsList(ctx, nil)
+func (l *ledgerGatewayService) BudgetList(ctx context.Context, asOf string) ([]model.BudgetGetResponse, error) {
+	resp, err := l.client.BudgetsList(ctx, &ledgerv1.BudgetsListRequest{AsOf: asOf})
 	if err != nil {
 		return nil, err
 	}
@@ -60,8 +94,10 @@ func (l *ledgerGatewayService) BudgetList(ctx context.Context) ([]model.BudgetGe
 			continue
 		}
 		out = append(out, model.BudgetGetResponse{
-			Category: r.Category,
-			Limit:    float64(r.Limit),
+			CategoryID: r.CategoryId,
+			Category:   r.Category,
+			Limit:      float64(r.Limit),
+			Spent:      float64(r.Spent),
 		})
 	}
 	return out, nil
@@ -69,16 +105,22 @@ func (l *ledgerGatewayService) BudgetList(ctx context.Context) ([]model.BudgetGe
 
 func (l *ledgerGatewayService) TransactionAdd(ctx context.Context, req model.TrasnactionAdd) (*model.TransactionAddResponse, error) {
 	resp, err := l.client.TransactionAdd(ctx, &ledgerv1.TransactionAddRequest{
-		Amount:      float32(req.Amount),
-		Category:    req.Category,
-		Description: req.Description,
-		Date:        req.Date,
+		Amount:         float32(req.Amount),
+		CategoryId:     req.CategoryID,
+		Category:       req.Category,
+		Description:    req.Description,
+		Date:           req.Date,
+		Tags:           req.Tags,
+		ExternalId:     req.ExternalID,
+		Force:          req.Force,
+		IdempotencyKey: req.IdempotencyKey,
 	})
 	if err != nil {
 		return nil, err
 	}
 	return &model.TransactionAddResponse{
-		Id: resp.GetId(),
+		Id:      resp.GetId(),
+		Anomaly: resp.GetAnomaly(),
 	}, nil
 }
 
@@ -92,14 +134,18 @@ func (l *ledgerGatewayService) TransactionGet(ctx context.Context, req model.Tra
 	return &model.TransactionGetResponse{
 		Id:          resp.GetId(),
 		Amount:      float64(resp.GetAmount()),
+		CategoryID:  resp.GetCategoryId(),
 		Category:    resp.GetCategory(),
 		Description: resp.GetDescription(),
 		Date:        resp.GetDate(),
+		Tags:        resp.GetTags(),
+		CreatedBy:   resp.GetCreatedBy(),
+		Kind:        resp.GetKind(),
 	}, nil
 }
 
-func (l *ledgerGatewayService) TransactionList(ctx context.Context) ([]model.TransactionGetResponse, error) {
-	resp, err := l.client.TransactionList(ctx, nil)
+func (l *ledgerGatewayService) TransactionList(ctx context.Context, req model.TransactionList) ([]model.TransactionGetResponse, error) {
+	resp, err := l.client.TransactionList(ctx, &ledgerv1.TransactionListRequest{Tag: req.Tag})
 	if err != nil {
 		return nil, err
 	}
@@ -111,20 +157,137 @@ func (l *ledgerGatewayService) TransactionList(ctx context.Context) ([]model.Tra
 		out = append(out, model.TransactionGetResponse{
 			Id:          tr.GetId(),
 			Amount:      float64(tr.GetAmount()),
+			CategoryID:  tr.GetCategoryId(),
 			Category:    tr.GetCategory(),
 			Description: tr.GetDescription(),
 			Date:        tr.GetDate(),
+			Tags:        tr.GetTags(),
+			CreatedBy:   tr.GetCreatedBy(),
+			Kind:        tr.GetKind(),
+		})
+	}
+	return out, nil
+}
+
+func (l *ledgerGatewayService) TransactionSearch(ctx context.Context, req model.TransactionSearch) ([]model.TransactionSearchResult, error) {
+	resp, err := l.client.TransactionSearch(ctx, &ledgerv1.TransactionSearchRequest{
+		Query:      req.Query,
+		Language:   req.Language,
+		From:       req.From,
+		To:         req.To,
+		CategoryId: req.CategoryID,
+		Limit:      req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.TransactionSearchResult, 0, len(resp.GetResults()))
+	for _, res := range resp.GetResults() {
+		tr := res.GetTransaction()
+		out = append(out, model.TransactionSearchResult{
+			Transaction: model.TransactionGetResponse{
+				Id:          tr.GetId(),
+				Amount:      float64(tr.GetAmount()),
+				CategoryID:  tr.GetCategoryId(),
+				Category:    tr.GetCategory(),
+				Description: tr.GetDescription(),
+				Date:        tr.GetDate(),
+				Tags:        tr.GetTags(),
+				CreatedBy:   tr.GetCreatedBy(),
+				Kind:        tr.GetKind(),
+			},
+			Rank: float64(res.GetRank()),
 		})
 	}
 	return out, nil
 }
 
 func (l *ledgerGatewayService) ReportSummary(ctx context.Context, req model.ReportSummary) (*model.ReportSummaryResponse, error) {
-	resp, err := l.client.ReportSummary(ctx, &ledgerv1.SummaryRequest{From: req.From, To: req.To})
+	resp, err := l.client.ReportSummary(ctx, &ledgerv1.SummaryRequest{From: req.From, To: req.To, GroupBy: req.GroupBy, AsOf: req.AsOf})
 	if err != nil {
 		return nil, err
 	}
-	return &model.ReportSummaryResponse{Report: resp.Report, CacheResult: resp.CacheResult}, nil
+	return &model.ReportSummaryResponse{Report: resp.Report, Tree: categoryNodesFromPb(resp.GetTree()), CacheResult: resp.CacheResult}, nil
+}
+
+func (l *ledgerGatewayService) ReportForecast(ctx context.Context, historyPeriods int32) (*model.ReportForecastResponse, error) {
+	resp, err := l.client.ReportForecast(ctx, &ledgerv1.ForecastRequest{HistoryPeriods: historyPeriods})
+	if err != nil {
+		return nil, err
+	}
+	out := &model.ReportForecastResponse{
+		PeriodStart:  resp.GetPeriodStart(),
+		PeriodEnd:    resp.GetPeriodEnd(),
+		DaysElapsed:  resp.GetDaysElapsed(),
+		DaysInPeriod: resp.GetDaysInPeriod(),
+		Categories:   make([]model.CategoryForecast, 0, len(resp.GetCategories())),
+		Budgets:      make([]model.BudgetForecast, 0, len(resp.GetBudgets())),
+	}
+	for _, c := range resp.GetCategories() {
+		out.Categories = append(out.Categories, model.CategoryForecast{
+			Category:          c.GetCategory(),
+			Spent:             float64(c.GetSpent()),
+			Scheduled:         float64(c.GetScheduled()),
+			HistoricalAverage: float64(c.GetHistoricalAverage()),
+			Projected:         float64(c.GetProjected()),
+		})
+	}
+	for _, b := range resp.GetBudgets() {
+		out.Budgets = append(out.Budgets, model.BudgetForecast{
+			CategoryID:     b.GetCategoryId(),
+			Category:       b.GetCategory(),
+			Limit:          float64(b.GetLimit()),
+			Projected:      float64(b.GetProjected()),
+			LikelyExceeded: b.GetLikelyExceeded(),
+		})
+	}
+	return out, nil
+}
+
+func (l *ledgerGatewayService) ReportAnomalies(ctx context.Context, from string, to string) ([]model.ReportAnomaly, error) {
+	resp, err := l.client.ReportAnomalies(ctx, &ledgerv1.AnomaliesRequest{From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.ReportAnomaly, 0, len(resp.GetAnomalies()))
+	for _, a := range resp.GetAnomalies() {
+		tr := a.GetTransaction()
+		out = append(out, model.ReportAnomaly{
+			Transaction: model.TransactionGetResponse{
+				Id:          tr.GetId(),
+				Amount:      float64(tr.GetAmount()),
+				CategoryID:  tr.GetCategoryId(),
+				Category:    tr.GetCategory(),
+				Description: tr.GetDescription(),
+				Date:        tr.GetDate(),
+			},
+			Score:  float64(a.GetScore()),
+			Ratio:  float64(a.GetRatio()),
+			Median: float64(a.GetMedian()),
+			MAD:    float64(a.GetMad()),
+		})
+	}
+	return out, nil
+}
+
+func categoryNodesFromPb(nodes []*ledgerv1.CategoryNode) []model.CategoryNode {
+	if len(nodes) == 0 {
+		return nil
+	}
+	out := make([]model.CategoryNode, 0, len(nodes))
+	for _, n := range nodes {
+		if n == nil {
+			continue
+		}
+		out = append(out, model.CategoryNode{
+			Id:       n.GetId(),
+			Name:     n.GetName(),
+			Amount:   float64(n.GetAmount()),
+			Total:    float64(n.GetTotal()),
+			Children: categoryNodesFromPb(n.GetChildren()),
+		})
+	}
+	return out
 }
 
 func (l *ledgerGatewayService) TransactionBulkAdd(ctx context.Context, req model.TransactionBulkAdd) (*model.TransactionBulkAddResponse, error) {
@@ -132,9 +295,12 @@ func (l *ledgerGatewayService) TransactionBulkAdd(ctx context.Context, req model
 	for _, transaction := range req.Transactions {
 		trs = append(trs, &ledgerv1.TransactionAddRequest{
 			Amount:      float32(transaction.Amount),
+			CategoryId:  transaction.CategoryID,
 			Category:    transaction.Category,
 			Date:        transaction.Date,
 			Description: transaction.Description,
+			Tags:        transaction.Tags,
+			ExternalId:  transaction.ExternalID,
 		})
 	}
 	resp, err := l.client.BulkAddTransactions(ctx, &ledgerv1.TransactionBulkAddRequest{Transactions: trs})
@@ -142,8 +308,451 @@ func (l *ledgerGatewayService) TransactionBulkAdd(ctx context.Context, req model
 		return nil, err
 	}
 	return &model.TransactionBulkAddResponse{
-		Accepted: resp.Accepted,
-		Rejected: resp.Rejected,
-		Errors:   resp.Errors,
+		Accepted:        resp.Accepted,
+		Rejected:        resp.Rejected,
+		Errors:          resp.Errors,
+		Duplicates:      resp.Duplicates,
+		BatchDuplicates: resp.BatchDuplicates,
 	}, nil
 }
+
+func (l *ledgerGatewayService) CategoryAdd(ctx context.Context, req model.CategoryAdd) (*model.CategoryAddResponse, error) {
+	resp, err := l.client.CategoryAdd(ctx, &ledgerv1.CategoryAddRequest{
+		Name:     req.Name,
+		ParentId: req.ParentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.CategoryAddResponse{Id: resp.GetId()}, nil
+}
+
+func (l *ledgerGatewayService) CategoryUpdate(ctx context.Context, req model.CategoryUpdate) error {
+	_, err := l.client.CategoryUpdate(ctx, &ledgerv1.CategoryUpdateRequest{
+		Id:       req.Id,
+		Name:     req.Name,
+		ParentId: req.ParentID,
+	})
+	return err
+}
+
+func (l *ledgerGatewayService) CategoryList(ctx context.Context) ([]model.CategoryGetResponse, error) {
+	resp, err := l.client.CategoriesList(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.CategoryGetResponse, 0, len(resp.GetCategories()))
+	for _, c := range resp.GetCategories() {
+		if c == nil {
+			continue
+		}
+		out = append(out, model.CategoryGetResponse{
+			Id:       c.GetId(),
+			Name:     c.GetName(),
+			ParentID: c.GetParentId(),
+		})
+	}
+	return out, nil
+}
+
+func (l *ledgerGatewayService) RuleAdd(ctx context.Context, req model.Rule) (*model.RuleAddResponse, error) {
+	resp, err := l.client.RuleAdd(ctx, ruleToPb(req))
+	if err != nil {
+		return nil, err
+	}
+	return &model.RuleAddResponse{Id: resp.GetId()}, nil
+}
+
+func (l *ledgerGatewayService) RuleUpdate(ctx context.Context, req model.Rule) error {
+	_, err := l.client.RuleUpdate(ctx, ruleToPb(req))
+	return err
+}
+
+func (l *ledgerGatewayService) RuleDelete(ctx context.Context, id int64) error {
+	_, err := l.client.RuleDelete(ctx, &ledgerv1.RuleDeleteRequest{Id: id})
+	return err
+}
+
+func (l *ledgerGatewayService) RuleList(ctx context.Context) ([]model.Rule, error) {
+	resp, err := l.client.RulesList(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.Rule, 0, len(resp.GetRules()))
+	for _, r := range resp.GetRules() {
+		if r == nil {
+			continue
+		}
+		out = append(out, ruleFromPb(r))
+	}
+	return out, nil
+}
+
+func (l *ledgerGatewayService) RuleTest(ctx context.Context, req model.RuleTest) ([]model.RuleMatch, error) {
+	resp, err := l.client.RuleTest(ctx, &ledgerv1.RuleTestRequest{Rule: ruleToPb(req.Rule), Last: req.Last})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.RuleMatch, 0, len(resp.GetMatches()))
+	for _, m := range resp.GetMatches() {
+		if m == nil {
+			continue
+		}
+		tr := m.GetTransaction()
+		out = append(out, model.RuleMatch{
+			Transaction: model.TransactionGetResponse{
+				Id:          tr.GetId(),
+				Amount:      float64(tr.GetAmount()),
+				CategoryID:  tr.GetCategoryId(),
+				Category:    tr.GetCategory(),
+				Description: tr.GetDescription(),
+				Date:        tr.GetDate(),
+				Tags:        tr.GetTags(),
+				CreatedBy:   tr.GetCreatedBy(),
+				Kind:        tr.GetKind(),
+			},
+			CategoryID:  m.GetCategoryId(),
+			Category:    m.GetCategory(),
+			Description: m.GetDescription(),
+		})
+	}
+	return out, nil
+}
+
+func ruleToPb(r model.Rule) *ledgerv1.Rule {
+	return &ledgerv1.Rule{
+		Id:                  r.Id,
+		Priority:            r.Priority,
+		DescriptionRegex:    r.DescriptionRegex,
+		DescriptionContains: r.DescriptionContains,
+		MinAmount:           float32(r.MinAmount),
+		MaxAmount:           float32(r.MaxAmount),
+		CategoryId:          r.CategoryID,
+		Category:            r.Category,
+		Description:         r.Description,
+	}
+}
+
+func ruleFromPb(r *ledgerv1.Rule) model.Rule {
+	return model.Rule{
+		Id:                  r.GetId(),
+		Priority:            r.GetPriority(),
+		DescriptionRegex:    r.GetDescriptionRegex(),
+		DescriptionContains: r.GetDescriptionContains(),
+		MinAmount:           float64(r.GetMinAmount()),
+		MaxAmount:           float64(r.GetMaxAmount()),
+		CategoryID:          r.GetCategoryId(),
+		Category:            r.GetCategory(),
+		Description:         r.GetDescription(),
+	}
+}
+
+const attachmentChunkSize = 64 << 10
+
+func (l *ledgerGatewayService) AttachmentUpload(ctx context.Context, transactionID int64, fileName string, data io.Reader) (*model.Attachment, error) {
+	stream, err := l.client.AttachmentUpload(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = stream.Send(&ledgerv1.AttachmentUploadRequest{Payload: &ledgerv1.AttachmentUploadRequest_Info{Info: &ledgerv1.Attachment{
+		TransactionId: transactionID,
+		FileName:      fileName,
+	}}})
+	if err != nil {
+		return nil, closeUpload(stream, err)
+	}
+	buf := make([]byte, attachmentChunkSize)
+	for {
+		n, err := data.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&ledgerv1.AttachmentUploadRequest{Payload: &ledgerv1.AttachmentUploadRequest_Chunk{Chunk: buf[:n]}}); sendErr != nil {
+				return nil, closeUpload(stream, sendErr)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+	return attachmentFromPb(resp), nil
+}
+
+// closeUpload surfaces the server status when a send fails because the
+// server already rejected the upload.
+func closeUpload(stream ledgerv1.LedgerService_AttachmentUploadClient, err error) error {
+	if !errors.Is(err, io.EOF) {
+		return err
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+func (l *ledgerGatewayService) AttachmentDownload(ctx context.Context, transactionID int64, id int64) (*model.Attachment, io.Reader, error) {
+	stream, err := l.client.AttachmentDownload(ctx, &ledgerv1.AttachmentRequest{TransactionId: transactionID, Id: id})
+	if err != nil {
+		return nil, nil, err
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		return nil, nil, err
+	}
+	if first.GetInfo() == nil {
+		return nil, nil, errors.New("attachment info missing from stream")
+	}
+	return attachmentFromPb(first.GetInfo()), &downloadReader{stream: stream}, nil
+}
+
+func (l *ledgerGatewayService) AttachmentDelete(ctx context.Context, transactionID int64, id int64) error {
+	_, err := l.client.AttachmentDelete(ctx, &ledgerv1.AttachmentRequest{TransactionId: transactionID, Id: id})
+	return err
+}
+
+func (l *ledgerGatewayService) AttachmentList(ctx context.Context, transactionID int64) ([]model.Attachment, error) {
+	resp, err := l.client.AttachmentsList(ctx, &ledgerv1.AttachmentsListRequest{TransactionId: transactionID})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.Attachment, 0, len(resp.GetAttachments()))
+	for _, a := range resp.GetAttachments() {
+		out = append(out, *attachmentFromPb(a))
+	}
+	return out, nil
+}
+
+func attachmentFromPb(a *ledgerv1.Attachment) *model.Attachment {
+	return &model.Attachment{
+		Id:            a.GetId(),
+		TransactionId: a.GetTransactionId(),
+		FileName:      a.GetFileName(),
+		ContentType:   a.GetContentType(),
+		Size:          a.GetSize(),
+		CreatedAt:     a.GetCreatedAt(),
+	}
+}
+
+type downloadReader struct {
+	stream ledgerv1.LedgerService_AttachmentDownloadClient
+	buf    []byte
+}
+
+func (r *downloadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = chunk.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (l *ledgerGatewayService) GoalAdd(ctx context.Context, req model.Goal) (*model.GoalAddResponse, error) {
+	resp, err := l.client.GoalAdd(ctx, goalToPb(req))
+	if err != nil {
+		return nil, err
+	}
+	return &model.GoalAddResponse{Id: resp.GetId()}, nil
+}
+
+func (l *ledgerGatewayService) GoalUpdate(ctx context.Context, req model.Goal) error {
+	_, err := l.client.GoalUpdate(ctx, goalToPb(req))
+	return err
+}
+
+func (l *ledgerGatewayService) GoalDelete(ctx context.Context, id int64) error {
+	_, err := l.client.GoalDelete(ctx, &ledgerv1.GoalRequest{Id: id})
+	return err
+}
+
+func (l *ledgerGatewayService) GoalGet(ctx context.Context, id int64) (*model.GoalProgress, error) {
+	resp, err := l.client.GoalGet(ctx, &ledgerv1.GoalRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	progress := goalProgressFromPb(resp)
+	return &progress, nil
+}
+
+func (l *ledgerGatewayService) GoalList(ctx context.Context) ([]model.GoalProgress, error) {
+	resp, err := l.client.GoalsList(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.GoalProgress, 0, len(resp.GetGoals()))
+	for _, g := range resp.GetGoals() {
+		if g == nil {
+			continue
+		}
+		out = append(out, goalProgressFromPb(g))
+	}
+	return out, nil
+}
+
+func (l *ledgerGatewayService) GoalContribute(ctx context.Context, req model.GoalContribute) (*model.TransactionAddResponse, error) {
+	resp, err := l.client.GoalContribute(ctx, &ledgerv1.GoalContributeRequest{
+		GoalId:      req.GoalId,
+		Amount:      float32(req.Amount),
+		Date:        req.Date,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.TransactionAddResponse{Id: resp.GetId()}, nil
+}
+
+func goalToPb(goal model.Goal) *ledgerv1.Goal {
+	return &ledgerv1.Goal{
+		Id:           goal.Id,
+		Name:         goal.Name,
+		TargetAmount: float32(goal.TargetAmount),
+		Deadline:     goal.Deadline,
+	}
+}
+
+func goalProgressFromPb(progress *ledgerv1.GoalProgress) model.GoalProgress {
+	goal := progress.GetGoal()
+	return model.GoalProgress{
+		Goal: model.Goal{
+			Id:           goal.GetId(),
+			Name:         goal.GetName(),
+			TargetAmount: float64(goal.GetTargetAmount()),
+			Deadline:     goal.GetDeadline(),
+		},
+		Saved:           float64(progress.GetSaved()),
+		Remaining:       float64(progress.GetRemaining()),
+		Percent:         float64(progress.GetPercent()),
+		MonthsLeft:      progress.GetMonthsLeft(),
+		MonthlyRequired: float64(progress.GetMonthlyRequired()),
+	}
+}
+
+func (l *ledgerGatewayService) WebhookAdd(ctx context.Context, req model.Webhook) (*model.WebhookAddResponse, error) {
+	resp, err := l.client.WebhookAdd(ctx, &ledgerv1.Webhook{
+		Url:    req.Url,
+		Secret: req.Secret,
+		Events: req.Events,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.WebhookAddResponse{Id: resp.GetId()}, nil
+}
+
+func (l *ledgerGatewayService) WebhookDelete(ctx context.Context, id int64) error {
+	_, err := l.client.WebhookDelete(ctx, &ledgerv1.WebhookRequest{Id: id})
+	return err
+}
+
+func (l *ledgerGatewayService) WebhookList(ctx context.Context) ([]model.Webhook, error) {
+	resp, err := l.client.WebhooksList(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.Webhook, 0, len(resp.GetWebhooks()))
+	for _, w := range resp.GetWebhooks() {
+		if w == nil {
+			continue
+		}
+		out = append(out, model.Webhook{
+			Id:     w.GetId(),
+			Url:    w.GetUrl(),
+			Events: w.GetEvents(),
+		})
+	}
+	return out, nil
+}
+
+func (l *ledgerGatewayService) WebhookDeliveries(ctx context.Context, webhookID int64, limit int32) ([]model.WebhookDelivery, error) {
+	resp, err := l.client.WebhookDeliveriesList(ctx, &ledgerv1.WebhookDeliveriesRequest{WebhookId: webhookID, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.WebhookDelivery, 0, len(resp.GetDeliveries()))
+	for _, d := range resp.GetDeliveries() {
+		if d == nil {
+			continue
+		}
+		out = append(out, model.WebhookDelivery{
+			Id:             d.GetId(),
+			WebhookId:      d.GetWebhookId(),
+			Event:          d.GetEvent(),
+			Payload:        json.RawMessage(d.GetPayload()),
+			Status:         d.GetStatus(),
+			Attempts:       d.GetAttempts(),
+			LastStatusCode: d.GetLastStatusCode(),
+			LastError:      d.GetLastError(),
+			NextAttemptAt:  d.GetNextAttemptAt(),
+			CreatedAt:      d.GetCreatedAt(),
+			DeliveredAt:    d.GetDeliveredAt(),
+		})
+	}
+	return out, nil
+}
+
+func (l *ledgerGatewayService) Subscribe(ctx context.Context, fromEventID int64, types []string, send func(model.LedgerEvent) error) error {
+	stream, err := l.client.Subscribe(ctx, &ledgerv1.SubscribeRequest{FromEventId: fromEventID, Types: types})
+	if err != nil {
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		err = send(model.LedgerEvent{
+			Id:          event.GetId(),
+			Type:        event.GetType(),
+			AggregateId: event.GetAggregateId(),
+			Payload:     json.RawMessage(event.GetPayload()),
+			CreatedAt:   event.GetCreatedAt(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (l *ledgerGatewayService) AuditList(ctx context.Context, req model.AuditList) ([]model.AuditEntry, error) {
+	resp, err := l.client.AuditList(ctx, &ledgerv1.AuditListRequest{
+		EntityType:  req.EntityType,
+		EntityId:    req.EntityId,
+		ActorUserId: req.ActorUserId,
+		RequestId:   req.RequestId,
+		Action:      req.Action,
+		From:        req.From,
+		To:          req.To,
+		BeforeId:    req.BeforeId,
+		Limit:       req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.AuditEntry, 0, len(resp.GetEntries()))
+	for _, e := range resp.GetEntries() {
+		out = append(out, model.AuditEntry{
+			Id:          e.GetId(),
+			Action:      e.GetAction(),
+			EntityType:  e.GetEntityType(),
+			EntityId:    e.GetEntityId(),
+			ActorUserId: e.GetActorUserId(),
+			RequestId:   e.GetRequestId(),
+			Method:      e.GetMethod(),
+			Before:      json.RawMessage(e.GetBefore()),
+			After:       json.RawMessage(e.GetAfter()),
+			CreatedAt:   e.GetCreatedAt(),
+		})
+	}
+	return out, nil
+}
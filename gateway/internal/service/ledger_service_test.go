@@ -2,12 +2,17 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"strings"
 	"testing"
 
 	"gateway/internal/model"
 	ledgerv1 "gateway/internal/pb/ledger/v1"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/stretchr/testify/assert"
@@ -34,7 +39,7 @@ func (m *MockLedgerServiceClient) BudgetGet(ctx context.Context, in *ledgerv1.Bu
 	return args.Get(0).(*ledgerv1.BudgetGetResponse), args.Error(1)
 }
 
-func (m *MockLedgerServiceClient) BudgetsList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ledgerv1.BudgetGetListResponse, error) {
+func (m *MockLedgerServiceClient) BudgetsList(ctx context.Context, in *ledgerv1.BudgetsListRequest, opts ...grpc.CallOption) (*ledgerv1.BudgetGetListResponse, error) {
 	args := m.Called(ctx, in, opts)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -58,7 +63,7 @@ func (m *MockLedgerServiceClient) TransactionGet(ctx context.Context, in *ledger
 	return args.Get(0).(*ledgerv1.TransactionGetResponse), args.Error(1)
 }
 
-func (m *MockLedgerServiceClient) TransactionList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ledgerv1.TransactionGetListResponse, error) {
+func (m *MockLedgerServiceClient) TransactionList(ctx context.Context, in *ledgerv1.TransactionListRequest, opts ...grpc.CallOption) (*ledgerv1.TransactionGetListResponse, error) {
 	args := m.Called(ctx, in, opts)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -82,6 +87,267 @@ func (m *MockLedgerServiceClient) BulkAddTransactions(ctx context.Context, in *l
 	return args.Get(0).(*ledgerv1.TransactionBulkAddResponse), args.Error(1)
 }
 
+func (m *MockLedgerServiceClient) CategoryAdd(ctx context.Context, in *ledgerv1.CategoryAddRequest, opts ...grpc.CallOption) (*ledgerv1.CategoryAddResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.CategoryAddResponse), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) CategoryUpdate(ctx context.Context, in *ledgerv1.CategoryUpdateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) CategoriesList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ledgerv1.CategoryGetListResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.CategoryGetListResponse), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) RuleAdd(ctx context.Context, in *ledgerv1.Rule, opts ...grpc.CallOption) (*ledgerv1.RuleAddResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.RuleAddResponse), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) RuleUpdate(ctx context.Context, in *ledgerv1.Rule, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) RuleDelete(ctx context.Context, in *ledgerv1.RuleDeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) RulesList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ledgerv1.RuleListResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.RuleListResponse), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) RuleTest(ctx context.Context, in *ledgerv1.RuleTestRequest, opts ...grpc.CallOption) (*ledgerv1.RuleTestResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.RuleTestResponse), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) AttachmentUpload(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ledgerv1.AttachmentUploadRequest, ledgerv1.Attachment], error) {
+	args := m.Called(ctx, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(grpc.ClientStreamingClient[ledgerv1.AttachmentUploadRequest, ledgerv1.Attachment]), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) AttachmentDownload(ctx context.Context, in *ledgerv1.AttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ledgerv1.AttachmentChunk], error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(grpc.ServerStreamingClient[ledgerv1.AttachmentChunk]), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) AttachmentDelete(ctx context.Context, in *ledgerv1.AttachmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) AttachmentsList(ctx context.Context, in *ledgerv1.AttachmentsListRequest, opts ...grpc.CallOption) (*ledgerv1.AttachmentListResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.AttachmentListResponse), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) TransactionSearch(ctx context.Context, in *ledgerv1.TransactionSearchRequest, opts ...grpc.CallOption) (*ledgerv1.TransactionSearchResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.TransactionSearchResponse), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) GoalAdd(ctx context.Context, in *ledgerv1.Goal, opts ...grpc.CallOption) (*ledgerv1.GoalAddResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.GoalAddResponse), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) GoalUpdate(ctx context.Context, in *ledgerv1.Goal, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) GoalDelete(ctx context.Context, in *ledgerv1.GoalRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) GoalGet(ctx context.Context, in *ledgerv1.GoalRequest, opts ...grpc.CallOption) (*ledgerv1.GoalProgress, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.GoalProgress), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) GoalsList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ledgerv1.GoalListResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.GoalListResponse), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) GoalContribute(ctx context.Context, in *ledgerv1.GoalContributeRequest, opts ...grpc.CallOption) (*ledgerv1.TransactionAddResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.TransactionAddResponse), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) ReportForecast(ctx context.Context, in *ledgerv1.ForecastRequest, opts ...grpc.CallOption) (*ledgerv1.ForecastResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.ForecastResponse), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) ReportAnomalies(ctx context.Context, in *ledgerv1.AnomaliesRequest, opts ...grpc.CallOption) (*ledgerv1.AnomaliesResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.AnomaliesResponse), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) WebhookAdd(ctx context.Context, in *ledgerv1.Webhook, opts ...grpc.CallOption) (*ledgerv1.WebhookAddResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.WebhookAddResponse), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) WebhookDelete(ctx context.Context, in *ledgerv1.WebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) WebhooksList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ledgerv1.WebhookListResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.WebhookListResponse), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) WebhookDeliveriesList(ctx context.Context, in *ledgerv1.WebhookDeliveriesRequest, opts ...grpc.CallOption) (*ledgerv1.WebhookDeliveriesResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.WebhookDeliveriesResponse), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) Subscribe(ctx context.Context, in *ledgerv1.SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ledgerv1.LedgerEvent], error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(grpc.ServerStreamingClient[ledgerv1.LedgerEvent]), args.Error(1)
+}
+
+func (m *MockLedgerServiceClient) AuditList(ctx context.Context, in *ledgerv1.AuditListRequest, opts ...grpc.CallOption) (*ledgerv1.AuditListResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ledgerv1.AuditListResponse), args.Error(1)
+}
+
+type fakeUploadClient struct {
+	grpc.ClientStream
+	sent     []*ledgerv1.AttachmentUploadRequest
+	response *ledgerv1.Attachment
+}
+
+func (f *fakeUploadClient) Send(req *ledgerv1.AttachmentUploadRequest) error {
+	f.sent = append(f.sent, req)
+	return nil
+}
+
+func (f *fakeUploadClient) CloseAndRecv() (*ledgerv1.Attachment, error) {
+	return f.response, nil
+}
+
+type fakeDownloadClient struct {
+	grpc.ClientStream
+	chunks []*ledgerv1.AttachmentChunk
+	err    error
+}
+
+func (f *fakeDownloadClient) Recv() (*ledgerv1.AttachmentChunk, error) {
+	if len(f.chunks) == 0 {
+		return nil, f.err
+	}
+	chunk := f.chunks[0]
+	f.chunks = f.chunks[1:]
+	return chunk, nil
+}
+
+type fakeEventClient struct {
+	grpc.ClientStream
+	events []*ledgerv1.LedgerEvent
+	err    error
+}
+
+func (f *fakeEventClient) Recv() (*ledgerv1.LedgerEvent, error) {
+	if len(f.events) == 0 {
+		return nil, f.err
+	}
+	event := f.events[0]
+	f.events = f.events[1:]
+	return event, nil
+}
+
 func TestLedgerGatewayService_BudgetAdd(t *testing.T) {
 	mockClient := &MockLedgerServiceClient{}
 	service := NewLedgerGatewayService(mockClient)
@@ -217,15 +483,15 @@ func TestLedgerGatewayService_BudgetList(t *testing.T) {
 		{
 			name: "successful budget list",
 			mockSetup: func() {
-				mockClient.On("BudgetsList", ctx, mock.AnythingOfType("*emptypb.Empty"), mock.Anything).Return(&ledgerv1.BudgetGetListResponse{
+				mockClient.On("BudgetsList", ctx, &ledgerv1.BudgetsListRequest{AsOf: "2025-12-15"}, mock.Anything).Return(&ledgerv1.BudgetGetListResponse{
 					Budgets: []*ledgerv1.BudgetGetResponse{
-						{Category: "Food", Limit: 1000.0},
+						{Category: "Food", Limit: 1000.0, Spent: 430.0},
 						{Category: "Transport", Limit: 500.0},
 					},
 				}, nil)
 			},
 			expected: []model.BudgetGetResponse{
-				{Category: "Food", Limit: 1000.0},
+				{Category: "Food", Limit: 1000.0, Spent: 430.0},
 				{Category: "Transport", Limit: 500.0},
 			},
 			expectedErr: false,
@@ -233,7 +499,7 @@ func TestLedgerGatewayService_BudgetList(t *testing.T) {
 		{
 			name: "gRPC error",
 			mockSetup: func() {
-				mockClient.On("BudgetsList", ctx, mock.AnythingOfType("*emptypb.Empty"), mock.Anything).Return(nil, assert.AnError)
+				mockClient.On("BudgetsList", ctx, mock.Anything, mock.Anything).Return(nil, assert.AnError)
 			},
 			expected:    nil,
 			expectedErr: true,
@@ -245,7 +511,7 @@ func TestLedgerGatewayService_BudgetList(t *testing.T) {
 			mockClient.ExpectedCalls = nil
 			tt.mockSetup()
 
-			result, err := service.BudgetList(ctx)
+			result, err := service.BudgetList(ctx, "2025-12-15")
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -287,11 +553,38 @@ func TestLedgerGatewayService_TransactionAdd(t *testing.T) {
 					Description: "Lunch",
 					Date:        "2025-12-01",
 				}, mock.Anything).Return(&ledgerv1.TransactionAddResponse{
-					Id: 1,
+					Id:      1,
+					Anomaly: true,
+				}, nil)
+			},
+			expected: &model.TransactionAddResponse{
+				Id:      1,
+				Anomaly: true,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "idempotency key forwarded",
+			req: model.TrasnactionAdd{
+				Amount:         100.0,
+				Category:       "Food",
+				Description:    "Lunch",
+				Date:           "2025-12-01",
+				IdempotencyKey: "key-1",
+			},
+			mockSetup: func() {
+				mockClient.On("TransactionAdd", ctx, &ledgerv1.TransactionAddRequest{
+					Amount:         100.0,
+					Category:       "Food",
+					Description:    "Lunch",
+					Date:           "2025-12-01",
+					IdempotencyKey: "key-1",
+				}, mock.Anything).Return(&ledgerv1.TransactionAddResponse{
+					Id: 7,
 				}, nil)
 			},
 			expected: &model.TransactionAddResponse{
-				Id: 1,
+				Id: 7,
 			},
 			expectedErr: false,
 		},
@@ -413,7 +706,7 @@ func TestLedgerGatewayService_TransactionList(t *testing.T) {
 		{
 			name: "successful transaction list",
 			mockSetup: func() {
-				mockClient.On("TransactionList", ctx, mock.AnythingOfType("*emptypb.Empty"), mock.Anything).Return(&ledgerv1.TransactionGetListResponse{
+				mockClient.On("TransactionList", ctx, &ledgerv1.TransactionListRequest{Tag: "vacation-2025"}, mock.Anything).Return(&ledgerv1.TransactionGetListResponse{
 					Transactions: []*ledgerv1.TransactionGetResponse{
 						{
 							Id:          1,
@@ -421,6 +714,7 @@ func TestLedgerGatewayService_TransactionList(t *testing.T) {
 							Category:    "Food",
 							Description: "Lunch",
 							Date:        "2025-12-01",
+							Tags:        []string{"vacation-2025"},
 						},
 						{
 							Id:          2,
@@ -439,6 +733,7 @@ func TestLedgerGatewayService_TransactionList(t *testing.T) {
 					Category:    "Food",
 					Description: "Lunch",
 					Date:        "2025-12-01",
+					Tags:        []string{"vacation-2025"},
 				},
 				{
 					Id:          2,
@@ -453,7 +748,7 @@ func TestLedgerGatewayService_TransactionList(t *testing.T) {
 		{
 			name: "gRPC error",
 			mockSetup: func() {
-				mockClient.On("TransactionList", ctx, mock.AnythingOfType("*emptypb.Empty"), mock.Anything).Return(nil, assert.AnError)
+				mockClient.On("TransactionList", ctx, &ledgerv1.TransactionListRequest{Tag: "vacation-2025"}, mock.Anything).Return(nil, assert.AnError)
 			},
 			expected:    nil,
 			expectedErr: true,
@@ -465,7 +760,7 @@ func TestLedgerGatewayService_TransactionList(t *testing.T) {
 			mockClient.ExpectedCalls = nil
 			tt.mockSetup()
 
-			result, err := service.TransactionList(ctx)
+			result, err := service.TransactionList(ctx, model.TransactionList{Tag: "vacation-2025"})
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -513,6 +808,32 @@ func TestLedgerGatewayService_ReportSummary(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		{
+			name: "report summary with tree",
+			req:  req,
+			mockSetup: func() {
+				mockClient.On("ReportSummary", ctx, &ledgerv1.SummaryRequest{
+					From: "2025-12-01",
+					To:   "2025-12-31",
+				}, mock.Anything).Return(&ledgerv1.SummaryResponse{
+					Report: map[string]float64{"Food": 100.0, "Groceries": 400.0},
+					Tree: []*ledgerv1.CategoryNode{
+						{Id: 1, Name: "Food", Amount: 100, Total: 500, Children: []*ledgerv1.CategoryNode{
+							{Id: 2, Name: "Groceries", Amount: 400, Total: 400},
+						}},
+					},
+				}, nil)
+			},
+			expected: &model.ReportSummaryResponse{
+				Report: map[string]float64{"Food": 100.0, "Groceries": 400.0},
+				Tree: []model.CategoryNode{
+					{Id: 1, Name: "Food", Amount: 100, Total: 500, Children: []model.CategoryNode{
+						{Id: 2, Name: "Groceries", Amount: 400, Total: 400},
+					}},
+				},
+			},
+			expectedErr: false,
+		},
 		{
 			name: "gRPC error",
 			req:  req,
@@ -594,6 +915,35 @@ func TestLedgerGatewayService_TransactionBulkAdd(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		{
+			name: "duplicates reported",
+			req: model.TransactionBulkAdd{
+				Transactions: []model.TrasnactionAdd{
+					{Amount: 10, Category: "Food", Description: "Bread", Date: "2025-12-01", ExternalID: "bank-1"},
+					{Amount: 10, Category: "Food", Description: "Bread", Date: "2025-12-01", ExternalID: "bank-1"},
+				},
+			},
+			mockSetup: func() {
+				mockClient.On("BulkAddTransactions", ctx, &ledgerv1.TransactionBulkAddRequest{
+					Transactions: []*ledgerv1.TransactionAddRequest{
+						{Amount: 10, Category: "Food", Description: "Bread", Date: "2025-12-01", ExternalId: "bank-1"},
+						{Amount: 10, Category: "Food", Description: "Bread", Date: "2025-12-01", ExternalId: "bank-1"},
+					},
+				}, mock.Anything).Return(&ledgerv1.TransactionBulkAddResponse{
+					Accepted:        0,
+					Rejected:        2,
+					Duplicates:      map[int64]int64{0: 5},
+					BatchDuplicates: map[int64]int64{1: 0},
+				}, nil)
+			},
+			expected: &model.TransactionBulkAddResponse{
+				Accepted:        0,
+				Rejected:        2,
+				Duplicates:      map[int64]int64{0: 5},
+				BatchDuplicates: map[int64]int64{1: 0},
+			},
+			expectedErr: false,
+		},
 		{
 			name: "gRPC error",
 			req:  req,
@@ -631,3 +981,356 @@ func TestLedgerGatewayService_TransactionBulkAdd(t *testing.T) {
 		})
 	}
 }
+
+func TestLedgerGatewayService_CategoryAdd(t *testing.T) {
+	mockClient := &MockLedgerServiceClient{}
+	service := NewLedgerGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("CategoryAdd", ctx, &ledgerv1.CategoryAddRequest{Name: "Groceries", ParentId: 1}, mock.Anything).
+		Return(&ledgerv1.CategoryAddResponse{Id: 2}, nil)
+
+	result, err := service.CategoryAdd(ctx, model.CategoryAdd{Name: "Groceries", ParentID: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.CategoryAddResponse{Id: 2}, result)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLedgerGatewayService_CategoryUpdate(t *testing.T) {
+	mockClient := &MockLedgerServiceClient{}
+	service := NewLedgerGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("CategoryUpdate", ctx, &ledgerv1.CategoryUpdateRequest{Id: 2, Name: "Supermarkets", ParentId: 1}, mock.Anything).
+		Return(nil, assert.AnError)
+
+	err := service.CategoryUpdate(ctx, model.CategoryUpdate{Id: 2, Name: "Supermarkets", ParentID: 1})
+
+	assert.Error(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLedgerGatewayService_CategoryList(t *testing.T) {
+	mockClient := &MockLedgerServiceClient{}
+	service := NewLedgerGatewayService(mockClient)
+	ctx := context.Background()
+
+	var empty *emptypb.Empty
+	mockClient.On("CategoriesList", ctx, empty, mock.Anything).Return(&ledgerv1.CategoryGetListResponse{
+		Categories: []*ledgerv1.CategoryGetResponse{
+			{Id: 1, Name: "Food"},
+			{Id: 2, Name: "Groceries", ParentId: 1},
+		},
+	}, nil)
+
+	result, err := service.CategoryList(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.CategoryGetResponse{
+		{Id: 1, Name: "Food"},
+		{Id: 2, Name: "Groceries", ParentID: 1},
+	}, result)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLedgerGatewayService_RuleAdd(t *testing.T) {
+	mockClient := &MockLedgerServiceClient{}
+	service := NewLedgerGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("RuleAdd", ctx, &ledgerv1.Rule{Priority: 1, DescriptionContains: "uber", MaxAmount: 100, Category: "Taxi"}, mock.Anything).
+		Return(&ledgerv1.RuleAddResponse{Id: 1}, nil)
+
+	result, err := service.RuleAdd(ctx, model.Rule{Priority: 1, DescriptionContains: "uber", MaxAmount: 100, Category: "Taxi"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.RuleAddResponse{Id: 1}, result)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLedgerGatewayService_RuleList(t *testing.T) {
+	mockClient := &MockLedgerServiceClient{}
+	service := NewLedgerGatewayService(mockClient)
+	ctx := context.Background()
+
+	var empty *emptypb.Empty
+	mockClient.On("RulesList", ctx, empty, mock.Anything).Return(&ledgerv1.RuleListResponse{
+		Rules: []*ledgerv1.Rule{
+			{Id: 1, Priority: 1, DescriptionRegex: "^NETFLIX", CategoryId: 4, Category: "Subscriptions"},
+		},
+	}, nil)
+
+	result, err := service.RuleList(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Rule{
+		{Id: 1, Priority: 1, DescriptionRegex: "^NETFLIX", CategoryID: 4, Category: "Subscriptions"},
+	}, result)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLedgerGatewayService_RuleTest(t *testing.T) {
+	mockClient := &MockLedgerServiceClient{}
+	service := NewLedgerGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("RuleTest", ctx, &ledgerv1.RuleTestRequest{Rule: &ledgerv1.Rule{Id: 4}, Last: 20}, mock.Anything).Return(&ledgerv1.RuleTestResponse{
+		Matches: []*ledgerv1.RuleMatch{
+			{
+				Transaction: &ledgerv1.TransactionGetResponse{Id: 1, Amount: 10, CategoryId: 9, Category: "Misc", Description: "Coffee", Date: "2025-12-01"},
+				CategoryId:  1,
+				Category:    "Food",
+				Description: "Coffee",
+			},
+		},
+	}, nil)
+
+	result, err := service.RuleTest(ctx, model.RuleTest{Rule: model.Rule{Id: 4}, Last: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.RuleMatch{
+		{
+			Transaction: model.TransactionGetResponse{Id: 1, Amount: 10, CategoryID: 9, Category: "Misc", Description: "Coffee", Date: "2025-12-01"},
+			CategoryID:  1,
+			Category:    "Food",
+			Description: "Coffee",
+		},
+	}, result)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLedgerGatewayService_AttachmentUpload(t *testing.T) {
+	mockClient := &MockLedgerServiceClient{}
+	service := NewLedgerGatewayService(mockClient)
+	ctx := context.Background()
+
+	stream := &fakeUploadClient{response: &ledgerv1.Attachment{Id: 5, TransactionId: 3, FileName: "receipt.pdf", ContentType: "application/pdf", Size: 8}}
+	mockClient.On("AttachmentUpload", ctx, mock.Anything).Return(stream, nil)
+
+	result, err := service.AttachmentUpload(ctx, 3, "receipt.pdf", strings.NewReader("%PDF-1.4"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Attachment{Id: 5, TransactionId: 3, FileName: "receipt.pdf", ContentType: "application/pdf", Size: 8}, result)
+	assert.Equal(t, []*ledgerv1.AttachmentUploadRequest{
+		{Payload: &ledgerv1.AttachmentUploadRequest_Info{Info: &ledgerv1.Attachment{TransactionId: 3, FileName: "receipt.pdf"}}},
+		{Payload: &ledgerv1.AttachmentUploadRequest_Chunk{Chunk: []byte("%PDF-1.4")}},
+	}, stream.sent)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLedgerGatewayService_AttachmentDownload(t *testing.T) {
+	mockClient := &MockLedgerServiceClient{}
+	service := NewLedgerGatewayService(mockClient)
+	ctx := context.Background()
+
+	stream := &fakeDownloadClient{
+		chunks: []*ledgerv1.AttachmentChunk{
+			{Payload: &ledgerv1.AttachmentChunk_Info{Info: &ledgerv1.Attachment{Id: 5, TransactionId: 3, FileName: "receipt.png", ContentType: "image/png", Size: 8}}},
+			{Payload: &ledgerv1.AttachmentChunk_Chunk{Chunk: []byte("\x89PNG")}},
+			{Payload: &ledgerv1.AttachmentChunk_Chunk{Chunk: []byte("data")}},
+		},
+		err: io.EOF,
+	}
+	mockClient.On("AttachmentDownload", ctx, &ledgerv1.AttachmentRequest{TransactionId: 3, Id: 5}, mock.Anything).Return(stream, nil)
+
+	attachment, data, err := service.AttachmentDownload(ctx, 3, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Attachment{Id: 5, TransactionId: 3, FileName: "receipt.png", ContentType: "image/png", Size: 8}, attachment)
+	content, err := io.ReadAll(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "\x89PNGdata", string(content))
+	mockClient.AssertExpectations(t)
+}
+
+func TestLedgerGatewayService_TransactionSearch(t *testing.T) {
+	mockClient := &MockLedgerServiceClient{}
+	service := NewLedgerGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("TransactionSearch", ctx, &ledgerv1.TransactionSearchRequest{Query: "pharm", Language: "english", CategoryId: 2, Limit: 10}, mock.Anything).
+		Return(&ledgerv1.TransactionSearchResponse{Results: []*ledgerv1.TransactionSearchResult{
+			{Transaction: &ledgerv1.TransactionGetResponse{Id: 4, Amount: 12.5, CategoryId: 2, Category: "Health", Description: "Pharmacy", Date: "2025-03-14"}, Rank: 0.5},
+		}}, nil)
+
+	result, err := service.TransactionSearch(ctx, model.TransactionSearch{Query: "pharm", Language: "english", CategoryID: 2, Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.TransactionSearchResult{
+		{Transaction: model.TransactionGetResponse{Id: 4, Amount: 12.5, CategoryID: 2, Category: "Health", Description: "Pharmacy", Date: "2025-03-14"}, Rank: 0.5},
+	}, result)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLedgerGatewayService_GoalList(t *testing.T) {
+	mockClient := &MockLedgerServiceClient{}
+	service := NewLedgerGatewayService(mockClient)
+	ctx := context.Background()
+
+	var empty *emptypb.Empty
+	mockClient.On("GoalsList", ctx, empty, mock.Anything).Return(&ledgerv1.GoalListResponse{
+		Goals: []*ledgerv1.GoalProgress{
+			{
+				Goal:            &ledgerv1.Goal{Id: 1, Name: "Vacation", TargetAmount: 1200, Deadline: "2026-06-30"},
+				Saved:           500,
+				Remaining:       700,
+				Percent:         41.5,
+				MonthsLeft:      7,
+				MonthlyRequired: 100,
+			},
+		},
+	}, nil)
+
+	result, err := service.GoalList(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.GoalProgress{
+		{
+			Goal:            model.Goal{Id: 1, Name: "Vacation", TargetAmount: 1200, Deadline: "2026-06-30"},
+			Saved:           500,
+			Remaining:       700,
+			Percent:         41.5,
+			MonthsLeft:      7,
+			MonthlyRequired: 100,
+		},
+	}, result)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLedgerGatewayService_GoalContribute(t *testing.T) {
+	mockClient := &MockLedgerServiceClient{}
+	service := NewLedgerGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("GoalContribute", ctx, &ledgerv1.GoalContributeRequest{GoalId: 1, Amount: 150, Date: "2025-12-11"}, mock.Anything).
+		Return(&ledgerv1.TransactionAddResponse{Id: 42}, nil)
+
+	result, err := service.GoalContribute(ctx, model.GoalContribute{GoalId: 1, Amount: 150, Date: "2025-12-11"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.TransactionAddResponse{Id: 42}, result)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLedgerGatewayService_ReportForecast(t *testing.T) {
+	mockClient := &MockLedgerServiceClient{}
+	service := NewLedgerGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("ReportForecast", ctx, &ledgerv1.ForecastRequest{HistoryPeriods: 3}, mock.Anything).Return(&ledgerv1.ForecastResponse{
+		PeriodStart:  "2025-12-01",
+		PeriodEnd:    "2025-12-31",
+		DaysElapsed:  10,
+		DaysInPeriod: 31,
+		Budgets:      []*ledgerv1.BudgetForecast{{CategoryId: 1, Category: "Food", Limit: 300, Projected: 310, LikelyExceeded: true}},
+	}, nil)
+
+	result, err := service.ReportForecast(ctx, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.ReportForecastResponse{
+		PeriodStart:  "2025-12-01",
+		PeriodEnd:    "2025-12-31",
+		DaysElapsed:  10,
+		DaysInPeriod: 31,
+		Categories:   []model.CategoryForecast{},
+		Budgets:      []model.BudgetForecast{{CategoryID: 1, Category: "Food", Limit: 300, Projected: 310, LikelyExceeded: true}},
+	}, result)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLedgerGatewayService_WebhookDeliveries(t *testing.T) {
+	mockClient := &MockLedgerServiceClient{}
+	service := NewLedgerGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("WebhookDeliveriesList", ctx, &ledgerv1.WebhookDeliveriesRequest{WebhookId: 1, Limit: 10}, mock.Anything).Return(&ledgerv1.WebhookDeliveriesResponse{
+		Deliveries: []*ledgerv1.WebhookDelivery{
+			{
+				Id:             7,
+				WebhookId:      1,
+				Event:          "budget.exceeded",
+				Payload:        `{"category":"Food"}`,
+				Status:         "delivered",
+				Attempts:       1,
+				LastStatusCode: 200,
+				NextAttemptAt:  "2025-12-19T10:00:00Z",
+				CreatedAt:      "2025-12-19T10:00:00Z",
+				DeliveredAt:    "2025-12-19T10:00:00Z",
+			},
+		},
+	}, nil)
+
+	result, err := service.WebhookDeliveries(ctx, 1, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.WebhookDelivery{
+		{
+			Id:             7,
+			WebhookId:      1,
+			Event:          "budget.exceeded",
+			Payload:        json.RawMessage(`{"category":"Food"}`),
+			Status:         "delivered",
+			Attempts:       1,
+			LastStatusCode: 200,
+			NextAttemptAt:  "2025-12-19T10:00:00Z",
+			CreatedAt:      "2025-12-19T10:00:00Z",
+			DeliveredAt:    "2025-12-19T10:00:00Z",
+		},
+	}, result)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLedgerGatewayService_Subscribe(t *testing.T) {
+	mockClient := &MockLedgerServiceClient{}
+	service := NewLedgerGatewayService(mockClient)
+	ctx := context.Background()
+
+	stream := &fakeEventClient{
+		events: []*ledgerv1.LedgerEvent{
+			{Id: 4, Type: "transaction.created", AggregateId: 15, Payload: `{"id":15}`, CreatedAt: "2025-12-19T10:00:00Z"},
+			{Id: 5, Type: "budget.set", AggregateId: 2, Payload: `{"category":"Food"}`, CreatedAt: "2025-12-19T10:00:01Z"},
+		},
+		err: io.EOF,
+	}
+	mockClient.On("Subscribe", ctx, &ledgerv1.SubscribeRequest{FromEventId: 3, Types: []string{"transaction.created"}}, mock.Anything).Return(stream, nil)
+
+	var received []model.LedgerEvent
+	err := service.Subscribe(ctx, 3, []string{"transaction.created"}, func(event model.LedgerEvent) error {
+		received = append(received, event)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.LedgerEvent{
+		{Id: 4, Type: "transaction.created", AggregateId: 15, Payload: json.RawMessage(`{"id":15}`), CreatedAt: "2025-12-19T10:00:00Z"},
+		{Id: 5, Type: "budget.set", AggregateId: 2, Payload: json.RawMessage(`{"category":"Food"}`), CreatedAt: "2025-12-19T10:00:01Z"},
+	}, received)
+
+	lagging := status.Error(codes.ResourceExhausted, "subscriber is too slow")
+	mockClient.On("Subscribe", ctx, &ledgerv1.SubscribeRequest{}, mock.Anything).Return(&fakeEventClient{err: lagging}, nil)
+	err = service.Subscribe(ctx, 0, nil, func(model.LedgerEvent) error { return nil })
+	assert.Equal(t, lagging, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLedgerGatewayService_AuditList(t *testing.T) {
+	mockClient := &MockLedgerServiceClient{}
+	service := NewLedgerGatewayService(mockClient)
+	ctx := context.Background()
+
+	mockClient.On("AuditList", ctx, &ledgerv1.AuditListRequest{EntityType: "transaction", EntityId: 15, Limit: 10}, mock.Anything).Return(&ledgerv1.AuditListResponse{
+		Entries: []*ledgerv1.AuditEntry{
+			{Id: 4, Action: "transaction.create", EntityType: "transaction", EntityId: 15, ActorUserId: "98ac2ffe", RequestId: "req-1", Method: "/ledger.v1.LedgerService/TransactionAdd", After: `{"id":15}`, CreatedAt: "2025-12-10T12:00:00Z"},
+		},
+	}, nil)
+
+	resp, err := service.AuditList(ctx, model.AuditList{EntityType: "transaction", EntityId: 15, Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.AuditEntry{
+		{Id: 4, Action: "transaction.create", EntityType: "transaction", EntityId: 15, ActorUserId: "98ac2ffe", RequestId: "req-1", Method: "/ledger.v1.LedgerService/TransactionAdd", Before: json.RawMessage(""), After: json.RawMessage(`{"id":15}`), CreatedAt: "2025-12-10T12:00:00Z"},
+	}, resp)
+	mockClient.AssertExpectations(t)
+}
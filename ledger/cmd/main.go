@@ -5,14 +5,21 @@ import (
 	"errors"
 	"ledger/internal/cache"
 	"ledger/internal/db"
+	"ledger/internal/domain"
 	"ledger/internal/grpcserver"
+	"ledger/internal/outbox"
 	"ledger/internal/repository/pg"
 	"ledger/internal/service"
+	"ledger/internal/snapshot"
+	"ledger/internal/storage"
+	"ledger/internal/webhook"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
 	pb "ledger/internal/pb/ledger/v1"
 
@@ -38,7 +45,10 @@ func main() {
 		log.Fatalf("listen gRPC: %v", err)
 	}
 
-	grpcSrv := grpc.NewServer()
+	grpcSrv := grpc.NewServer(
+		grpc.UnaryInterceptor(grpcserver.ActorUnaryInterceptor),
+		grpc.StreamInterceptor(grpcserver.ActorStreamInterceptor),
+	)
 
 	healthSrv := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(grpcSrv, healthSrv)
@@ -61,11 +71,121 @@ func main() {
 	}
 	defer redisConn.Close()
 
+	blobStorage, err := storage.Connect()
+	if err != nil {
+		println("Attachment storage error: %v", err.Error())
+		healthSrv.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+		return
+	}
+
+	idempotencyRepo := pg.NewIdempotencyPgRepository(dbConn)
+	transactionRepo := pg.NewTransactionPgRepository(dbConn)
+	webhookRepo := pg.NewWebhookPgRepository(dbConn)
+	outboxRepo := pg.NewOutboxPgRepository(dbConn)
+	eventHub := outbox.NewHub(outboxRepo)
 	ledgerService := service.NewLedgerService(
 		pg.NewBudgetPgRepository(dbConn, redisConn),
-		pg.NewTransactionPgRepository(dbConn),
+		transactionRepo,
 		pg.NewSummaryPgRepository(dbConn, redisConn),
+		pg.NewCategoryPgRepository(dbConn, redisConn),
+		pg.NewRulePgRepository(dbConn, redisConn),
+		idempotencyRepo,
+		pg.NewAttachmentPgRepository(dbConn),
+		blobStorage,
+		pg.NewGoalPgRepository(dbConn),
+		pg.NewAnomalyPgRepository(dbConn),
+		webhookRepo,
+		eventHub,
+		pg.NewAuditPgRepository(dbConn),
 	)
+	if window := os.Getenv("DUPLICATE_WINDOW_DAYS"); window != "" {
+		days, err := strconv.Atoi(window)
+		if err != nil || days < 0 {
+			log.Fatalf("invalid DUPLICATE_WINDOW_DAYS: %q", window)
+		}
+		ledgerService.SetDuplicateWindow(days)
+	}
+	if maxSize := os.Getenv("ATTACHMENT_MAX_SIZE"); maxSize != "" {
+		size, err := strconv.ParseInt(maxSize, 10, 64)
+		if err != nil || size <= 0 {
+			log.Fatalf("invalid ATTACHMENT_MAX_SIZE: %q", maxSize)
+		}
+		ledgerService.SetMaxAttachmentSize(size)
+	}
+	if language := os.Getenv("SEARCH_LANGUAGE"); language != "" {
+		if !domain.IsSearchLanguage(language) {
+			log.Fatalf("invalid SEARCH_LANGUAGE: %q", language)
+		}
+		ledgerService.SetSearchLanguage(language)
+	}
+	detector := domain.DefaultAnomalyDetector()
+	if ratio := os.Getenv("ANOMALY_MEDIAN_RATIO"); ratio != "" {
+		detector.MedianRatio, err = strconv.ParseFloat(ratio, 64)
+		if err != nil || detector.MedianRatio <= 1 {
+			log.Fatalf("invalid ANOMALY_MEDIAN_RATIO: %q", ratio)
+		}
+	}
+	if threshold := os.Getenv("ANOMALY_THRESHOLD"); threshold != "" {
+		detector.Threshold, err = strconv.ParseFloat(threshold, 64)
+		if err != nil || detector.Threshold <= 0 {
+			log.Fatalf("invalid ANOMALY_THRESHOLD: %q", threshold)
+		}
+	}
+	ledgerService.SetAnomalyDetector(detector)
+	idempotencyTTL := domain.DefaultIdempotencyTTL
+	if ttl := os.Getenv("IDEMPOTENCY_TTL"); ttl != "" {
+		idempotencyTTL, err = time.ParseDuration(ttl)
+		if err != nil || idempotencyTTL <= 0 {
+			log.Fatalf("invalid IDEMPOTENCY_TTL: %q", ttl)
+		}
+	}
+	idempotencyRepo.SetTTL(idempotencyTTL)
+	transactionRepo.SetIdempotencyTTL(idempotencyTTL)
+	webhookMaxAttempts, webhookBackoff := 6, 5*time.Second
+	if attempts := os.Getenv("WEBHOOK_MAX_ATTEMPTS"); attempts != "" {
+		webhookMaxAttempts, err = strconv.Atoi(attempts)
+		if err != nil || webhookMaxAttempts <= 0 {
+			log.Fatalf("invalid WEBHOOK_MAX_ATTEMPTS: %q", attempts)
+		}
+	}
+	if backoff := os.Getenv("WEBHOOK_BACKOFF"); backoff != "" {
+		webhookBackoff, err = time.ParseDuration(backoff)
+		if err != nil || webhookBackoff <= 0 {
+			log.Fatalf("invalid WEBHOOK_BACKOFF: %q", backoff)
+		}
+	}
+	dispatcher := webhook.NewDispatcher(webhookRepo, nil)
+	dispatcher.SetRetryPolicy(webhookMaxAttempts, webhookBackoff)
+	var eventSink domain.EventSink
+	switch sink := os.Getenv("OUTBOX_SINK"); sink {
+	case "", "redis":
+		stream := os.Getenv("OUTBOX_STREAM")
+		if stream == "" {
+			stream = "ledger:events"
+		}
+		eventSink = outbox.NewRedisStreamSink(redisConn, stream, 0)
+	case "log":
+		eventSink = outbox.NewLogSink(nil)
+	default:
+		log.Fatalf("invalid OUTBOX_SINK: %q", sink)
+	}
+	relay := outbox.NewRelay(outboxRepo, eventSink)
+	snapshotInterval := 24 * time.Hour
+	if interval := os.Getenv("SNAPSHOT_INTERVAL"); interval != "" {
+		snapshotInterval, err = time.ParseDuration(interval)
+		if err != nil || snapshotInterval <= 0 {
+			log.Fatalf("invalid SNAPSHOT_INTERVAL: %q", interval)
+		}
+	}
+	snapshotRepo := pg.NewSnapshotPgRepository(dbConn)
+	if keep := os.Getenv("SNAPSHOT_KEEP"); keep != "" {
+		n, err := strconv.Atoi(keep)
+		if err != nil || n <= 0 {
+			log.Fatalf("invalid SNAPSHOT_KEEP: %q", keep)
+		}
+		snapshotRepo.SetKeep(n)
+	}
+	snapshotBuilder := snapshot.NewBuilder(snapshotRepo)
 	pb.RegisterLedgerServiceServer(grpcSrv, grpcserver.NewLedgerServer(ledgerService))
 
 	reflection.Register(grpcSrv)
@@ -88,6 +208,42 @@ func main() {
 		return nil
 	})
 
+	g.Go(func() error {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-gctx.Done():
+				return nil
+			case <-ticker.C:
+				deleted, err := idempotencyRepo.DeleteExpiredIdempotencyRecords(gctx, time.Now().Add(-idempotencyTTL))
+				if err != nil {
+					log.Printf("idempotency cleanup: %v", err)
+					continue
+				}
+				if deleted > 0 {
+					log.Printf("idempotency cleanup: removed %d keys", deleted)
+				}
+			}
+		}
+	})
+
+	g.Go(func() error {
+		return dispatcher.Run(gctx, time.Second)
+	})
+
+	g.Go(func() error {
+		return relay.Run(gctx, time.Second)
+	})
+
+	g.Go(func() error {
+		return eventHub.Run(gctx, 500*time.Millisecond)
+	})
+
+	g.Go(func() error {
+		return snapshotBuilder.Run(gctx, snapshotInterval)
+	})
+
 	if err := g.Wait(); err != nil && !errors.Is(err, context.Canceled) {
 		log.Fatalf("server error: %v", err)
 	}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+type AnomalyScore struct {
+	Score  float64
+	Ratio  float64
+	Median float64
+	MAD    float64
+}
+
+type Anomaly struct {
+	Transaction Transaction
+	AnomalyScore
+}
+
+type CategoryStats struct {
+	Count  int
+	Median float64
+	MAD    float64
+}
+
+func NewCategoryStats(amounts []float64) CategoryStats {
+	stats := CategoryStats{Count: len(amounts), Median: Median(amounts)}
+	deviations := make([]float64, len(amounts))
+	for i, amount := range amounts {
+		deviations[i] = math.Abs(amount - stats.Median)
+	}
+	stats.MAD = Median(deviations)
+	return stats
+}
+
+func Median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+type AnomalyDetector struct {
+	MinSamples  int
+	Threshold   float64
+	MedianRatio float64
+}
+
+func DefaultAnomalyDetector() AnomalyDetector {
+	return AnomalyDetector{MinSamples: 5, Threshold: 3.5, MedianRatio: 5}
+}
+
+// Check flags amounts far above the category norm: either a robust
+// (median/MAD based) z-score over the threshold or a multiple of the median.
+// Only unusually large expenses are flagged, small ones are never suspicious.
+func (d AnomalyDetector) Check(stats CategoryStats, amount float64) *AnomalyScore {
+	if stats.Count < d.MinSamples || stats.Median <= 0 || amount <= stats.Median {
+		return nil
+	}
+	score := AnomalyScore{
+		Ratio:  math.Round(amount/stats.Median*100) / 100,
+		Median: stats.Median,
+		MAD:    stats.MAD,
+	}
+	if stats.MAD > 0 {
+		score.Score = math.Round(0.6745*(amount-stats.Median)/stats.MAD*100) / 100
+	}
+	if score.Ratio < d.MedianRatio && (stats.MAD == 0 || score.Score <= d.Threshold) {
+		return nil
+	}
+	return &score
+}
+
+type AnomalyRepository interface {
+	GetCategoryAmounts(ctx context.Context, categoryID int64, limit int) ([]float64, error)
+	GetAnomaly(ctx context.Context, transactionID int64) (*AnomalyScore, error)
+	ListAnomalies(ctx context.Context, from string, to string) ([]Anomaly, error)
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrAttachmentTooLarge        = errors.New("attachment too large")
+	ErrUnsupportedAttachmentType = errors.New("unsupported attachment type")
+)
+
+var attachmentContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
+
+type Attachment struct {
+	ID            int64     `json:"id"`
+	TransactionID int64     `json:"transaction_id"`
+	FileName      string    `json:"file_name"`
+	ContentType   string    `json:"content_type"`
+	Size          int64     `json:"size"`
+	StorageKey    string    `json:"storage_key"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func DetectAttachmentType(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if !attachmentContentTypes[contentType] {
+		return "", ErrUnsupportedAttachmentType
+	}
+	return contentType, nil
+}
+
+func NewAttachmentKey(transactionID int64) string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("transactions/%d/%s", transactionID, hex.EncodeToString(b))
+}
+
+type BlobStorage interface {
+	Put(ctx context.Context, key string, data io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type AttachmentRepository interface {
+	AddAttachment(ctx context.Context, attachment *Attachment) (int64, error)
+	GetAttachment(ctx context.Context, id int64) (*Attachment, error)
+	ListAttachments(ctx context.Context, transactionID int64) ([]Attachment, error)
+	DeleteAttachment(ctx context.Context, id int64) error
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	AuditBudgetSet         = "budget.set"
+	AuditTransactionCreate = "transaction.create"
+	AuditGoalContribute    = "goal.contribute"
+	AuditCategoryCreate    = "category.create"
+	AuditCategoryUpdate    = "category.update"
+	AuditRuleCreate        = "rule.create"
+	AuditRuleUpdate        = "rule.update"
+	AuditRuleDelete        = "rule.delete"
+	AuditGoalCreate        = "goal.create"
+	AuditGoalUpdate        = "goal.update"
+	AuditGoalDelete        = "goal.delete"
+	AuditWebhookCreate     = "webhook.create"
+	AuditWebhookDelete     = "webhook.delete"
+	AuditAttachmentCreate  = "attachment.create"
+	AuditAttachmentDelete  = "attachment.delete"
+
+	AuditEntityBudget      = "budget"
+	AuditEntityTransaction = "transaction"
+	AuditEntityCategory    = "category"
+	AuditEntityRule        = "rule"
+	AuditEntityGoal        = "goal"
+	AuditEntityWebhook     = "webhook"
+	AuditEntityAttachment  = "attachment"
+)
+
+const MaxAuditLimit = 500
+
+// Actor identifies who caused a mutation. It travels in the request context
+// so repositories can record it next to the change itself. SpaceID is the
+// caller's active ledger space; empty means the caller's personal ledger.
+type Actor struct {
+	UserID    string
+	SpaceID   string
+	RequestID string
+	Method    string
+}
+
+// Ledger is the space whose rows the actor reads and writes. A personal
+// ledger is a space keyed by its owner's user id, so users without an active
+// space never see each other's data. It is empty only without a user, which
+// leaves the rows written before spaces existed.
+func (a Actor) Ledger() string {
+	if a.SpaceID != "" {
+		return a.SpaceID
+	}
+	return a.UserID
+}
+
+type actorKey struct{}
+
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	return actor
+}
+
+type AuditEntry struct {
+	ID         int64
+	Action     string
+	EntityType string
+	EntityID   int64
+	Actor      Actor
+	Before     []byte
+	After      []byte
+	CreatedAt  time.Time
+}
+
+type AuditFilter struct {
+	EntityType  string
+	EntityID    int64
+	ActorUserID string
+	RequestID   string
+	Action      string
+	From        time.Time
+	To          time.Time
+	BeforeID    int64
+	Limit       int
+}
+
+func (f *AuditFilter) Validate() error {
+	if f.Limit < 0 || f.Limit > MaxAuditLimit {
+		return errors.New("invalid limit")
+	}
+	if f.EntityID != 0 && f.EntityType == "" {
+		return errors.New("entity_id requires entity_type")
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && f.To.Before(f.From) {
+		return errors.New("invalid period")
+	}
+	if f.BeforeID < 0 {
+		return errors.New("invalid before_id")
+	}
+	return nil
+}
+
+type AuditRepository interface {
+	// ListAudit returns matching entries newest first.
+	ListAudit(ctx context.Context, filter AuditFilter) ([]AuditEntry, error)
+}
@@ -3,18 +3,21 @@ package domain
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 type Budget struct {
-	Category string  `json:"category"`
-	Limit    float64 `json:"limit"`
+	CategoryID int64   `json:"category_id"`
+	Category   string  `json:"category"`
+	Limit      float64 `json:"limit"`
+	Spent      float64 `json:"spent,omitempty"`
 }
 
 func (budget *Budget) Validate() error {
 	if budget.Limit <= 0 {
 		return errors.New("invalid limit")
 	}
-	if budget.Category == "" {
+	if budget.Category == "" && budget.CategoryID == 0 {
 		return errors.New("invalid category")
 	}
 	return nil
@@ -24,4 +27,8 @@ type BudgetRepository interface {
 	SetBudget(budget *Budget, ctx context.Context) error
 	GetBudgets(ctx context.Context) ([]Budget, error)
 	GetBudget(category string, ctx context.Context) (*Budget, error)
+	// GetBudgetsAsOf returns the budgets that existed before asOf with the
+	// limits then in effect, taken from the budget history. A budget set
+	// before the history was kept counts as always present.
+	GetBudgetsAsOf(ctx context.Context, asOf time.Time) ([]Budget, error)
 }
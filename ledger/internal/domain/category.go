@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+type Category struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	ParentID int64  `json:"parent_id"`
+}
+
+func (c *Category) Validate() error {
+	if c.Name == "" {
+		return errors.New("invalid name")
+	}
+	if c.ID != 0 && c.ParentID == c.ID {
+		return errors.New("category can not be its own parent")
+	}
+	return nil
+}
+
+type CategoryNode struct {
+	ID       int64          `json:"id"`
+	Name     string         `json:"name"`
+	Amount   float64        `json:"amount"`
+	Total    float64        `json:"total"`
+	Children []CategoryNode `json:"children"`
+}
+
+func BuildCategoryTree(categories []Category, amounts map[string]float64) []CategoryNode {
+	children := make(map[int64][]Category)
+	for _, c := range categories {
+		children[c.ParentID] = append(children[c.ParentID], c)
+	}
+	var build func(parentID int64) []CategoryNode
+	build = func(parentID int64) []CategoryNode {
+		var nodes []CategoryNode
+		for _, c := range children[parentID] {
+			node := CategoryNode{ID: c.ID, Name: c.Name, Amount: amounts[c.Name]}
+			node.Children = build(c.ID)
+			node.Total = node.Amount
+			for _, child := range node.Children {
+				node.Total += child.Total
+			}
+			if node.Total != 0 {
+				nodes = append(nodes, node)
+			}
+		}
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+		return nodes
+	}
+	return build(0)
+}
+
+// CategoryTotals maps every category in the tree to its total, children
+// included.
+func CategoryTotals(nodes []CategoryNode) map[int64]float64 {
+	totals := make(map[int64]float64)
+	var walk func(nodes []CategoryNode)
+	walk = func(nodes []CategoryNode) {
+		for _, node := range nodes {
+			totals[node.ID] = node.Total
+			walk(node.Children)
+		}
+	}
+	walk(nodes)
+	return totals
+}
+
+func IsDescendant(categories []Category, id int64, ancestorID int64) bool {
+	parents := make(map[int64]int64, len(categories))
+	for _, c := range categories {
+		parents[c.ID] = c.ParentID
+	}
+	for steps := 0; id != 0 && steps <= len(categories); steps++ {
+		if id == ancestorID {
+			return true
+		}
+		id = parents[id]
+	}
+	return false
+}
+
+type CategoryRepository interface {
+	AddCategory(ctx context.Context, category *Category) (int64, error)
+	UpdateCategory(ctx context.Context, category *Category) error
+	GetCategory(ctx context.Context, id int64) (*Category, error)
+	GetCategoryByName(ctx context.Context, name string) (*Category, error)
+	GetCategoryPath(ctx context.Context, id int64) ([]Category, error)
+	ListCategories(ctx context.Context) ([]Category, error)
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+type Forecast struct {
+	PeriodStart  string
+	PeriodEnd    string
+	DaysElapsed  int
+	DaysInPeriod int
+	Categories   []CategoryForecast
+	Budgets      []BudgetForecast
+}
+
+type CategoryForecast struct {
+	Category          string
+	Spent             float64
+	Scheduled         float64
+	HistoricalAverage float64
+	Projected         float64
+}
+
+type BudgetForecast struct {
+	CategoryID     int64
+	Category       string
+	Limit          float64
+	Projected      float64
+	LikelyExceeded bool
+}
+
+type ForecastPeriod struct {
+	Start        time.Time
+	End          time.Time
+	DaysElapsed  int
+	DaysInPeriod int
+}
+
+// MonthPeriod returns the budget period containing now. Today counts as an
+// elapsed day since its expenses are already part of the spend so far.
+func MonthPeriod(now time.Time) ForecastPeriod {
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, -1)
+	return ForecastPeriod{
+		Start:        start,
+		End:          end,
+		DaysElapsed:  now.Day(),
+		DaysInPeriod: end.Day(),
+	}
+}
+
+// ProjectSpend extrapolates the daily rate over the rest of the period.
+// The rate blends the current pace with the historical average, trusting the
+// current pace more as the period goes on. Transactions already entered for
+// the rest of the period are a floor for the remaining spend rather than an
+// addition to it, so a scheduled rent payment is not counted twice.
+func ProjectSpend(spent float64, scheduled float64, history []float64, period ForecastPeriod) float64 {
+	rate := spent / float64(period.DaysElapsed)
+	if len(history) > 0 {
+		weight := float64(period.DaysElapsed) / float64(period.DaysInPeriod)
+		rate = weight*rate + (1-weight)*Mean(history)/float64(period.DaysInPeriod)
+	}
+	remaining := float64(period.DaysInPeriod - period.DaysElapsed)
+	return math.Round((spent+math.Max(scheduled, rate*remaining))*100) / 100
+}
+
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
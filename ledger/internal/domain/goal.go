@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+const (
+	TransactionKindExpense          = "expense"
+	TransactionKindGoalContribution = "goal_contribution"
+	GoalContributionCategory        = "Savings"
+)
+
+var ErrGoalHasContributions = errors.New("goal has contributions")
+
+type Goal struct {
+	ID           int64   `json:"id"`
+	Name         string  `json:"name"`
+	TargetAmount float64 `json:"target_amount"`
+	Deadline     string  `json:"deadline"`
+	Saved        float64 `json:"saved,omitempty"`
+}
+
+type GoalProgress struct {
+	Goal            Goal
+	Saved           float64
+	Remaining       float64
+	Percent         float64
+	MonthsLeft      int
+	MonthlyRequired float64
+}
+
+type GoalContribution struct {
+	GoalID      int64
+	Amount      float64
+	Date        string
+	Description string
+}
+
+func (g *Goal) Validate() error {
+	if g.Name == "" {
+		return errors.New("invalid name")
+	}
+	if g.TargetAmount <= 0 {
+		return errors.New("invalid target amount")
+	}
+	if _, err := time.Parse("2006-01-02", g.Deadline); err != nil {
+		return errors.New("invalid deadline")
+	}
+	return nil
+}
+
+func (c *GoalContribution) Validate() error {
+	if c.GoalID == 0 {
+		return errors.New("invalid goal")
+	}
+	if c.Amount <= 0 {
+		return errors.New("invalid amount")
+	}
+	if _, err := time.Parse("2006-01-02", c.Date); err != nil {
+		return errors.New("invalid date")
+	}
+	return nil
+}
+
+// Progress counts the months in which a contribution can still be made,
+// including the current one, so a deadline later this month leaves one
+// month and a deadline in the past leaves none and the whole remainder is due.
+func (g *Goal) Progress(now time.Time) GoalProgress {
+	progress := GoalProgress{Goal: *g, Saved: g.Saved}
+	progress.Remaining = math.Max(g.TargetAmount-g.Saved, 0)
+	progress.Percent = math.Min(math.Round(g.Saved/g.TargetAmount*10000)/100, 100)
+	deadline, err := time.Parse("2006-01-02", g.Deadline)
+	if err != nil {
+		return progress
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if !deadline.Before(today) {
+		progress.MonthsLeft = (deadline.Year()-today.Year())*12 + int(deadline.Month()-today.Month()) + 1
+		if deadline.Day() < today.Day() {
+			progress.MonthsLeft--
+		}
+	}
+	progress.MonthlyRequired = progress.Remaining
+	if progress.MonthsLeft > 1 {
+		progress.MonthlyRequired = math.Ceil(progress.Remaining/float64(progress.MonthsLeft)*100) / 100
+	}
+	return progress
+}
+
+type GoalRepository interface {
+	AddGoal(ctx context.Context, goal *Goal) (int64, error)
+	UpdateGoal(ctx context.Context, goal *Goal) error
+	DeleteGoal(ctx context.Context, id int64) error
+	GetGoal(ctx context.Context, id int64) (*Goal, error)
+	ListGoals(ctx context.Context) ([]Goal, error)
+	AddContribution(ctx context.Context, contribution *GoalContribution, categoryID int64) (int64, error)
+}
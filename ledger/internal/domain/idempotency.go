@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrIdempotencyKeyReused   = errors.New("idempotency key reused with different request")
+	ErrIdempotencyKeyConflict = errors.New("idempotency key already stored")
+)
+
+// DefaultIdempotencyTTL is how long a stored key replays its transaction.
+// An older key is treated as unused and may be stored again.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+type IdempotencyRecord struct {
+	Key           string
+	RequestHash   string
+	TransactionID int64
+}
+
+type IdempotencyRepository interface {
+	GetIdempotencyRecord(ctx context.Context, key string) (*IdempotencyRecord, error)
+	DeleteExpiredIdempotencyRecords(ctx context.Context, before time.Time) (int64, error)
+}
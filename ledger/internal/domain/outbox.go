@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrSubscriberLagging ends a subscription whose consumer fell so far behind
+// that its buffer filled up. The consumer resumes from the last event it got.
+var ErrSubscriberLagging = errors.New("subscriber is too slow")
+
+var ErrEventStreamClosed = errors.New("event stream closed")
+
+var ledgerEvents = map[string]bool{
+	EventTransactionCreated: true,
+	EventBudgetSet:          true,
+}
+
+func IsLedgerEvent(eventType string) bool {
+	return ledgerEvents[eventType]
+}
+
+type OutboxEvent struct {
+	ID          int64
+	Type        string
+	AggregateID int64
+	Payload     []byte
+	CreatedAt   time.Time
+	SpaceID     string
+}
+
+type EventSink interface {
+	Publish(ctx context.Context, events []OutboxEvent) error
+}
+
+type OutboxRepository interface {
+	// RelayOutbox hands the oldest unpublished events to publish and marks
+	// them published only if it succeeds.
+	RelayOutbox(ctx context.Context, limit int, publish func(context.Context, []OutboxEvent) error) (int, error)
+	ListEventsAfter(ctx context.Context, afterID int64, limit int) ([]OutboxEvent, error)
+	// ListSpaceEventsAfter is ListEventsAfter for the events of one space.
+	ListSpaceEventsAfter(ctx context.Context, spaceID string, afterID int64, limit int) ([]OutboxEvent, error)
+	LatestEventID(ctx context.Context) (int64, error)
+}
+
+type EventStream interface {
+	// Stream calls send for every event of spaceID after afterID, first from
+	// history and then live, until ctx is done or send fails.
+	Stream(ctx context.Context, spaceID string, afterID int64, types []string, send func(OutboxEvent) error) error
+}
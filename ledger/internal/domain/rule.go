@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+type Rule struct {
+	ID                  int64   `json:"id"`
+	Priority            int32   `json:"priority"`
+	DescriptionRegex    string  `json:"description_regex"`
+	DescriptionContains string  `json:"description_contains"`
+	MinAmount           float64 `json:"min_amount"`
+	MaxAmount           float64 `json:"max_amount"`
+	CategoryID          int64   `json:"category_id"`
+	Category            string  `json:"category"`
+	Description         string  `json:"description"`
+
+	re *regexp.Regexp
+}
+
+type RuleMatch struct {
+	Transaction Transaction
+	CategoryID  int64
+	Category    string
+	Description string
+}
+
+func (r *Rule) Validate() error {
+	if r.CategoryID == 0 && r.Category == "" {
+		return errors.New("invalid category")
+	}
+	if r.DescriptionRegex == "" && r.DescriptionContains == "" && r.MinAmount == 0 && r.MaxAmount == 0 {
+		return errors.New("rule must have at least one condition")
+	}
+	if err := r.Compile(); err != nil {
+		return errors.New("invalid description regex")
+	}
+	if r.MinAmount < 0 || r.MaxAmount < 0 || (r.MaxAmount != 0 && r.MinAmount > r.MaxAmount) {
+		return errors.New("invalid amount range")
+	}
+	return nil
+}
+
+// Compile prepares DescriptionRegex for Apply, which otherwise compiles it
+// on every call.
+func (r *Rule) Compile() error {
+	r.re = nil
+	if r.DescriptionRegex == "" {
+		return nil
+	}
+	re, err := regexp.Compile(r.DescriptionRegex)
+	if err != nil {
+		return err
+	}
+	r.re = re
+	return nil
+}
+
+func (r *Rule) Apply(tx *Transaction) bool {
+	if r.MinAmount != 0 && tx.Amount < r.MinAmount {
+		return false
+	}
+	if r.MaxAmount != 0 && tx.Amount > r.MaxAmount {
+		return false
+	}
+	if r.DescriptionContains != "" && !strings.Contains(strings.ToLower(tx.Description), strings.ToLower(r.DescriptionContains)) {
+		return false
+	}
+	description := r.Description
+	if r.DescriptionRegex != "" {
+		re := r.re
+		if re == nil {
+			var err error
+			if re, err = regexp.Compile(r.DescriptionRegex); err != nil {
+				return false
+			}
+		}
+		match := re.FindStringSubmatchIndex(tx.Description)
+		if match == nil {
+			return false
+		}
+		description = string(re.ExpandString(nil, r.Description, tx.Description, match))
+	}
+	tx.CategoryID = r.CategoryID
+	tx.Category = r.Category
+	if description != "" {
+		tx.Description = description
+	}
+	return true
+}
+
+// CompileRules compiles the regex of every rule. A rule whose regex does not
+// compile is left as is and never matches.
+func CompileRules(rules []Rule) {
+	for i := range rules {
+		_ = rules[i].Compile()
+	}
+}
+
+func ApplyRules(rules []Rule, tx *Transaction) *Rule {
+	for i := range rules {
+		if rules[i].Apply(tx) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+type RuleRepository interface {
+	AddRule(ctx context.Context, rule *Rule) (int64, error)
+	UpdateRule(ctx context.Context, rule *Rule) error
+	DeleteRule(ctx context.Context, id int64) error
+	GetRule(ctx context.Context, id int64) (*Rule, error)
+	ListRules(ctx context.Context) ([]Rule, error)
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var ErrInvalidSearch = errors.New("invalid search")
+
+var searchLanguages = map[string]bool{
+	"simple":  true,
+	"english": true,
+	"russian": true,
+}
+
+func IsSearchLanguage(language string) bool {
+	return searchLanguages[language]
+}
+
+type TransactionSearch struct {
+	Query      string
+	Language   string
+	From       string
+	To         string
+	CategoryID int64
+	Limit      int
+}
+
+type SearchResult struct {
+	Transaction Transaction
+	Rank        float64
+}
+
+func (s *TransactionSearch) Validate() error {
+	if s.TSQuery() == "" {
+		return fmt.Errorf("%w: query is required", ErrInvalidSearch)
+	}
+	if !IsSearchLanguage(s.Language) {
+		return fmt.Errorf("%w: unsupported search language", ErrInvalidSearch)
+	}
+	for _, date := range []string{s.From, s.To} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return fmt.Errorf("%w: invalid date", ErrInvalidSearch)
+		}
+	}
+	if s.Limit < 0 || s.Limit > 500 {
+		return fmt.Errorf("%w: invalid limit", ErrInvalidSearch)
+	}
+	return nil
+}
+
+// TSQuery turns free text into a prefix-matching tsquery: every word must
+// match the start of a lexeme, so "pharm mar" finds "Pharmacy, March".
+// Stray single letters such as the "s" in "March's" are dropped.
+func (s *TransactionSearch) TSQuery() string {
+	words := strings.FieldsFunc(s.Query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var terms []string
+	for _, word := range words {
+		if r := []rune(word); len(r) == 1 && unicode.IsLetter(r[0]) {
+			continue
+		}
+		terms = append(terms, strings.ToLower(word)+":*")
+	}
+	return strings.Join(terms, " & ")
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Snapshot holds per-category totals per month of every expense recorded up
+// to TakenAt. A report as of some moment starts from the newest snapshot
+// taken before it and adds what was recorded in between. Pruned counts the
+// older snapshots dropped when it was taken.
+type Snapshot struct {
+	ID      int64
+	TakenAt time.Time
+	Rows    int64
+	Pruned  int64
+}
+
+// DefaultSnapshotsKept is how many of the newest snapshots are kept; reports
+// as of a moment before the oldest of them read expenses from the start.
+const DefaultSnapshotsKept = 30
+
+type SnapshotRepository interface {
+	// CreateSnapshot builds a snapshot unless one newer than minAge exists or
+	// another instance is building one, in which case it returns nil. It
+	// drops the snapshots beyond the newest kept ones in the same transaction.
+	CreateSnapshot(ctx context.Context, minAge time.Duration) (*Snapshot, error)
+}
+
+// SummaryBounds splits the period [from, to] into the whole calendar months
+// it covers, [fullFrom, fullTo), which are read from a snapshot, and the
+// partial months at its edges. Without a whole month fullFrom equals fullTo.
+func SummaryBounds(from time.Time, to time.Time) (fullFrom time.Time, fullTo time.Time) {
+	fullFrom = time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if !fullFrom.Equal(from) {
+		fullFrom = fullFrom.AddDate(0, 1, 0)
+	}
+	fullTo = time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if fullTo.AddDate(0, 1, -1).Equal(to) {
+		fullTo = fullTo.AddDate(0, 1, 0)
+	}
+	if !fullFrom.Before(fullTo) {
+		return from, from
+	}
+	return fullFrom, fullTo
+}
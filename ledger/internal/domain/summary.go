@@ -1,12 +1,25 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+const (
+	SummaryGroupByCategory = "category"
+	SummaryGroupByTag      = "tag"
+)
 
 type Summary struct {
 	Categories  map[string]float64 `json:"categories"`
+	Tags        map[string]float64 `json:"tags"`
+	Tree        []CategoryNode     `json:"tree"`
 	CacheResult bool
 }
 
 type SummaryRepository interface {
-	GetSummary(ctx context.Context, from string, to string) (*Summary, error)
+	// GetSummary and GetTagSummary count only what was recorded before asOf;
+	// a zero asOf counts everything.
+	GetSummary(ctx context.Context, from string, to string, asOf time.Time) (*Summary, error)
+	GetTagSummary(ctx context.Context, from string, to string, asOf time.Time) (*Summary, error)
 }
@@ -2,31 +2,119 @@ package domain
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
 )
 
 type Transaction struct {
 	ID          int64
 	Amount      float64
+	CategoryID  int64
 	Category    string
 	Description string
 	Date        string
+	Tags        []string
+	CreatedBy   string
+	// Kind is TransactionKindExpense or TransactionKindGoalContribution.
+	Kind           string
+	ExternalID     string
+	IdempotencyKey string
+	RequestHash    string
+	Anomaly        *AnomalyScore
+	// Events go to webhooks along with transaction.created, queued in the
+	// same database transaction as the insert.
+	Events []Event
+}
+
+type TransactionFilter struct {
+	Tag   string
+	Limit int
 }
 
 func (tx *Transaction) Validate() error {
 	if tx.Amount <= 0 {
 		return errors.New("invalid amount")
 	}
-	if tx.Category == "" {
+	if tx.Category == "" && tx.CategoryID == 0 {
 		return errors.New("invalid category")
 	}
+	for _, tag := range tx.Tags {
+		if strings.TrimSpace(tag) == "" {
+			return errors.New("invalid tag")
+		}
+	}
+	return nil
+}
+
+func (tx *Transaction) Fingerprint() string {
+	data, _ := json.Marshal([]any{tx.Amount, tx.CategoryID, tx.Category, tx.Description, tx.Date, tx.Tags, tx.ExternalID})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+type DuplicateTransactionError struct {
+	ID int64
+}
+
+func (e *DuplicateTransactionError) Error() string {
+	return fmt.Sprintf("duplicate transaction: matches transaction %d", e.ID)
+}
+
+func FindDuplicate(tx *Transaction, candidates []Transaction) *Transaction {
+	for i := range candidates {
+		c := &candidates[i]
+		if tx.ExternalID != "" && c.ExternalID != "" {
+			if tx.ExternalID == c.ExternalID {
+				return c
+			}
+			continue
+		}
+		if math.Abs(tx.Amount-c.Amount) < 0.005 && SimilarDescriptions(tx.Description, c.Description) {
+			return c
+		}
+	}
 	return nil
 }
 
+func SimilarDescriptions(a string, b string) bool {
+	ta, tb := descriptionTokens(a), descriptionTokens(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return len(ta) == len(tb)
+	}
+	common := 0
+	for token := range ta {
+		if tb[token] {
+			common++
+		}
+	}
+	return float64(common)/float64(len(ta)+len(tb)-common) >= 0.5
+}
+
+func descriptionTokens(s string) map[string]bool {
+	tokens := make(map[string]bool)
+	for _, field := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if strings.IndexFunc(field, unicode.IsLetter) < 0 {
+			continue
+		}
+		tokens[field] = true
+	}
+	return tokens
+}
+
 type TransactionRepository interface {
 	AddTransaction(transaction *Transaction, ctx context.Context) (int64, error)
 	GetTransaction(id int64, ctx context.Context) (*Transaction, error)
-	ListTransactions(ctx context.Context) ([]Transaction, error)
-	GetAmountTransactionByCategory(category string, ctx context.Context) (float64, error)
-	GetAmountTransactionByCategoryAndMonth(ctx context.Context, category string, date string) (float64, error)
+	ListTransactions(ctx context.Context, filter TransactionFilter) ([]Transaction, error)
+	SearchTransactions(ctx context.Context, search TransactionSearch) ([]SearchResult, error)
+	GetAmountTransactionByCategory(categoryID int64, ctx context.Context) (float64, error)
+	GetAmountTransactionByCategoryAndMonth(ctx context.Context, categoryID int64, date string) (float64, error)
+	FindDuplicateCandidates(ctx context.Context, transaction *Transaction, windowDays int) ([]Transaction, error)
 }
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	EventBudgetThresholdReached = "budget.threshold_reached"
+	EventBudgetExceeded         = "budget.exceeded"
+	EventTransactionCreated     = "transaction.created"
+	EventBudgetSet              = "budget.set"
+)
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+var webhookEvents = map[string]bool{
+	EventBudgetThresholdReached: true,
+	EventBudgetExceeded:         true,
+	EventTransactionCreated:     true,
+}
+
+// Webhook is audited as JSON, without its secret.
+type Webhook struct {
+	ID     int64    `json:"id"`
+	URL    string   `json:"url"`
+	Secret string   `json:"-"`
+	Events []string `json:"events"`
+}
+
+// Event is queued for every webhook subscribed to its type. A non-empty Key
+// makes the enqueue idempotent: a webhook gets one delivery per key.
+type Event struct {
+	Type    string
+	Payload any
+	Key     string
+}
+
+type TransactionEvent struct {
+	ID          int64    `json:"id"`
+	Amount      float64  `json:"amount"`
+	CategoryID  int64    `json:"category_id"`
+	Category    string   `json:"category"`
+	Description string   `json:"description"`
+	Date        string   `json:"date"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+func NewTransactionEvent(transaction *Transaction) TransactionEvent {
+	return TransactionEvent{
+		ID:          transaction.ID,
+		Amount:      transaction.Amount,
+		CategoryID:  transaction.CategoryID,
+		Category:    transaction.Category,
+		Description: transaction.Description,
+		Date:        transaction.Date,
+		Tags:        transaction.Tags,
+	}
+}
+
+type BudgetEvent struct {
+	CategoryID int64   `json:"category_id"`
+	Category   string  `json:"category"`
+	Limit      float64 `json:"limit"`
+	Spent      float64 `json:"spent"`
+	Amount     float64 `json:"amount"`
+	Date       string  `json:"date"`
+}
+
+type WebhookDelivery struct {
+	ID             int64
+	WebhookID      int64
+	URL            string
+	Secret         string
+	Event          string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	LastStatusCode int
+	LastError      string
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+func (w *Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("invalid url")
+	}
+	if w.Secret == "" {
+		return errors.New("secret is required")
+	}
+	if len(w.Events) == 0 {
+		return errors.New("at least one event is required")
+	}
+	for _, event := range w.Events {
+		if !webhookEvents[event] {
+			return errors.New("unknown event " + strconv.Quote(event))
+		}
+	}
+	return nil
+}
+
+// SignWebhook signs the timestamp together with the body so a captured
+// request can not be replayed later with a fresh timestamp.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// BudgetThresholdCrossed reports whether spending moved from below the
+// threshold share of the limit to at or above it.
+func BudgetThresholdCrossed(limit float64, before float64, after float64, threshold float64) bool {
+	mark := limit * threshold
+	return before < mark && after >= mark
+}
+
+type WebhookRepository interface {
+	AddWebhook(ctx context.Context, webhook *Webhook) (int64, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	EnqueueEvent(ctx context.Context, event Event) error
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]WebhookDelivery, error)
+}
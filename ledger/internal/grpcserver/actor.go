@@ -0,0 +1,56 @@
+package grpcserver
+
+import (
+	"context"
+	"ledger/internal/domain"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys the gateway fills from the authenticated request.
+const (
+	userIDKey    = "x-user-id"
+	spaceIDKey   = "x-space-id"
+	requestIDKey = "x-request-id"
+)
+
+func metadataValue(ctx context.Context, key string) string {
+	values := metadata.ValueFromIncomingContext(ctx, key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func callerID(ctx context.Context) string {
+	return metadataValue(ctx, userIDKey)
+}
+
+func withActor(ctx context.Context, method string) context.Context {
+	return domain.WithActor(ctx, domain.Actor{
+		UserID:    callerID(ctx),
+		SpaceID:   metadataValue(ctx, spaceIDKey),
+		RequestID: metadataValue(ctx, requestIDKey),
+		Method:    method,
+	})
+}
+
+// ActorUnaryInterceptor puts the calling user and request id into the
+// context, where repositories pick them up for the audit log.
+func ActorUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(withActor(ctx, info.FullMethod), req)
+}
+
+func ActorStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &actorStream{ServerStream: stream, ctx: withActor(stream.Context(), info.FullMethod)})
+}
+
+type actorStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *actorStream) Context() context.Context {
+	return s.ctx
+}
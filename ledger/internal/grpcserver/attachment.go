@@ -0,0 +1,141 @@
+package grpcserver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"ledger/internal/domain"
+	pb "ledger/internal/pb/ledger/v1"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const attachmentChunkSize = 64 << 10
+
+func (s *LedgerServer) AttachmentUpload(stream pb.LedgerService_AttachmentUploadServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "upload attachment: %v", err)
+	}
+	info := req.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "attachment info is required")
+	}
+	if info.GetTransactionId() == 0 {
+		return status.Error(codes.InvalidArgument, "transaction_id is required")
+	}
+	if info.GetFileName() == "" {
+		return status.Error(codes.InvalidArgument, "file_name is required")
+	}
+	attachment := domain.Attachment{
+		TransactionID: info.GetTransactionId(),
+		FileName:      info.GetFileName(),
+	}
+	id, err := s.ledgerService.AttachmentUpload(stream.Context(), &attachment, &uploadReader{stream: stream})
+	if err != nil {
+		return attachmentError("upload attachment", "transaction not found", err)
+	}
+	attachment.ID = id
+	return stream.SendAndClose(attachmentToPb(attachment))
+}
+
+func (s *LedgerServer) AttachmentDownload(req *pb.AttachmentRequest, stream pb.LedgerService_AttachmentDownloadServer) error {
+	if req.GetTransactionId() == 0 || req.GetId() == 0 {
+		return status.Error(codes.InvalidArgument, "transaction_id and id are required")
+	}
+	attachment, data, err := s.ledgerService.AttachmentGet(stream.Context(), req.GetTransactionId(), req.GetId())
+	if err != nil {
+		return attachmentError("download attachment", "attachment not found", err)
+	}
+	defer data.Close()
+	err = stream.Send(&pb.AttachmentChunk{Payload: &pb.AttachmentChunk_Info{Info: attachmentToPb(*attachment)}})
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, attachmentChunkSize)
+	for {
+		n, err := data.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&pb.AttachmentChunk{Payload: &pb.AttachmentChunk_Chunk{Chunk: buf[:n]}}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "download attachment: %v", err)
+		}
+	}
+}
+
+func (s *LedgerServer) AttachmentDelete(ctx context.Context, req *pb.AttachmentRequest) (*emptypb.Empty, error) {
+	if req.GetTransactionId() == 0 || req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "transaction_id and id are required")
+	}
+	err := s.ledgerService.AttachmentDelete(ctx, req.GetTransactionId(), req.GetId())
+	if err != nil {
+		return nil, attachmentError("delete attachment", "attachment not found", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *LedgerServer) AttachmentsList(ctx context.Context, req *pb.AttachmentsListRequest) (*pb.AttachmentListResponse, error) {
+	if req.GetTransactionId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "transaction_id is required")
+	}
+	attachments, err := s.ledgerService.AttachmentsList(ctx, req.GetTransactionId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list attachments: %v", err)
+	}
+	resp := &pb.AttachmentListResponse{}
+	for _, attachment := range attachments {
+		resp.Attachments = append(resp.Attachments, attachmentToPb(attachment))
+	}
+	return resp, nil
+}
+
+func attachmentError(action string, notFound string, err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, notFound)
+	case errors.Is(err, domain.ErrAttachmentTooLarge):
+		return status.Errorf(codes.ResourceExhausted, "%s: %v", action, err)
+	case errors.Is(err, domain.ErrUnsupportedAttachmentType):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", action, err)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", action, err)
+}
+
+func attachmentToPb(attachment domain.Attachment) *pb.Attachment {
+	return &pb.Attachment{
+		Id:            attachment.ID,
+		TransactionId: attachment.TransactionID,
+		FileName:      attachment.FileName,
+		ContentType:   attachment.ContentType,
+		Size:          attachment.Size,
+		CreatedAt:     attachment.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type uploadReader struct {
+	stream pb.LedgerService_AttachmentUploadServer
+	buf    []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = req.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,172 @@
+package grpcserver
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"ledger/internal/domain"
+
+	pb "ledger/internal/pb/ledger/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeUploadStream struct {
+	grpc.ServerStream
+	requests []*pb.AttachmentUploadRequest
+	response *pb.Attachment
+}
+
+func (f *fakeUploadStream) Context() context.Context {
+	return context.Background()
+}
+
+func (f *fakeUploadStream) Recv() (*pb.AttachmentUploadRequest, error) {
+	if len(f.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := f.requests[0]
+	f.requests = f.requests[1:]
+	return req, nil
+}
+
+func (f *fakeUploadStream) SendAndClose(resp *pb.Attachment) error {
+	f.response = resp
+	return nil
+}
+
+type fakeDownloadStream struct {
+	grpc.ServerStream
+	chunks []*pb.AttachmentChunk
+}
+
+func (f *fakeDownloadStream) Context() context.Context {
+	return context.Background()
+}
+
+func (f *fakeDownloadStream) Send(chunk *pb.AttachmentChunk) error {
+	f.chunks = append(f.chunks, chunk)
+	return nil
+}
+
+func TestLedgerServer_AttachmentUpload(t *testing.T) {
+	info := &pb.AttachmentUploadRequest{Payload: &pb.AttachmentUploadRequest_Info{Info: &pb.Attachment{TransactionId: 3, FileName: "receipt.pdf"}}}
+	chunk := func(data string) *pb.AttachmentUploadRequest {
+		return &pb.AttachmentUploadRequest{Payload: &pb.AttachmentUploadRequest_Chunk{Chunk: []byte(data)}}
+	}
+	createdAt := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		requests    []*pb.AttachmentUploadRequest
+		mockSetup   func(*MockLedgerServiceImpl)
+		expected    *pb.Attachment
+		expectedErr error
+	}{
+		{
+			name:     "chunks joined",
+			requests: []*pb.AttachmentUploadRequest{info, chunk("%PDF-1.4 "), chunk("body")},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("AttachmentUpload", mock.Anything, mock.MatchedBy(func(a *domain.Attachment) bool {
+					return a.TransactionID == 3 && a.FileName == "receipt.pdf"
+				}), []byte("%PDF-1.4 body")).Run(func(args mock.Arguments) {
+					a := args.Get(1).(*domain.Attachment)
+					a.ContentType = "application/pdf"
+					a.Size = 13
+					a.CreatedAt = createdAt
+				}).Return(int64(5), nil)
+			},
+			expected: &pb.Attachment{Id: 5, TransactionId: 3, FileName: "receipt.pdf", ContentType: "application/pdf", Size: 13, CreatedAt: "2025-12-01T10:00:00Z"},
+		},
+		{
+			name:        "info missing",
+			requests:    []*pb.AttachmentUploadRequest{chunk("data")},
+			mockSetup:   func(m *MockLedgerServiceImpl) {},
+			expectedErr: status.Error(codes.InvalidArgument, "attachment info is required"),
+		},
+		{
+			name:     "unsupported type",
+			requests: []*pb.AttachmentUploadRequest{info, chunk("hello")},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("AttachmentUpload", mock.Anything, mock.Anything, []byte("hello")).Return(int64(0), domain.ErrUnsupportedAttachmentType)
+			},
+			expectedErr: status.Error(codes.InvalidArgument, "upload attachment: unsupported attachment type"),
+		},
+		{
+			name:     "too large",
+			requests: []*pb.AttachmentUploadRequest{info, chunk("big")},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("AttachmentUpload", mock.Anything, mock.Anything, []byte("big")).Return(int64(0), domain.ErrAttachmentTooLarge)
+			},
+			expectedErr: status.Error(codes.ResourceExhausted, "upload attachment: attachment too large"),
+		},
+		{
+			name:     "transaction not found",
+			requests: []*pb.AttachmentUploadRequest{info},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("AttachmentUpload", mock.Anything, mock.Anything, []byte{}).Return(int64(0), sql.ErrNoRows)
+			},
+			expectedErr: status.Error(codes.NotFound, "transaction not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerServiceImpl{}
+			tt.mockSetup(mockService)
+			server := NewLedgerServer(mockService)
+			stream := &fakeUploadStream{requests: tt.requests}
+
+			err := server.AttachmentUpload(stream)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, stream.response)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerServer_AttachmentDownload(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	attachment := &domain.Attachment{ID: 5, TransactionID: 3, FileName: "receipt.png", ContentType: "image/png", Size: 4, CreatedAt: time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)}
+	mockService.On("AttachmentGet", mock.Anything, int64(3), int64(5)).Return(attachment, io.NopCloser(strings.NewReader("\x89PNG")), nil)
+	mockService.On("AttachmentGet", mock.Anything, int64(3), int64(6)).Return(nil, nil, sql.ErrNoRows)
+
+	stream := &fakeDownloadStream{}
+	err := server.AttachmentDownload(&pb.AttachmentRequest{TransactionId: 3, Id: 5}, stream)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.AttachmentChunk{
+		{Payload: &pb.AttachmentChunk_Info{Info: &pb.Attachment{Id: 5, TransactionId: 3, FileName: "receipt.png", ContentType: "image/png", Size: 4, CreatedAt: "2025-12-01T10:00:00Z"}}},
+		{Payload: &pb.AttachmentChunk_Chunk{Chunk: []byte("\x89PNG")}},
+	}, stream.chunks)
+
+	err = server.AttachmentDownload(&pb.AttachmentRequest{TransactionId: 3, Id: 6}, &fakeDownloadStream{})
+
+	assert.Equal(t, status.Error(codes.NotFound, "attachment not found"), err)
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerServer_AttachmentDelete(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	mockService.On("AttachmentDelete", mock.Anything, int64(3), int64(5)).Return(nil)
+
+	_, err := server.AttachmentDelete(context.Background(), &pb.AttachmentRequest{TransactionId: 3, Id: 5})
+	assert.NoError(t, err)
+
+	_, err = server.AttachmentDelete(context.Background(), &pb.AttachmentRequest{TransactionId: 3})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "transaction_id and id are required"), err)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,71 @@
+package grpcserver
+
+import (
+	"context"
+	"ledger/internal/domain"
+	pb "ledger/internal/pb/ledger/v1"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *LedgerServer) AuditList(ctx context.Context, req *pb.AuditListRequest) (*pb.AuditListResponse, error) {
+	filter := domain.AuditFilter{
+		EntityType:  req.GetEntityType(),
+		EntityID:    req.GetEntityId(),
+		ActorUserID: req.GetActorUserId(),
+		RequestID:   req.GetRequestId(),
+		Action:      req.GetAction(),
+		BeforeID:    req.GetBeforeId(),
+		Limit:       int(req.GetLimit()),
+	}
+	var err error
+	if filter.From, err = parseTimeBound(req.GetFrom(), false); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "from must be YYYY-MM-DD or RFC3339")
+	}
+	if filter.To, err = parseTimeBound(req.GetTo(), true); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "to must be YYYY-MM-DD or RFC3339")
+	}
+	if filter.Limit == 0 {
+		filter.Limit = 100
+	}
+	if err = filter.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	entries, err := s.ledgerService.AuditList(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list audit log: %v", err)
+	}
+	resp := &pb.AuditListResponse{}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, &pb.AuditEntry{
+			Id:          entry.ID,
+			Action:      entry.Action,
+			EntityType:  entry.EntityType,
+			EntityId:    entry.EntityID,
+			ActorUserId: entry.Actor.UserID,
+			RequestId:   entry.Actor.RequestID,
+			Method:      entry.Actor.Method,
+			Before:      string(entry.Before),
+			After:       string(entry.After),
+			CreatedAt:   entry.CreatedAt.Format(time.RFC3339Nano),
+		})
+	}
+	return resp, nil
+}
+
+// parseTimeBound accepts a date or a timestamp. A date used as the upper
+// bound covers the whole day.
+func parseTimeBound(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		if end {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,108 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ledger/internal/domain"
+
+	pb "ledger/internal/pb/ledger/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestLedgerServer_AuditList(t *testing.T) {
+	createdAt := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		req          *pb.AuditListRequest
+		mockSetup    func(m *MockLedgerServiceImpl)
+		expectedCode codes.Code
+		expected     *pb.AuditListResponse
+	}{
+		{
+			name: "filters and dates",
+			req:  &pb.AuditListRequest{EntityType: "budget", EntityId: 1, ActorUserId: "98ac2ffe", From: "2025-12-01", To: "2025-12-31"},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("AuditList", mock.Anything, domain.AuditFilter{
+					EntityType:  "budget",
+					EntityID:    1,
+					ActorUserID: "98ac2ffe",
+					From:        time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+					To:          time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+					Limit:       100,
+				}).Return([]domain.AuditEntry{{
+					ID:         9,
+					Action:     "budget.set",
+					EntityType: "budget",
+					EntityID:   1,
+					Actor:      domain.Actor{UserID: "98ac2ffe", RequestID: "req-1", Method: "/ledger.v1.LedgerService/BudgetAdd"},
+					Before:     []byte(`{"limit": 800}`),
+					After:      []byte(`{"limit": 1000}`),
+					CreatedAt:  createdAt,
+				}}, nil)
+			},
+			expectedCode: codes.OK,
+			expected: &pb.AuditListResponse{Entries: []*pb.AuditEntry{{
+				Id:          9,
+				Action:      "budget.set",
+				EntityType:  "budget",
+				EntityId:    1,
+				ActorUserId: "98ac2ffe",
+				RequestId:   "req-1",
+				Method:      "/ledger.v1.LedgerService/BudgetAdd",
+				Before:      `{"limit": 800}`,
+				After:       `{"limit": 1000}`,
+				CreatedAt:   "2025-12-10T12:00:00Z",
+			}}},
+		},
+		{
+			name:         "invalid from",
+			req:          &pb.AuditListRequest{From: "yesterday"},
+			mockSetup:    func(m *MockLedgerServiceImpl) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "limit too large",
+			req:          &pb.AuditListRequest{Limit: 1000},
+			mockSetup:    func(m *MockLedgerServiceImpl) {},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerServiceImpl{}
+			tt.mockSetup(mockService)
+			server := NewLedgerServer(mockService)
+
+			resp, err := server.AuditList(context.Background(), tt.req)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expected != nil {
+				assert.Equal(t, tt.expected.Entries, resp.Entries)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestActorUnaryInterceptor(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-id", "98ac2ffe", "x-space-id", "5f0c2a8e-3b1d-4c7a-9e21-7d4b6a1c0f93", "x-request-id", "req-1"))
+	var actor domain.Actor
+
+	_, err := ActorUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/ledger.v1.LedgerService/BudgetAdd"}, func(ctx context.Context, req any) (any, error) {
+		actor = domain.ActorFromContext(ctx)
+		return nil, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Actor{UserID: "98ac2ffe", SpaceID: "5f0c2a8e-3b1d-4c7a-9e21-7d4b6a1c0f93", RequestID: "req-1", Method: "/ledger.v1.LedgerService/BudgetAdd"}, actor)
+}
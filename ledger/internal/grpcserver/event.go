@@ -0,0 +1,49 @@
+package grpcserver
+
+import (
+	"errors"
+	"ledger/internal/domain"
+	pb "ledger/internal/pb/ledger/v1"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *LedgerServer) Subscribe(req *pb.SubscribeRequest, stream pb.LedgerService_SubscribeServer) error {
+	ctx := stream.Context()
+	if callerID(ctx) == "" {
+		return status.Error(codes.Unauthenticated, "caller user id is required")
+	}
+	if req.GetFromEventId() < 0 {
+		return status.Error(codes.InvalidArgument, "from_event_id must not be negative")
+	}
+	for _, t := range req.GetTypes() {
+		if !domain.IsLedgerEvent(t) {
+			return status.Errorf(codes.InvalidArgument, "unknown event type %q", t)
+		}
+	}
+	err := s.ledgerService.Subscribe(ctx, req.GetFromEventId(), req.GetTypes(), func(event domain.OutboxEvent) error {
+		return stream.Send(&pb.LedgerEvent{
+			Id:          event.ID,
+			Type:        event.Type,
+			AggregateId: event.AggregateID,
+			Payload:     string(event.Payload),
+			CreatedAt:   event.CreatedAt.Format(time.RFC3339Nano),
+		})
+	})
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domain.ErrSubscriberLagging):
+		return status.Error(codes.ResourceExhausted, "subscriber is too slow, resume from the last received event")
+	case errors.Is(err, domain.ErrEventStreamClosed):
+		return status.Error(codes.Unavailable, "server is shutting down, resume from the last received event")
+	case ctx.Err() != nil:
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Errorf(codes.Internal, "subscribe: %v", err)
+}
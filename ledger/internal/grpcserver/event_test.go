@@ -0,0 +1,104 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ledger/internal/domain"
+
+	pb "ledger/internal/pb/ledger/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeSubscribeStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events []*pb.LedgerEvent
+}
+
+func (f *fakeSubscribeStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeSubscribeStream) Send(event *pb.LedgerEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+func TestLedgerServer_Subscribe(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-id", "98ac2ffe"))
+	createdAt := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		req          *pb.SubscribeRequest
+		mockSetup    func(m *MockLedgerServiceImpl)
+		expectedCode codes.Code
+		expectedIDs  []int64
+	}{
+		{
+			name: "resume streams events",
+			ctx:  ctx,
+			req:  &pb.SubscribeRequest{FromEventId: 3, Types: []string{"transaction.created"}},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("Subscribe", ctx, int64(3), []string{"transaction.created"}, mock.Anything).Return([]domain.OutboxEvent{
+					{ID: 4, Type: "transaction.created", AggregateID: 15, Payload: []byte(`{"id":15}`), CreatedAt: createdAt},
+					{ID: 6, Type: "transaction.created", AggregateID: 16, Payload: []byte(`{"id":16}`), CreatedAt: createdAt},
+				}, nil)
+			},
+			expectedCode: codes.OK,
+			expectedIDs:  []int64{4, 6},
+		},
+		{
+			name: "slow subscriber",
+			ctx:  ctx,
+			req:  &pb.SubscribeRequest{},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("Subscribe", ctx, int64(0), []string(nil), mock.Anything).Return([]domain.OutboxEvent{{ID: 1, CreatedAt: createdAt}}, domain.ErrSubscriberLagging)
+			},
+			expectedCode: codes.ResourceExhausted,
+			expectedIDs:  []int64{1},
+		},
+		{
+			name:         "missing caller",
+			ctx:          context.Background(),
+			req:          &pb.SubscribeRequest{},
+			mockSetup:    func(m *MockLedgerServiceImpl) {},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "unknown type",
+			ctx:          ctx,
+			req:          &pb.SubscribeRequest{Types: []string{"budget.deleted"}},
+			mockSetup:    func(m *MockLedgerServiceImpl) {},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerServiceImpl{}
+			tt.mockSetup(mockService)
+			server := NewLedgerServer(mockService)
+			stream := &fakeSubscribeStream{ctx: tt.ctx}
+
+			err := server.Subscribe(tt.req, stream)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			var ids []int64
+			for _, event := range stream.events {
+				ids = append(ids, event.Id)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
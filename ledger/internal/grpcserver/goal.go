@@ -0,0 +1,128 @@
+package grpcserver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"ledger/internal/domain"
+	pb "ledger/internal/pb/ledger/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *LedgerServer) GoalAdd(ctx context.Context, req *pb.Goal) (*pb.GoalAddResponse, error) {
+	goal := goalFromPb(req)
+	if err := goal.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	id, err := s.ledgerService.GoalAdd(ctx, goal)
+	if err != nil {
+		return nil, goalError("add goal", err)
+	}
+	return &pb.GoalAddResponse{Id: id}, nil
+}
+
+func (s *LedgerServer) GoalUpdate(ctx context.Context, req *pb.Goal) (*emptypb.Empty, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	goal := goalFromPb(req)
+	if err := goal.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	err := s.ledgerService.GoalUpdate(ctx, goal)
+	if err != nil {
+		return nil, goalError("update goal", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *LedgerServer) GoalDelete(ctx context.Context, req *pb.GoalRequest) (*emptypb.Empty, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	err := s.ledgerService.GoalDelete(ctx, req.GetId())
+	if err != nil {
+		return nil, goalError("delete goal", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *LedgerServer) GoalGet(ctx context.Context, req *pb.GoalRequest) (*pb.GoalProgress, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	progress, err := s.ledgerService.GoalGet(ctx, req.GetId())
+	if err != nil {
+		return nil, goalError("get goal", err)
+	}
+	return goalProgressToPb(*progress), nil
+}
+
+func (s *LedgerServer) GoalsList(ctx context.Context, _ *emptypb.Empty) (*pb.GoalListResponse, error) {
+	goals, err := s.ledgerService.GoalsList(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list goals: %v", err)
+	}
+	resp := &pb.GoalListResponse{}
+	for _, progress := range goals {
+		resp.Goals = append(resp.Goals, goalProgressToPb(progress))
+	}
+	return resp, nil
+}
+
+func (s *LedgerServer) GoalContribute(ctx context.Context, req *pb.GoalContributeRequest) (*pb.TransactionAddResponse, error) {
+	if req.GetGoalId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "goal_id is required")
+	}
+	if req.GetAmount() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	id, err := s.ledgerService.GoalContribute(ctx, &domain.GoalContribution{
+		GoalID:      req.GetGoalId(),
+		Amount:      float64(req.GetAmount()),
+		Date:        req.GetDate(),
+		Description: req.GetDescription(),
+	})
+	if err != nil {
+		return nil, goalError("contribute to goal", err)
+	}
+	return &pb.TransactionAddResponse{Id: id}, nil
+}
+
+func goalError(action string, err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, "goal not found")
+	case errors.Is(err, domain.ErrGoalHasContributions):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", action, err)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", action, err)
+}
+
+func goalFromPb(goal *pb.Goal) *domain.Goal {
+	return &domain.Goal{
+		ID:           goal.GetId(),
+		Name:         goal.GetName(),
+		TargetAmount: float64(goal.GetTargetAmount()),
+		Deadline:     goal.GetDeadline(),
+	}
+}
+
+func goalProgressToPb(progress domain.GoalProgress) *pb.GoalProgress {
+	return &pb.GoalProgress{
+		Goal: &pb.Goal{
+			Id:           progress.Goal.ID,
+			Name:         progress.Goal.Name,
+			TargetAmount: float32(progress.Goal.TargetAmount),
+			Deadline:     progress.Goal.Deadline,
+		},
+		Saved:           float32(progress.Saved),
+		Remaining:       float32(progress.Remaining),
+		Percent:         float32(progress.Percent),
+		MonthsLeft:      int32(progress.MonthsLeft),
+		MonthlyRequired: float32(progress.MonthlyRequired),
+	}
+}
@@ -0,0 +1,84 @@
+package grpcserver
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"ledger/internal/domain"
+
+	pb "ledger/internal/pb/ledger/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLedgerServer_GoalAdd(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	mockService.On("GoalAdd", mock.Anything, &domain.Goal{Name: "Vacation", TargetAmount: 1200, Deadline: "2026-06-30"}).Return(int64(1), nil)
+
+	resp, err := server.GoalAdd(context.Background(), &pb.Goal{Name: "Vacation", TargetAmount: 1200, Deadline: "2026-06-30"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Id)
+
+	_, err = server.GoalAdd(context.Background(), &pb.Goal{Name: "Vacation", TargetAmount: 1200, Deadline: "30.06.2026"})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "invalid deadline"), err)
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerServer_GoalGet(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	progress := &domain.GoalProgress{
+		Goal:            domain.Goal{ID: 1, Name: "Vacation", TargetAmount: 1200, Deadline: "2026-06-30", Saved: 500},
+		Saved:           500,
+		Remaining:       700,
+		Percent:         41.67,
+		MonthsLeft:      7,
+		MonthlyRequired: 100,
+	}
+	mockService.On("GoalGet", mock.Anything, int64(1)).Return(progress, nil)
+	mockService.On("GoalGet", mock.Anything, int64(2)).Return(nil, sql.ErrNoRows)
+
+	resp, err := server.GoalGet(context.Background(), &pb.GoalRequest{Id: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "Vacation", resp.Goal.Name)
+	assert.Equal(t, float32(700), resp.Remaining)
+	assert.Equal(t, int32(7), resp.MonthsLeft)
+	assert.Equal(t, float32(100), resp.MonthlyRequired)
+
+	_, err = server.GoalGet(context.Background(), &pb.GoalRequest{Id: 2})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerServer_GoalDelete(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	mockService.On("GoalDelete", mock.Anything, int64(1)).Return(domain.ErrGoalHasContributions)
+
+	_, err := server.GoalDelete(context.Background(), &pb.GoalRequest{Id: 1})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerServer_GoalContribute(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	mockService.On("GoalContribute", mock.Anything, &domain.GoalContribution{GoalID: 1, Amount: 150, Date: "2025-12-11"}).Return(int64(42), nil)
+
+	resp, err := server.GoalContribute(context.Background(), &pb.GoalContributeRequest{GoalId: 1, Amount: 150, Date: "2025-12-11"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), resp.Id)
+
+	_, err = server.GoalContribute(context.Background(), &pb.GoalContributeRequest{GoalId: 1, Amount: -1})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "amount must be positive"), err)
+	mockService.AssertExpectations(t)
+}
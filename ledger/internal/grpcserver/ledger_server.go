@@ -2,6 +2,7 @@ package grpcserver
 
 import (
 	"context"
+	"errors"
 	"ledger/internal/domain"
 	pb "ledger/internal/pb/ledger/v1"
 	"ledger/internal/service"
@@ -23,15 +24,16 @@ func NewLedgerServer(svc service.LedgerService) *LedgerServer {
 }
 
 func (s *LedgerServer) BudgetAdd(ctx context.Context, req *pb.BudgetAddRequest) (*emptypb.Empty, error) {
-	if req.GetCategory() == "" {
+	if req.GetCategory() == "" && req.GetCategoryId() == 0 {
 		return nil, status.Error(codes.InvalidArgument, "category is required")
 	}
 	if req.GetLimit() == 0 {
 		return nil, status.Error(codes.InvalidArgument, "limit is required")
 	}
 	budget := domain.Budget{
-		Category: req.GetCategory(),
-		Limit:    float64(req.GetLimit()),
+		CategoryID: req.GetCategoryId(),
+		Category:   req.GetCategory(),
+		Limit:      float64(req.GetLimit()),
 	}
 	err := s.ledgerService.BudgetAdd(ctx, &budget)
 	if err != nil {
@@ -44,18 +46,28 @@ func (s *LedgerServer) BudgetGet(ctx context.Context, req *pb.BudgetGetRequest)
 	if req.GetCategory() == "" {
 		return nil, status.Error(codes.InvalidArgument, "category is required")
 	}
-	resp, err := s.ledgerService.BudgetGet(ctx, req.GetCategory())
+	asOf, err := parseTimeBound(req.GetAsOf(), true)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "as_of must be YYYY-MM-DD or RFC3339")
+	}
+	resp, err := s.ledgerService.BudgetGet(ctx, req.GetCategory(), asOf)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "get budget: %v", err)
 	}
 	return &pb.BudgetGetResponse{
-		Category: resp.Category,
-		Limit:    float32(resp.Limit),
+		Category:   resp.Category,
+		Limit:      float32(resp.Limit),
+		CategoryId: resp.CategoryID,
+		Spent:      float32(resp.Spent),
 	}, nil
 }
 
-func (s *LedgerServer) BudgetsList(ctx context.Context, _ *emptypb.Empty) (*pb.BudgetGetListResponse, error) {
-	budgets, err := s.ledgerService.BudgetsList(ctx)
+func (s *LedgerServer) BudgetsList(ctx context.Context, req *pb.BudgetsListRequest) (*pb.BudgetGetListResponse, error) {
+	asOf, err := parseTimeBound(req.GetAsOf(), true)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "as_of must be YYYY-MM-DD or RFC3339")
+	}
+	budgets, err := s.ledgerService.BudgetsList(ctx, asOf)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "get budgets: %v", err)
 	}
@@ -63,8 +75,10 @@ func (s *LedgerServer) BudgetsList(ctx context.Context, _ *emptypb.Empty) (*pb.B
 	pbBudgets := make([]*pb.BudgetGetResponse, 0)
 	for _, budget := range budgets {
 		pbBudgets = append(pbBudgets, &pb.BudgetGetResponse{
-			Category: budget.Category,
-			Limit:    float32(budget.Limit),
+			Category:   budget.Category,
+			Limit:      float32(budget.Limit),
+			CategoryId: budget.CategoryID,
+			Spent:      float32(budget.Spent),
 		})
 	}
 
@@ -77,9 +91,6 @@ func (s *LedgerServer) TransactionAdd(ctx context.Context, req *pb.TransactionAd
 	if req.GetAmount() == 0 {
 		return nil, status.Error(codes.InvalidArgument, "amount is required")
 	}
-	if req.GetCategory() == "" {
-		return nil, status.Error(codes.InvalidArgument, "category is required")
-	}
 	if req.GetDate() == "" {
 		return nil, status.Error(codes.InvalidArgument, "date is required")
 	}
@@ -87,17 +98,32 @@ func (s *LedgerServer) TransactionAdd(ctx context.Context, req *pb.TransactionAd
 		return nil, status.Error(codes.InvalidArgument, "description is required")
 	}
 	tr := domain.Transaction{
-		Amount:      float64(req.GetAmount()),
-		Category:    req.GetCategory(),
-		Date:        req.GetDate(),
-		Description: req.GetDescription(),
+		Amount:         float64(req.GetAmount()),
+		CategoryID:     req.GetCategoryId(),
+		Category:       req.GetCategory(),
+		Date:           req.GetDate(),
+		Description:    req.GetDescription(),
+		Tags:           req.GetTags(),
+		ExternalID:     req.GetExternalId(),
+		IdempotencyKey: req.GetIdempotencyKey(),
+	}
+	id, err := s.ledgerService.TransactionAdd(ctx, &tr, req.GetForce())
+	var duplicate *domain.DuplicateTransactionError
+	if errors.As(err, &duplicate) {
+		return nil, status.Errorf(codes.AlreadyExists, "add transaction: %v", err)
+	}
+	if errors.Is(err, domain.ErrIdempotencyKeyReused) {
+		return nil, status.Errorf(codes.FailedPrecondition, "add transaction: %v", err)
+	}
+	if errors.Is(err, domain.ErrIdempotencyKeyConflict) {
+		return nil, status.Errorf(codes.Aborted, "add transaction: %v", err)
 	}
-	id, err := s.ledgerService.TransactionAdd(ctx, &tr)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "add transaction: %v", err)
 	}
 	return &pb.TransactionAddResponse{
-		Id: id,
+		Id:      id,
+		Anomaly: tr.Anomaly != nil,
 	}, nil
 }
 
@@ -115,11 +141,15 @@ func (s *LedgerServer) TransactionGet(ctx context.Context, req *pb.TransactionGe
 		Category:    tr.Category,
 		Date:        tr.Date,
 		Description: tr.Description,
+		Tags:        tr.Tags,
+		CategoryId:  tr.CategoryID,
+		CreatedBy:   tr.CreatedBy,
+		Kind:        tr.Kind,
 	}, nil
 }
 
-func (s *LedgerServer) TransactionList(ctx context.Context, req *emptypb.Empty) (*pb.TransactionGetListResponse, error) {
-	trs, err := s.ledgerService.TransactionsList(ctx)
+func (s *LedgerServer) TransactionList(ctx context.Context, req *pb.TransactionListRequest) (*pb.TransactionGetListResponse, error) {
+	trs, err := s.ledgerService.TransactionsList(ctx, domain.TransactionFilter{Tag: req.GetTag()})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "list transactions: %v", err)
 	}
@@ -131,6 +161,10 @@ func (s *LedgerServer) TransactionList(ctx context.Context, req *emptypb.Empty)
 			Category:    tr.Category,
 			Date:        tr.Date,
 			Description: tr.Description,
+			Tags:        tr.Tags,
+			CategoryId:  tr.CategoryID,
+			CreatedBy:   tr.CreatedBy,
+			Kind:        tr.Kind,
 		}
 	}
 
@@ -139,6 +173,46 @@ func (s *LedgerServer) TransactionList(ctx context.Context, req *emptypb.Empty)
 	}, nil
 }
 
+func (s *LedgerServer) TransactionSearch(ctx context.Context, req *pb.TransactionSearchRequest) (*pb.TransactionSearchResponse, error) {
+	if req.GetQuery() == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	search := domain.TransactionSearch{
+		Query:      req.GetQuery(),
+		Language:   req.GetLanguage(),
+		From:       req.GetFrom(),
+		To:         req.GetTo(),
+		CategoryID: req.GetCategoryId(),
+		Limit:      int(req.GetLimit()),
+	}
+	results, err := s.ledgerService.TransactionSearch(ctx, &search)
+	if errors.Is(err, domain.ErrInvalidSearch) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "search transactions: %v", err)
+	}
+	resp := &pb.TransactionSearchResponse{}
+	for _, res := range results {
+		tr := res.Transaction
+		resp.Results = append(resp.Results, &pb.TransactionSearchResult{
+			Transaction: &pb.TransactionGetResponse{
+				Id:          tr.ID,
+				Amount:      float32(tr.Amount),
+				Category:    tr.Category,
+				Date:        tr.Date,
+				Description: tr.Description,
+				Tags:        tr.Tags,
+				CategoryId:  tr.CategoryID,
+				CreatedBy:   tr.CreatedBy,
+				Kind:        tr.Kind,
+			},
+			Rank: float32(res.Rank),
+		})
+	}
+	return resp, nil
+}
+
 func (s *LedgerServer) ReportSummary(ctx context.Context, req *pb.SummaryRequest) (*pb.SummaryResponse, error) {
 	if req.From == "" {
 		return nil, status.Error(codes.InvalidArgument, "from is required")
@@ -146,14 +220,103 @@ func (s *LedgerServer) ReportSummary(ctx context.Context, req *pb.SummaryRequest
 	if req.To == "" {
 		return nil, status.Error(codes.InvalidArgument, "to is required")
 	}
-	summary, err := s.ledgerService.GetReportSummary(ctx, req.From, req.To)
+	asOf, err := parseTimeBound(req.GetAsOf(), true)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "report summary: %v", err)
+		return nil, status.Error(codes.InvalidArgument, "as_of must be YYYY-MM-DD or RFC3339")
+	}
+	switch req.GetGroupBy() {
+	case "", domain.SummaryGroupByCategory:
+		summary, err := s.ledgerService.GetReportSummary(ctx, req.From, req.To, asOf)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "report summary: %v", err)
+		}
+		return &pb.SummaryResponse{
+			Report:      summary.Categories,
+			CacheResult: summary.CacheResult,
+			Tree:        categoryNodesToPb(summary.Tree),
+		}, nil
+	case domain.SummaryGroupByTag:
+		summary, err := s.ledgerService.GetReportTagSummary(ctx, req.From, req.To, asOf)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "report summary: %v", err)
+		}
+		return &pb.SummaryResponse{
+			Report:      summary.Tags,
+			CacheResult: summary.CacheResult,
+		}, nil
+	default:
+		return nil, status.Error(codes.InvalidArgument, "group_by must be category or tag")
 	}
-	return &pb.SummaryResponse{
-		Report:      summary.Categories,
-		CacheResult: summary.CacheResult,
-	}, nil
+}
+
+func (s *LedgerServer) ReportForecast(ctx context.Context, req *pb.ForecastRequest) (*pb.ForecastResponse, error) {
+	if req.GetHistoryPeriods() < 0 || req.GetHistoryPeriods() > 24 {
+		return nil, status.Error(codes.InvalidArgument, "history_periods must be between 0 and 24")
+	}
+	historyPeriods := int(req.GetHistoryPeriods())
+	if historyPeriods == 0 {
+		historyPeriods = 3
+	}
+	forecast, err := s.ledgerService.GetReportForecast(ctx, historyPeriods)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "report forecast: %v", err)
+	}
+	resp := &pb.ForecastResponse{
+		PeriodStart:  forecast.PeriodStart,
+		PeriodEnd:    forecast.PeriodEnd,
+		DaysElapsed:  int32(forecast.DaysElapsed),
+		DaysInPeriod: int32(forecast.DaysInPeriod),
+	}
+	for _, c := range forecast.Categories {
+		resp.Categories = append(resp.Categories, &pb.CategoryForecast{
+			Category:          c.Category,
+			Spent:             float32(c.Spent),
+			Scheduled:         float32(c.Scheduled),
+			HistoricalAverage: float32(c.HistoricalAverage),
+			Projected:         float32(c.Projected),
+		})
+	}
+	for _, b := range forecast.Budgets {
+		resp.Budgets = append(resp.Budgets, &pb.BudgetForecast{
+			CategoryId:     b.CategoryID,
+			Category:       b.Category,
+			Limit:          float32(b.Limit),
+			Projected:      float32(b.Projected),
+			LikelyExceeded: b.LikelyExceeded,
+		})
+	}
+	return resp, nil
+}
+
+func (s *LedgerServer) ReportAnomalies(ctx context.Context, req *pb.AnomaliesRequest) (*pb.AnomaliesResponse, error) {
+	if req.GetFrom() == "" {
+		return nil, status.Error(codes.InvalidArgument, "from is required")
+	}
+	if req.GetTo() == "" {
+		return nil, status.Error(codes.InvalidArgument, "to is required")
+	}
+	anomalies, err := s.ledgerService.GetReportAnomalies(ctx, req.GetFrom(), req.GetTo())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "report anomalies: %v", err)
+	}
+	resp := &pb.AnomaliesResponse{}
+	for _, a := range anomalies {
+		resp.Anomalies = append(resp.Anomalies, &pb.Anomaly{
+			Transaction: &pb.TransactionGetResponse{
+				Id:          a.Transaction.ID,
+				Amount:      float32(a.Transaction.Amount),
+				Category:    a.Transaction.Category,
+				Description: a.Transaction.Description,
+				Date:        a.Transaction.Date,
+				CategoryId:  a.Transaction.CategoryID,
+			},
+			Score:  float32(a.Score),
+			Ratio:  float32(a.Ratio),
+			Median: float32(a.Median),
+			Mad:    float32(a.MAD),
+		})
+	}
+	return resp, nil
 }
 
 func (s *LedgerServer) BulkAddTransactions(ctx context.Context, req *pb.TransactionBulkAddRequest) (*pb.TransactionBulkAddResponse, error) {
@@ -161,9 +324,12 @@ func (s *LedgerServer) BulkAddTransactions(ctx context.Context, req *pb.Transact
 	for _, transaction := range req.Transactions {
 		trs = append(trs, domain.Transaction{
 			Amount:      float64(transaction.Amount),
+			CategoryID:  transaction.CategoryId,
 			Category:    transaction.Category,
 			Description: transaction.Description,
 			Date:        transaction.Date,
+			Tags:        transaction.Tags,
+			ExternalID:  transaction.ExternalId,
 		})
 	}
 	resp, err := s.ledgerService.BulkAddTransactions(ctx, trs, 4)
@@ -171,8 +337,186 @@ func (s *LedgerServer) BulkAddTransactions(ctx context.Context, req *pb.Transact
 		return nil, status.Errorf(codes.Internal, "bulk add transaction: %v", err)
 	}
 	return &pb.TransactionBulkAddResponse{
-		Accepted: resp.Accepted,
-		Rejected: resp.Rejected,
-		Errors:   resp.Errors,
+		Accepted:        resp.Accepted,
+		Rejected:        resp.Rejected,
+		Errors:          resp.Errors,
+		Duplicates:      resp.Duplicates,
+		BatchDuplicates: resp.BatchDuplicates,
 	}, nil
 }
+
+func (s *LedgerServer) CategoryAdd(ctx context.Context, req *pb.CategoryAddRequest) (*pb.CategoryAddResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	id, err := s.ledgerService.CategoryAdd(ctx, &domain.Category{
+		Name:     req.GetName(),
+		ParentID: req.GetParentId(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "add category: %v", err)
+	}
+	return &pb.CategoryAddResponse{Id: id}, nil
+}
+
+func (s *LedgerServer) CategoryUpdate(ctx context.Context, req *pb.CategoryUpdateRequest) (*emptypb.Empty, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	err := s.ledgerService.CategoryUpdate(ctx, &domain.Category{
+		ID:       req.GetId(),
+		Name:     req.GetName(),
+		ParentID: req.GetParentId(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "update category: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *LedgerServer) CategoriesList(ctx context.Context, _ *emptypb.Empty) (*pb.CategoryGetListResponse, error) {
+	categories, err := s.ledgerService.CategoriesList(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list categories: %v", err)
+	}
+	pbCategories := make([]*pb.CategoryGetResponse, len(categories))
+	for i, category := range categories {
+		pbCategories[i] = &pb.CategoryGetResponse{
+			Id:       category.ID,
+			Name:     category.Name,
+			ParentId: category.ParentID,
+		}
+	}
+	return &pb.CategoryGetListResponse{Categories: pbCategories}, nil
+}
+
+func (s *LedgerServer) RuleAdd(ctx context.Context, req *pb.Rule) (*pb.RuleAddResponse, error) {
+	if req.GetCategory() == "" && req.GetCategoryId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "category is required")
+	}
+	id, err := s.ledgerService.RuleAdd(ctx, ruleFromPb(req))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "add rule: %v", err)
+	}
+	return &pb.RuleAddResponse{Id: id}, nil
+}
+
+func (s *LedgerServer) RuleUpdate(ctx context.Context, req *pb.Rule) (*emptypb.Empty, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.GetCategory() == "" && req.GetCategoryId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "category is required")
+	}
+	err := s.ledgerService.RuleUpdate(ctx, ruleFromPb(req))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "update rule: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *LedgerServer) RuleDelete(ctx context.Context, req *pb.RuleDeleteRequest) (*emptypb.Empty, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	err := s.ledgerService.RuleDelete(ctx, req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "delete rule: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *LedgerServer) RulesList(ctx context.Context, _ *emptypb.Empty) (*pb.RuleListResponse, error) {
+	rules, err := s.ledgerService.RulesList(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list rules: %v", err)
+	}
+	pbRules := make([]*pb.Rule, len(rules))
+	for i, rule := range rules {
+		pbRules[i] = ruleToPb(rule)
+	}
+	return &pb.RuleListResponse{Rules: pbRules}, nil
+}
+
+func (s *LedgerServer) RuleTest(ctx context.Context, req *pb.RuleTestRequest) (*pb.RuleTestResponse, error) {
+	if req.GetRule() == nil {
+		return nil, status.Error(codes.InvalidArgument, "rule is required")
+	}
+	if req.GetLast() < 0 || req.GetLast() > 1000 {
+		return nil, status.Error(codes.InvalidArgument, "last must be between 1 and 1000, or omitted for 100")
+	}
+	last := int(req.GetLast())
+	if last == 0 {
+		last = 100
+	}
+	matches, err := s.ledgerService.RuleTest(ctx, ruleFromPb(req.GetRule()), last)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "test rule: %v", err)
+	}
+	pbMatches := make([]*pb.RuleMatch, len(matches))
+	for i, match := range matches {
+		pbMatches[i] = &pb.RuleMatch{
+			Transaction: &pb.TransactionGetResponse{
+				Id:          match.Transaction.ID,
+				Amount:      float32(match.Transaction.Amount),
+				Category:    match.Transaction.Category,
+				Date:        match.Transaction.Date,
+				Description: match.Transaction.Description,
+				Tags:        match.Transaction.Tags,
+				CategoryId:  match.Transaction.CategoryID,
+			},
+			CategoryId:  match.CategoryID,
+			Category:    match.Category,
+			Description: match.Description,
+		}
+	}
+	return &pb.RuleTestResponse{Matches: pbMatches}, nil
+}
+
+func ruleFromPb(rule *pb.Rule) *domain.Rule {
+	return &domain.Rule{
+		ID:                  rule.GetId(),
+		Priority:            rule.GetPriority(),
+		DescriptionRegex:    rule.GetDescriptionRegex(),
+		DescriptionContains: rule.GetDescriptionContains(),
+		MinAmount:           float64(rule.GetMinAmount()),
+		MaxAmount:           float64(rule.GetMaxAmount()),
+		CategoryID:          rule.GetCategoryId(),
+		Category:            rule.GetCategory(),
+		Description:         rule.GetDescription(),
+	}
+}
+
+func ruleToPb(rule domain.Rule) *pb.Rule {
+	return &pb.Rule{
+		Id:                  rule.ID,
+		Priority:            rule.Priority,
+		DescriptionRegex:    rule.DescriptionRegex,
+		DescriptionContains: rule.DescriptionContains,
+		MinAmount:           float32(rule.MinAmount),
+		MaxAmount:           float32(rule.MaxAmount),
+		CategoryId:          rule.CategoryID,
+		Category:            rule.Category,
+		Description:         rule.Description,
+	}
+}
+
+func categoryNodesToPb(nodes []domain.CategoryNode) []*pb.CategoryNode {
+	if len(nodes) == 0 {
+		return nil
+	}
+	pbNodes := make([]*pb.CategoryNode, len(nodes))
+	for i, node := range nodes {
+		pbNodes[i] = &pb.CategoryNode{
+			Id:       node.ID,
+			Name:     node.Name,
+			Amount:   node.Amount,
+			Total:    node.Total,
+			Children: categoryNodesToPb(node.Children),
+		}
+	}
+	return pbNodes
+}
@@ -2,7 +2,10 @@ package grpcserver
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"testing"
+	"time"
 
 	"ledger/internal/domain"
 	"ledger/internal/service"
@@ -27,18 +30,18 @@ func (m *MockLedgerServiceImpl) BudgetAdd(ctx context.Context, budget *domain.Bu
 	return args.Error(0)
 }
 
-func (m *MockLedgerServiceImpl) BudgetGet(ctx context.Context, category string) (*domain.Budget, error) {
-	args := m.Called(ctx, category)
+func (m *MockLedgerServiceImpl) BudgetGet(ctx context.Context, category string, asOf time.Time) (*domain.Budget, error) {
+	args := m.Called(ctx, category, asOf)
 	return args.Get(0).(*domain.Budget), args.Error(1)
 }
 
-func (m *MockLedgerServiceImpl) BudgetsList(ctx context.Context) (map[string]domain.Budget, error) {
-	args := m.Called(ctx)
+func (m *MockLedgerServiceImpl) BudgetsList(ctx context.Context, asOf time.Time) (map[string]domain.Budget, error) {
+	args := m.Called(ctx, asOf)
 	return args.Get(0).(map[string]domain.Budget), args.Error(1)
 }
 
-func (m *MockLedgerServiceImpl) TransactionAdd(ctx context.Context, transaction *domain.Transaction) (int64, error) {
-	args := m.Called(ctx, transaction)
+func (m *MockLedgerServiceImpl) TransactionAdd(ctx context.Context, transaction *domain.Transaction, force bool) (int64, error) {
+	args := m.Called(ctx, transaction, force)
 	return args.Get(0).(int64), args.Error(1)
 }
 
@@ -47,8 +50,8 @@ func (m *MockLedgerServiceImpl) TransactionGet(ctx context.Context, id int64) (*
 	return args.Get(0).(*domain.Transaction), args.Error(1)
 }
 
-func (m *MockLedgerServiceImpl) TransactionsList(ctx context.Context) ([]domain.Transaction, error) {
-	args := m.Called(ctx)
+func (m *MockLedgerServiceImpl) TransactionsList(ctx context.Context, filter domain.TransactionFilter) ([]domain.Transaction, error) {
+	args := m.Called(ctx, filter)
 	return args.Get(0).([]domain.Transaction), args.Error(1)
 }
 
@@ -57,11 +60,174 @@ func (m *MockLedgerServiceImpl) BulkAddTransactions(ctx context.Context, transac
 	return args.Get(0).(*service.BulkTransactionResult), args.Error(1)
 }
 
-func (m *MockLedgerServiceImpl) GetReportSummary(ctx context.Context, from string, to string) (*domain.Summary, error) {
-	args := m.Called(ctx, from, to)
+func (m *MockLedgerServiceImpl) GetReportSummary(ctx context.Context, from string, to string, asOf time.Time) (*domain.Summary, error) {
+	args := m.Called(ctx, from, to, asOf)
+	return args.Get(0).(*domain.Summary), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) GetReportTagSummary(ctx context.Context, from string, to string, asOf time.Time) (*domain.Summary, error) {
+	args := m.Called(ctx, from, to, asOf)
 	return args.Get(0).(*domain.Summary), args.Error(1)
 }
 
+func (m *MockLedgerServiceImpl) CategoryAdd(ctx context.Context, category *domain.Category) (int64, error) {
+	args := m.Called(ctx, category)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) CategoryUpdate(ctx context.Context, category *domain.Category) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+
+func (m *MockLedgerServiceImpl) CategoriesList(ctx context.Context) ([]domain.Category, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Category), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) RuleAdd(ctx context.Context, rule *domain.Rule) (int64, error) {
+	args := m.Called(ctx, rule)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) RuleUpdate(ctx context.Context, rule *domain.Rule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockLedgerServiceImpl) RuleDelete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockLedgerServiceImpl) RulesList(ctx context.Context) ([]domain.Rule, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Rule), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) RuleTest(ctx context.Context, rule *domain.Rule, last int) ([]domain.RuleMatch, error) {
+	args := m.Called(ctx, rule, last)
+	return args.Get(0).([]domain.RuleMatch), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) AttachmentUpload(ctx context.Context, attachment *domain.Attachment, data io.Reader) (int64, error) {
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return 0, err
+	}
+	args := m.Called(ctx, attachment, content)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) AttachmentGet(ctx context.Context, transactionID int64, id int64) (*domain.Attachment, io.ReadCloser, error) {
+	args := m.Called(ctx, transactionID, id)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*domain.Attachment), args.Get(1).(io.ReadCloser), args.Error(2)
+}
+
+func (m *MockLedgerServiceImpl) AttachmentDelete(ctx context.Context, transactionID int64, id int64) error {
+	args := m.Called(ctx, transactionID, id)
+	return args.Error(0)
+}
+
+func (m *MockLedgerServiceImpl) AttachmentsList(ctx context.Context, transactionID int64) ([]domain.Attachment, error) {
+	args := m.Called(ctx, transactionID)
+	return args.Get(0).([]domain.Attachment), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) TransactionSearch(ctx context.Context, search *domain.TransactionSearch) ([]domain.SearchResult, error) {
+	args := m.Called(ctx, search)
+	return args.Get(0).([]domain.SearchResult), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) GoalAdd(ctx context.Context, goal *domain.Goal) (int64, error) {
+	args := m.Called(ctx, goal)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) GoalUpdate(ctx context.Context, goal *domain.Goal) error {
+	args := m.Called(ctx, goal)
+	return args.Error(0)
+}
+
+func (m *MockLedgerServiceImpl) GoalDelete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockLedgerServiceImpl) GoalGet(ctx context.Context, id int64) (*domain.GoalProgress, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GoalProgress), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) GoalsList(ctx context.Context) ([]domain.GoalProgress, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.GoalProgress), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) GoalContribute(ctx context.Context, contribution *domain.GoalContribution) (int64, error) {
+	args := m.Called(ctx, contribution)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) GetReportForecast(ctx context.Context, historyPeriods int) (*domain.Forecast, error) {
+	args := m.Called(ctx, historyPeriods)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Forecast), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) GetReportAnomalies(ctx context.Context, from string, to string) ([]domain.Anomaly, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).([]domain.Anomaly), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) WebhookAdd(ctx context.Context, webhook *domain.Webhook) (int64, error) {
+	args := m.Called(ctx, webhook)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) WebhookDelete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockLedgerServiceImpl) WebhooksList(ctx context.Context) ([]domain.Webhook, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) WebhookDeliveriesList(ctx context.Context, webhookID int64, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID, limit)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) Subscribe(ctx context.Context, fromEventID int64, types []string, send func(domain.OutboxEvent) error) error {
+	args := m.Called(ctx, fromEventID, types, send)
+	if events, ok := args.Get(0).([]domain.OutboxEvent); ok {
+		for _, event := range events {
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockLedgerServiceImpl) AuditList(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AuditEntry), args.Error(1)
+}
+
 func TestLedgerServer_BudgetAdd(t *testing.T) {
 	mockService := &MockLedgerServiceImpl{}
 	server := NewLedgerServer(mockService)
@@ -152,7 +318,7 @@ func TestLedgerServer_BudgetGet(t *testing.T) {
 				Category: "Food",
 			},
 			mockSetup: func(m *MockLedgerServiceImpl) {
-				m.On("BudgetGet", mock.Anything, "Food").Return(&domain.Budget{
+				m.On("BudgetGet", mock.Anything, "Food", time.Time{}).Return(&domain.Budget{
 					Category: "Food",
 					Limit:    1000.0,
 				}, nil)
@@ -178,7 +344,7 @@ func TestLedgerServer_BudgetGet(t *testing.T) {
 				Category: "Error",
 			},
 			mockSetup: func(m *MockLedgerServiceImpl) {
-				m.On("BudgetGet", mock.Anything, "Error").Return((*domain.Budget)(nil), assert.AnError)
+				m.On("BudgetGet", mock.Anything, "Error", time.Time{}).Return((*domain.Budget)(nil), assert.AnError)
 			},
 			expectedResp: nil,
 			expectedErr:  status.Errorf(codes.Internal, "get budget: %v", assert.AnError),
@@ -210,16 +376,16 @@ func TestLedgerServer_BudgetsList(t *testing.T) {
 
 	tests := []struct {
 		name         string
-		req          *emptypb.Empty
+		req          *pb.BudgetsListRequest
 		mockSetup    func(*MockLedgerServiceImpl)
 		expectedResp *pb.BudgetGetListResponse
 		expectedErr  error
 	}{
 		{
 			name: "successful budgets list",
-			req:  &emptypb.Empty{},
+			req:  &pb.BudgetsListRequest{},
 			mockSetup: func(m *MockLedgerServiceImpl) {
-				m.On("BudgetsList", mock.Anything).Return(map[string]domain.Budget{
+				m.On("BudgetsList", mock.Anything, time.Time{}).Return(map[string]domain.Budget{
 					"Food":      {Category: "Food", Limit: 1000.0},
 					"Transport": {Category: "Transport", Limit: 500.0},
 				}, nil)
@@ -232,6 +398,28 @@ func TestLedgerServer_BudgetsList(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "budgets as of date",
+			req:  &pb.BudgetsListRequest{AsOf: "2025-12-15"},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("BudgetsList", mock.Anything, time.Date(2025, 12, 16, 0, 0, 0, 0, time.UTC)).Return(map[string]domain.Budget{
+					"Food": {CategoryID: 1, Category: "Food", Limit: 800.0, Spent: 150.0},
+				}, nil)
+			},
+			expectedResp: &pb.BudgetGetListResponse{
+				Budgets: []*pb.BudgetGetResponse{
+					{Category: "Food", Limit: 800.0, CategoryId: 1, Spent: 150.0},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:         "invalid as_of",
+			req:          &pb.BudgetsListRequest{AsOf: "yesterday"},
+			mockSetup:    func(m *MockLedgerServiceImpl) {},
+			expectedResp: nil,
+			expectedErr:  status.Error(codes.InvalidArgument, "as_of must be YYYY-MM-DD or RFC3339"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -250,7 +438,7 @@ func TestLedgerServer_BudgetsList(t *testing.T) {
 				for _, expected := range tt.expectedResp.Budgets {
 					found := false
 					for _, actual := range resp.Budgets {
-						if actual.Category == expected.Category && actual.Limit == expected.Limit {
+						if actual.Category == expected.Category && actual.Limit == expected.Limit && actual.Spent == expected.Spent {
 							found = true
 							break
 						}
@@ -275,7 +463,7 @@ func TestLedgerServer_TransactionAdd(t *testing.T) {
 	}
 	mockService.On("TransactionAdd", mock.Anything, mock.MatchedBy(func(tr *domain.Transaction) bool {
 		return tr.Amount == 100.0 && tr.Category == "Food"
-	})).Return(int64(1), nil)
+	}), false).Return(int64(1), nil)
 
 	resp, err := server.TransactionAdd(context.Background(), req)
 
@@ -284,6 +472,150 @@ func TestLedgerServer_TransactionAdd(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestLedgerServer_TransactionAddWithoutCategory(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	req := &pb.TransactionAddRequest{
+		Amount:      12.5,
+		Description: "UBER *TRIP",
+		Date:        "2025-12-01",
+	}
+	mockService.On("TransactionAdd", mock.Anything, mock.MatchedBy(func(tr *domain.Transaction) bool {
+		return tr.Category == "" && tr.CategoryID == 0 && tr.Description == "UBER *TRIP"
+	}), false).Return(int64(2), nil)
+
+	resp, err := server.TransactionAdd(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.TransactionAddResponse{Id: 2}, resp)
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerServer_TransactionAddDuplicate(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         *pb.TransactionAddRequest
+		mockSetup   func(*MockLedgerServiceImpl)
+		expected    *pb.TransactionAddResponse
+		expectedErr error
+	}{
+		{
+			name: "duplicate rejected",
+			req:  &pb.TransactionAddRequest{Amount: 42, Category: "Food", Description: "Coffee", Date: "2025-12-05", ExternalId: "bank-1"},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("TransactionAdd", mock.Anything, mock.MatchedBy(func(tr *domain.Transaction) bool {
+					return tr.ExternalID == "bank-1"
+				}), false).Return(int64(0), &domain.DuplicateTransactionError{ID: 9})
+			},
+			expected:    nil,
+			expectedErr: status.Error(codes.AlreadyExists, "add transaction: duplicate transaction: matches transaction 9"),
+		},
+		{
+			name: "forced",
+			req:  &pb.TransactionAddRequest{Amount: 42, Category: "Food", Description: "Coffee", Date: "2025-12-05", Force: true},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("TransactionAdd", mock.Anything, mock.Anything, true).Return(int64(10), nil)
+			},
+			expected:    &pb.TransactionAddResponse{Id: 10},
+			expectedErr: nil,
+		},
+		{
+			name: "idempotency key forwarded",
+			req:  &pb.TransactionAddRequest{Amount: 42, Category: "Food", Description: "Coffee", Date: "2025-12-05", IdempotencyKey: "key-1"},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("TransactionAdd", mock.Anything, mock.MatchedBy(func(tr *domain.Transaction) bool {
+					return tr.IdempotencyKey == "key-1"
+				}), false).Return(int64(7), nil)
+			},
+			expected:    &pb.TransactionAddResponse{Id: 7},
+			expectedErr: nil,
+		},
+		{
+			name: "idempotency key reused",
+			req:  &pb.TransactionAddRequest{Amount: 43, Category: "Food", Description: "Coffee", Date: "2025-12-05", IdempotencyKey: "key-1"},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("TransactionAdd", mock.Anything, mock.Anything, false).Return(int64(0), domain.ErrIdempotencyKeyReused)
+			},
+			expected:    nil,
+			expectedErr: status.Error(codes.FailedPrecondition, "add transaction: idempotency key reused with different request"),
+		},
+		{
+			name: "idempotency key stored concurrently",
+			req:  &pb.TransactionAddRequest{Amount: 42, Category: "Food", Description: "Coffee", Date: "2025-12-05", IdempotencyKey: "key-1"},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("TransactionAdd", mock.Anything, mock.Anything, false).Return(int64(0), domain.ErrIdempotencyKeyConflict)
+			},
+			expected:    nil,
+			expectedErr: status.Error(codes.Aborted, "add transaction: idempotency key already stored"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerServiceImpl{}
+			tt.mockSetup(mockService)
+			server := NewLedgerServer(mockService)
+
+			resp, err := server.TransactionAdd(context.Background(), tt.req)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, resp)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerServer_TransactionSearch(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         *pb.TransactionSearchRequest
+		mockSetup   func(*MockLedgerServiceImpl)
+		expected    *pb.TransactionSearchResponse
+		expectedErr error
+	}{
+		{
+			name: "ranked results",
+			req:  &pb.TransactionSearchRequest{Query: "pharm", Language: "english", From: "2025-03-01", To: "2025-03-31", CategoryId: 2, Limit: 10},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("TransactionSearch", mock.Anything, &domain.TransactionSearch{Query: "pharm", Language: "english", From: "2025-03-01", To: "2025-03-31", CategoryID: 2, Limit: 10}).
+					Return([]domain.SearchResult{{Transaction: domain.Transaction{ID: 4, Amount: 12.5, CategoryID: 2, Category: "Health", Description: "Pharmacy", Date: "2025-03-14"}, Rank: 0.5}}, nil)
+			},
+			expected: &pb.TransactionSearchResponse{Results: []*pb.TransactionSearchResult{
+				{Transaction: &pb.TransactionGetResponse{Id: 4, Amount: 12.5, CategoryId: 2, Category: "Health", Description: "Pharmacy", Date: "2025-03-14"}, Rank: 0.5},
+			}},
+		},
+		{
+			name:        "query required",
+			req:         &pb.TransactionSearchRequest{},
+			mockSetup:   func(m *MockLedgerServiceImpl) {},
+			expectedErr: status.Error(codes.InvalidArgument, "query is required"),
+		},
+		{
+			name: "invalid search",
+			req:  &pb.TransactionSearchRequest{Query: "pharm", Language: "klingon"},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("TransactionSearch", mock.Anything, mock.Anything).Return([]domain.SearchResult(nil), fmt.Errorf("%w: unsupported search language", domain.ErrInvalidSearch))
+			},
+			expectedErr: status.Error(codes.InvalidArgument, "invalid search: unsupported search language"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerServiceImpl{}
+			tt.mockSetup(mockService)
+			server := NewLedgerServer(mockService)
+
+			resp, err := server.TransactionSearch(context.Background(), tt.req)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, resp)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 func TestLedgerServer_TransactionGet(t *testing.T) {
 	mockService := &MockLedgerServiceImpl{}
 	server := NewLedgerServer(mockService)
@@ -314,14 +646,17 @@ func TestLedgerServer_TransactionList(t *testing.T) {
 	mockService := &MockLedgerServiceImpl{}
 	server := NewLedgerServer(mockService)
 
-	req := &emptypb.Empty{}
-	mockService.On("TransactionsList", mock.Anything).Return([]domain.Transaction{
+	req := &pb.TransactionListRequest{Tag: "work"}
+	mockService.On("TransactionsList", mock.Anything, domain.TransactionFilter{Tag: "work"}).Return([]domain.Transaction{
 		{
 			ID:          1,
 			Amount:      100.0,
 			Category:    "Food",
 			Description: "Lunch",
 			Date:        "2025-12-01",
+			Tags:        []string{"work"},
+			CreatedBy:   "98ac2ffe",
+			Kind:        domain.TransactionKindGoalContribution,
 		},
 	}, nil)
 
@@ -335,6 +670,9 @@ func TestLedgerServer_TransactionList(t *testing.T) {
 		Category:    "Food",
 		Description: "Lunch",
 		Date:        "2025-12-01",
+		Tags:        []string{"work"},
+		CreatedBy:   "98ac2ffe",
+		Kind:        "goal_contribution",
 	}, resp.Transactions[0])
 	mockService.AssertExpectations(t)
 }
@@ -344,7 +682,7 @@ func TestLedgerServer_ReportSummary(t *testing.T) {
 	server := NewLedgerServer(mockService)
 
 	req := &pb.SummaryRequest{From: "2025-12-01", To: "2025-12-31"}
-	mockService.On("GetReportSummary", mock.Anything, "2025-12-01", "2025-12-31").Return(&domain.Summary{
+	mockService.On("GetReportSummary", mock.Anything, "2025-12-01", "2025-12-31", time.Time{}).Return(&domain.Summary{
 		Categories:  map[string]float64{"Food": 500.0},
 		CacheResult: true,
 	}, nil)
@@ -359,6 +697,82 @@ func TestLedgerServer_ReportSummary(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestLedgerServer_ReportSummaryByTag(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	req := &pb.SummaryRequest{From: "2025-12-01", To: "2025-12-31", GroupBy: "tag"}
+	mockService.On("GetReportTagSummary", mock.Anything, "2025-12-01", "2025-12-31", time.Time{}).Return(&domain.Summary{
+		Tags:        map[string]float64{"vacation-2025": 300.0},
+		CacheResult: false,
+	}, nil)
+
+	resp, err := server.ReportSummary(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.SummaryResponse{
+		Report:      map[string]float64{"vacation-2025": 300.0},
+		CacheResult: false,
+	}, resp)
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerServer_ReportSummaryInvalidGroupBy(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	req := &pb.SummaryRequest{From: "2025-12-01", To: "2025-12-31", GroupBy: "month"}
+
+	_, err := server.ReportSummary(context.Background(), req)
+
+	assert.Equal(t, status.Error(codes.InvalidArgument, "group_by must be category or tag"), err)
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerServer_ReportForecast(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	mockService.On("GetReportForecast", mock.Anything, 3).Return(&domain.Forecast{
+		PeriodStart:  "2025-12-01",
+		PeriodEnd:    "2025-12-31",
+		DaysElapsed:  10,
+		DaysInPeriod: 31,
+		Categories:   []domain.CategoryForecast{{Category: "Food", Spent: 100, HistoricalAverage: 310, Projected: 310}},
+		Budgets:      []domain.BudgetForecast{{CategoryID: 1, Category: "Food", Limit: 300, Projected: 310, LikelyExceeded: true}},
+	}, nil)
+
+	resp, err := server.ReportForecast(context.Background(), &pb.ForecastRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-12-31", resp.PeriodEnd)
+	assert.Equal(t, float32(310), resp.Categories[0].Projected)
+	assert.True(t, resp.Budgets[0].LikelyExceeded)
+
+	_, err = server.ReportForecast(context.Background(), &pb.ForecastRequest{HistoryPeriods: 25})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "history_periods must be between 0 and 24"), err)
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerServer_ReportAnomalies(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	mockService.On("GetReportAnomalies", mock.Anything, "2025-12-01", "2025-12-31").Return([]domain.Anomaly{{
+		Transaction:  domain.Transaction{ID: 7, Amount: 900, CategoryID: 1, Category: "Food", Description: "Banquet", Date: "2025-12-01"},
+		AnomalyScore: domain.AnomalyScore{Score: 40.47, Ratio: 9, Median: 100, MAD: 13.33},
+	}}, nil)
+
+	resp, err := server.ReportAnomalies(context.Background(), &pb.AnomaliesRequest{From: "2025-12-01", To: "2025-12-31"})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Anomalies, 1)
+	assert.Equal(t, int64(7), resp.Anomalies[0].Transaction.Id)
+	assert.Equal(t, float32(9), resp.Anomalies[0].Ratio)
+
+	_, err = server.ReportAnomalies(context.Background(), &pb.AnomaliesRequest{From: "2025-12-01"})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "to is required"), err)
+	mockService.AssertExpectations(t)
+}
+
 func TestLedgerServer_BulkAddTransactions(t *testing.T) {
 	mockService := &MockLedgerServiceImpl{}
 	server := NewLedgerServer(mockService)
@@ -376,24 +790,310 @@ func TestLedgerServer_BulkAddTransactions(t *testing.T) {
 				Category:    "Transport",
 				Description: "Bus",
 				Date:        "2025-12-02",
+				ExternalId:  "bank-2",
 			},
 		},
 	}
 	mockService.On("BulkAddTransactions", mock.Anything, mock.MatchedBy(func(trs []domain.Transaction) bool {
-		return len(trs) == 2
+		return len(trs) == 2 && trs[1].ExternalID == "bank-2"
 	}), 4).Return(&service.BulkTransactionResult{
-		Accepted: 2,
-		Rejected: 0,
-		Errors:   nil,
+		Accepted:        1,
+		Rejected:        2,
+		Errors:          nil,
+		Duplicates:      map[int64]int64{1: 7},
+		BatchDuplicates: map[int64]int64{2: 0},
 	}, nil)
 
 	resp, err := server.BulkAddTransactions(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.Equal(t, &pb.TransactionBulkAddResponse{
-		Accepted: 2,
-		Rejected: 0,
-		Errors:   nil,
+		Accepted:        1,
+		Rejected:        2,
+		Errors:          nil,
+		Duplicates:      map[int64]int64{1: 7},
+		BatchDuplicates: map[int64]int64{2: 0},
 	}, resp)
 	mockService.AssertExpectations(t)
 }
+
+func TestLedgerServer_ReportSummaryTree(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	req := &pb.SummaryRequest{From: "2025-12-01", To: "2025-12-31"}
+	mockService.On("GetReportSummary", mock.Anything, "2025-12-01", "2025-12-31", time.Time{}).Return(&domain.Summary{
+		Categories: map[string]float64{"Groceries": 300.0, "Restaurants": 200.0},
+		Tree: []domain.CategoryNode{
+			{ID: 1, Name: "Food", Amount: 0, Total: 500.0, Children: []domain.CategoryNode{
+				{ID: 2, Name: "Groceries", Amount: 300.0, Total: 300.0},
+				{ID: 3, Name: "Restaurants", Amount: 200.0, Total: 200.0},
+			}},
+		},
+	}, nil)
+
+	resp, err := server.ReportSummary(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.CategoryNode{
+		{Id: 1, Name: "Food", Amount: 0, Total: 500.0, Children: []*pb.CategoryNode{
+			{Id: 2, Name: "Groceries", Amount: 300.0, Total: 300.0},
+			{Id: 3, Name: "Restaurants", Amount: 200.0, Total: 200.0},
+		}},
+	}, resp.Tree)
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerServer_CategoryAdd(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         *pb.CategoryAddRequest
+		mockSetup   func(*MockLedgerServiceImpl)
+		expected    *pb.CategoryAddResponse
+		expectedErr error
+	}{
+		{
+			name: "successful category add",
+			req:  &pb.CategoryAddRequest{Name: "Groceries", ParentId: 1},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("CategoryAdd", mock.Anything, &domain.Category{Name: "Groceries", ParentID: 1}).Return(int64(2), nil)
+			},
+			expected:    &pb.CategoryAddResponse{Id: 2},
+			expectedErr: nil,
+		},
+		{
+			name:        "empty name",
+			req:         &pb.CategoryAddRequest{ParentId: 1},
+			mockSetup:   func(m *MockLedgerServiceImpl) {},
+			expected:    nil,
+			expectedErr: status.Error(codes.InvalidArgument, "name is required"),
+		},
+		{
+			name: "service error",
+			req:  &pb.CategoryAddRequest{Name: "Groceries", ParentId: 99},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("CategoryAdd", mock.Anything, mock.Anything).Return(int64(0), assert.AnError)
+			},
+			expected:    nil,
+			expectedErr: status.Errorf(codes.Internal, "add category: %v", assert.AnError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerServiceImpl{}
+			tt.mockSetup(mockService)
+			server := NewLedgerServer(mockService)
+
+			resp, err := server.CategoryAdd(context.Background(), tt.req)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, resp)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerServer_CategoryUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         *pb.CategoryUpdateRequest
+		mockSetup   func(*MockLedgerServiceImpl)
+		expectedErr error
+	}{
+		{
+			name: "successful rename",
+			req:  &pb.CategoryUpdateRequest{Id: 2, Name: "Supermarket", ParentId: 1},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("CategoryUpdate", mock.Anything, &domain.Category{ID: 2, Name: "Supermarket", ParentID: 1}).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "empty id",
+			req:         &pb.CategoryUpdateRequest{Name: "Supermarket"},
+			mockSetup:   func(m *MockLedgerServiceImpl) {},
+			expectedErr: status.Error(codes.InvalidArgument, "id is required"),
+		},
+		{
+			name:        "empty name",
+			req:         &pb.CategoryUpdateRequest{Id: 2},
+			mockSetup:   func(m *MockLedgerServiceImpl) {},
+			expectedErr: status.Error(codes.InvalidArgument, "name is required"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerServiceImpl{}
+			tt.mockSetup(mockService)
+			server := NewLedgerServer(mockService)
+
+			_, err := server.CategoryUpdate(context.Background(), tt.req)
+
+			assert.Equal(t, tt.expectedErr, err)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerServer_CategoriesList(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	mockService.On("CategoriesList", mock.Anything).Return([]domain.Category{
+		{ID: 1, Name: "Food"},
+		{ID: 2, Name: "Groceries", ParentID: 1},
+	}, nil)
+
+	resp, err := server.CategoriesList(context.Background(), &emptypb.Empty{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.CategoryGetResponse{
+		{Id: 1, Name: "Food"},
+		{Id: 2, Name: "Groceries", ParentId: 1},
+	}, resp.Categories)
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerServer_RuleAdd(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         *pb.Rule
+		mockSetup   func(*MockLedgerServiceImpl)
+		expected    *pb.RuleAddResponse
+		expectedErr error
+	}{
+		{
+			name: "successful rule add",
+			req:  &pb.Rule{Priority: 1, DescriptionContains: "uber", MaxAmount: 100, Category: "Taxi", Description: "Uber"},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("RuleAdd", mock.Anything, &domain.Rule{Priority: 1, DescriptionContains: "uber", MaxAmount: 100, Category: "Taxi", Description: "Uber"}).Return(int64(1), nil)
+			},
+			expected:    &pb.RuleAddResponse{Id: 1},
+			expectedErr: nil,
+		},
+		{
+			name:        "empty category",
+			req:         &pb.Rule{DescriptionContains: "uber"},
+			mockSetup:   func(m *MockLedgerServiceImpl) {},
+			expected:    nil,
+			expectedErr: status.Error(codes.InvalidArgument, "category is required"),
+		},
+		{
+			name: "service error",
+			req:  &pb.Rule{DescriptionRegex: "(", CategoryId: 3},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("RuleAdd", mock.Anything, mock.Anything).Return(int64(0), assert.AnError)
+			},
+			expected:    nil,
+			expectedErr: status.Errorf(codes.Internal, "add rule: %v", assert.AnError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerServiceImpl{}
+			tt.mockSetup(mockService)
+			server := NewLedgerServer(mockService)
+
+			resp, err := server.RuleAdd(context.Background(), tt.req)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, resp)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerServer_RuleDelete(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	_, err := server.RuleDelete(context.Background(), &pb.RuleDeleteRequest{})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "id is required"), err)
+
+	mockService.On("RuleDelete", mock.Anything, int64(1)).Return(nil)
+	_, err = server.RuleDelete(context.Background(), &pb.RuleDeleteRequest{Id: 1})
+	assert.NoError(t, err)
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerServer_RulesList(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	mockService.On("RulesList", mock.Anything).Return([]domain.Rule{
+		{ID: 1, Priority: 1, DescriptionRegex: `\*UBER`, CategoryID: 3, Category: "Taxi", Description: "Uber"},
+	}, nil)
+
+	resp, err := server.RulesList(context.Background(), &emptypb.Empty{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.Rule{
+		{Id: 1, Priority: 1, DescriptionRegex: `\*UBER`, CategoryId: 3, Category: "Taxi", Description: "Uber"},
+	}, resp.Rules)
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerServer_RuleTest(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         *pb.RuleTestRequest
+		mockSetup   func(*MockLedgerServiceImpl)
+		expected    *pb.RuleTestResponse
+		expectedErr error
+	}{
+		{
+			name: "default window",
+			req:  &pb.RuleTestRequest{Rule: &pb.Rule{Id: 4}},
+			mockSetup: func(m *MockLedgerServiceImpl) {
+				m.On("RuleTest", mock.Anything, &domain.Rule{ID: 4}, 100).Return([]domain.RuleMatch{
+					{
+						Transaction: domain.Transaction{ID: 1, Amount: 10, CategoryID: 9, Category: "Misc", Description: "Coffee", Date: "2025-12-01"},
+						CategoryID:  1,
+						Category:    "Food",
+						Description: "Coffee",
+					},
+				}, nil)
+			},
+			expected: &pb.RuleTestResponse{Matches: []*pb.RuleMatch{
+				{
+					Transaction: &pb.TransactionGetResponse{Id: 1, Amount: 10, CategoryId: 9, Category: "Misc", Description: "Coffee", Date: "2025-12-01"},
+					CategoryId:  1,
+					Category:    "Food",
+					Description: "Coffee",
+				},
+			}},
+			expectedErr: nil,
+		},
+		{
+			name:        "missing rule",
+			req:         &pb.RuleTestRequest{Last: 10},
+			mockSetup:   func(m *MockLedgerServiceImpl) {},
+			expected:    nil,
+			expectedErr: status.Error(codes.InvalidArgument, "rule is required"),
+		},
+		{
+			name:        "window too large",
+			req:         &pb.RuleTestRequest{Rule: &pb.Rule{Id: 4}, Last: 5000},
+			mockSetup:   func(m *MockLedgerServiceImpl) {},
+			expected:    nil,
+			expectedErr: status.Error(codes.InvalidArgument, "last must be between 1 and 1000, or omitted for 100"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockLedgerServiceImpl{}
+			tt.mockSetup(mockService)
+			server := NewLedgerServer(mockService)
+
+			resp, err := server.RuleTest(context.Background(), tt.req)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, resp)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package grpcserver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"ledger/internal/domain"
+	pb "ledger/internal/pb/ledger/v1"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *LedgerServer) WebhookAdd(ctx context.Context, req *pb.Webhook) (*pb.WebhookAddResponse, error) {
+	webhook := &domain.Webhook{
+		URL:    req.GetUrl(),
+		Secret: req.GetSecret(),
+		Events: req.GetEvents(),
+	}
+	if err := webhook.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	id, err := s.ledgerService.WebhookAdd(ctx, webhook)
+	if err != nil {
+		return nil, webhookError("add webhook", err)
+	}
+	return &pb.WebhookAddResponse{Id: id}, nil
+}
+
+func (s *LedgerServer) WebhookDelete(ctx context.Context, req *pb.WebhookRequest) (*emptypb.Empty, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	err := s.ledgerService.WebhookDelete(ctx, req.GetId())
+	if err != nil {
+		return nil, webhookError("delete webhook", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *LedgerServer) WebhooksList(ctx context.Context, _ *emptypb.Empty) (*pb.WebhookListResponse, error) {
+	webhooks, err := s.ledgerService.WebhooksList(ctx)
+	if err != nil {
+		return nil, webhookError("list webhooks", err)
+	}
+	resp := &pb.WebhookListResponse{}
+	for _, webhook := range webhooks {
+		resp.Webhooks = append(resp.Webhooks, &pb.Webhook{
+			Id:     webhook.ID,
+			Url:    webhook.URL,
+			Events: webhook.Events,
+		})
+	}
+	return resp, nil
+}
+
+func (s *LedgerServer) WebhookDeliveriesList(ctx context.Context, req *pb.WebhookDeliveriesRequest) (*pb.WebhookDeliveriesResponse, error) {
+	if req.GetWebhookId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "webhook_id is required")
+	}
+	if req.GetLimit() < 0 || req.GetLimit() > 500 {
+		return nil, status.Error(codes.InvalidArgument, "limit must be between 0 and 500")
+	}
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = 50
+	}
+	deliveries, err := s.ledgerService.WebhookDeliveriesList(ctx, req.GetWebhookId(), limit)
+	if err != nil {
+		return nil, webhookError("list webhook deliveries", err)
+	}
+	resp := &pb.WebhookDeliveriesResponse{}
+	for _, delivery := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, webhookDeliveryToPb(delivery))
+	}
+	return resp, nil
+}
+
+func webhookError(action string, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, "webhook not found")
+	}
+	return status.Errorf(codes.Internal, "%s: %v", action, err)
+}
+
+func webhookDeliveryToPb(delivery domain.WebhookDelivery) *pb.WebhookDelivery {
+	resp := &pb.WebhookDelivery{
+		Id:             delivery.ID,
+		WebhookId:      delivery.WebhookID,
+		Event:          delivery.Event,
+		Payload:        string(delivery.Payload),
+		Status:         delivery.Status,
+		Attempts:       int32(delivery.Attempts),
+		LastStatusCode: int32(delivery.LastStatusCode),
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt.Format(time.RFC3339),
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+	}
+	if delivery.DeliveredAt != nil {
+		resp.DeliveredAt = delivery.DeliveredAt.Format(time.RFC3339)
+	}
+	return resp
+}
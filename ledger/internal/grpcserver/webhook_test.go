@@ -0,0 +1,101 @@
+package grpcserver
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"ledger/internal/domain"
+
+	pb "ledger/internal/pb/ledger/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestLedgerServer_WebhookAdd(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	webhook := &domain.Webhook{URL: "https://bot.example.com/hook", Secret: "s3cret", Events: []string{"budget.exceeded"}}
+	mockService.On("WebhookAdd", mock.Anything, webhook).Return(int64(1), nil)
+
+	resp, err := server.WebhookAdd(context.Background(), &pb.Webhook{Url: "https://bot.example.com/hook", Secret: "s3cret", Events: []string{"budget.exceeded"}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Id)
+
+	_, err = server.WebhookAdd(context.Background(), &pb.Webhook{Url: "https://bot.example.com/hook", Secret: "s3cret", Events: []string{"budget.spent"}})
+	assert.Equal(t, status.Error(codes.InvalidArgument, `unknown event "budget.spent"`), err)
+
+	_, err = server.WebhookAdd(context.Background(), &pb.Webhook{Url: "ftp://bot.example.com", Secret: "s3cret", Events: []string{"budget.exceeded"}})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "invalid url"), err)
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerServer_WebhookDelete(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	mockService.On("WebhookDelete", mock.Anything, int64(1)).Return(nil)
+	mockService.On("WebhookDelete", mock.Anything, int64(2)).Return(sql.ErrNoRows)
+
+	_, err := server.WebhookDelete(context.Background(), &pb.WebhookRequest{Id: 1})
+	assert.NoError(t, err)
+
+	_, err = server.WebhookDelete(context.Background(), &pb.WebhookRequest{Id: 2})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = server.WebhookDelete(context.Background(), &pb.WebhookRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerServer_WebhooksList(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	mockService.On("WebhooksList", mock.Anything).Return([]domain.Webhook{{ID: 1, URL: "https://bot.example.com/hook", Events: []string{"budget.exceeded"}}}, nil)
+
+	resp, err := server.WebhooksList(context.Background(), &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Webhooks, 1)
+	assert.Equal(t, "https://bot.example.com/hook", resp.Webhooks[0].Url)
+	assert.Empty(t, resp.Webhooks[0].Secret)
+	mockService.AssertExpectations(t)
+}
+
+func TestLedgerServer_WebhookDeliveriesList(t *testing.T) {
+	mockService := &MockLedgerServiceImpl{}
+	server := NewLedgerServer(mockService)
+
+	deliveredAt := time.Date(2025, 12, 10, 12, 0, 5, 0, time.UTC)
+	mockService.On("WebhookDeliveriesList", mock.Anything, int64(1), 50).Return([]domain.WebhookDelivery{
+		{
+			ID:             7,
+			WebhookID:      1,
+			Event:          "budget.exceeded",
+			Payload:        []byte(`{"category":"Food"}`),
+			Status:         "delivered",
+			Attempts:       2,
+			LastStatusCode: 200,
+			NextAttemptAt:  deliveredAt,
+			CreatedAt:      time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC),
+			DeliveredAt:    &deliveredAt,
+		},
+	}, nil)
+
+	resp, err := server.WebhookDeliveriesList(context.Background(), &pb.WebhookDeliveriesRequest{WebhookId: 1})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Deliveries, 1)
+	assert.Equal(t, `{"category":"Food"}`, resp.Deliveries[0].Payload)
+	assert.Equal(t, int32(2), resp.Deliveries[0].Attempts)
+	assert.Equal(t, "2025-12-10T12:00:05Z", resp.Deliveries[0].DeliveredAt)
+
+	_, err = server.WebhookDeliveriesList(context.Background(), &pb.WebhookDeliveriesRequest{WebhookId: 1, Limit: 1000})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockService.AssertExpectations(t)
+}
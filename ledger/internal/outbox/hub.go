@@ -0,0 +1,217 @@
+package outbox
+
+import (
+	"context"
+	"ledger/internal/domain"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	hubPageSize             = 500
+	defaultSubscriberBuffer = 256
+)
+
+// Hub polls the outbox table and fans new events out to subscribers. Every
+// ledger instance runs its own hub, so a subscriber sees events written
+// through any instance regardless of which one relays them.
+type Hub struct {
+	repository  domain.OutboxRepository
+	bufferSize  int
+	mu          sync.Mutex
+	started     bool
+	closed      bool
+	lastID      int64
+	subscribers map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	events chan domain.OutboxEvent
+	space  string
+	types  map[string]bool
+	err    error
+}
+
+func (s *subscriber) accepts(event domain.OutboxEvent) bool {
+	return event.SpaceID == s.space && (len(s.types) == 0 || s.types[event.Type])
+}
+
+func NewHub(repository domain.OutboxRepository) *Hub {
+	return &Hub{
+		repository:  repository,
+		bufferSize:  defaultSubscriberBuffer,
+		subscribers: map[*subscriber]struct{}{},
+	}
+}
+
+// Run polls until ctx is done and then ends every open stream, which lets a
+// graceful server stop finish instead of waiting on idle subscribers.
+func (h *Hub) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer h.close()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := h.Poll(ctx); err != nil {
+				log.Printf("event hub: %v", err)
+			}
+		}
+	}
+}
+
+// Poll broadcasts the events committed since the previous poll.
+func (h *Hub) Poll(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.start(ctx); err != nil {
+		return err
+	}
+	for {
+		events, err := h.repository.ListEventsAfter(ctx, h.lastID, hubPageSize)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			h.broadcast(event)
+		}
+		if len(events) < hubPageSize {
+			return nil
+		}
+	}
+}
+
+// start skips the history that existed before the hub came up; subscribers
+// that want it replay it from the table.
+func (h *Hub) start(ctx context.Context) error {
+	if h.started {
+		return nil
+	}
+	id, err := h.repository.LatestEventID(ctx)
+	if err != nil {
+		return err
+	}
+	h.lastID = id
+	h.started = true
+	return nil
+}
+
+// broadcast never blocks on a subscriber. One whose buffer is full is cut
+// off so a stalled consumer can not hold back the others; events of other
+// spaces never take up its buffer.
+func (h *Hub) broadcast(event domain.OutboxEvent) {
+	h.lastID = event.ID
+	for s := range h.subscribers {
+		if !s.accepts(event) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+			h.drop(s, domain.ErrSubscriberLagging)
+		}
+	}
+}
+
+func (h *Hub) drop(s *subscriber, err error) {
+	delete(h.subscribers, s)
+	s.err = err
+	close(s.events)
+}
+
+func (h *Hub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for s := range h.subscribers {
+		h.drop(s, domain.ErrEventStreamClosed)
+	}
+}
+
+func (h *Hub) subscribe(ctx context.Context, space string, types []string) (*subscriber, int64, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, 0, domain.ErrEventStreamClosed
+	}
+	if err := h.start(ctx); err != nil {
+		return nil, 0, err
+	}
+	s := &subscriber{events: make(chan domain.OutboxEvent, h.bufferSize), space: space}
+	if len(types) > 0 {
+		s.types = map[string]bool{}
+		for _, t := range types {
+			s.types[t] = true
+		}
+	}
+	h.subscribers[s] = struct{}{}
+	return s, h.lastID, nil
+}
+
+func (h *Hub) unsubscribe(s *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscribers[s]; ok {
+		h.drop(s, nil)
+	}
+}
+
+// Stream replays events of space after afterID from the table up to the point
+// where the subscription went live, then forwards live events. An afterID of
+// zero skips the replay. Events are delivered in id order without duplicates.
+func (h *Hub) Stream(ctx context.Context, space string, afterID int64, types []string, send func(domain.OutboxEvent) error) error {
+	s, liveFrom, err := h.subscribe(ctx, space, types)
+	if err != nil {
+		return err
+	}
+	defer h.unsubscribe(s)
+	cursor := afterID
+	if afterID > 0 {
+		cursor, err = h.replay(ctx, s, afterID, liveFrom, send)
+		if err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-s.events:
+			if !ok {
+				return s.err
+			}
+			if event.ID <= cursor {
+				continue
+			}
+			cursor = event.ID
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (h *Hub) replay(ctx context.Context, s *subscriber, cursor int64, until int64, send func(domain.OutboxEvent) error) (int64, error) {
+	for cursor < until {
+		events, err := h.repository.ListSpaceEventsAfter(ctx, s.space, cursor, hubPageSize)
+		if err != nil || len(events) == 0 {
+			return cursor, err
+		}
+		for _, event := range events {
+			if event.ID > until {
+				return cursor, nil
+			}
+			cursor = event.ID
+			if !s.accepts(event) {
+				continue
+			}
+			if err := send(event); err != nil {
+				return cursor, err
+			}
+		}
+	}
+	return cursor, nil
+}
@@ -0,0 +1,166 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ledger/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type streamResult struct {
+	events chan domain.OutboxEvent
+	done   chan error
+}
+
+func startStream(ctx context.Context, hub *Hub, space string, afterID int64, types []string) *streamResult {
+	r := &streamResult{events: make(chan domain.OutboxEvent, 100), done: make(chan error, 1)}
+	go func() {
+		r.done <- hub.Stream(ctx, space, afterID, types, func(e domain.OutboxEvent) error {
+			r.events <- e
+			return nil
+		})
+	}()
+	return r
+}
+
+func (r *streamResult) next(t *testing.T) domain.OutboxEvent {
+	t.Helper()
+	select {
+	case e := <-r.events:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return domain.OutboxEvent{}
+	}
+}
+
+func waitSubscribers(t *testing.T, hub *Hub, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		return len(hub.subscribers) == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestHub_ResumeThenLive(t *testing.T) {
+	repo := newMemoryOutbox(4)
+	hub := NewHub(repo)
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, hub.Poll(ctx))
+
+	stream := startStream(ctx, hub, "", 2, nil)
+	assert.Equal(t, int64(3), stream.next(t).ID)
+	assert.Equal(t, int64(4), stream.next(t).ID)
+
+	repo.append(domain.EventBudgetSet)
+	require.NoError(t, hub.Poll(ctx))
+	assert.Equal(t, int64(5), stream.next(t).ID)
+
+	cancel()
+	assert.ErrorIs(t, <-stream.done, context.Canceled)
+	waitSubscribers(t, hub, 0)
+}
+
+func TestHub_LiveOnlyWithTypeFilter(t *testing.T) {
+	repo := newMemoryOutbox(3)
+	hub := NewHub(repo)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := startStream(ctx, hub, "", 0, []string{domain.EventBudgetSet})
+	waitSubscribers(t, hub, 1)
+	repo.append(domain.EventTransactionCreated)
+	repo.append(domain.EventBudgetSet)
+	require.NoError(t, hub.Poll(ctx))
+
+	assert.Equal(t, domain.OutboxEvent{ID: 5, Type: domain.EventBudgetSet, AggregateID: 50, Payload: []byte(`{}`)}, stream.next(t))
+	assert.Empty(t, stream.events)
+}
+
+func TestHub_SlowSubscriberIsDisconnected(t *testing.T) {
+	repo := newMemoryOutbox(0)
+	hub := NewHub(repo)
+	ctx := context.Background()
+
+	fast := startStream(ctx, hub, "", 0, nil)
+	waitSubscribers(t, hub, 1)
+
+	hub.mu.Lock()
+	hub.bufferSize = 2
+	hub.mu.Unlock()
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	received := 0
+	go func() {
+		done <- hub.Stream(ctx, "", 0, nil, func(e domain.OutboxEvent) error {
+			received++
+			<-release
+			return nil
+		})
+	}()
+	waitSubscribers(t, hub, 2)
+
+	for i := 0; i < 5; i++ {
+		repo.append(domain.EventTransactionCreated)
+	}
+	require.NoError(t, hub.Poll(ctx))
+	close(release)
+
+	assert.True(t, errors.Is(<-done, domain.ErrSubscriberLagging))
+	assert.Less(t, received, 5)
+	for i := int64(1); i <= 5; i++ {
+		assert.Equal(t, i, fast.next(t).ID)
+	}
+}
+
+func TestHub_OtherSpacesDoNotFillBuffer(t *testing.T) {
+	const space = "5f0c2a8e-3b1d-4c7a-9e21-7d4b6a1c0f93"
+	const other = "3d7e9a4c-1b2f-4c5d-8e6f-7a8b9c0d1e2f"
+	repo := newMemoryOutbox(0)
+	repo.appendTo(other, domain.EventTransactionCreated)
+	repo.appendTo(space, domain.EventBudgetSet)
+	repo.appendTo(other, domain.EventBudgetSet)
+	hub := NewHub(repo)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, hub.Poll(ctx))
+	hub.mu.Lock()
+	hub.bufferSize = 2
+	hub.mu.Unlock()
+
+	stream := startStream(ctx, hub, space, 1, nil)
+	assert.Equal(t, int64(2), stream.next(t).ID)
+	for i := 0; i < 5; i++ {
+		repo.appendTo(other, domain.EventTransactionCreated)
+	}
+	repo.appendTo(space, domain.EventTransactionCreated)
+	require.NoError(t, hub.Poll(ctx))
+
+	assert.Equal(t, domain.OutboxEvent{ID: 9, Type: domain.EventTransactionCreated, AggregateID: 90, Payload: []byte(`{}`), SpaceID: space}, stream.next(t))
+	assert.Empty(t, stream.events)
+	waitSubscribers(t, hub, 1)
+}
+
+func TestHub_RunClosesStreams(t *testing.T) {
+	hub := NewHub(newMemoryOutbox(1))
+	ctx, cancel := context.WithCancel(context.Background())
+	runDone := make(chan error, 1)
+	go func() {
+		runDone <- hub.Run(ctx, time.Millisecond)
+	}()
+
+	stream := startStream(context.Background(), hub, "", 0, nil)
+	waitSubscribers(t, hub, 1)
+	cancel()
+
+	assert.NoError(t, <-runDone)
+	assert.ErrorIs(t, <-stream.done, domain.ErrEventStreamClosed)
+	err := hub.Stream(context.Background(), "", 0, nil, func(domain.OutboxEvent) error { return nil })
+	assert.ErrorIs(t, err, domain.ErrEventStreamClosed)
+}
@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"context"
+	"ledger/internal/domain"
+	"log"
+	"time"
+)
+
+const defaultBatchSize = 100
+
+// Relay moves committed outbox events to a sink. An event is marked
+// published only after the sink accepted it, so a crash in between delivers
+// it again with the same id: consumers get every event at least once and
+// should deduplicate by id.
+type Relay struct {
+	repository domain.OutboxRepository
+	sink       domain.EventSink
+	batchSize  int
+}
+
+func NewRelay(repository domain.OutboxRepository, sink domain.EventSink) *Relay {
+	return &Relay{
+		repository: repository,
+		sink:       sink,
+		batchSize:  defaultBatchSize,
+	}
+}
+
+func (r *Relay) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := r.Drain(ctx); err != nil {
+				log.Printf("outbox relay: %v", err)
+			}
+		}
+	}
+}
+
+// Drain publishes batches until the outbox is empty and returns how many
+// events were published.
+func (r *Relay) Drain(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		n, err := r.repository.RelayOutbox(ctx, r.batchSize, r.sink.Publish)
+		total += n
+		if err != nil || n < r.batchSize {
+			return total, err
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"ledger/internal/domain"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryOutbox struct {
+	mu        sync.Mutex
+	events    []domain.OutboxEvent
+	published map[int64]bool
+}
+
+func newMemoryOutbox(n int) *memoryOutbox {
+	o := &memoryOutbox{published: map[int64]bool{}}
+	for i := 1; i <= n; i++ {
+		o.events = append(o.events, domain.OutboxEvent{ID: int64(i), Type: domain.EventTransactionCreated, AggregateID: int64(i * 10), Payload: []byte(`{}`)})
+	}
+	return o
+}
+
+func (o *memoryOutbox) RelayOutbox(ctx context.Context, limit int, publish func(context.Context, []domain.OutboxEvent) error) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var batch []domain.OutboxEvent
+	for _, e := range o.events {
+		if !o.published[e.ID] && len(batch) < limit {
+			batch = append(batch, e)
+		}
+	}
+	if len(batch) == 0 {
+		return 0, nil
+	}
+	if err := publish(ctx, batch); err != nil {
+		return 0, err
+	}
+	for _, e := range batch {
+		o.published[e.ID] = true
+	}
+	return len(batch), nil
+}
+
+func (o *memoryOutbox) ListEventsAfter(ctx context.Context, afterID int64, limit int) ([]domain.OutboxEvent, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var events []domain.OutboxEvent
+	for _, e := range o.events {
+		if e.ID > afterID && len(events) < limit {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (o *memoryOutbox) ListSpaceEventsAfter(ctx context.Context, spaceID string, afterID int64, limit int) ([]domain.OutboxEvent, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var events []domain.OutboxEvent
+	for _, e := range o.events {
+		if e.SpaceID == spaceID && e.ID > afterID && len(events) < limit {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (o *memoryOutbox) LatestEventID(ctx context.Context) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.events) == 0 {
+		return 0, nil
+	}
+	return o.events[len(o.events)-1].ID, nil
+}
+
+func (o *memoryOutbox) append(eventType string) {
+	o.appendTo("", eventType)
+}
+
+func (o *memoryOutbox) appendTo(space string, eventType string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	id := int64(len(o.events) + 1)
+	o.events = append(o.events, domain.OutboxEvent{ID: id, Type: eventType, AggregateID: id * 10, Payload: []byte(`{}`), SpaceID: space})
+}
+
+type flakySink struct {
+	*MemorySink
+	failures int
+}
+
+func (s *flakySink) Publish(ctx context.Context, events []domain.OutboxEvent) error {
+	if err := s.MemorySink.Publish(ctx, events); err != nil {
+		return err
+	}
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("ack lost")
+	}
+	return nil
+}
+
+func eventIDs(events []domain.OutboxEvent) []int64 {
+	ids := make([]int64, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+func TestRelay_DrainInBatches(t *testing.T) {
+	sink := NewMemorySink()
+	relay := NewRelay(newMemoryOutbox(5), sink)
+	relay.batchSize = 2
+
+	n, err := relay.Drain(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, eventIDs(sink.Events()))
+
+	n, err = relay.Drain(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestRelay_AtLeastOnce(t *testing.T) {
+	sink := &flakySink{MemorySink: NewMemorySink(), failures: 1}
+	relay := NewRelay(newMemoryOutbox(3), sink)
+	relay.batchSize = 2
+
+	_, err := relay.Drain(context.Background())
+	assert.EqualError(t, err, "ack lost")
+
+	n, err := relay.Drain(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []int64{1, 2, 1, 2, 3}, eventIDs(sink.Events()))
+}
+
+func TestRedisStreamSink_Publish(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+
+	sink := NewRedisStreamSink(client, "ledger:events", 0)
+	createdAt := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+	first := []domain.OutboxEvent{
+		{ID: 1, Type: "budget.set", AggregateID: 3, Payload: []byte(`{"category_id":3,"limit":300}`), CreatedAt: createdAt, SpaceID: "5f0c2a8e-3b1d-4c7a-9e21-7d4b6a1c0f93"},
+		{ID: 2, Type: "transaction.created", AggregateID: 7, Payload: []byte(`{"id":7}`), CreatedAt: createdAt},
+	}
+	require.NoError(t, sink.Publish(ctx, first))
+	require.NoError(t, sink.Publish(ctx, append(first, domain.OutboxEvent{ID: 3, Type: "transaction.created", AggregateID: 8, Payload: []byte(`{"id":8}`), CreatedAt: createdAt})))
+
+	entries, err := client.XRange(ctx, "ledger:events", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "1-0", entries[0].ID)
+	assert.Equal(t, "3-0", entries[2].ID)
+	assert.Equal(t, map[string]any{
+		"type":         "budget.set",
+		"aggregate_id": "3",
+		"payload":      `{"category_id":3,"limit":300}`,
+		"created_at":   "2025-12-10T12:00:00Z",
+		"space_id":     "5f0c2a8e-3b1d-4c7a-9e21-7d4b6a1c0f93",
+	}, entries[0].Values)
+}
@@ -0,0 +1,92 @@
+package outbox
+
+import (
+	"context"
+	"ledger/internal/domain"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStreamSink appends events to a Redis stream using the outbox id as
+// the entry id. A redelivered event is rejected by Redis as not newer than
+// the stream top and is skipped, which makes republishing idempotent.
+type RedisStreamSink struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+func NewRedisStreamSink(client *redis.Client, stream string, maxLen int64) *RedisStreamSink {
+	return &RedisStreamSink{
+		client: client,
+		stream: stream,
+		maxLen: maxLen,
+	}
+}
+
+func (s *RedisStreamSink) Publish(ctx context.Context, events []domain.OutboxEvent) error {
+	for _, event := range events {
+		err := s.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: s.stream,
+			MaxLen: s.maxLen,
+			Approx: s.maxLen > 0,
+			ID:     strconv.FormatInt(event.ID, 10) + "-0",
+			Values: map[string]any{
+				"type":         event.Type,
+				"aggregate_id": event.AggregateID,
+				"payload":      string(event.Payload),
+				"created_at":   event.CreatedAt.Format(time.RFC3339Nano),
+				"space_id":     event.SpaceID,
+			},
+		}).Err()
+		if err != nil && !strings.Contains(err.Error(), "equal or smaller than the target stream top item") {
+			return err
+		}
+	}
+	return nil
+}
+
+type LogSink struct {
+	logger *log.Logger
+}
+
+func NewLogSink(logger *log.Logger) *LogSink {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LogSink{logger: logger}
+}
+
+func (s *LogSink) Publish(ctx context.Context, events []domain.OutboxEvent) error {
+	for _, event := range events {
+		s.logger.Printf("event %d %s aggregate=%d %s", event.ID, event.Type, event.AggregateID, event.Payload)
+	}
+	return nil
+}
+
+type MemorySink struct {
+	mu     sync.Mutex
+	events []domain.OutboxEvent
+}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (s *MemorySink) Publish(ctx context.Context, events []domain.OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, events...)
+	return nil
+}
+
+func (s *MemorySink) Events() []domain.OutboxEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]domain.OutboxEvent(nil), s.events...)
+}
@@ -20,14 +20,41 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	LedgerService_BudgetAdd_FullMethodName           = "/ledger.v1.LedgerService/BudgetAdd"
-	LedgerService_BudgetGet_FullMethodName           = "/ledger.v1.LedgerService/BudgetGet"
-	LedgerService_BudgetsList_FullMethodName         = "/ledger.v1.LedgerService/BudgetsList"
-	LedgerService_TransactionAdd_FullMethodName      = "/ledger.v1.LedgerService/TransactionAdd"
-	LedgerService_TransactionGet_FullMethodName      = "/ledger.v1.LedgerService/TransactionGet"
-	LedgerService_TransactionList_FullMethodName     = "/ledger.v1.LedgerService/TransactionList"
-	LedgerService_ReportSummary_FullMethodName       = "/ledger.v1.LedgerService/ReportSummary"
-	LedgerService_BulkAddTransactions_FullMethodName = "/ledger.v1.LedgerService/BulkAddTransactions"
+	LedgerService_BudgetAdd_FullMethodName             = "/ledger.v1.LedgerService/BudgetAdd"
+	LedgerService_BudgetGet_FullMethodName             = "/ledger.v1.LedgerService/BudgetGet"
+	LedgerService_BudgetsList_FullMethodName           = "/ledger.v1.LedgerService/BudgetsList"
+	LedgerService_TransactionAdd_FullMethodName        = "/ledger.v1.LedgerService/TransactionAdd"
+	LedgerService_TransactionGet_FullMethodName        = "/ledger.v1.LedgerService/TransactionGet"
+	LedgerService_TransactionList_FullMethodName       = "/ledger.v1.LedgerService/TransactionList"
+	LedgerService_TransactionSearch_FullMethodName     = "/ledger.v1.LedgerService/TransactionSearch"
+	LedgerService_ReportSummary_FullMethodName         = "/ledger.v1.LedgerService/ReportSummary"
+	LedgerService_ReportForecast_FullMethodName        = "/ledger.v1.LedgerService/ReportForecast"
+	LedgerService_ReportAnomalies_FullMethodName       = "/ledger.v1.LedgerService/ReportAnomalies"
+	LedgerService_BulkAddTransactions_FullMethodName   = "/ledger.v1.LedgerService/BulkAddTransactions"
+	LedgerService_CategoryAdd_FullMethodName           = "/ledger.v1.LedgerService/CategoryAdd"
+	LedgerService_CategoryUpdate_FullMethodName        = "/ledger.v1.LedgerService/CategoryUpdate"
+	LedgerService_CategoriesList_FullMethodName        = "/ledger.v1.LedgerService/CategoriesList"
+	LedgerService_RuleAdd_FullMethodName               = "/ledger.v1.LedgerService/RuleAdd"
+	LedgerService_RuleUpdate_FullMethodName            = "/ledger.v1.LedgerService/RuleUpdate"
+	LedgerService_RuleDelete_FullMethodName            = "/ledger.v1.LedgerService/RuleDelete"
+	LedgerService_RulesList_FullMethodName             = "/ledger.v1.LedgerService/RulesList"
+	LedgerService_RuleTest_FullMethodName              = "/ledger.v1.LedgerService/RuleTest"
+	LedgerService_AttachmentUpload_FullMethodName      = "/ledger.v1.LedgerService/AttachmentUpload"
+	LedgerService_AttachmentDownload_FullMethodName    = "/ledger.v1.LedgerService/AttachmentDownload"
+	LedgerService_AttachmentDelete_FullMethodName      = "/ledger.v1.LedgerService/AttachmentDelete"
+	LedgerService_AttachmentsList_FullMethodName       = "/ledger.v1.LedgerService/AttachmentsList"
+	LedgerService_GoalAdd_FullMethodName               = "/ledger.v1.LedgerService/GoalAdd"
+	LedgerService_GoalUpdate_FullMethodName            = "/ledger.v1.LedgerService/GoalUpdate"
+	LedgerService_GoalDelete_FullMethodName            = "/ledger.v1.LedgerService/GoalDelete"
+	LedgerService_GoalGet_FullMethodName               = "/ledger.v1.LedgerService/GoalGet"
+	LedgerService_GoalsList_FullMethodName             = "/ledger.v1.LedgerService/GoalsList"
+	LedgerService_GoalContribute_FullMethodName        = "/ledger.v1.LedgerService/GoalContribute"
+	LedgerService_WebhookAdd_FullMethodName            = "/ledger.v1.LedgerService/WebhookAdd"
+	LedgerService_WebhookDelete_FullMethodName         = "/ledger.v1.LedgerService/WebhookDelete"
+	LedgerService_WebhooksList_FullMethodName          = "/ledger.v1.LedgerService/WebhooksList"
+	LedgerService_WebhookDeliveriesList_FullMethodName = "/ledger.v1.LedgerService/WebhookDeliveriesList"
+	LedgerService_Subscribe_FullMethodName             = "/ledger.v1.LedgerService/Subscribe"
+	LedgerService_AuditList_FullMethodName             = "/ledger.v1.LedgerService/AuditList"
 )
 
 // LedgerServiceClient is the client API for LedgerService service.
@@ -36,12 +63,39 @@ const (
 type LedgerServiceClient interface {
 	BudgetAdd(ctx context.Context, in *BudgetAddRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	BudgetGet(ctx context.Context, in *BudgetGetRequest, opts ...grpc.CallOption) (*BudgetGetResponse, error)
-	BudgetsList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BudgetGetListResponse, error)
+	BudgetsList(ctx context.Context, in *BudgetsListRequest, opts ...grpc.CallOption) (*BudgetGetListResponse, error)
 	TransactionAdd(ctx context.Context, in *TransactionAddRequest, opts ...grpc.CallOption) (*TransactionAddResponse, error)
 	TransactionGet(ctx context.Context, in *TransactionGetRequest, opts ...grpc.CallOption) (*TransactionGetResponse, error)
-	TransactionList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TransactionGetListResponse, error)
+	TransactionList(ctx context.Context, in *TransactionListRequest, opts ...grpc.CallOption) (*TransactionGetListResponse, error)
+	TransactionSearch(ctx context.Context, in *TransactionSearchRequest, opts ...grpc.CallOption) (*TransactionSearchResponse, error)
 	ReportSummary(ctx context.Context, in *SummaryRequest, opts ...grpc.CallOption) (*SummaryResponse, error)
+	ReportForecast(ctx context.Context, in *ForecastRequest, opts ...grpc.CallOption) (*ForecastResponse, error)
+	ReportAnomalies(ctx context.Context, in *AnomaliesRequest, opts ...grpc.CallOption) (*AnomaliesResponse, error)
 	BulkAddTransactions(ctx context.Context, in *TransactionBulkAddRequest, opts ...grpc.CallOption) (*TransactionBulkAddResponse, error)
+	CategoryAdd(ctx context.Context, in *CategoryAddRequest, opts ...grpc.CallOption) (*CategoryAddResponse, error)
+	CategoryUpdate(ctx context.Context, in *CategoryUpdateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CategoriesList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CategoryGetListResponse, error)
+	RuleAdd(ctx context.Context, in *Rule, opts ...grpc.CallOption) (*RuleAddResponse, error)
+	RuleUpdate(ctx context.Context, in *Rule, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RuleDelete(ctx context.Context, in *RuleDeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RulesList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RuleListResponse, error)
+	RuleTest(ctx context.Context, in *RuleTestRequest, opts ...grpc.CallOption) (*RuleTestResponse, error)
+	AttachmentUpload(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[AttachmentUploadRequest, Attachment], error)
+	AttachmentDownload(ctx context.Context, in *AttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AttachmentChunk], error)
+	AttachmentDelete(ctx context.Context, in *AttachmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	AttachmentsList(ctx context.Context, in *AttachmentsListRequest, opts ...grpc.CallOption) (*AttachmentListResponse, error)
+	GoalAdd(ctx context.Context, in *Goal, opts ...grpc.CallOption) (*GoalAddResponse, error)
+	GoalUpdate(ctx context.Context, in *Goal, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GoalDelete(ctx context.Context, in *GoalRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GoalGet(ctx context.Context, in *GoalRequest, opts ...grpc.CallOption) (*GoalProgress, error)
+	GoalsList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GoalListResponse, error)
+	GoalContribute(ctx context.Context, in *GoalContributeRequest, opts ...grpc.CallOption) (*TransactionAddResponse, error)
+	WebhookAdd(ctx context.Context, in *Webhook, opts ...grpc.CallOption) (*WebhookAddResponse, error)
+	WebhookDelete(ctx context.Context, in *WebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	WebhooksList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WebhookListResponse, error)
+	WebhookDeliveriesList(ctx context.Context, in *WebhookDeliveriesRequest, opts ...grpc.CallOption) (*WebhookDeliveriesResponse, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LedgerEvent], error)
+	AuditList(ctx context.Context, in *AuditListRequest, opts ...grpc.CallOption) (*AuditListResponse, error)
 }
 
 type ledgerServiceClient struct {
@@ -72,7 +126,7 @@ func (c *ledgerServiceClient) BudgetGet(ctx context.Context, in *BudgetGetReques
 	return out, nil
 }
 
-func (c *ledgerServiceClient) BudgetsList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BudgetGetListResponse, error) {
+func (c *ledgerServiceClient) BudgetsList(ctx context.Context, in *BudgetsListRequest, opts ...grpc.CallOption) (*BudgetGetListResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(BudgetGetListResponse)
 	err := c.cc.Invoke(ctx, LedgerService_BudgetsList_FullMethodName, in, out, cOpts...)
@@ -102,7 +156,7 @@ func (c *ledgerServiceClient) TransactionGet(ctx context.Context, in *Transactio
 	return out, nil
 }
 
-func (c *ledgerServiceClient) TransactionList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TransactionGetListResponse, error) {
+func (c *ledgerServiceClient) TransactionList(ctx context.Context, in *TransactionListRequest, opts ...grpc.CallOption) (*TransactionGetListResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TransactionGetListResponse)
 	err := c.cc.Invoke(ctx, LedgerService_TransactionList_FullMethodName, in, out, cOpts...)
@@ -112,6 +166,16 @@ func (c *ledgerServiceClient) TransactionList(ctx context.Context, in *emptypb.E
 	return out, nil
 }
 
+func (c *ledgerServiceClient) TransactionSearch(ctx context.Context, in *TransactionSearchRequest, opts ...grpc.CallOption) (*TransactionSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionSearchResponse)
+	err := c.cc.Invoke(ctx, LedgerService_TransactionSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ledgerServiceClient) ReportSummary(ctx context.Context, in *SummaryRequest, opts ...grpc.CallOption) (*SummaryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SummaryResponse)
@@ -122,6 +186,26 @@ func (c *ledgerServiceClient) ReportSummary(ctx context.Context, in *SummaryRequ
 	return out, nil
 }
 
+func (c *ledgerServiceClient) ReportForecast(ctx context.Context, in *ForecastRequest, opts ...grpc.CallOption) (*ForecastResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ForecastResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ReportForecast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ReportAnomalies(ctx context.Context, in *AnomaliesRequest, opts ...grpc.CallOption) (*AnomaliesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnomaliesResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ReportAnomalies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ledgerServiceClient) BulkAddTransactions(ctx context.Context, in *TransactionBulkAddRequest, opts ...grpc.CallOption) (*TransactionBulkAddResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TransactionBulkAddResponse)
@@ -132,18 +216,306 @@ func (c *ledgerServiceClient) BulkAddTransactions(ctx context.Context, in *Trans
 	return out, nil
 }
 
+func (c *ledgerServiceClient) CategoryAdd(ctx context.Context, in *CategoryAddRequest, opts ...grpc.CallOption) (*CategoryAddResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CategoryAddResponse)
+	err := c.cc.Invoke(ctx, LedgerService_CategoryAdd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) CategoryUpdate(ctx context.Context, in *CategoryUpdateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LedgerService_CategoryUpdate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) CategoriesList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CategoryGetListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CategoryGetListResponse)
+	err := c.cc.Invoke(ctx, LedgerService_CategoriesList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) RuleAdd(ctx context.Context, in *Rule, opts ...grpc.CallOption) (*RuleAddResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RuleAddResponse)
+	err := c.cc.Invoke(ctx, LedgerService_RuleAdd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) RuleUpdate(ctx context.Context, in *Rule, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LedgerService_RuleUpdate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) RuleDelete(ctx context.Context, in *RuleDeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LedgerService_RuleDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) RulesList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RuleListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RuleListResponse)
+	err := c.cc.Invoke(ctx, LedgerService_RulesList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) RuleTest(ctx context.Context, in *RuleTestRequest, opts ...grpc.CallOption) (*RuleTestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RuleTestResponse)
+	err := c.cc.Invoke(ctx, LedgerService_RuleTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) AttachmentUpload(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[AttachmentUploadRequest, Attachment], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LedgerService_ServiceDesc.Streams[0], LedgerService_AttachmentUpload_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AttachmentUploadRequest, Attachment]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_AttachmentUploadClient = grpc.ClientStreamingClient[AttachmentUploadRequest, Attachment]
+
+func (c *ledgerServiceClient) AttachmentDownload(ctx context.Context, in *AttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AttachmentChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LedgerService_ServiceDesc.Streams[1], LedgerService_AttachmentDownload_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AttachmentRequest, AttachmentChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_AttachmentDownloadClient = grpc.ServerStreamingClient[AttachmentChunk]
+
+func (c *ledgerServiceClient) AttachmentDelete(ctx context.Context, in *AttachmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LedgerService_AttachmentDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) AttachmentsList(ctx context.Context, in *AttachmentsListRequest, opts ...grpc.CallOption) (*AttachmentListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachmentListResponse)
+	err := c.cc.Invoke(ctx, LedgerService_AttachmentsList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GoalAdd(ctx context.Context, in *Goal, opts ...grpc.CallOption) (*GoalAddResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GoalAddResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GoalAdd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GoalUpdate(ctx context.Context, in *Goal, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LedgerService_GoalUpdate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GoalDelete(ctx context.Context, in *GoalRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LedgerService_GoalDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GoalGet(ctx context.Context, in *GoalRequest, opts ...grpc.CallOption) (*GoalProgress, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GoalProgress)
+	err := c.cc.Invoke(ctx, LedgerService_GoalGet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GoalsList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GoalListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GoalListResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GoalsList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GoalContribute(ctx context.Context, in *GoalContributeRequest, opts ...grpc.CallOption) (*TransactionAddResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionAddResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GoalContribute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) WebhookAdd(ctx context.Context, in *Webhook, opts ...grpc.CallOption) (*WebhookAddResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookAddResponse)
+	err := c.cc.Invoke(ctx, LedgerService_WebhookAdd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) WebhookDelete(ctx context.Context, in *WebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LedgerService_WebhookDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) WebhooksList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WebhookListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookListResponse)
+	err := c.cc.Invoke(ctx, LedgerService_WebhooksList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) WebhookDeliveriesList(ctx context.Context, in *WebhookDeliveriesRequest, opts ...grpc.CallOption) (*WebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, LedgerService_WebhookDeliveriesList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LedgerEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LedgerService_ServiceDesc.Streams[2], LedgerService_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, LedgerEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_SubscribeClient = grpc.ServerStreamingClient[LedgerEvent]
+
+func (c *ledgerServiceClient) AuditList(ctx context.Context, in *AuditListRequest, opts ...grpc.CallOption) (*AuditListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuditListResponse)
+	err := c.cc.Invoke(ctx, LedgerService_AuditList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LedgerServiceServer is the server API for LedgerService service.
 // All implementations must embed UnimplementedLedgerServiceServer
 // for forward compatibility.
 type LedgerServiceServer interface {
 	BudgetAdd(context.Context, *BudgetAddRequest) (*emptypb.Empty, error)
 	BudgetGet(context.Context, *BudgetGetRequest) (*BudgetGetResponse, error)
-	BudgetsList(context.Context, *emptypb.Empty) (*BudgetGetListResponse, error)
+	BudgetsList(context.Context, *BudgetsListRequest) (*BudgetGetListResponse, error)
 	TransactionAdd(context.Context, *TransactionAddRequest) (*TransactionAddResponse, error)
 	TransactionGet(context.Context, *TransactionGetRequest) (*TransactionGetResponse, error)
-	TransactionList(context.Context, *emptypb.Empty) (*TransactionGetListResponse, error)
+	TransactionList(context.Context, *TransactionListRequest) (*TransactionGetListResponse, error)
+	TransactionSearch(context.Context, *TransactionSearchRequest) (*TransactionSearchResponse, error)
 	ReportSummary(context.Context, *SummaryRequest) (*SummaryResponse, error)
+	ReportForecast(context.Context, *ForecastRequest) (*ForecastResponse, error)
+	ReportAnomalies(context.Context, *AnomaliesRequest) (*AnomaliesResponse, error)
 	BulkAddTransactions(context.Context, *TransactionBulkAddRequest) (*TransactionBulkAddResponse, error)
+	CategoryAdd(context.Context, *CategoryAddRequest) (*CategoryAddResponse, error)
+	CategoryUpdate(context.Context, *CategoryUpdateRequest) (*emptypb.Empty, error)
+	CategoriesList(context.Context, *emptypb.Empty) (*CategoryGetListResponse, error)
+	RuleAdd(context.Context, *Rule) (*RuleAddResponse, error)
+	RuleUpdate(context.Context, *Rule) (*emptypb.Empty, error)
+	RuleDelete(context.Context, *RuleDeleteRequest) (*emptypb.Empty, error)
+	RulesList(context.Context, *emptypb.Empty) (*RuleListResponse, error)
+	RuleTest(context.Context, *RuleTestRequest) (*RuleTestResponse, error)
+	AttachmentUpload(grpc.ClientStreamingServer[AttachmentUploadRequest, Attachment]) error
+	AttachmentDownload(*AttachmentRequest, grpc.ServerStreamingServer[AttachmentChunk]) error
+	AttachmentDelete(context.Context, *AttachmentRequest) (*emptypb.Empty, error)
+	AttachmentsList(context.Context, *AttachmentsListRequest) (*AttachmentListResponse, error)
+	GoalAdd(context.Context, *Goal) (*GoalAddResponse, error)
+	GoalUpdate(context.Context, *Goal) (*emptypb.Empty, error)
+	GoalDelete(context.Context, *GoalRequest) (*emptypb.Empty, error)
+	GoalGet(context.Context, *GoalRequest) (*GoalProgress, error)
+	GoalsList(context.Context, *emptypb.Empty) (*GoalListResponse, error)
+	GoalContribute(context.Context, *GoalContributeRequest) (*TransactionAddResponse, error)
+	WebhookAdd(context.Context, *Webhook) (*WebhookAddResponse, error)
+	WebhookDelete(context.Context, *WebhookRequest) (*emptypb.Empty, error)
+	WebhooksList(context.Context, *emptypb.Empty) (*WebhookListResponse, error)
+	WebhookDeliveriesList(context.Context, *WebhookDeliveriesRequest) (*WebhookDeliveriesResponse, error)
+	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[LedgerEvent]) error
+	AuditList(context.Context, *AuditListRequest) (*AuditListResponse, error)
 	mustEmbedUnimplementedLedgerServiceServer()
 }
 
@@ -160,7 +532,7 @@ func (UnimplementedLedgerServiceServer) BudgetAdd(context.Context, *BudgetAddReq
 func (UnimplementedLedgerServiceServer) BudgetGet(context.Context, *BudgetGetRequest) (*BudgetGetResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method BudgetGet not implemented")
 }
-func (UnimplementedLedgerServiceServer) BudgetsList(context.Context, *emptypb.Empty) (*BudgetGetListResponse, error) {
+func (UnimplementedLedgerServiceServer) BudgetsList(context.Context, *BudgetsListRequest) (*BudgetGetListResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method BudgetsList not implemented")
 }
 func (UnimplementedLedgerServiceServer) TransactionAdd(context.Context, *TransactionAddRequest) (*TransactionAddResponse, error) {
@@ -169,15 +541,96 @@ func (UnimplementedLedgerServiceServer) TransactionAdd(context.Context, *Transac
 func (UnimplementedLedgerServiceServer) TransactionGet(context.Context, *TransactionGetRequest) (*TransactionGetResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method TransactionGet not implemented")
 }
-func (UnimplementedLedgerServiceServer) TransactionList(context.Context, *emptypb.Empty) (*TransactionGetListResponse, error) {
+func (UnimplementedLedgerServiceServer) TransactionList(context.Context, *TransactionListRequest) (*TransactionGetListResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method TransactionList not implemented")
 }
+func (UnimplementedLedgerServiceServer) TransactionSearch(context.Context, *TransactionSearchRequest) (*TransactionSearchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TransactionSearch not implemented")
+}
 func (UnimplementedLedgerServiceServer) ReportSummary(context.Context, *SummaryRequest) (*SummaryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ReportSummary not implemented")
 }
+func (UnimplementedLedgerServiceServer) ReportForecast(context.Context, *ForecastRequest) (*ForecastResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportForecast not implemented")
+}
+func (UnimplementedLedgerServiceServer) ReportAnomalies(context.Context, *AnomaliesRequest) (*AnomaliesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportAnomalies not implemented")
+}
 func (UnimplementedLedgerServiceServer) BulkAddTransactions(context.Context, *TransactionBulkAddRequest) (*TransactionBulkAddResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method BulkAddTransactions not implemented")
 }
+func (UnimplementedLedgerServiceServer) CategoryAdd(context.Context, *CategoryAddRequest) (*CategoryAddResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CategoryAdd not implemented")
+}
+func (UnimplementedLedgerServiceServer) CategoryUpdate(context.Context, *CategoryUpdateRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method CategoryUpdate not implemented")
+}
+func (UnimplementedLedgerServiceServer) CategoriesList(context.Context, *emptypb.Empty) (*CategoryGetListResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CategoriesList not implemented")
+}
+func (UnimplementedLedgerServiceServer) RuleAdd(context.Context, *Rule) (*RuleAddResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RuleAdd not implemented")
+}
+func (UnimplementedLedgerServiceServer) RuleUpdate(context.Context, *Rule) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RuleUpdate not implemented")
+}
+func (UnimplementedLedgerServiceServer) RuleDelete(context.Context, *RuleDeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RuleDelete not implemented")
+}
+func (UnimplementedLedgerServiceServer) RulesList(context.Context, *emptypb.Empty) (*RuleListResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RulesList not implemented")
+}
+func (UnimplementedLedgerServiceServer) RuleTest(context.Context, *RuleTestRequest) (*RuleTestResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RuleTest not implemented")
+}
+func (UnimplementedLedgerServiceServer) AttachmentUpload(grpc.ClientStreamingServer[AttachmentUploadRequest, Attachment]) error {
+	return status.Error(codes.Unimplemented, "method AttachmentUpload not implemented")
+}
+func (UnimplementedLedgerServiceServer) AttachmentDownload(*AttachmentRequest, grpc.ServerStreamingServer[AttachmentChunk]) error {
+	return status.Error(codes.Unimplemented, "method AttachmentDownload not implemented")
+}
+func (UnimplementedLedgerServiceServer) AttachmentDelete(context.Context, *AttachmentRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method AttachmentDelete not implemented")
+}
+func (UnimplementedLedgerServiceServer) AttachmentsList(context.Context, *AttachmentsListRequest) (*AttachmentListResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AttachmentsList not implemented")
+}
+func (UnimplementedLedgerServiceServer) GoalAdd(context.Context, *Goal) (*GoalAddResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GoalAdd not implemented")
+}
+func (UnimplementedLedgerServiceServer) GoalUpdate(context.Context, *Goal) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method GoalUpdate not implemented")
+}
+func (UnimplementedLedgerServiceServer) GoalDelete(context.Context, *GoalRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method GoalDelete not implemented")
+}
+func (UnimplementedLedgerServiceServer) GoalGet(context.Context, *GoalRequest) (*GoalProgress, error) {
+	return nil, status.Error(codes.Unimplemented, "method GoalGet not implemented")
+}
+func (UnimplementedLedgerServiceServer) GoalsList(context.Context, *emptypb.Empty) (*GoalListResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GoalsList not implemented")
+}
+func (UnimplementedLedgerServiceServer) GoalContribute(context.Context, *GoalContributeRequest) (*TransactionAddResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GoalContribute not implemented")
+}
+func (UnimplementedLedgerServiceServer) WebhookAdd(context.Context, *Webhook) (*WebhookAddResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method WebhookAdd not implemented")
+}
+func (UnimplementedLedgerServiceServer) WebhookDelete(context.Context, *WebhookRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method WebhookDelete not implemented")
+}
+func (UnimplementedLedgerServiceServer) WebhooksList(context.Context, *emptypb.Empty) (*WebhookListResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method WebhooksList not implemented")
+}
+func (UnimplementedLedgerServiceServer) WebhookDeliveriesList(context.Context, *WebhookDeliveriesRequest) (*WebhookDeliveriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method WebhookDeliveriesList not implemented")
+}
+func (UnimplementedLedgerServiceServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[LedgerEvent]) error {
+	return status.Error(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedLedgerServiceServer) AuditList(context.Context, *AuditListRequest) (*AuditListResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AuditList not implemented")
+}
 func (UnimplementedLedgerServiceServer) mustEmbedUnimplementedLedgerServiceServer() {}
 func (UnimplementedLedgerServiceServer) testEmbeddedByValue()                       {}
 
@@ -236,7 +689,7 @@ func _LedgerService_BudgetGet_Handler(srv interface{}, ctx context.Context, dec
 }
 
 func _LedgerService_BudgetsList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(BudgetsListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -248,7 +701,7 @@ func _LedgerService_BudgetsList_Handler(srv interface{}, ctx context.Context, de
 		FullMethod: LedgerService_BudgetsList_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).BudgetsList(ctx, req.(*emptypb.Empty))
+		return srv.(LedgerServiceServer).BudgetsList(ctx, req.(*BudgetsListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -290,7 +743,7 @@ func _LedgerService_TransactionGet_Handler(srv interface{}, ctx context.Context,
 }
 
 func _LedgerService_TransactionList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(TransactionListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -302,7 +755,25 @@ func _LedgerService_TransactionList_Handler(srv interface{}, ctx context.Context
 		FullMethod: LedgerService_TransactionList_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).TransactionList(ctx, req.(*emptypb.Empty))
+		return srv.(LedgerServiceServer).TransactionList(ctx, req.(*TransactionListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_TransactionSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).TransactionSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_TransactionSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).TransactionSearch(ctx, req.(*TransactionSearchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -325,28 +796,471 @@ func _LedgerService_ReportSummary_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_BulkAddTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TransactionBulkAddRequest)
+func _LedgerService_ReportForecast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForecastRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).BulkAddTransactions(ctx, in)
+		return srv.(LedgerServiceServer).ReportForecast(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LedgerService_BulkAddTransactions_FullMethodName,
+		FullMethod: LedgerService_ReportForecast_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).BulkAddTransactions(ctx, req.(*TransactionBulkAddRequest))
+		return srv.(LedgerServiceServer).ReportForecast(ctx, req.(*ForecastRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// LedgerService_ServiceDesc is the grpc.ServiceDesc for LedgerService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var LedgerService_ServiceDesc = grpc.ServiceDesc{
+func _LedgerService_ReportAnomalies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnomaliesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ReportAnomalies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ReportAnomalies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ReportAnomalies(ctx, req.(*AnomaliesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_BulkAddTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionBulkAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).BulkAddTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_BulkAddTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).BulkAddTransactions(ctx, req.(*TransactionBulkAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_CategoryAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).CategoryAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_CategoryAdd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).CategoryAdd(ctx, req.(*CategoryAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_CategoryUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).CategoryUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_CategoryUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).CategoryUpdate(ctx, req.(*CategoryUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_CategoriesList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).CategoriesList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_CategoriesList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).CategoriesList(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_RuleAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Rule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).RuleAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_RuleAdd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).RuleAdd(ctx, req.(*Rule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_RuleUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Rule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).RuleUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_RuleUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).RuleUpdate(ctx, req.(*Rule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_RuleDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RuleDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).RuleDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_RuleDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).RuleDelete(ctx, req.(*RuleDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_RulesList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).RulesList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_RulesList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).RulesList(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_RuleTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RuleTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).RuleTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_RuleTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).RuleTest(ctx, req.(*RuleTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_AttachmentUpload_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LedgerServiceServer).AttachmentUpload(&grpc.GenericServerStream[AttachmentUploadRequest, Attachment]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_AttachmentUploadServer = grpc.ClientStreamingServer[AttachmentUploadRequest, Attachment]
+
+func _LedgerService_AttachmentDownload_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AttachmentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LedgerServiceServer).AttachmentDownload(m, &grpc.GenericServerStream[AttachmentRequest, AttachmentChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_AttachmentDownloadServer = grpc.ServerStreamingServer[AttachmentChunk]
+
+func _LedgerService_AttachmentDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).AttachmentDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_AttachmentDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).AttachmentDelete(ctx, req.(*AttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_AttachmentsList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachmentsListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).AttachmentsList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_AttachmentsList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).AttachmentsList(ctx, req.(*AttachmentsListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GoalAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Goal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GoalAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GoalAdd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GoalAdd(ctx, req.(*Goal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GoalUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Goal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GoalUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GoalUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GoalUpdate(ctx, req.(*Goal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GoalDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GoalDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GoalDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GoalDelete(ctx, req.(*GoalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GoalGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GoalGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GoalGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GoalGet(ctx, req.(*GoalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GoalsList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GoalsList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GoalsList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GoalsList(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GoalContribute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoalContributeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GoalContribute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GoalContribute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GoalContribute(ctx, req.(*GoalContributeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_WebhookAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Webhook)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).WebhookAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_WebhookAdd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).WebhookAdd(ctx, req.(*Webhook))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_WebhookDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).WebhookDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_WebhookDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).WebhookDelete(ctx, req.(*WebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_WebhooksList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).WebhooksList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_WebhooksList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).WebhooksList(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_WebhookDeliveriesList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).WebhookDeliveriesList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_WebhookDeliveriesList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).WebhookDeliveriesList(ctx, req.(*WebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LedgerServiceServer).Subscribe(m, &grpc.GenericServerStream[SubscribeRequest, LedgerEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_SubscribeServer = grpc.ServerStreamingServer[LedgerEvent]
+
+func _LedgerService_AuditList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).AuditList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_AuditList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).AuditList(ctx, req.(*AuditListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LedgerService_ServiceDesc is the grpc.ServiceDesc for LedgerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LedgerService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "ledger.v1.LedgerService",
 	HandlerType: (*LedgerServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
@@ -374,15 +1288,127 @@ var LedgerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TransactionList",
 			Handler:    _LedgerService_TransactionList_Handler,
 		},
+		{
+			MethodName: "TransactionSearch",
+			Handler:    _LedgerService_TransactionSearch_Handler,
+		},
 		{
 			MethodName: "ReportSummary",
 			Handler:    _LedgerService_ReportSummary_Handler,
 		},
+		{
+			MethodName: "ReportForecast",
+			Handler:    _LedgerService_ReportForecast_Handler,
+		},
+		{
+			MethodName: "ReportAnomalies",
+			Handler:    _LedgerService_ReportAnomalies_Handler,
+		},
 		{
 			MethodName: "BulkAddTransactions",
 			Handler:    _LedgerService_BulkAddTransactions_Handler,
 		},
+		{
+			MethodName: "CategoryAdd",
+			Handler:    _LedgerService_CategoryAdd_Handler,
+		},
+		{
+			MethodName: "CategoryUpdate",
+			Handler:    _LedgerService_CategoryUpdate_Handler,
+		},
+		{
+			MethodName: "CategoriesList",
+			Handler:    _LedgerService_CategoriesList_Handler,
+		},
+		{
+			MethodName: "RuleAdd",
+			Handler:    _LedgerService_RuleAdd_Handler,
+		},
+		{
+			MethodName: "RuleUpdate",
+			Handler:    _LedgerService_RuleUpdate_Handler,
+		},
+		{
+			MethodName: "RuleDelete",
+			Handler:    _LedgerService_RuleDelete_Handler,
+		},
+		{
+			MethodName: "RulesList",
+			Handler:    _LedgerService_RulesList_Handler,
+		},
+		{
+			MethodName: "RuleTest",
+			Handler:    _LedgerService_RuleTest_Handler,
+		},
+		{
+			MethodName: "AttachmentDelete",
+			Handler:    _LedgerService_AttachmentDelete_Handler,
+		},
+		{
+			MethodName: "AttachmentsList",
+			Handler:    _LedgerService_AttachmentsList_Handler,
+		},
+		{
+			MethodName: "GoalAdd",
+			Handler:    _LedgerService_GoalAdd_Handler,
+		},
+		{
+			MethodName: "GoalUpdate",
+			Handler:    _LedgerService_GoalUpdate_Handler,
+		},
+		{
+			MethodName: "GoalDelete",
+			Handler:    _LedgerService_GoalDelete_Handler,
+		},
+		{
+			MethodName: "GoalGet",
+			Handler:    _LedgerService_GoalGet_Handler,
+		},
+		{
+			MethodName: "GoalsList",
+			Handler:    _LedgerService_GoalsList_Handler,
+		},
+		{
+			MethodName: "GoalContribute",
+			Handler:    _LedgerService_GoalContribute_Handler,
+		},
+		{
+			MethodName: "WebhookAdd",
+			Handler:    _LedgerService_WebhookAdd_Handler,
+		},
+		{
+			MethodName: "WebhookDelete",
+			Handler:    _LedgerService_WebhookDelete_Handler,
+		},
+		{
+			MethodName: "WebhooksList",
+			Handler:    _LedgerService_WebhooksList_Handler,
+		},
+		{
+			MethodName: "WebhookDeliveriesList",
+			Handler:    _LedgerService_WebhookDeliveriesList_Handler,
+		},
+		{
+			MethodName: "AuditList",
+			Handler:    _LedgerService_AuditList_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AttachmentUpload",
+			Handler:       _LedgerService_AttachmentUpload_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "AttachmentDownload",
+			Handler:       _LedgerService_AttachmentDownload_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _LedgerService_Subscribe_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "ledger/v1/ledger.proto",
 }
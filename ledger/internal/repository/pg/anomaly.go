@@ -0,0 +1,76 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"ledger/internal/domain"
+)
+
+type AnomalyPgRepository struct {
+	db *sql.DB
+}
+
+func NewAnomalyPgRepository(db *sql.DB) *AnomalyPgRepository {
+	return &AnomalyPgRepository{
+		db: db,
+	}
+}
+
+func (r *AnomalyPgRepository) GetCategoryAmounts(ctx context.Context, categoryID int64, limit int) ([]float64, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT amount FROM expenses WHERE category_id=$1 AND space_id IS NOT DISTINCT FROM $3 AND kind = 'expense' ORDER BY date DESC, id DESC LIMIT $2", categoryID, limit, spaceID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+	var amounts []float64
+	for rows.Next() {
+		var amount float64
+		if err := rows.Scan(&amount); err != nil {
+			return amounts, err
+		}
+		amounts = append(amounts, amount)
+	}
+	if err = rows.Err(); err != nil {
+		return amounts, err
+	}
+	return amounts, nil
+}
+
+func (r *AnomalyPgRepository) GetAnomaly(ctx context.Context, transactionID int64) (*domain.AnomalyScore, error) {
+	var score domain.AnomalyScore
+	err := r.db.QueryRowContext(ctx, "SELECT a.score, a.ratio, a.median, a.mad FROM expense_anomalies a JOIN expenses e ON e.id = a.expense_id WHERE a.expense_id=$1 AND e.space_id IS NOT DISTINCT FROM $2", transactionID, spaceID(ctx)).Scan(&score.Score, &score.Ratio, &score.Median, &score.MAD)
+	if err != nil {
+		return nil, err
+	}
+	return &score, nil
+}
+
+func (r *AnomalyPgRepository) ListAnomalies(ctx context.Context, from string, to string) ([]domain.Anomaly, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT e.id, e.amount, e.category_id, c.name, e.description, e.date, a.score, a.ratio, a.median, a.mad FROM expense_anomalies a JOIN expenses e ON e.id = a.expense_id JOIN categories c ON c.id = e.category_id WHERE e.date BETWEEN $1 AND $2 AND e.space_id IS NOT DISTINCT FROM $3 ORDER BY e.date DESC, e.id DESC", from, to, spaceID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+	var anomalies []domain.Anomaly
+	for rows.Next() {
+		var a domain.Anomaly
+		if err := rows.Scan(&a.Transaction.ID, &a.Transaction.Amount, &a.Transaction.CategoryID, &a.Transaction.Category, &a.Transaction.Description, &a.Transaction.Date, &a.Score, &a.Ratio, &a.Median, &a.MAD); err != nil {
+			return anomalies, err
+		}
+		anomalies = append(anomalies, a)
+	}
+	if err = rows.Err(); err != nil {
+		return anomalies, err
+	}
+	return anomalies, nil
+}
@@ -0,0 +1,74 @@
+package pg
+
+import (
+	"context"
+	"testing"
+
+	"ledger/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnomalyPgRepository_GetCategoryAmounts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAnomalyPgRepository(db)
+	ctx := domain.WithActor(context.Background(), domain.Actor{SpaceID: testSpaceID})
+
+	mock.ExpectQuery(`SELECT amount FROM expenses WHERE category_id=\$1 AND space_id IS NOT DISTINCT FROM \$3 AND kind = 'expense' ORDER BY date DESC, id DESC LIMIT \$2`).
+		WithArgs(1, 100, testSpaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(12.5).AddRow(9.0))
+
+	amounts, err := repo.GetCategoryAmounts(ctx, 1, 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{12.5, 9}, amounts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAnomalyPgRepository_GetAnomaly(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAnomalyPgRepository(db)
+	ctx := domain.WithActor(context.Background(), domain.Actor{SpaceID: testSpaceID})
+
+	mock.ExpectQuery(`SELECT a.score, a.ratio, a.median, a.mad FROM expense_anomalies a JOIN expenses e ON e.id = a.expense_id WHERE a.expense_id=\$1 AND e.space_id IS NOT DISTINCT FROM \$2`).
+		WithArgs(7, testSpaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"score", "ratio", "median", "mad"}).AddRow(40.47, 9.0, 100.0, 13.33))
+
+	score, err := repo.GetAnomaly(ctx, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.AnomalyScore{Score: 40.47, Ratio: 9, Median: 100, MAD: 13.33}, score)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAnomalyPgRepository_ListAnomalies(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAnomalyPgRepository(db)
+	ctx := context.Background()
+
+	rows := sqlmock.NewRows([]string{"id", "amount", "category_id", "name", "description", "date", "score", "ratio", "median", "mad"}).
+		AddRow(7, 900.0, 1, "Food", "Banquet", "2025-12-01", 40.47, 9.0, 100.0, 13.33)
+	mock.ExpectQuery(`SELECT e.id, .+ FROM expense_anomalies a JOIN expenses e ON e.id = a.expense_id JOIN categories c ON c.id = e.category_id WHERE e.date BETWEEN \$1 AND \$2 AND e.space_id IS NOT DISTINCT FROM \$3 ORDER BY e.date DESC, e.id DESC`).
+		WithArgs("2025-12-01", "2025-12-31", nil).
+		WillReturnRows(rows)
+
+	anomalies, err := repo.ListAnomalies(ctx, "2025-12-01", "2025-12-31")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Anomaly{{
+		Transaction:  domain.Transaction{ID: 7, Amount: 900, CategoryID: 1, Category: "Food", Description: "Banquet", Date: "2025-12-01"},
+		AnomalyScore: domain.AnomalyScore{Score: 40.47, Ratio: 9, Median: 100, MAD: 13.33},
+	}}, anomalies)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
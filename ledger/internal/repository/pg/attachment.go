@@ -0,0 +1,101 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"ledger/internal/domain"
+)
+
+type AttachmentPgRepository struct {
+	db *sql.DB
+}
+
+func NewAttachmentPgRepository(db *sql.DB) *AttachmentPgRepository {
+	return &AttachmentPgRepository{
+		db: db,
+	}
+}
+
+const attachmentSelect = "SELECT id, expense_id, file_name, content_type, size, storage_key, created_at FROM attachments"
+
+const attachmentInSpace = " AND expense_id IN (SELECT id FROM expenses WHERE space_id IS NOT DISTINCT FROM $2)"
+
+func (r *AttachmentPgRepository) AddAttachment(ctx context.Context, attachment *domain.Attachment) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var newID int64
+	err = tx.QueryRowContext(ctx, "INSERT INTO attachments(expense_id, file_name, content_type, size, storage_key) VALUES($1,$2,$3,$4,$5) RETURNING id, created_at",
+		attachment.TransactionID, attachment.FileName, attachment.ContentType, attachment.Size, attachment.StorageKey).Scan(&newID, &attachment.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+	after := *attachment
+	after.ID = newID
+	err = appendAudit(ctx, tx, domain.AuditAttachmentCreate, domain.AuditEntityAttachment, newID, nil, after)
+	if err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return newID, nil
+}
+
+func (r *AttachmentPgRepository) GetAttachment(ctx context.Context, id int64) (*domain.Attachment, error) {
+	var a domain.Attachment
+	err := r.db.QueryRowContext(ctx, attachmentSelect+" WHERE id=$1"+attachmentInSpace, id, spaceID(ctx)).Scan(&a.ID, &a.TransactionID, &a.FileName, &a.ContentType, &a.Size, &a.StorageKey, &a.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *AttachmentPgRepository) ListAttachments(ctx context.Context, transactionID int64) ([]domain.Attachment, error) {
+	rows, err := r.db.QueryContext(ctx, attachmentSelect+" WHERE expense_id=$1"+attachmentInSpace+" ORDER BY id", transactionID, spaceID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+	var attachments []domain.Attachment
+	for rows.Next() {
+		var a domain.Attachment
+		if err := rows.Scan(&a.ID, &a.TransactionID, &a.FileName, &a.ContentType, &a.Size, &a.StorageKey, &a.CreatedAt); err != nil {
+			return attachments, err
+		}
+		attachments = append(attachments, a)
+	}
+	if err = rows.Err(); err != nil {
+		return attachments, err
+	}
+	return attachments, nil
+}
+
+func (r *AttachmentPgRepository) DeleteAttachment(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var before domain.Attachment
+	err = tx.QueryRowContext(ctx, attachmentSelect+" WHERE id=$1"+attachmentInSpace+" FOR UPDATE", id, spaceID(ctx)).Scan(&before.ID, &before.TransactionID, &before.FileName, &before.ContentType, &before.Size, &before.StorageKey, &before.CreatedAt)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM attachments WHERE id=$1", id)
+	if err != nil {
+		return err
+	}
+	err = appendAudit(ctx, tx, domain.AuditAttachmentDelete, domain.AuditEntityAttachment, id, before, nil)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
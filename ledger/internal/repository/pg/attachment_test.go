@@ -0,0 +1,114 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"ledger/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentPgRepository_AddAttachment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAttachmentPgRepository(db)
+	createdAt := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	attachment := &domain.Attachment{TransactionID: 3, FileName: "receipt.pdf", ContentType: "application/pdf", Size: 16, StorageKey: "transactions/3/abc"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO attachments\(expense_id, file_name, content_type, size, storage_key\) VALUES\(\$1,\$2,\$3,\$4,\$5\) RETURNING id, created_at`).
+		WithArgs(3, "receipt.pdf", "application/pdf", 16, "transactions/3/abc").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(5, createdAt))
+	expectAudit(mock, "attachment.create", "attachment", 5, domain.Actor{}, nil, `{"id":5,"transaction_id":3,"file_name":"receipt.pdf","content_type":"application/pdf","size":16,"storage_key":"transactions/3/abc","created_at":"2025-12-01T10:00:00Z"}`)
+	mock.ExpectCommit()
+
+	id, err := repo.AddAttachment(context.Background(), attachment)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), id)
+	assert.Equal(t, createdAt, attachment.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttachmentPgRepository_ListAttachments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAttachmentPgRepository(db)
+	createdAt := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT id, expense_id, file_name, content_type, size, storage_key, created_at FROM attachments WHERE expense_id=\$1 AND expense_id IN \(SELECT id FROM expenses WHERE space_id IS NOT DISTINCT FROM \$2\) ORDER BY id`).
+		WithArgs(3, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "expense_id", "file_name", "content_type", "size", "storage_key", "created_at"}).
+			AddRow(5, 3, "receipt.pdf", "application/pdf", 16, "transactions/3/abc", createdAt))
+
+	result, err := repo.ListAttachments(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Attachment{
+		{ID: 5, TransactionID: 3, FileName: "receipt.pdf", ContentType: "application/pdf", Size: 16, StorageKey: "transactions/3/abc", CreatedAt: createdAt},
+	}, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttachmentPgRepository_DeleteAttachment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAttachmentPgRepository(db)
+	createdAt := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "expense_id", "file_name", "content_type", "size", "storage_key", "created_at"}
+	selectQuery := `SELECT id, expense_id, file_name, content_type, size, storage_key, created_at FROM attachments WHERE id=\$1 AND expense_id IN \(SELECT id FROM expenses WHERE space_id IS NOT DISTINCT FROM \$2\) FOR UPDATE`
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expectedErr error
+	}{
+		{
+			name: "successful delete",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectQuery).
+					WithArgs(5, nil).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(5, 3, "receipt.pdf", "application/pdf", 16, "transactions/3/abc", createdAt))
+				mock.ExpectExec(`DELETE FROM attachments WHERE id=\$1`).
+					WithArgs(5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAudit(mock, "attachment.delete", "attachment", 5, domain.Actor{}, `{"id":5,"transaction_id":3,"file_name":"receipt.pdf","content_type":"application/pdf","size":16,"storage_key":"transactions/3/abc","created_at":"2025-12-01T10:00:00Z"}`, nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "not found",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectQuery).
+					WithArgs(5, nil).
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectRollback()
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			err := repo.DeleteAttachment(context.Background(), 5)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
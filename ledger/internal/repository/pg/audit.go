@@ -0,0 +1,112 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"ledger/internal/domain"
+	"strconv"
+	"strings"
+)
+
+// appendAudit records a mutation in the same transaction as the change, so
+// the audit log can not miss a committed write. A nil before or after is
+// stored as NULL.
+func appendAudit(ctx context.Context, tx *sql.Tx, action string, entityType string, entityID int64, before any, after any) error {
+	beforeJSON, err := auditJSON(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := auditJSON(after)
+	if err != nil {
+		return err
+	}
+	actor := domain.ActorFromContext(ctx)
+	_, err = tx.ExecContext(ctx, "INSERT INTO audit_log(action, entity_type, entity_id, actor_user_id, request_id, method, before, after, space_id) VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9)",
+		action, entityType, entityID, actor.UserID, actor.RequestID, actor.Method, beforeJSON, afterJSON, spaceID(ctx))
+	return err
+}
+
+func auditJSON(v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+type AuditPgRepository struct {
+	db *sql.DB
+}
+
+func NewAuditPgRepository(db *sql.DB) *AuditPgRepository {
+	return &AuditPgRepository{
+		db: db,
+	}
+}
+
+func (r *AuditPgRepository) ListAudit(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	var conditions []string
+	var args []any
+	where := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+	where("space_id IS NOT DISTINCT FROM ?", spaceID(ctx))
+	if filter.EntityType != "" {
+		where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != 0 {
+		where("entity_id = ?", filter.EntityID)
+	}
+	if filter.ActorUserID != "" {
+		where("actor_user_id = ?", filter.ActorUserID)
+	}
+	if filter.RequestID != "" {
+		where("request_id = ?", filter.RequestID)
+	}
+	if filter.Action != "" {
+		where("action = ?", filter.Action)
+	}
+	if !filter.From.IsZero() {
+		where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		where("created_at < ?", filter.To)
+	}
+	if filter.BeforeID != 0 {
+		where("id < ?", filter.BeforeID)
+	}
+	query := "SELECT id, action, entity_type, entity_id, actor_user_id, request_id, method, before, after, created_at FROM audit_log"
+	query += " WHERE " + strings.Join(conditions, " AND ")
+	query += " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += " LIMIT $" + strconv.Itoa(len(args))
+	}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+	var entries []domain.AuditEntry
+	for rows.Next() {
+		var e domain.AuditEntry
+		if err := rows.Scan(&e.ID, &e.Action, &e.EntityType, &e.EntityID, &e.Actor.UserID, &e.Actor.RequestID, &e.Actor.Method, &e.Before, &e.After, &e.CreatedAt); err != nil {
+			return entries, err
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return entries, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,92 @@
+package pg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ledger/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpaceID = "5f0c2a8e-3b1d-4c7a-9e21-7d4b6a1c0f93"
+
+// spaceArg is what a query gets for the actor's space: NULL without one.
+func spaceArg(id string) any {
+	if id == "" {
+		return nil
+	}
+	return id
+}
+
+func expectAudit(mock sqlmock.Sqlmock, action string, entityType string, entityID int64, actor domain.Actor, before any, after any) {
+	mock.ExpectExec(`INSERT INTO audit_log\(action, entity_type, entity_id, actor_user_id, request_id, method, before, after, space_id\) VALUES\(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8,\$9\)`).
+		WithArgs(action, entityType, entityID, actor.UserID, actor.RequestID, actor.Method, before, after, spaceArg(actor.SpaceID)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestAuditPgRepository_ListAudit(t *testing.T) {
+	createdAt := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+	from := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "action", "entity_type", "entity_id", "actor_user_id", "request_id", "method", "before", "after", "created_at"}
+	entry := domain.AuditEntry{
+		ID:         9,
+		Action:     "budget.set",
+		EntityType: "budget",
+		EntityID:   1,
+		Actor:      domain.Actor{UserID: "98ac2ffe", RequestID: "req-1", Method: "/ledger.v1.LedgerService/BudgetAdd"},
+		Before:     []byte(`{"limit": 800}`),
+		After:      []byte(`{"limit": 1000}`),
+		CreatedAt:  createdAt,
+	}
+	row := func() *sqlmock.Rows {
+		return sqlmock.NewRows(columns).AddRow(entry.ID, entry.Action, entry.EntityType, entry.EntityID, entry.Actor.UserID, entry.Actor.RequestID, entry.Actor.Method, entry.Before, entry.After, entry.CreatedAt)
+	}
+
+	tests := []struct {
+		name      string
+		space     string
+		filter    domain.AuditFilter
+		mockSetup func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:   "no filters",
+			filter: domain.AuditFilter{},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, action, entity_type, entity_id, actor_user_id, request_id, method, before, after, created_at FROM audit_log WHERE space_id IS NOT DISTINCT FROM \$1 ORDER BY id DESC$`).
+					WithArgs(nil).
+					WillReturnRows(row())
+			},
+		},
+		{
+			name:   "all filters in a space",
+			space:  testSpaceID,
+			filter: domain.AuditFilter{EntityType: "budget", EntityID: 1, ActorUserID: "98ac2ffe", RequestID: "req-1", Action: "budget.set", From: from, To: from.AddDate(0, 1, 0), BeforeID: 10, Limit: 50},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM audit_log WHERE space_id IS NOT DISTINCT FROM \$1 AND entity_type = \$2 AND entity_id = \$3 AND actor_user_id = \$4 AND request_id = \$5 AND action = \$6 AND created_at >= \$7 AND created_at < \$8 AND id < \$9 ORDER BY id DESC LIMIT \$10`).
+					WithArgs(testSpaceID, "budget", int64(1), "98ac2ffe", "req-1", "budget.set", from, from.AddDate(0, 1, 0), int64(10), 50).
+					WillReturnRows(row())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := NewAuditPgRepository(db)
+			tt.mockSetup(mock)
+
+			ctx := domain.WithActor(context.Background(), domain.Actor{SpaceID: tt.space})
+			entries, err := repo.ListAudit(ctx, tt.filter)
+
+			require.NoError(t, err)
+			assert.Equal(t, []domain.AuditEntry{entry}, entries)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
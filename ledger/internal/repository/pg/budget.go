@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"ledger/internal/domain"
 	"time"
 
@@ -23,17 +24,50 @@ func NewBudgetPgRepository(db *sql.DB, cache *redis.Client) *BudgetPgRepository
 }
 
 func (r *BudgetPgRepository) SetBudget(b *domain.Budget, ctx context.Context) error {
-	_, err := r.db.ExecContext(ctx, "INSERT INTO budgets(category, limit_amount) VALUES($1,$2) ON CONFLICT(category) DO UPDATE SET limit_amount =EXCLUDED.limit_amount", &b.Category, &b.Limit)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	r.cache.Del(ctx, "budgets:all")
+	defer tx.Rollback()
+	var before any
+	var previousLimit float64
+	err = tx.QueryRowContext(ctx, "SELECT limit_amount FROM budgets WHERE category_id=$1 AND space_id IS NOT DISTINCT FROM $2 FOR UPDATE", b.CategoryID, spaceID(ctx)).Scan(&previousLimit)
+	switch {
+	case err == nil:
+		before = domain.Budget{CategoryID: b.CategoryID, Category: b.Category, Limit: previousLimit}
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO budgets(category_id, limit_amount, space_id) VALUES($1,$2,$3) ON CONFLICT(space_id, category_id) DO UPDATE SET limit_amount =EXCLUDED.limit_amount", &b.CategoryID, &b.Limit, spaceID(ctx))
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO budget_history(category_id, limit_amount, space_id) VALUES($1,$2,$3)", b.CategoryID, b.Limit, spaceID(ctx))
+	if err != nil {
+		return err
+	}
+	err = appendOutbox(ctx, tx, domain.EventBudgetSet, b.CategoryID, b)
+	if err != nil {
+		return err
+	}
+	err = appendAudit(ctx, tx, domain.AuditBudgetSet, domain.AuditEntityBudget, b.CategoryID, before, b)
+	if err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	r.cache.HDel(ctx, budgetsKey, spaceField(ctx))
 	return nil
 }
 
+// budgetsKey is a hash with a field per space, so that expiring it drops the
+// cached budgets of every space at once.
+const budgetsKey = "budgets:all"
+
 func (r *BudgetPgRepository) GetBudgets(ctx context.Context) ([]domain.Budget, error) {
-	key := "budgets:all"
-	val, err := r.cache.Get(ctx, key).Result()
+	field := spaceField(ctx)
+	val, err := r.cache.HGet(ctx, budgetsKey, field).Result()
 	if err == nil {
 		println("Get budgets from cache")
 		var result []domain.Budget
@@ -42,7 +76,7 @@ func (r *BudgetPgRepository) GetBudgets(ctx context.Context) ([]domain.Budget, e
 		}
 	}
 	println("Get budgets from db")
-	rows, err := r.db.QueryContext(ctx, "SELECT category, limit_amount FROM budgets ORDER BY category")
+	rows, err := r.db.QueryContext(ctx, "SELECT b.category_id, c.name, b.limit_amount FROM budgets b JOIN categories c ON c.id = b.category_id WHERE b.space_id IS NOT DISTINCT FROM $1 ORDER BY c.name", spaceID(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +89,7 @@ func (r *BudgetPgRepository) GetBudgets(ctx context.Context) ([]domain.Budget, e
 	var dbBudgets []domain.Budget
 	for rows.Next() {
 		var b domain.Budget
-		if err := rows.Scan(&b.Category, &b.Limit); err != nil {
+		if err := rows.Scan(&b.CategoryID, &b.Category, &b.Limit); err != nil {
 			return dbBudgets, err
 		}
 		dbBudgets = append(dbBudgets, b)
@@ -64,15 +98,44 @@ func (r *BudgetPgRepository) GetBudgets(ctx context.Context) ([]domain.Budget, e
 		return dbBudgets, err
 	}
 	data, _ := json.Marshal(dbBudgets)
-	r.cache.Set(ctx, key, data, 20*time.Second)
+	r.cache.HSet(ctx, budgetsKey, field, data)
+	r.cache.Expire(ctx, budgetsKey, 20*time.Second)
 	return dbBudgets, nil
 }
 
 func (r *BudgetPgRepository) GetBudget(category string, ctx context.Context) (*domain.Budget, error) {
 	var budget domain.Budget
-	err := r.db.QueryRowContext(ctx, "SELECT category, limit_amount FROM budgets WHERE category = $1", category).Scan(&budget.Category, &budget.Limit)
+	err := r.db.QueryRowContext(ctx, "SELECT b.category_id, c.name, b.limit_amount FROM budgets b JOIN categories c ON c.id = b.category_id WHERE c.name = $1 AND b.space_id IS NOT DISTINCT FROM $2", category, spaceID(ctx)).Scan(&budget.CategoryID, &budget.Category, &budget.Limit)
 	if err != nil {
 		return &budget, err
 	}
 	return &budget, nil
 }
+
+func (r *BudgetPgRepository) GetBudgetsAsOf(ctx context.Context, asOf time.Time) ([]domain.Budget, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT b.category_id, c.name, h.limit_amount FROM budgets b JOIN categories c ON c.id = b.category_id
+JOIN LATERAL (SELECT h.limit_amount FROM budget_history h WHERE h.space_id IS NOT DISTINCT FROM b.space_id AND h.category_id = b.category_id AND h.valid_from < $1 ORDER BY h.valid_from DESC, h.id DESC LIMIT 1) h ON true
+WHERE b.space_id IS NOT DISTINCT FROM $2
+ORDER BY c.name`, asOf, spaceID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+	var budgets []domain.Budget
+	for rows.Next() {
+		var b domain.Budget
+		if err := rows.Scan(&b.CategoryID, &b.Category, &b.Limit); err != nil {
+			return budgets, err
+		}
+		budgets = append(budgets, b)
+	}
+	if err = rows.Err(); err != nil {
+		return budgets, err
+	}
+	return budgets, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"ledger/internal/domain"
 
@@ -29,10 +30,12 @@ func TestBudgetPgRepository_SetBudget(t *testing.T) {
 	defer redisClient.Close()
 
 	repo := NewBudgetPgRepository(db, redisClient)
-	ctx := context.Background()
+	actor := domain.Actor{UserID: "98ac2ffe", SpaceID: testSpaceID, RequestID: "req-1", Method: "/ledger.v1.LedgerService/BudgetAdd"}
+	ctx := domain.WithActor(context.Background(), actor)
 	budget := &domain.Budget{
-		Category: "Food",
-		Limit:    1000.0,
+		CategoryID: 1,
+		Category:   "Food",
+		Limit:      1000.0,
 	}
 
 	tests := []struct {
@@ -45,9 +48,39 @@ func TestBudgetPgRepository_SetBudget(t *testing.T) {
 			name:   "successful insert",
 			budget: budget,
 			mockSetup: func() {
-				mock.ExpectExec(`INSERT INTO budgets\(category, limit_amount\) VALUES\(\$1,\$2\) ON CONFLICT\(category\) DO UPDATE SET limit_amount =EXCLUDED\.limit_amount`).
-					WithArgs("Food", 1000.0).
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT limit_amount FROM budgets WHERE category_id=\$1 AND space_id IS NOT DISTINCT FROM \$2 FOR UPDATE`).
+					WithArgs(1, testSpaceID).
+					WillReturnRows(sqlmock.NewRows([]string{"limit_amount"}))
+				mock.ExpectExec(`INSERT INTO budgets\(category_id, limit_amount, space_id\) VALUES\(\$1,\$2,\$3\) ON CONFLICT\(space_id, category_id\) DO UPDATE SET limit_amount =EXCLUDED\.limit_amount`).
+					WithArgs(1, 1000.0, testSpaceID).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO budget_history\(category_id, limit_amount, space_id\) VALUES\(\$1,\$2,\$3\)`).
+					WithArgs(1, 1000.0, testSpaceID).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectOutbox(mock, "budget.set", 1, `{"category_id":1,"category":"Food","limit":1000}`, testSpaceID)
+				expectAudit(mock, "budget.set", "budget", 1, actor, nil, `{"category_id":1,"category":"Food","limit":1000}`)
+				mock.ExpectCommit()
+			},
+			expectedErr: false,
+		},
+		{
+			name:   "update records previous limit",
+			budget: budget,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT limit_amount FROM budgets WHERE category_id=\$1 AND space_id IS NOT DISTINCT FROM \$2 FOR UPDATE`).
+					WithArgs(1, testSpaceID).
+					WillReturnRows(sqlmock.NewRows([]string{"limit_amount"}).AddRow(800.0))
+				mock.ExpectExec(`INSERT INTO budgets\(category_id, limit_amount, space_id\) VALUES\(\$1,\$2,\$3\) ON CONFLICT\(space_id, category_id\) DO UPDATE SET limit_amount =EXCLUDED\.limit_amount`).
+					WithArgs(1, 1000.0, testSpaceID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`INSERT INTO budget_history\(category_id, limit_amount, space_id\) VALUES\(\$1,\$2,\$3\)`).
+					WithArgs(1, 1000.0, testSpaceID).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectOutbox(mock, "budget.set", 1, `{"category_id":1,"category":"Food","limit":1000}`, testSpaceID)
+				expectAudit(mock, "budget.set", "budget", 1, actor, `{"category_id":1,"category":"Food","limit":800}`, `{"category_id":1,"category":"Food","limit":1000}`)
+				mock.ExpectCommit()
 			},
 			expectedErr: false,
 		},
@@ -55,9 +88,14 @@ func TestBudgetPgRepository_SetBudget(t *testing.T) {
 			name:   "database error",
 			budget: budget,
 			mockSetup: func() {
-				mock.ExpectExec(`INSERT INTO budgets\(category, limit_amount\) VALUES\(\$1,\$2\) ON CONFLICT\(category\) DO UPDATE SET limit_amount =EXCLUDED\.limit_amount`).
-					WithArgs("Food", 1000.0).
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT limit_amount FROM budgets WHERE category_id=\$1 AND space_id IS NOT DISTINCT FROM \$2 FOR UPDATE`).
+					WithArgs(1, testSpaceID).
+					WillReturnRows(sqlmock.NewRows([]string{"limit_amount"}))
+				mock.ExpectExec(`INSERT INTO budgets\(category_id, limit_amount, space_id\) VALUES\(\$1,\$2,\$3\) ON CONFLICT\(space_id, category_id\) DO UPDATE SET limit_amount =EXCLUDED\.limit_amount`).
+					WithArgs(1, 1000.0, testSpaceID).
 					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
 			},
 			expectedErr: true,
 		},
@@ -107,34 +145,36 @@ func TestBudgetPgRepository_GetBudgets(t *testing.T) {
 		{
 			name: "cache hit",
 			mockSetup: func() {
-				mr.Set("budgets:all", `[{"category":"Food","limit":1000},{"category":"Transport","limit":500}]`)
+				mr.HSet("budgets:all", "", `[{"category_id":1,"category":"Food","limit":1000},{"category_id":2,"category":"Transport","limit":500}]`)
 			},
 			expected: []domain.Budget{
-				{Category: "Food", Limit: 1000.0},
-				{Category: "Transport", Limit: 500.0},
+				{CategoryID: 1, Category: "Food", Limit: 1000.0},
+				{CategoryID: 2, Category: "Transport", Limit: 500.0},
 			},
 			expectedErr: false,
 		},
 		{
 			name: "successful get budgets from db",
 			mockSetup: func() {
-				rows := sqlmock.NewRows([]string{"category", "limit_amount"}).
-					AddRow("Food", 1000.0).
-					AddRow("Transport", 500.0)
-				mock.ExpectQuery(`SELECT category, limit_amount FROM budgets ORDER BY category`).
+				rows := sqlmock.NewRows([]string{"category_id", "name", "limit_amount"}).
+					AddRow(1, "Food", 1000.0).
+					AddRow(2, "Transport", 500.0)
+				mock.ExpectQuery(`SELECT b.category_id, c.name, b.limit_amount FROM budgets b JOIN categories c ON c.id = b.category_id WHERE b.space_id IS NOT DISTINCT FROM \$1 ORDER BY c.name`).
+					WithArgs(nil).
 					WillReturnRows(rows)
 			},
 			expected: []domain.Budget{
-				{Category: "Food", Limit: 1000.0},
-				{Category: "Transport", Limit: 500.0},
+				{CategoryID: 1, Category: "Food", Limit: 1000.0},
+				{CategoryID: 2, Category: "Transport", Limit: 500.0},
 			},
 			expectedErr: false,
 		},
 		{
 			name: "empty result",
 			mockSetup: func() {
-				rows := sqlmock.NewRows([]string{"category", "limit_amount"})
-				mock.ExpectQuery(`SELECT category, limit_amount FROM budgets ORDER BY category`).
+				rows := sqlmock.NewRows([]string{"category_id", "name", "limit_amount"})
+				mock.ExpectQuery(`SELECT b.category_id, c.name, b.limit_amount FROM budgets b JOIN categories c ON c.id = b.category_id WHERE b.space_id IS NOT DISTINCT FROM \$1 ORDER BY c.name`).
+					WithArgs(nil).
 					WillReturnRows(rows)
 			},
 			expected:    nil,
@@ -143,7 +183,8 @@ func TestBudgetPgRepository_GetBudgets(t *testing.T) {
 		{
 			name: "database error",
 			mockSetup: func() {
-				mock.ExpectQuery(`SELECT category, limit_amount FROM budgets ORDER BY category`).
+				mock.ExpectQuery(`SELECT b.category_id, c.name, b.limit_amount FROM budgets b JOIN categories c ON c.id = b.category_id WHERE b.space_id IS NOT DISTINCT FROM \$1 ORDER BY c.name`).
+					WithArgs(nil).
 					WillReturnError(sql.ErrConnDone)
 			},
 			expected:    nil,
@@ -201,15 +242,16 @@ func TestBudgetPgRepository_GetBudget(t *testing.T) {
 			name:     "successful get budget",
 			category: "Food",
 			mockSetup: func() {
-				rows := sqlmock.NewRows([]string{"category", "limit_amount"}).
-					AddRow("Food", 1000.0)
-				mock.ExpectQuery(`SELECT category, limit_amount FROM budgets WHERE category = \$1`).
-					WithArgs("Food").
+				rows := sqlmock.NewRows([]string{"category_id", "name", "limit_amount"}).
+					AddRow(1, "Food", 1000.0)
+				mock.ExpectQuery(`SELECT b.category_id, c.name, b.limit_amount FROM budgets b JOIN categories c ON c.id = b.category_id WHERE c.name = \$1 AND b.space_id IS NOT DISTINCT FROM \$2`).
+					WithArgs("Food", nil).
 					WillReturnRows(rows)
 			},
 			expected: &domain.Budget{
-				Category: "Food",
-				Limit:    1000.0,
+				CategoryID: 1,
+				Category:   "Food",
+				Limit:      1000.0,
 			},
 			expectedErr: false,
 		},
@@ -217,8 +259,8 @@ func TestBudgetPgRepository_GetBudget(t *testing.T) {
 			name:     "budget not found",
 			category: "NonExistent",
 			mockSetup: func() {
-				mock.ExpectQuery(`SELECT category, limit_amount FROM budgets WHERE category = \$1`).
-					WithArgs("NonExistent").
+				mock.ExpectQuery(`SELECT b.category_id, c.name, b.limit_amount FROM budgets b JOIN categories c ON c.id = b.category_id WHERE c.name = \$1 AND b.space_id IS NOT DISTINCT FROM \$2`).
+					WithArgs("NonExistent", nil).
 					WillReturnError(sql.ErrNoRows)
 			},
 			expected: &domain.Budget{
@@ -231,8 +273,8 @@ func TestBudgetPgRepository_GetBudget(t *testing.T) {
 			name:     "database error",
 			category: "Food",
 			mockSetup: func() {
-				mock.ExpectQuery(`SELECT category, limit_amount FROM budgets WHERE category = \$1`).
-					WithArgs("Food").
+				mock.ExpectQuery(`SELECT b.category_id, c.name, b.limit_amount FROM budgets b JOIN categories c ON c.id = b.category_id WHERE c.name = \$1 AND b.space_id IS NOT DISTINCT FROM \$2`).
+					WithArgs("Food", nil).
 					WillReturnError(sql.ErrConnDone)
 			},
 			expected: &domain.Budget{
@@ -259,3 +301,27 @@ func TestBudgetPgRepository_GetBudget(t *testing.T) {
 		})
 	}
 }
+
+func TestBudgetPgRepository_GetBudgetsAsOf(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBudgetPgRepository(db, nil)
+	asOf := time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT b\.category_id, c\.name, h\.limit_amount FROM budgets b JOIN categories c ON c\.id = b\.category_id JOIN LATERAL \(SELECT h\.limit_amount FROM budget_history h WHERE h\.space_id IS NOT DISTINCT FROM b\.space_id AND h\.category_id = b\.category_id AND h\.valid_from < \$1 ORDER BY h\.valid_from DESC, h\.id DESC LIMIT 1\) h ON true WHERE b\.space_id IS NOT DISTINCT FROM \$2 ORDER BY c\.name`).
+		WithArgs(asOf, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"category_id", "name", "limit_amount"}).
+			AddRow(1, "Food", 800.0).
+			AddRow(2, "Transport", 500.0))
+
+	budgets, err := repo.GetBudgetsAsOf(context.Background(), asOf)
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Budget{
+		{CategoryID: 1, Category: "Food", Limit: 800.0},
+		{CategoryID: 2, Category: "Transport", Limit: 500.0},
+	}, budgets)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
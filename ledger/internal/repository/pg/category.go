@@ -0,0 +1,125 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"ledger/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type CategoryPgRepository struct {
+	db    *sql.DB
+	cache *redis.Client
+}
+
+func NewCategoryPgRepository(db *sql.DB, cache *redis.Client) *CategoryPgRepository {
+	return &CategoryPgRepository{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (r *CategoryPgRepository) AddCategory(ctx context.Context, category *domain.Category) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var newID int64
+	err = tx.QueryRowContext(ctx, "INSERT INTO categories(name, parent_id, space_id) VALUES($1, NULLIF($2, 0), $3) RETURNING id", category.Name, category.ParentID, spaceID(ctx)).Scan(&newID)
+	if err != nil {
+		return 0, err
+	}
+	after := domain.Category{ID: newID, Name: category.Name, ParentID: category.ParentID}
+	err = appendAudit(ctx, tx, domain.AuditCategoryCreate, domain.AuditEntityCategory, newID, nil, after)
+	if err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return newID, nil
+}
+
+func (r *CategoryPgRepository) UpdateCategory(ctx context.Context, category *domain.Category) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var before domain.Category
+	err = tx.QueryRowContext(ctx, "SELECT id, name, COALESCE(parent_id, 0) FROM categories WHERE id=$1 AND space_id IS NOT DISTINCT FROM $2 FOR UPDATE", category.ID, spaceID(ctx)).Scan(&before.ID, &before.Name, &before.ParentID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE categories SET name=$1, parent_id=NULLIF($2, 0) WHERE id=$3 AND space_id IS NOT DISTINCT FROM $4", category.Name, category.ParentID, category.ID, spaceID(ctx))
+	if err != nil {
+		return err
+	}
+	err = appendAudit(ctx, tx, domain.AuditCategoryUpdate, domain.AuditEntityCategory, category.ID, before, category)
+	if err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	r.cache.HDel(ctx, budgetsKey, spaceField(ctx))
+	r.cache.HDel(ctx, rulesKey, spaceField(ctx))
+	return nil
+}
+
+func (r *CategoryPgRepository) GetCategory(ctx context.Context, id int64) (*domain.Category, error) {
+	var c domain.Category
+	err := r.db.QueryRowContext(ctx, "SELECT id, name, COALESCE(parent_id, 0) FROM categories WHERE id=$1 AND space_id IS NOT DISTINCT FROM $2", id, spaceID(ctx)).Scan(&c.ID, &c.Name, &c.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *CategoryPgRepository) GetCategoryByName(ctx context.Context, name string) (*domain.Category, error) {
+	var c domain.Category
+	err := r.db.QueryRowContext(ctx, "SELECT id, name, COALESCE(parent_id, 0) FROM categories WHERE name=$1 AND space_id IS NOT DISTINCT FROM $2", name, spaceID(ctx)).Scan(&c.ID, &c.Name, &c.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *CategoryPgRepository) GetCategoryPath(ctx context.Context, id int64) ([]domain.Category, error) {
+	rows, err := r.db.QueryContext(ctx, "WITH RECURSIVE path AS (SELECT id, name, parent_id, 0 AS depth FROM categories WHERE id=$1 AND space_id IS NOT DISTINCT FROM $2 UNION ALL SELECT c.id, c.name, c.parent_id, p.depth + 1 FROM categories c JOIN path p ON c.id = p.parent_id) SELECT id, name, COALESCE(parent_id, 0) FROM path ORDER BY depth", id, spaceID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return scanCategories(rows)
+}
+
+func (r *CategoryPgRepository) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, name, COALESCE(parent_id, 0) FROM categories WHERE space_id IS NOT DISTINCT FROM $1 ORDER BY name", spaceID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return scanCategories(rows)
+}
+
+func scanCategories(rows *sql.Rows) ([]domain.Category, error) {
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+	var categories []domain.Category
+	for rows.Next() {
+		var c domain.Category
+		if err := rows.Scan(&c.ID, &c.Name, &c.ParentID); err != nil {
+			return categories, err
+		}
+		categories = append(categories, c)
+	}
+	if err := rows.Err(); err != nil {
+		return categories, err
+	}
+	return categories, nil
+}
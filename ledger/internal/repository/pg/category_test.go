@@ -0,0 +1,237 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"ledger/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryPgRepository_AddCategory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewCategoryPgRepository(db, nil)
+	actor := domain.Actor{UserID: "98ac2ffe", SpaceID: testSpaceID, RequestID: "req-1", Method: "/ledger.v1.LedgerService/CategoryAdd"}
+	ctx := domain.WithActor(context.Background(), actor)
+
+	tests := []struct {
+		name        string
+		category    *domain.Category
+		mockSetup   func()
+		expected    int64
+		expectedErr bool
+	}{
+		{
+			name:     "successful insert",
+			category: &domain.Category{Name: "Groceries", ParentID: 1},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO categories\(name, parent_id, space_id\) VALUES\(\$1, NULLIF\(\$2, 0\), \$3\) RETURNING id`).
+					WithArgs("Groceries", 1, testSpaceID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				expectAudit(mock, "category.create", "category", 2, actor, nil, `{"id":2,"name":"Groceries","parent_id":1}`)
+				mock.ExpectCommit()
+			},
+			expected:    2,
+			expectedErr: false,
+		},
+		{
+			name:     "database error",
+			category: &domain.Category{Name: "Groceries"},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO categories\(name, parent_id, space_id\) VALUES\(\$1, NULLIF\(\$2, 0\), \$3\) RETURNING id`).
+					WithArgs("Groceries", 0, testSpaceID).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expected:    0,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			id, err := repo.AddCategory(ctx, tt.category)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, id)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCategoryPgRepository_UpdateCategory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer redisClient.Close()
+
+	repo := NewCategoryPgRepository(db, redisClient)
+	actor := domain.Actor{UserID: "98ac2ffe", SpaceID: testSpaceID, RequestID: "req-1", Method: "/ledger.v1.LedgerService/CategoryUpdate"}
+	ctx := domain.WithActor(context.Background(), actor)
+	category := &domain.Category{ID: 2, Name: "Groceries", ParentID: 1}
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expectedErr error
+	}{
+		{
+			name: "successful update",
+			mockSetup: func() {
+				mr.HSet("budgets:all", "", `[]`, testSpaceID, `[]`)
+				mr.HSet("rules:all", "", `[]`, testSpaceID, `[]`)
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT id, name, COALESCE\(parent_id, 0\) FROM categories WHERE id=\$1 AND space_id IS NOT DISTINCT FROM \$2 FOR UPDATE`).
+					WithArgs(2, testSpaceID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "parent_id"}).AddRow(2, "Food", 0))
+				mock.ExpectExec(`UPDATE categories SET name=\$1, parent_id=NULLIF\(\$2, 0\) WHERE id=\$3 AND space_id IS NOT DISTINCT FROM \$4`).
+					WithArgs("Groceries", 1, 2, testSpaceID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAudit(mock, "category.update", "category", 2, actor, `{"id":2,"name":"Food","parent_id":0}`, `{"id":2,"name":"Groceries","parent_id":1}`)
+				mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "not found",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT id, name, COALESCE\(parent_id, 0\) FROM categories WHERE id=\$1 AND space_id IS NOT DISTINCT FROM \$2 FOR UPDATE`).
+					WithArgs(2, testSpaceID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "parent_id"}))
+				mock.ExpectRollback()
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr.FlushAll()
+
+			tt.mockSetup()
+
+			err := repo.UpdateCategory(ctx, category)
+
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, `[]`, mr.HGet("budgets:all", ""))
+				assert.Empty(t, mr.HGet("budgets:all", testSpaceID))
+				assert.Equal(t, `[]`, mr.HGet("rules:all", ""))
+				assert.Empty(t, mr.HGet("rules:all", testSpaceID))
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCategoryPgRepository_GetCategoryPath(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewCategoryPgRepository(db, nil)
+	ctx := domain.WithActor(context.Background(), domain.Actor{SpaceID: testSpaceID})
+
+	rows := sqlmock.NewRows([]string{"id", "name", "parent_id"}).
+		AddRow(3, "Coffee", 2).
+		AddRow(2, "Restaurants", 1).
+		AddRow(1, "Food", 0)
+	mock.ExpectQuery(`WITH RECURSIVE path AS \(.+\) SELECT id, name, COALESCE\(parent_id, 0\) FROM path ORDER BY depth`).
+		WithArgs(3, testSpaceID).
+		WillReturnRows(rows)
+
+	path, err := repo.GetCategoryPath(ctx, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Category{
+		{ID: 3, Name: "Coffee", ParentID: 2},
+		{ID: 2, Name: "Restaurants", ParentID: 1},
+		{ID: 1, Name: "Food", ParentID: 0},
+	}, path)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCategoryPgRepository_ListCategories(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewCategoryPgRepository(db, nil)
+	ctx := domain.WithActor(context.Background(), domain.Actor{SpaceID: testSpaceID})
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expected    []domain.Category
+		expectedErr bool
+	}{
+		{
+			name: "successful list",
+			mockSetup: func() {
+				rows := sqlmock.NewRows([]string{"id", "name", "parent_id"}).
+					AddRow(1, "Food", 0).
+					AddRow(2, "Groceries", 1)
+				mock.ExpectQuery(`SELECT id, name, COALESCE\(parent_id, 0\) FROM categories WHERE space_id IS NOT DISTINCT FROM \$1 ORDER BY name`).
+					WithArgs(testSpaceID).
+					WillReturnRows(rows)
+			},
+			expected: []domain.Category{
+				{ID: 1, Name: "Food", ParentID: 0},
+				{ID: 2, Name: "Groceries", ParentID: 1},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "database error",
+			mockSetup: func() {
+				mock.ExpectQuery(`SELECT id, name, COALESCE\(parent_id, 0\) FROM categories WHERE space_id IS NOT DISTINCT FROM \$1 ORDER BY name`).
+					WithArgs(testSpaceID).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expected:    nil,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			result, err := repo.ListCategories(ctx)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
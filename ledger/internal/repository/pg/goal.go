@@ -0,0 +1,168 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"ledger/internal/domain"
+)
+
+type GoalPgRepository struct {
+	db *sql.DB
+}
+
+func NewGoalPgRepository(db *sql.DB) *GoalPgRepository {
+	return &GoalPgRepository{
+		db: db,
+	}
+}
+
+const goalSelect = "SELECT g.id, g.name, g.target_amount, to_char(g.deadline, 'YYYY-MM-DD'), COALESCE(SUM(e.amount), 0) FROM goals g LEFT JOIN expenses e ON e.goal_id = g.id"
+
+func (r *GoalPgRepository) AddGoal(ctx context.Context, goal *domain.Goal) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var newID int64
+	err = tx.QueryRowContext(ctx, "INSERT INTO goals(name, target_amount, deadline, space_id) VALUES($1,$2,$3,$4) RETURNING id", goal.Name, goal.TargetAmount, goal.Deadline, spaceID(ctx)).Scan(&newID)
+	if err != nil {
+		return 0, err
+	}
+	after := domain.Goal{ID: newID, Name: goal.Name, TargetAmount: goal.TargetAmount, Deadline: goal.Deadline}
+	err = appendAudit(ctx, tx, domain.AuditGoalCreate, domain.AuditEntityGoal, newID, nil, after)
+	if err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return newID, nil
+}
+
+func (r *GoalPgRepository) UpdateGoal(ctx context.Context, goal *domain.Goal) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	before, err := lockGoal(ctx, tx, goal.ID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE goals SET name=$1, target_amount=$2, deadline=$3 WHERE id=$4", goal.Name, goal.TargetAmount, goal.Deadline, goal.ID)
+	if err != nil {
+		return err
+	}
+	after := domain.Goal{ID: goal.ID, Name: goal.Name, TargetAmount: goal.TargetAmount, Deadline: goal.Deadline}
+	err = appendAudit(ctx, tx, domain.AuditGoalUpdate, domain.AuditEntityGoal, goal.ID, before, after)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *GoalPgRepository) DeleteGoal(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	before, err := lockGoal(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, "DELETE FROM goals WHERE id=$1 AND NOT EXISTS (SELECT 1 FROM expenses WHERE goal_id=$1)", id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	err = appendAudit(ctx, tx, domain.AuditGoalDelete, domain.AuditEntityGoal, id, before, nil)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockGoal returns the stored fields of a goal of the current space for the
+// audit log, or sql.ErrNoRows.
+func lockGoal(ctx context.Context, tx *sql.Tx, id int64) (*domain.Goal, error) {
+	var g domain.Goal
+	err := tx.QueryRowContext(ctx, "SELECT id, name, target_amount, to_char(deadline, 'YYYY-MM-DD') FROM goals WHERE id=$1 AND space_id IS NOT DISTINCT FROM $2 FOR UPDATE", id, spaceID(ctx)).Scan(&g.ID, &g.Name, &g.TargetAmount, &g.Deadline)
+	if err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *GoalPgRepository) GetGoal(ctx context.Context, id int64) (*domain.Goal, error) {
+	var g domain.Goal
+	err := r.db.QueryRowContext(ctx, goalSelect+" WHERE g.id=$1 AND g.space_id IS NOT DISTINCT FROM $2 GROUP BY g.id", id, spaceID(ctx)).Scan(&g.ID, &g.Name, &g.TargetAmount, &g.Deadline, &g.Saved)
+	if err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *GoalPgRepository) ListGoals(ctx context.Context) ([]domain.Goal, error) {
+	rows, err := r.db.QueryContext(ctx, goalSelect+" WHERE g.space_id IS NOT DISTINCT FROM $1 GROUP BY g.id ORDER BY g.deadline, g.id", spaceID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+	var goals []domain.Goal
+	for rows.Next() {
+		var g domain.Goal
+		if err := rows.Scan(&g.ID, &g.Name, &g.TargetAmount, &g.Deadline, &g.Saved); err != nil {
+			return goals, err
+		}
+		goals = append(goals, g)
+	}
+	if err = rows.Err(); err != nil {
+		return goals, err
+	}
+	return goals, nil
+}
+
+func (r *GoalPgRepository) AddContribution(ctx context.Context, contribution *domain.GoalContribution, categoryID int64) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	if err = lockWriters(ctx, tx); err != nil {
+		return 0, err
+	}
+	var newID int64
+	err = tx.QueryRowContext(ctx, "INSERT INTO expenses(amount, category_id, description, date, kind, goal_id, space_id, created_by) VALUES($1,$2,$3,$4,$5,$6,$7,NULLIF($8, '')) RETURNING id",
+		contribution.Amount, categoryID, contribution.Description, contribution.Date, domain.TransactionKindGoalContribution, contribution.GoalID, spaceID(ctx), domain.ActorFromContext(ctx).UserID).Scan(&newID)
+	if err != nil {
+		return 0, err
+	}
+	after := struct {
+		domain.TransactionEvent
+		GoalID int64 `json:"goal_id"`
+	}{
+		TransactionEvent: domain.TransactionEvent{ID: newID, Amount: contribution.Amount, CategoryID: categoryID, Description: contribution.Description, Date: contribution.Date},
+		GoalID:           contribution.GoalID,
+	}
+	err = appendAudit(ctx, tx, domain.AuditGoalContribute, domain.AuditEntityTransaction, newID, nil, after)
+	if err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return newID, nil
+}
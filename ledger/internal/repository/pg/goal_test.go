@@ -0,0 +1,201 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"ledger/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoalPgRepository_AddGoal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewGoalPgRepository(db)
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO goals\(name, target_amount, deadline, space_id\) VALUES\(\$1,\$2,\$3,\$4\) RETURNING id`).
+		WithArgs("Vacation", 1200.0, "2026-06-30", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	expectAudit(mock, "goal.create", "goal", 1, domain.Actor{}, nil, `{"id":1,"name":"Vacation","target_amount":1200,"deadline":"2026-06-30"}`)
+	mock.ExpectCommit()
+
+	id, err := repo.AddGoal(ctx, &domain.Goal{Name: "Vacation", TargetAmount: 1200, Deadline: "2026-06-30"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGoalPgRepository_DeleteGoal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewGoalPgRepository(db)
+	ctx := context.Background()
+
+	lockQuery := `SELECT id, name, target_amount, to_char\(deadline, 'YYYY-MM-DD'\) FROM goals WHERE id=\$1 AND space_id IS NOT DISTINCT FROM \$2 FOR UPDATE`
+	columns := []string{"id", "name", "target_amount", "deadline"}
+	deleteQuery := `DELETE FROM goals WHERE id=\$1 AND NOT EXISTS \(SELECT 1 FROM expenses WHERE goal_id=\$1\)`
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expectedErr error
+	}{
+		{
+			name: "successful delete",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(1, nil).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "Vacation", 1200.0, "2026-06-30"))
+				mock.ExpectExec(deleteQuery).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAudit(mock, "goal.delete", "goal", 1, domain.Actor{}, `{"id":1,"name":"Vacation","target_amount":1200,"deadline":"2026-06-30"}`, nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "not found",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(1, nil).
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectRollback()
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			name: "has contributions",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(1, nil).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "Vacation", 1200.0, "2026-06-30"))
+				mock.ExpectExec(deleteQuery).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			err := repo.DeleteGoal(ctx, 1)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGoalPgRepository_UpdateGoal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewGoalPgRepository(db)
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id, name, target_amount, to_char\(deadline, 'YYYY-MM-DD'\) FROM goals WHERE id=\$1 AND space_id IS NOT DISTINCT FROM \$2 FOR UPDATE`).
+		WithArgs(1, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "target_amount", "deadline"}).AddRow(1, "Vacation", 1200.0, "2026-06-30"))
+	mock.ExpectExec(`UPDATE goals SET name=\$1, target_amount=\$2, deadline=\$3 WHERE id=\$4`).
+		WithArgs("Vacation", 1500.0, "2026-08-31", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAudit(mock, "goal.update", "goal", 1, domain.Actor{},
+		`{"id":1,"name":"Vacation","target_amount":1200,"deadline":"2026-06-30"}`,
+		`{"id":1,"name":"Vacation","target_amount":1500,"deadline":"2026-08-31"}`)
+	mock.ExpectCommit()
+
+	err = repo.UpdateGoal(ctx, &domain.Goal{ID: 1, Name: "Vacation", TargetAmount: 1500, Deadline: "2026-08-31"})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGoalPgRepository_GetGoal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewGoalPgRepository(db)
+	ctx := context.Background()
+
+	rows := sqlmock.NewRows([]string{"id", "name", "target_amount", "deadline", "saved"}).
+		AddRow(1, "Vacation", 1200.0, "2026-06-30", 300.0)
+	mock.ExpectQuery(`SELECT g.id, g.name, g.target_amount, to_char\(g.deadline, 'YYYY-MM-DD'\), COALESCE\(SUM\(e.amount\), 0\) FROM goals g LEFT JOIN expenses e ON e.goal_id = g.id WHERE g.id=\$1 AND g.space_id IS NOT DISTINCT FROM \$2 GROUP BY g.id`).
+		WithArgs(1, nil).
+		WillReturnRows(rows)
+
+	goal, err := repo.GetGoal(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Goal{ID: 1, Name: "Vacation", TargetAmount: 1200, Deadline: "2026-06-30", Saved: 300}, goal)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGoalPgRepository_ListGoals(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewGoalPgRepository(db)
+	ctx := context.Background()
+
+	rows := sqlmock.NewRows([]string{"id", "name", "target_amount", "deadline", "saved"}).
+		AddRow(2, "Laptop", 2000.0, "2026-03-01", 0.0).
+		AddRow(1, "Vacation", 1200.0, "2026-06-30", 300.0)
+	mock.ExpectQuery(`SELECT g.id, .+ FROM goals g LEFT JOIN expenses e ON e.goal_id = g.id WHERE g.space_id IS NOT DISTINCT FROM \$1 GROUP BY g.id ORDER BY g.deadline, g.id`).
+		WithArgs(nil).
+		WillReturnRows(rows)
+
+	goals, err := repo.ListGoals(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Goal{
+		{ID: 2, Name: "Laptop", TargetAmount: 2000, Deadline: "2026-03-01"},
+		{ID: 1, Name: "Vacation", TargetAmount: 1200, Deadline: "2026-06-30", Saved: 300},
+	}, goals)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGoalPgRepository_AddContribution(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewGoalPgRepository(db)
+	actor := domain.Actor{UserID: "98ac2ffe", SpaceID: testSpaceID, RequestID: "req-1", Method: "/ledger.v1.LedgerService/GoalContribute"}
+	ctx := domain.WithActor(context.Background(), actor)
+
+	mock.ExpectBegin()
+	expectLockWriters(mock)
+	mock.ExpectQuery(`INSERT INTO expenses\(amount, category_id, description, date, kind, goal_id, space_id, created_by\) VALUES\(\$1,\$2,\$3,\$4,\$5,\$6,\$7,NULLIF\(\$8, ''\)\) RETURNING id`).
+		WithArgs(150.0, 7, "Vacation", "2025-12-11", "goal_contribution", 1, testSpaceID, "98ac2ffe").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+	expectAudit(mock, "goal.contribute", "transaction", 42, actor, nil, `{"id":42,"amount":150,"category_id":7,"category":"","description":"Vacation","date":"2025-12-11","goal_id":1}`)
+	mock.ExpectCommit()
+
+	id, err := repo.AddContribution(ctx, &domain.GoalContribution{GoalID: 1, Amount: 150, Date: "2025-12-11", Description: "Vacation"}, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,42 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"ledger/internal/domain"
+	"time"
+)
+
+type IdempotencyPgRepository struct {
+	db  *sql.DB
+	ttl time.Duration
+}
+
+func NewIdempotencyPgRepository(db *sql.DB) *IdempotencyPgRepository {
+	return &IdempotencyPgRepository{
+		db:  db,
+		ttl: domain.DefaultIdempotencyTTL,
+	}
+}
+
+// SetTTL sets how long a stored key is returned by GetIdempotencyRecord.
+func (r *IdempotencyPgRepository) SetTTL(ttl time.Duration) {
+	r.ttl = ttl
+}
+
+func (r *IdempotencyPgRepository) GetIdempotencyRecord(ctx context.Context, key string) (*domain.IdempotencyRecord, error) {
+	var record domain.IdempotencyRecord
+	err := r.db.QueryRowContext(ctx, "SELECT key, request_hash, transaction_id FROM idempotency_keys WHERE key=$1 AND space_id IS NOT DISTINCT FROM $2 AND created_at > now() - make_interval(secs => $3)", key, spaceID(ctx), r.ttl.Seconds()).Scan(&record.Key, &record.RequestHash, &record.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *IdempotencyPgRepository) DeleteExpiredIdempotencyRecords(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE created_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,80 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"ledger/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyPgRepository_GetIdempotencyRecord(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewIdempotencyPgRepository(db)
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expected    *domain.IdempotencyRecord
+		expectedErr error
+	}{
+		{
+			name: "found",
+			mockSetup: func() {
+				mock.ExpectQuery(`SELECT key, request_hash, transaction_id FROM idempotency_keys WHERE key=\$1 AND space_id IS NOT DISTINCT FROM \$2 AND created_at > now\(\) - make_interval\(secs => \$3\)`).
+					WithArgs("key-1", nil, 86400.0).
+					WillReturnRows(sqlmock.NewRows([]string{"key", "request_hash", "transaction_id"}).AddRow("key-1", "hash", 7))
+			},
+			expected: &domain.IdempotencyRecord{Key: "key-1", RequestHash: "hash", TransactionID: 7},
+		},
+		{
+			name: "not found",
+			mockSetup: func() {
+				mock.ExpectQuery(`SELECT key, request_hash, transaction_id FROM idempotency_keys WHERE key=\$1 AND space_id IS NOT DISTINCT FROM \$2 AND created_at > now\(\) - make_interval\(secs => \$3\)`).
+					WithArgs("key-1", nil, 86400.0).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			record, err := repo.GetIdempotencyRecord(ctx, "key-1")
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, record)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestIdempotencyPgRepository_DeleteExpiredIdempotencyRecords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewIdempotencyPgRepository(db)
+	before := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`DELETE FROM idempotency_keys WHERE created_at < \$1`).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+
+	deleted, err := repo.DeleteExpiredIdempotencyRecords(context.Background(), before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,138 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"ledger/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+// Advisory lock keys. Writers hold outboxWriteLock until commit, so outbox
+// ids become visible in the order they were allocated and the relay never
+// publishes an id after a larger one. Expenses are recorded under the same
+// lock, which orders their recorded_at the same way. outboxRelayLock keeps a
+// single relay active when several ledger instances run, snapshotLock a
+// single snapshot builder.
+const (
+	outboxWriteLock = 7302114
+	outboxRelayLock = 7302115
+	snapshotLock    = 7302116
+)
+
+func lockWriters(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", outboxWriteLock)
+	return err
+}
+
+func appendOutbox(ctx context.Context, tx *sql.Tx, eventType string, aggregateID int64, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if err = lockWriters(ctx, tx); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO outbox(event_type, aggregate_id, payload, space_id) VALUES($1,$2,$3,$4)", eventType, aggregateID, string(data), spaceID(ctx))
+	return err
+}
+
+type OutboxPgRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxPgRepository(db *sql.DB) *OutboxPgRepository {
+	return &OutboxPgRepository{
+		db: db,
+	}
+}
+
+func (r *OutboxPgRepository) RelayOutbox(ctx context.Context, limit int, publish func(context.Context, []domain.OutboxEvent) error) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var locked bool
+	err = tx.QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", outboxRelayLock).Scan(&locked)
+	if err != nil {
+		return 0, err
+	}
+	if !locked {
+		return 0, nil
+	}
+	events, err := r.unpublished(ctx, tx, limit)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+	if err = publish(ctx, events); err != nil {
+		return 0, err
+	}
+	ids := make([]int64, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE outbox SET published_at = now() WHERE id = ANY($1)", pq.Array(ids))
+	if err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+func (r *OutboxPgRepository) unpublished(ctx context.Context, tx *sql.Tx, limit int) ([]domain.OutboxEvent, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT id, event_type, aggregate_id, payload, created_at, COALESCE(space_id::text, '') FROM outbox WHERE published_at IS NULL ORDER BY id LIMIT $1", limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanOutboxEvents(rows)
+}
+
+func (r *OutboxPgRepository) ListEventsAfter(ctx context.Context, afterID int64, limit int) ([]domain.OutboxEvent, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, event_type, aggregate_id, payload, created_at, COALESCE(space_id::text, '') FROM outbox WHERE id > $1 ORDER BY id LIMIT $2", afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanOutboxEvents(rows)
+}
+
+func (r *OutboxPgRepository) ListSpaceEventsAfter(ctx context.Context, spaceID string, afterID int64, limit int) ([]domain.OutboxEvent, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, event_type, aggregate_id, payload, created_at, COALESCE(space_id::text, '') FROM outbox WHERE space_id IS NOT DISTINCT FROM $1 AND id > $2 ORDER BY id LIMIT $3", sql.NullString{String: spaceID, Valid: spaceID != ""}, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanOutboxEvents(rows)
+}
+
+func (r *OutboxPgRepository) LatestEventID(ctx context.Context) (int64, error) {
+	var id int64
+	err := r.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(id), 0) FROM outbox").Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func scanOutboxEvents(rows *sql.Rows) ([]domain.OutboxEvent, error) {
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+	var events []domain.OutboxEvent
+	for rows.Next() {
+		var e domain.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.Type, &e.AggregateID, &e.Payload, &e.CreatedAt, &e.SpaceID); err != nil {
+			return events, err
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return events, err
+	}
+	return events, nil
+}
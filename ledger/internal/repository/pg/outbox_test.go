@@ -0,0 +1,162 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ledger/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func expectLockWriters(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock\(\$1\)`).
+		WithArgs(outboxWriteLock).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectOutbox(mock sqlmock.Sqlmock, eventType string, aggregateID int64, payload string, space string) {
+	expectLockWriters(mock)
+	mock.ExpectExec(`INSERT INTO outbox\(event_type, aggregate_id, payload, space_id\) VALUES\(\$1,\$2,\$3,\$4\)`).
+		WithArgs(eventType, aggregateID, payload, spaceArg(space)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestOutboxPgRepository_RelayOutbox(t *testing.T) {
+	createdAt := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+	events := []domain.OutboxEvent{
+		{ID: 4, Type: "budget.set", AggregateID: 1, Payload: []byte(`{"category_id":1}`), CreatedAt: createdAt, SpaceID: testSpaceID},
+		{ID: 5, Type: "transaction.created", AggregateID: 9, Payload: []byte(`{"id":9}`), CreatedAt: createdAt},
+	}
+	selectOutbox := `SELECT id, event_type, aggregate_id, payload, created_at, COALESCE\(space_id::text, ''\) FROM outbox WHERE published_at IS NULL ORDER BY id LIMIT \$1`
+	outboxRows := func() *sqlmock.Rows {
+		rows := sqlmock.NewRows([]string{"id", "event_type", "aggregate_id", "payload", "created_at", "space_id"})
+		for _, e := range events {
+			rows.AddRow(e.ID, e.Type, e.AggregateID, e.Payload, e.CreatedAt, e.SpaceID)
+		}
+		return rows
+	}
+
+	tests := []struct {
+		name        string
+		mockSetup   func(mock sqlmock.Sqlmock)
+		publishErr  error
+		expected    int
+		published   bool
+		expectedErr bool
+	}{
+		{
+			name: "publishes and marks events",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT pg_try_advisory_xact_lock\(\$1\)`).WithArgs(outboxRelayLock).
+					WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+				mock.ExpectQuery(selectOutbox).WithArgs(100).WillReturnRows(outboxRows())
+				mock.ExpectExec(`UPDATE outbox SET published_at = now\(\) WHERE id = ANY\(\$1\)`).
+					WithArgs(pq.Array([]int64{4, 5})).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			expected:  2,
+			published: true,
+		},
+		{
+			name: "sink failure leaves events unpublished",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT pg_try_advisory_xact_lock\(\$1\)`).WithArgs(outboxRelayLock).
+					WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+				mock.ExpectQuery(selectOutbox).WithArgs(100).WillReturnRows(outboxRows())
+				mock.ExpectRollback()
+			},
+			publishErr:  errors.New("sink down"),
+			published:   true,
+			expectedErr: true,
+		},
+		{
+			name: "another relay holds the lock",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT pg_try_advisory_xact_lock\(\$1\)`).WithArgs(outboxRelayLock).
+					WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := NewOutboxPgRepository(db)
+			tt.mockSetup(mock)
+
+			var published []domain.OutboxEvent
+			n, err := repo.RelayOutbox(context.Background(), 100, func(ctx context.Context, batch []domain.OutboxEvent) error {
+				published = batch
+				return tt.publishErr
+			})
+
+			assert.Equal(t, tt.expectedErr, err != nil)
+			assert.Equal(t, tt.expected, n)
+			if tt.published {
+				assert.Equal(t, events, published)
+			} else {
+				assert.Empty(t, published)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOutboxPgRepository_ListEventsAfter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := NewOutboxPgRepository(db)
+	createdAt := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT id, event_type, aggregate_id, payload, created_at, COALESCE\(space_id::text, ''\) FROM outbox WHERE id > \$1 ORDER BY id LIMIT \$2`).
+		WithArgs(int64(3), 500).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_type", "aggregate_id", "payload", "created_at", "space_id"}).
+			AddRow(4, "transaction.created", 9, []byte(`{"id":9}`), createdAt, ""))
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(id\), 0\) FROM outbox`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+
+	events, err := repo.ListEventsAfter(context.Background(), 3, 500)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.OutboxEvent{
+		{ID: 4, Type: "transaction.created", AggregateID: 9, Payload: []byte(`{"id":9}`), CreatedAt: createdAt},
+	}, events)
+
+	latest, err := repo.LatestEventID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), latest)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxPgRepository_ListSpaceEventsAfter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := NewOutboxPgRepository(db)
+	createdAt := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT id, event_type, aggregate_id, payload, created_at, COALESCE\(space_id::text, ''\) FROM outbox WHERE space_id IS NOT DISTINCT FROM \$1 AND id > \$2 ORDER BY id LIMIT \$3`).
+		WithArgs(testSpaceID, int64(3), 500).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_type", "aggregate_id", "payload", "created_at", "space_id"}).
+			AddRow(6, "budget.set", 1, []byte(`{"category_id":1}`), createdAt, testSpaceID))
+
+	events, err := repo.ListSpaceEventsAfter(context.Background(), testSpaceID, 3, 500)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.OutboxEvent{
+		{ID: 6, Type: "budget.set", AggregateID: 1, Payload: []byte(`{"category_id":1}`), CreatedAt: createdAt, SpaceID: testSpaceID},
+	}, events)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,163 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"ledger/internal/domain"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type RulePgRepository struct {
+	db    *sql.DB
+	cache *redis.Client
+}
+
+func NewRulePgRepository(db *sql.DB, cache *redis.Client) *RulePgRepository {
+	return &RulePgRepository{
+		db:    db,
+		cache: cache,
+	}
+}
+
+const ruleSelect = "SELECT r.id, r.priority, r.description_regex, r.description_contains, r.min_amount, r.max_amount, r.category_id, c.name, r.description FROM category_rules r JOIN categories c ON c.id = r.category_id"
+
+func (r *RulePgRepository) AddRule(ctx context.Context, rule *domain.Rule) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var newID int64
+	err = tx.QueryRowContext(ctx, "INSERT INTO category_rules(priority, description_regex, description_contains, min_amount, max_amount, category_id, description, space_id) VALUES($1,$2,$3,$4,$5,$6,$7,$8) RETURNING id",
+		rule.Priority, rule.DescriptionRegex, rule.DescriptionContains, rule.MinAmount, rule.MaxAmount, rule.CategoryID, rule.Description, spaceID(ctx)).Scan(&newID)
+	if err != nil {
+		return 0, err
+	}
+	after := *rule
+	after.ID = newID
+	err = appendAudit(ctx, tx, domain.AuditRuleCreate, domain.AuditEntityRule, newID, nil, after)
+	if err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	r.cache.HDel(ctx, rulesKey, spaceField(ctx))
+	return newID, nil
+}
+
+func (r *RulePgRepository) UpdateRule(ctx context.Context, rule *domain.Rule) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	before, err := lockRule(ctx, tx, rule.ID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE category_rules SET priority=$1, description_regex=$2, description_contains=$3, min_amount=$4, max_amount=$5, category_id=$6, description=$7 WHERE id=$8",
+		rule.Priority, rule.DescriptionRegex, rule.DescriptionContains, rule.MinAmount, rule.MaxAmount, rule.CategoryID, rule.Description, rule.ID)
+	if err != nil {
+		return err
+	}
+	err = appendAudit(ctx, tx, domain.AuditRuleUpdate, domain.AuditEntityRule, rule.ID, before, rule)
+	if err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	r.cache.HDel(ctx, rulesKey, spaceField(ctx))
+	return nil
+}
+
+func (r *RulePgRepository) DeleteRule(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	before, err := lockRule(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM category_rules WHERE id=$1", id)
+	if err != nil {
+		return err
+	}
+	err = appendAudit(ctx, tx, domain.AuditRuleDelete, domain.AuditEntityRule, id, before, nil)
+	if err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	r.cache.HDel(ctx, rulesKey, spaceField(ctx))
+	return nil
+}
+
+// lockRule returns the rule of the current space for the audit log, or
+// sql.ErrNoRows.
+func lockRule(ctx context.Context, tx *sql.Tx, id int64) (*domain.Rule, error) {
+	var rule domain.Rule
+	err := tx.QueryRowContext(ctx, ruleSelect+" WHERE r.id=$1 AND r.space_id IS NOT DISTINCT FROM $2 FOR UPDATE OF r", id, spaceID(ctx)).Scan(&rule.ID, &rule.Priority, &rule.DescriptionRegex, &rule.DescriptionContains, &rule.MinAmount, &rule.MaxAmount, &rule.CategoryID, &rule.Category, &rule.Description)
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *RulePgRepository) GetRule(ctx context.Context, id int64) (*domain.Rule, error) {
+	var rule domain.Rule
+	err := r.db.QueryRowContext(ctx, ruleSelect+" WHERE r.id=$1 AND r.space_id IS NOT DISTINCT FROM $2", id, spaceID(ctx)).Scan(&rule.ID, &rule.Priority, &rule.DescriptionRegex, &rule.DescriptionContains, &rule.MinAmount, &rule.MaxAmount, &rule.CategoryID, &rule.Category, &rule.Description)
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// rulesKey is a hash with a field per space like budgetsKey; renaming a
+// category drops the cached rules of its space.
+const rulesKey = "rules:all"
+
+func (r *RulePgRepository) ListRules(ctx context.Context) ([]domain.Rule, error) {
+	field := spaceField(ctx)
+	val, err := r.cache.HGet(ctx, rulesKey, field).Result()
+	if err == nil {
+		var result []domain.Rule
+		if err := json.Unmarshal([]byte(val), &result); err == nil {
+			domain.CompileRules(result)
+			return result, nil
+		}
+	}
+	rows, err := r.db.QueryContext(ctx, ruleSelect+" WHERE r.space_id IS NOT DISTINCT FROM $1 ORDER BY r.priority, r.id", spaceID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+	var rules []domain.Rule
+	for rows.Next() {
+		var rule domain.Rule
+		if err := rows.Scan(&rule.ID, &rule.Priority, &rule.DescriptionRegex, &rule.DescriptionContains, &rule.MinAmount, &rule.MaxAmount, &rule.CategoryID, &rule.Category, &rule.Description); err != nil {
+			return rules, err
+		}
+		rules = append(rules, rule)
+	}
+	if err = rows.Err(); err != nil {
+		return rules, err
+	}
+	data, _ := json.Marshal(rules)
+	r.cache.HSet(ctx, rulesKey, field, data)
+	r.cache.Expire(ctx, rulesKey, 20*time.Second)
+	domain.CompileRules(rules)
+	return rules, nil
+}
@@ -0,0 +1,270 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"ledger/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulePgRepository_AddRule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer redisClient.Close()
+
+	repo := NewRulePgRepository(db, redisClient)
+	ctx := context.Background()
+	rule := &domain.Rule{Priority: 1, DescriptionContains: "uber", MaxAmount: 100, CategoryID: 3, Description: "Uber"}
+
+	mr.HSet("rules:all", "", `[]`)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO category_rules\(priority, description_regex, description_contains, min_amount, max_amount, category_id, description, space_id\) VALUES\(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8\) RETURNING id`).
+		WithArgs(int32(1), "", "uber", 0.0, 100.0, 3, "Uber", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	expectAudit(mock, "rule.create", "rule", 1, domain.Actor{}, nil, `{"id":1,"priority":1,"description_regex":"","description_contains":"uber","min_amount":0,"max_amount":100,"category_id":3,"category":"","description":"Uber"}`)
+	mock.ExpectCommit()
+
+	id, err := repo.AddRule(ctx, rule)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+	assert.False(t, mr.Exists("rules:all"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+var ruleColumns = []string{"id", "priority", "description_regex", "description_contains", "min_amount", "max_amount", "category_id", "name", "description"}
+
+const lockRuleQuery = `FROM category_rules r JOIN categories c ON c.id = r.category_id WHERE r.id=\$1 AND r.space_id IS NOT DISTINCT FROM \$2 FOR UPDATE OF r`
+
+func TestRulePgRepository_UpdateRule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer redisClient.Close()
+
+	repo := NewRulePgRepository(db, redisClient)
+	ctx := domain.WithActor(context.Background(), domain.Actor{SpaceID: testSpaceID})
+	rule := &domain.Rule{ID: 1, Priority: 2, DescriptionContains: "uber", MaxAmount: 50, CategoryID: 3, Category: "Taxi", Description: "Uber"}
+
+	mr.HSet("rules:all", testSpaceID, `[]`, "", `[]`)
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockRuleQuery).
+		WithArgs(1, testSpaceID).
+		WillReturnRows(sqlmock.NewRows(ruleColumns).AddRow(1, 1, "", "uber", 0.0, 100.0, 3, "Taxi", "Uber"))
+	mock.ExpectExec(`UPDATE category_rules SET priority=\$1, description_regex=\$2, description_contains=\$3, min_amount=\$4, max_amount=\$5, category_id=\$6, description=\$7 WHERE id=\$8`).
+		WithArgs(int32(2), "", "uber", 0.0, 50.0, 3, "Uber", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAudit(mock, "rule.update", "rule", 1, domain.Actor{SpaceID: testSpaceID},
+		`{"id":1,"priority":1,"description_regex":"","description_contains":"uber","min_amount":0,"max_amount":100,"category_id":3,"category":"Taxi","description":"Uber"}`,
+		`{"id":1,"priority":2,"description_regex":"","description_contains":"uber","min_amount":0,"max_amount":50,"category_id":3,"category":"Taxi","description":"Uber"}`)
+	mock.ExpectCommit()
+
+	err = repo.UpdateRule(ctx, rule)
+
+	assert.NoError(t, err)
+	fields, err := mr.HKeys("rules:all")
+	require.NoError(t, err)
+	assert.Equal(t, []string{""}, fields, "rules cached for other spaces stay")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRulePgRepository_DeleteRule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer redisClient.Close()
+
+	repo := NewRulePgRepository(db, redisClient)
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		id          int64
+		mockSetup   func()
+		expectedErr error
+	}{
+		{
+			name: "successful delete",
+			id:   1,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockRuleQuery).
+					WithArgs(1, nil).
+					WillReturnRows(sqlmock.NewRows(ruleColumns).AddRow(1, 1, "", "uber", 0.0, 100.0, 3, "Taxi", "Uber"))
+				mock.ExpectExec(`DELETE FROM category_rules WHERE id=\$1`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAudit(mock, "rule.delete", "rule", 1, domain.Actor{}, `{"id":1,"priority":1,"description_regex":"","description_contains":"uber","min_amount":0,"max_amount":100,"category_id":3,"category":"Taxi","description":"Uber"}`, nil)
+				mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "not found",
+			id:   2,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockRuleQuery).
+					WithArgs(2, nil).
+					WillReturnRows(sqlmock.NewRows(ruleColumns))
+				mock.ExpectRollback()
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			err := repo.DeleteRule(ctx, tt.id)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRulePgRepository_ListRules(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer redisClient.Close()
+
+	repo := NewRulePgRepository(db, redisClient)
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expected    []domain.Rule
+		expectedErr bool
+	}{
+		{
+			name: "cache hit",
+			mockSetup: func() {
+				mr.HSet("rules:all", "", `[{"id":1,"priority":1,"description_contains":"uber","category_id":3,"category":"Taxi"},{"id":2,"priority":5,"description_regex":"^NETFLIX","category_id":4,"category":"Subscriptions"}]`)
+			},
+			expected: []domain.Rule{
+				{ID: 1, Priority: 1, DescriptionContains: "uber", CategoryID: 3, Category: "Taxi"},
+				{ID: 2, Priority: 5, DescriptionRegex: `^NETFLIX`, CategoryID: 4, Category: "Subscriptions"},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "cache miss",
+			mockSetup: func() {
+				rows := sqlmock.NewRows([]string{"id", "priority", "description_regex", "description_contains", "min_amount", "max_amount", "category_id", "name", "description"}).
+					AddRow(1, 1, "", "uber", 0, 100, 3, "Taxi", "Uber").
+					AddRow(2, 5, `^NETFLIX`, "", 0, 0, 4, "Subscriptions", "")
+				mock.ExpectQuery(`SELECT r.id, r.priority, r.description_regex, r.description_contains, r.min_amount, r.max_amount, r.category_id, c.name, r.description FROM category_rules r JOIN categories c ON c.id = r.category_id WHERE r.space_id IS NOT DISTINCT FROM \$1 ORDER BY r.priority, r.id`).
+					WithArgs(nil).
+					WillReturnRows(rows)
+			},
+			expected: []domain.Rule{
+				{ID: 1, Priority: 1, DescriptionContains: "uber", MaxAmount: 100, CategoryID: 3, Category: "Taxi", Description: "Uber"},
+				{ID: 2, Priority: 5, DescriptionRegex: `^NETFLIX`, CategoryID: 4, Category: "Subscriptions"},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "database error",
+			mockSetup: func() {
+				mock.ExpectQuery(`FROM category_rules r JOIN categories c ON c.id = r.category_id WHERE r.space_id IS NOT DISTINCT FROM \$1 ORDER BY r.priority, r.id`).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expected:    nil,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr.FlushAll()
+
+			tt.mockSetup()
+
+			result, err := repo.ListRules(ctx)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				domain.CompileRules(tt.expected)
+				assert.Equal(t, tt.expected, result, "rules come back with their regex compiled")
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRulePgRepository_ListRules_Space(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer redisClient.Close()
+
+	repo := NewRulePgRepository(db, redisClient)
+	ctx := domain.WithActor(context.Background(), domain.Actor{SpaceID: testSpaceID})
+
+	mr.HSet("rules:all", "", `[{"id":1,"priority":1,"description_contains":"uber","category_id":3,"category":"Taxi"}]`)
+	mock.ExpectQuery(`FROM category_rules r JOIN categories c ON c.id = r.category_id WHERE r.space_id IS NOT DISTINCT FROM \$1 ORDER BY r.priority, r.id`).
+		WithArgs(testSpaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "priority", "description_regex", "description_contains", "min_amount", "max_amount", "category_id", "name", "description"}))
+
+	result, err := repo.ListRules(ctx)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result, "rules of the personal ledger do not leak into a space")
+	assert.Equal(t, "null", mr.HGet("rules:all", testSpaceID))
+	assert.Equal(t, `[{"id":1,"priority":1,"description_contains":"uber","category_id":3,"category":"Taxi"}]`, mr.HGet("rules:all", ""))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
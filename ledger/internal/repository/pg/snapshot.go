@@ -0,0 +1,99 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"ledger/internal/domain"
+	"time"
+)
+
+type SnapshotPgRepository struct {
+	db   *sql.DB
+	keep int
+}
+
+func NewSnapshotPgRepository(db *sql.DB) *SnapshotPgRepository {
+	return &SnapshotPgRepository{
+		db:   db,
+		keep: domain.DefaultSnapshotsKept,
+	}
+}
+
+// SetKeep sets how many of the newest snapshots CreateSnapshot keeps.
+func (r *SnapshotPgRepository) SetKeep(keep int) {
+	r.keep = keep
+}
+
+func (r *SnapshotPgRepository) CreateSnapshot(ctx context.Context, minAge time.Duration) (*domain.Snapshot, error) {
+	takenAt, err := r.barrier(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	var locked bool
+	err = tx.QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", snapshotLock).Scan(&locked)
+	if err != nil || !locked {
+		return nil, err
+	}
+	var previousID int64
+	var previousTakenAt time.Time
+	err = tx.QueryRowContext(ctx, "SELECT id, taken_at FROM ledger_snapshots ORDER BY taken_at DESC LIMIT 1").Scan(&previousID, &previousTakenAt)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if previousID != 0 && takenAt.Sub(previousTakenAt) < minAge {
+		return nil, nil
+	}
+	snapshot := &domain.Snapshot{TakenAt: takenAt}
+	err = tx.QueryRowContext(ctx, "INSERT INTO ledger_snapshots(taken_at) VALUES($1) RETURNING id", takenAt).Scan(&snapshot.ID)
+	if err != nil {
+		return nil, err
+	}
+	res, err := tx.ExecContext(ctx, `INSERT INTO ledger_snapshot_totals(snapshot_id, space_id, month, category_id, total)
+SELECT $1, t.space_id, t.month, t.category_id, SUM(t.amount) FROM (
+SELECT space_id, month, category_id, total AS amount FROM ledger_snapshot_totals WHERE snapshot_id = $2
+UNION ALL
+SELECT space_id, date_trunc('month', date)::date, category_id, amount FROM expenses WHERE ($3::timestamptz IS NULL OR recorded_at > $3) AND recorded_at <= $4 AND kind = 'expense'
+) t GROUP BY t.space_id, t.month, t.category_id`, snapshot.ID, previousID, nullTime(previousTakenAt), takenAt)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.Rows, err = res.RowsAffected(); err != nil {
+		return nil, err
+	}
+	res, err = tx.ExecContext(ctx, "DELETE FROM ledger_snapshots WHERE id NOT IN (SELECT id FROM ledger_snapshots ORDER BY taken_at DESC LIMIT $1)", r.keep)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.Pruned, err = res.RowsAffected(); err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// barrier returns a moment such that every expense recorded up to it is
+// already committed and every later one is recorded after it: writers stamp
+// recorded_at while holding the writer lock, and this waits for the lock.
+func (r *SnapshotPgRepository) barrier(ctx context.Context) (time.Time, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer tx.Rollback()
+	if err = lockWriters(ctx, tx); err != nil {
+		return time.Time{}, err
+	}
+	var takenAt time.Time
+	if err = tx.QueryRowContext(ctx, "SELECT clock_timestamp()").Scan(&takenAt); err != nil {
+		return time.Time{}, err
+	}
+	return takenAt, tx.Commit()
+}
@@ -0,0 +1,117 @@
+package pg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ledger/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotPgRepository_CreateSnapshot(t *testing.T) {
+	takenAt := time.Date(2025, 12, 20, 3, 0, 0, 0, time.UTC)
+	insertTotals := `INSERT INTO ledger_snapshot_totals\(snapshot_id, space_id, month, category_id, total\) .* recorded_at <= \$4 AND kind = 'expense' \) t GROUP BY t.space_id, t.month, t.category_id`
+	expectBarrier := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		expectLockWriters(mock)
+		mock.ExpectQuery(`SELECT clock_timestamp\(\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"clock_timestamp"}).AddRow(takenAt))
+		mock.ExpectCommit()
+	}
+	expectTryLock := func(mock sqlmock.Sqlmock, locked bool) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT pg_try_advisory_xact_lock\(\$1\)`).
+			WithArgs(snapshotLock).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(locked))
+	}
+	selectPrevious := `SELECT id, taken_at FROM ledger_snapshots ORDER BY taken_at DESC LIMIT 1`
+	insertSnapshot := `INSERT INTO ledger_snapshots\(taken_at\) VALUES\(\$1\) RETURNING id`
+	pruneSnapshots := `DELETE FROM ledger_snapshots WHERE id NOT IN \(SELECT id FROM ledger_snapshots ORDER BY taken_at DESC LIMIT \$1\)`
+
+	tests := []struct {
+		name      string
+		mockSetup func(sqlmock.Sqlmock)
+		expected  *domain.Snapshot
+	}{
+		{
+			name: "first snapshot",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectBarrier(mock)
+				expectTryLock(mock, true)
+				mock.ExpectQuery(selectPrevious).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "taken_at"}))
+				mock.ExpectQuery(insertSnapshot).
+					WithArgs(takenAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec(insertTotals).
+					WithArgs(1, 0, nil, takenAt).
+					WillReturnResult(sqlmock.NewResult(0, 12))
+				mock.ExpectExec(pruneSnapshots).
+					WithArgs(30).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			expected: &domain.Snapshot{ID: 1, TakenAt: takenAt, Rows: 12},
+		},
+		{
+			name: "incremental from previous snapshot",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				previous := takenAt.Add(-24 * time.Hour)
+				expectBarrier(mock)
+				expectTryLock(mock, true)
+				mock.ExpectQuery(selectPrevious).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "taken_at"}).AddRow(4, previous))
+				mock.ExpectQuery(insertSnapshot).
+					WithArgs(takenAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectExec(insertTotals).
+					WithArgs(5, 4, previous, takenAt).
+					WillReturnResult(sqlmock.NewResult(0, 14))
+				mock.ExpectExec(pruneSnapshots).
+					WithArgs(30).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expected: &domain.Snapshot{ID: 5, TakenAt: takenAt, Rows: 14, Pruned: 1},
+		},
+		{
+			name: "previous snapshot is fresh",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectBarrier(mock)
+				expectTryLock(mock, true)
+				mock.ExpectQuery(selectPrevious).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "taken_at"}).AddRow(4, takenAt.Add(-time.Hour)))
+				mock.ExpectRollback()
+			},
+			expected: nil,
+		},
+		{
+			name: "another builder holds the lock",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				expectBarrier(mock)
+				expectTryLock(mock, false)
+				mock.ExpectRollback()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			tt.mockSetup(mock)
+
+			snapshot, err := NewSnapshotPgRepository(db).CreateSnapshot(context.Background(), 12*time.Hour)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, snapshot)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"ledger/internal/domain"
+)
+
+// spaceID is the caller's ledger space as a query argument. It is NULL only
+// for callers without a user, which is why queries compare it with IS NOT
+// DISTINCT FROM.
+func spaceID(ctx context.Context) sql.NullString {
+	id := domain.ActorFromContext(ctx).Ledger()
+	return sql.NullString{String: id, Valid: id != ""}
+}
+
+// spaceField is the caller's field in the cache hashes that hold one entry
+// per space.
+func spaceField(ctx context.Context) string {
+	return domain.ActorFromContext(ctx).Ledger()
+}
+
+// spaceKey keeps cached results of different spaces apart.
+func spaceKey(ctx context.Context) string {
+	id := domain.ActorFromContext(ctx).Ledger()
+	if id == "" {
+		return ""
+	}
+	return ":space:" + id
+}
@@ -0,0 +1,51 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"ledger/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpaceID(t *testing.T) {
+	const userID = "98ac2ffe-5749-4ade-9053-6a7555d2bf04"
+
+	tests := []struct {
+		name        string
+		actor       domain.Actor
+		expected    sql.NullString
+		expectedKey string
+	}{
+		{
+			name:        "active space",
+			actor:       domain.Actor{UserID: userID, SpaceID: testSpaceID},
+			expected:    sql.NullString{String: testSpaceID, Valid: true},
+			expectedKey: ":space:" + testSpaceID,
+		},
+		{
+			name:        "personal ledger of the user",
+			actor:       domain.Actor{UserID: userID},
+			expected:    sql.NullString{String: userID, Valid: true},
+			expectedKey: ":space:" + userID,
+		},
+		{
+			name:        "no user",
+			actor:       domain.Actor{},
+			expected:    sql.NullString{},
+			expectedKey: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := domain.WithActor(context.Background(), tt.actor)
+
+			assert.Equal(t, tt.expected, spaceID(ctx))
+			assert.Equal(t, tt.expected.String, spaceField(ctx))
+			assert.Equal(t, tt.expectedKey, spaceKey(ctx))
+		})
+	}
+}
@@ -4,9 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
-	"fmt"
 	"ledger/internal/domain"
-	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -24,8 +22,34 @@ func NewSummaryPgRepository(db *sql.DB, cache *redis.Client) *SummaryPgRepositor
 	}
 }
 
-func (r *SummaryPgRepository) GetSummary(ctx context.Context, from string, to string) (*domain.Summary, error) {
-	key := "report:summary:" + from + ":" + to
+// summaryQuery reads whole months from the newest snapshot taken before as of
+// ($5) and the rest from expenses: the partial months at the edges, plus
+// whatever was recorded in the whole months after the snapshot was taken.
+// $6 is the space. Goal contributions are savings, not spending, and are
+// left out.
+const summaryQuery = `WITH s AS (SELECT id, taken_at FROM ledger_snapshots WHERE $5::timestamptz IS NULL OR taken_at < $5 ORDER BY taken_at DESC LIMIT 1)
+SELECT c.name, SUM(t.amount) FROM (
+SELECT st.category_id, st.total AS amount FROM ledger_snapshot_totals st JOIN s ON s.id = st.snapshot_id WHERE st.month >= $1 AND st.month < $2 AND st.space_id IS NOT DISTINCT FROM $6
+UNION ALL
+SELECT e.category_id, e.amount FROM expenses e WHERE e.date >= $3 AND e.date < $1 AND ($5::timestamptz IS NULL OR e.recorded_at < $5) AND e.space_id IS NOT DISTINCT FROM $6 AND e.kind = 'expense'
+UNION ALL
+SELECT e.category_id, e.amount FROM expenses e WHERE e.date >= $2 AND e.date <= $4 AND ($5::timestamptz IS NULL OR e.recorded_at < $5) AND e.space_id IS NOT DISTINCT FROM $6 AND e.kind = 'expense'
+UNION ALL
+SELECT e.category_id, e.amount FROM expenses e WHERE e.date >= $1 AND e.date < $2 AND e.recorded_at > COALESCE((SELECT taken_at FROM s), '-infinity') AND ($5::timestamptz IS NULL OR e.recorded_at < $5) AND e.space_id IS NOT DISTINCT FROM $6 AND e.kind = 'expense'
+) t JOIN categories c ON c.id = t.category_id GROUP BY c.name`
+
+func (r *SummaryPgRepository) GetSummary(ctx context.Context, from string, to string, asOf time.Time) (*domain.Summary, error) {
+	const layout = "2006-01-02"
+	fromDate, err := time.Parse(layout, from)
+	if err != nil {
+		return nil, err
+	}
+	toDate, err := time.Parse(layout, to)
+	if err != nil {
+		return nil, err
+	}
+	fullFrom, fullTo := domain.SummaryBounds(fromDate, toDate)
+	key := "report:summary:" + from + ":" + to + asOfKey(asOf) + spaceKey(ctx)
 	val, err := r.cache.Get(ctx, key).Result()
 	if err == nil {
 		println("Get result from cache")
@@ -35,7 +59,7 @@ func (r *SummaryPgRepository) GetSummary(ctx context.Context, from string, to st
 		}
 	}
 	println("Get result from db")
-	rows, err := r.db.QueryContext(ctx, "SELECT distinct category FROM expenses WHERE date BETWEEN $1 AND $2", from, to)
+	rows, err := r.db.QueryContext(ctx, summaryQuery, fullFrom.Format(layout), fullTo.Format(layout), from, to, nullTime(asOf), spaceID(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -45,47 +69,69 @@ func (r *SummaryPgRepository) GetSummary(ctx context.Context, from string, to st
 			println(err.Error())
 		}
 	}(rows)
-	var categories []string
+	result := make(map[string]float64)
 	for rows.Next() {
-		var c string
-		if err := rows.Scan(&c); err != nil {
+		var category string
+		var amount float64
+		if err := rows.Scan(&category, &amount); err != nil {
 			println(err.Error())
 			return nil, err
 		}
-		categories = append(categories, c)
+		result[category] = amount
 	}
 	if err = rows.Err(); err != nil {
 		println(err.Error())
 		return nil, err
 	}
-	var wg sync.WaitGroup
-	result := make(map[string]float64)
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
-	done := make(chan bool)
+	data, _ := json.Marshal(result)
+	print("save result to cache")
+	r.cache.Set(ctx, key, data, 30*time.Second)
+	return &domain.Summary{Categories: result, CacheResult: false}, nil
+}
 
-	go func() {
-		for {
-			select {
-			case <-done:
-				fmt.Println("Ticker stopped")
-				return
-			case t := <-ticker.C:
-				fmt.Println("Tick at", t)
-			}
+func (r *SummaryPgRepository) GetTagSummary(ctx context.Context, from string, to string, asOf time.Time) (*domain.Summary, error) {
+	key := "report:summary:tag:" + from + ":" + to + asOfKey(asOf) + spaceKey(ctx)
+	val, err := r.cache.Get(ctx, key).Result()
+	if err == nil {
+		var result map[string]float64
+		if err := json.Unmarshal([]byte(val), &result); err == nil {
+			return &domain.Summary{Tags: result, CacheResult: true}, nil
+		}
+	}
+	rows, err := r.db.QueryContext(ctx, "SELECT t.name, SUM(e.amount) FROM expenses e JOIN expense_tags et ON et.expense_id = e.id JOIN tags t ON t.id = et.tag_id WHERE e.date BETWEEN $1 AND $2 AND ($3::timestamptz IS NULL OR e.recorded_at < $3) AND e.space_id IS NOT DISTINCT FROM $4 AND e.kind = 'expense' GROUP BY t.name", from, to, nullTime(asOf), spaceID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+	result := make(map[string]float64)
+	for rows.Next() {
+		var tag string
+		var amount float64
+		if err := rows.Scan(&tag, &amount); err != nil {
+			return nil, err
 		}
-	}()
-	for _, category := range categories {
-		wg.Go(func() {
-			var amount float64
-			r.db.QueryRowContext(ctx, "SELECT sum(amount) FROM expenses WHERE category = $1 AND date BETWEEN $2 AND $3", category, from, to).Scan(&amount)
-			result[category] = amount
-		})
+		result[tag] = amount
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
 	}
-	wg.Wait()
-	done <- true
 	data, _ := json.Marshal(result)
-	print("save result to cache")
 	r.cache.Set(ctx, key, data, 30*time.Second)
-	return &domain.Summary{Categories: result, CacheResult: false}, nil
+	return &domain.Summary{Tags: result, CacheResult: false}, nil
+}
+
+func asOfKey(asOf time.Time) string {
+	if asOf.IsZero() {
+		return ""
+	}
+	return ":" + asOf.UTC().Format(time.RFC3339Nano)
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
 }
@@ -3,7 +3,9 @@ package pg
 import (
 	"context"
 	"database/sql"
+	"regexp"
 	"testing"
+	"time"
 
 	"ledger/internal/domain"
 
@@ -37,6 +39,7 @@ func TestSummaryPgRepository_GetSummary(t *testing.T) {
 		to          string
 		mockSetup   func()
 		expected    *domain.Summary
+		asOf        time.Time
 		expectedErr bool
 	}{
 		{
@@ -57,21 +60,39 @@ func TestSummaryPgRepository_GetSummary(t *testing.T) {
 			from: "2025-12-01",
 			to:   "2025-12-31",
 			mockSetup: func() {
-				dbMock.ExpectQuery(`SELECT distinct category FROM expenses WHERE date BETWEEN \$1 AND \$2`).
-					WithArgs("2025-12-01", "2025-12-31").
+				dbMock.ExpectQuery(regexp.QuoteMeta(summaryQuery)).
+					WithArgs("2025-12-01", "2026-01-01", "2025-12-01", "2025-12-31", nil, nil).
 					WillReturnError(sql.ErrConnDone)
 			},
 			expected:    nil,
 			expectedErr: true,
 		},
+		{
+			name: "successful grouped query",
+			from: "2025-12-01",
+			to:   "2025-12-31",
+			mockSetup: func() {
+				rows := sqlmock.NewRows([]string{"name", "sum"}).
+					AddRow("Food", 500.0).
+					AddRow("Groceries", 120.0)
+				dbMock.ExpectQuery(regexp.QuoteMeta(summaryQuery)).
+					WithArgs("2025-12-01", "2026-01-01", "2025-12-01", "2025-12-31", nil, nil).
+					WillReturnRows(rows)
+			},
+			expected: &domain.Summary{
+				Categories:  map[string]float64{"Food": 500.0, "Groceries": 120.0},
+				CacheResult: false,
+			},
+			expectedErr: false,
+		},
 		{
 			name: "no categories found",
 			from: "2025-12-01",
 			to:   "2025-12-31",
 			mockSetup: func() {
-				categoryRows := sqlmock.NewRows([]string{"category"})
-				dbMock.ExpectQuery(`SELECT distinct category FROM expenses WHERE date BETWEEN \$1 AND \$2`).
-					WithArgs("2025-12-01", "2025-12-31").
+				categoryRows := sqlmock.NewRows([]string{"name", "sum"})
+				dbMock.ExpectQuery(regexp.QuoteMeta(summaryQuery)).
+					WithArgs("2025-12-01", "2026-01-01", "2025-12-01", "2025-12-31", nil, nil).
 					WillReturnRows(categoryRows)
 			},
 			expected: &domain.Summary{
@@ -80,6 +101,24 @@ func TestSummaryPgRepository_GetSummary(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		{
+			name: "as of with partial months",
+			from: "2025-11-15",
+			to:   "2026-01-10",
+			asOf: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+			mockSetup: func() {
+				rows := sqlmock.NewRows([]string{"name", "sum"}).
+					AddRow("Food", 740.0)
+				dbMock.ExpectQuery(regexp.QuoteMeta(summaryQuery)).
+					WithArgs("2025-12-01", "2026-01-01", "2025-11-15", "2026-01-10", time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), nil).
+					WillReturnRows(rows)
+			},
+			expected: &domain.Summary{
+				Categories:  map[string]float64{"Food": 740.0},
+				CacheResult: false,
+			},
+			expectedErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -88,7 +127,7 @@ func TestSummaryPgRepository_GetSummary(t *testing.T) {
 
 			tt.mockSetup()
 
-			result, err := repo.GetSummary(ctx, tt.from, tt.to)
+			result, err := repo.GetSummary(ctx, tt.from, tt.to, tt.asOf)
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -103,3 +142,84 @@ func TestSummaryPgRepository_GetSummary(t *testing.T) {
 		})
 	}
 }
+
+func TestSummaryPgRepository_GetTagSummary(t *testing.T) {
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer redisClient.Close()
+
+	repo := NewSummaryPgRepository(db, redisClient)
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expected    *domain.Summary
+		expectedErr bool
+	}{
+		{
+			name: "cache hit",
+			mockSetup: func() {
+				mr.Set("report:summary:tag:2025-12-01:2025-12-31", `{"vacation-2025":300}`)
+			},
+			expected: &domain.Summary{
+				Tags:        map[string]float64{"vacation-2025": 300.0},
+				CacheResult: true,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "cache miss",
+			mockSetup: func() {
+				rows := sqlmock.NewRows([]string{"name", "sum"}).
+					AddRow("vacation-2025", 300.0).
+					AddRow("reimbursable", 50.0)
+				dbMock.ExpectQuery(`SELECT t.name, SUM\(e.amount\) FROM expenses e JOIN expense_tags et ON et.expense_id = e.id JOIN tags t ON t.id = et.tag_id WHERE e.date BETWEEN \$1 AND \$2 AND \(\$3::timestamptz IS NULL OR e.recorded_at < \$3\) AND e.space_id IS NOT DISTINCT FROM \$4 AND e.kind = 'expense' GROUP BY t.name`).
+					WithArgs("2025-12-01", "2025-12-31", nil, nil).
+					WillReturnRows(rows)
+			},
+			expected: &domain.Summary{
+				Tags:        map[string]float64{"vacation-2025": 300.0, "reimbursable": 50.0},
+				CacheResult: false,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "database error",
+			mockSetup: func() {
+				dbMock.ExpectQuery(`SELECT t.name, SUM\(e.amount\)`).
+					WithArgs("2025-12-01", "2025-12-31", nil, nil).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expected:    nil,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr.FlushAll()
+
+			tt.mockSetup()
+
+			result, err := repo.GetTagSummary(ctx, "2025-12-01", "2025-12-31", time.Time{})
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			assert.NoError(t, dbMock.ExpectationsWereMet())
+		})
+	}
+}
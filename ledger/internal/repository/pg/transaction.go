@@ -7,28 +7,40 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type TransactionPgRepository struct {
-	db *sql.DB
+	db             *sql.DB
+	idempotencyTTL time.Duration
 }
 
 func NewTransactionPgRepository(db *sql.DB) *TransactionPgRepository {
 	return &TransactionPgRepository{
-		db: db,
+		db:             db,
+		idempotencyTTL: domain.DefaultIdempotencyTTL,
 	}
 }
 
-func (r *TransactionPgRepository) GetAmountTransactionByCategory(category string, ctx context.Context) (float64, error) {
+// SetIdempotencyTTL sets the age after which AddTransaction may store an
+// idempotency key again.
+func (r *TransactionPgRepository) SetIdempotencyTTL(ttl time.Duration) {
+	r.idempotencyTTL = ttl
+}
+
+const categorySubtree = "WITH RECURSIVE subtree AS (SELECT id FROM categories WHERE id=$1 UNION ALL SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id) "
+
+func (r *TransactionPgRepository) GetAmountTransactionByCategory(categoryID int64, ctx context.Context) (float64, error) {
 	var totalAmount float64
-	err := r.db.QueryRowContext(ctx, "SELECT COALESCE(SUM(amount),0) FROM expenses WHERE category=$1", category).Scan(&totalAmount)
+	err := r.db.QueryRowContext(ctx, categorySubtree+"SELECT COALESCE(SUM(amount),0) FROM expenses WHERE category_id IN (SELECT id FROM subtree) AND space_id IS NOT DISTINCT FROM $2 AND kind = 'expense'", categoryID, spaceID(ctx)).Scan(&totalAmount)
 	if err != nil {
 		return 0, err
 	}
 	return totalAmount, nil
 }
 
-func (r *TransactionPgRepository) GetAmountTransactionByCategoryAndMonth(ctx context.Context, category string, date string) (float64, error) {
+func (r *TransactionPgRepository) GetAmountTransactionByCategoryAndMonth(ctx context.Context, categoryID int64, date string) (float64, error) {
 	t, err := time.Parse("2006-01-02", date)
 	if err != nil {
 		return 0, err
@@ -57,7 +69,7 @@ func (r *TransactionPgRepository) GetAmountTransactionByCategoryAndMonth(ctx con
 	}
 
 	var totalAmount float64
-	err = r.db.QueryRowContext(ctx, "SELECT COALESCE(SUM(amount),0) FROM expenses WHERE category=$1 AND date between $2 and $3", category, currentMonthStart.String(), nextMonthStart.String()).Scan(&totalAmount)
+	err = r.db.QueryRowContext(ctx, categorySubtree+"SELECT COALESCE(SUM(amount),0) FROM expenses WHERE category_id IN (SELECT id FROM subtree) AND date between $2 and $3 AND space_id IS NOT DISTINCT FROM $4 AND kind = 'expense'", categoryID, currentMonthStart.String(), nextMonthStart.String(), spaceID(ctx)).Scan(&totalAmount)
 	if err != nil {
 		return 0, err
 	}
@@ -65,25 +77,110 @@ func (r *TransactionPgRepository) GetAmountTransactionByCategoryAndMonth(ctx con
 }
 
 func (r *TransactionPgRepository) AddTransaction(transaction *domain.Transaction, ctx context.Context) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	if err = lockWriters(ctx, tx); err != nil {
+		return 0, err
+	}
 	var newID int64
-	err := r.db.QueryRowContext(ctx, "INSERT INTO expenses(amount, category, description, date) VALUES($1,$2,$3,$4) RETURNING id", transaction.Amount, transaction.Category, transaction.Description, transaction.Date).Scan(&newID)
+	err = tx.QueryRowContext(ctx, "INSERT INTO expenses(amount, category_id, description, date, external_id, space_id, created_by) VALUES($1,$2,$3,$4,NULLIF($5, ''),$6,NULLIF($7, '')) RETURNING id",
+		transaction.Amount, transaction.CategoryID, transaction.Description, transaction.Date, transaction.ExternalID, spaceID(ctx), domain.ActorFromContext(ctx).UserID).Scan(&newID)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" && pqErr.Constraint == "expenses_space_id_external_id_key" {
+		tx.Rollback()
+		return 0, r.externalIDDuplicate(ctx, transaction.ExternalID)
+	}
 	if err != nil {
 		return 0, err
 	}
+	for _, tag := range transaction.Tags {
+		var tagID int64
+		err = tx.QueryRowContext(ctx, "INSERT INTO tags(name, space_id) VALUES($1,$2) ON CONFLICT(space_id, name) DO UPDATE SET name=EXCLUDED.name RETURNING id", tag, spaceID(ctx)).Scan(&tagID)
+		if err != nil {
+			return 0, err
+		}
+		_, err = tx.ExecContext(ctx, "INSERT INTO expense_tags(expense_id, tag_id) VALUES($1,$2) ON CONFLICT DO NOTHING", newID, tagID)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if transaction.Anomaly != nil {
+		a := transaction.Anomaly
+		_, err = tx.ExecContext(ctx, "INSERT INTO expense_anomalies(expense_id, score, ratio, median, mad) VALUES($1,$2,$3,$4,$5)", newID, a.Score, a.Ratio, a.Median, a.MAD)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if transaction.IdempotencyKey != "" {
+		res, err := tx.ExecContext(ctx, "INSERT INTO idempotency_keys(key, request_hash, transaction_id, space_id) VALUES($1,$2,$3,$4) ON CONFLICT(space_id, key) DO UPDATE SET request_hash=EXCLUDED.request_hash, transaction_id=EXCLUDED.transaction_id, created_at=now() WHERE idempotency_keys.created_at <= now() - make_interval(secs => $5)", transaction.IdempotencyKey, transaction.RequestHash, newID, spaceID(ctx), r.idempotencyTTL.Seconds())
+		if err != nil {
+			return 0, err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		if affected == 0 {
+			return 0, domain.ErrIdempotencyKeyConflict
+		}
+	}
+	event := domain.NewTransactionEvent(transaction)
+	event.ID = newID
+	err = appendOutbox(ctx, tx, domain.EventTransactionCreated, newID, event)
+	if err != nil {
+		return 0, err
+	}
+	err = enqueueEvents(ctx, tx, append([]domain.Event{{Type: domain.EventTransactionCreated, Payload: event}}, transaction.Events...))
+	if err != nil {
+		return 0, err
+	}
+	err = appendAudit(ctx, tx, domain.AuditTransactionCreate, domain.AuditEntityTransaction, newID, nil, event)
+	if err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
 	return newID, nil
 }
 
+// externalIDDuplicate reports the transaction that got the external id
+// first; a forced add skips the duplicate check but not the unique index.
+func (r *TransactionPgRepository) externalIDDuplicate(ctx context.Context, externalID string) error {
+	var id int64
+	err := r.db.QueryRowContext(ctx, "SELECT id FROM expenses WHERE external_id=$1 AND space_id IS NOT DISTINCT FROM $2", externalID, spaceID(ctx)).Scan(&id)
+	if err != nil {
+		return err
+	}
+	return &domain.DuplicateTransactionError{ID: id}
+}
+
+const transactionSelect = "SELECT e.id, e.amount, e.category_id, c.name, e.description, e.date, ARRAY(SELECT t.name FROM expense_tags et JOIN tags t ON t.id = et.tag_id WHERE et.expense_id = e.id ORDER BY t.name), COALESCE(e.created_by, ''), e.kind FROM expenses e JOIN categories c ON c.id = e.category_id"
+
 func (r *TransactionPgRepository) GetTransaction(id int64, ctx context.Context) (*domain.Transaction, error) {
 	var tr domain.Transaction
-	err := r.db.QueryRowContext(ctx, "SELECT id, amount, category, description, date FROM expenses where id=$1", id).Scan(&tr.ID, &tr.Amount, &tr.Category, &tr.Description, &tr.Date)
+	err := r.db.QueryRowContext(ctx, transactionSelect+" WHERE e.id=$1 AND e.space_id IS NOT DISTINCT FROM $2", id, spaceID(ctx)).Scan(&tr.ID, &tr.Amount, &tr.CategoryID, &tr.Category, &tr.Description, &tr.Date, pq.Array(&tr.Tags), &tr.CreatedBy, &tr.Kind)
 	if err != nil {
 		return nil, err
 	}
 	return &tr, nil
 }
 
-func (r *TransactionPgRepository) ListTransactions(ctx context.Context) ([]domain.Transaction, error) {
-	rows, err := r.db.QueryContext(ctx, "SELECT id, amount, category, description, date FROM expenses ORDER BY date DESC, id DESC")
+func (r *TransactionPgRepository) ListTransactions(ctx context.Context, filter domain.TransactionFilter) ([]domain.Transaction, error) {
+	query := transactionSelect + " WHERE e.space_id IS NOT DISTINCT FROM $1"
+	args := []any{spaceID(ctx)}
+	if filter.Tag != "" {
+		args = append(args, filter.Tag)
+		query += " AND e.id IN (SELECT et.expense_id FROM expense_tags et JOIN tags t ON t.id = et.tag_id WHERE t.name = $2 AND t.space_id IS NOT DISTINCT FROM $1)"
+	}
+	query += " ORDER BY e.date DESC, e.id DESC"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += " LIMIT $" + strconv.Itoa(len(args))
+	}
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +193,7 @@ func (r *TransactionPgRepository) ListTransactions(ctx context.Context) ([]domai
 	var dbTransactions []domain.Transaction
 	for rows.Next() {
 		var t domain.Transaction
-		if err := rows.Scan(&t.ID, &t.Amount, &t.Category, &t.Description, &t.Date); err != nil {
+		if err := rows.Scan(&t.ID, &t.Amount, &t.CategoryID, &t.Category, &t.Description, &t.Date, pq.Array(&t.Tags), &t.CreatedBy, &t.Kind); err != nil {
 			return dbTransactions, err
 		}
 		dbTransactions = append(dbTransactions, t)
@@ -106,3 +203,79 @@ func (r *TransactionPgRepository) ListTransactions(ctx context.Context) ([]domai
 	}
 	return dbTransactions, nil
 }
+
+func (r *TransactionPgRepository) SearchTransactions(ctx context.Context, search domain.TransactionSearch) ([]domain.SearchResult, error) {
+	var query string
+	var args []any
+	var filters string
+	if search.CategoryID != 0 {
+		args = append(args, search.CategoryID)
+		query = categorySubtree
+		filters += " AND e.category_id IN (SELECT id FROM subtree)"
+	}
+	args = append(args, spaceID(ctx))
+	filters += " AND e.space_id IS NOT DISTINCT FROM $" + strconv.Itoa(len(args))
+	args = append(args, search.Language, search.TSQuery())
+	query += "SELECT e.id, e.amount, e.category_id, c.name, e.description, e.date, ARRAY(SELECT t.name FROM expense_tags et JOIN tags t ON t.id = et.tag_id WHERE et.expense_id = e.id ORDER BY t.name), COALESCE(e.created_by, ''), e.kind, ts_rank(e.search_vector, q) AS rank" +
+		" FROM expenses e JOIN categories c ON c.id = e.category_id, to_tsquery($" + strconv.Itoa(len(args)-1) + "::regconfig, $" + strconv.Itoa(len(args)) + ") q" +
+		" WHERE e.search_vector @@ q"
+	if search.From != "" {
+		args = append(args, search.From)
+		filters += " AND e.date >= $" + strconv.Itoa(len(args))
+	}
+	if search.To != "" {
+		args = append(args, search.To)
+		filters += " AND e.date <= $" + strconv.Itoa(len(args))
+	}
+	args = append(args, search.Limit)
+	query += filters + " ORDER BY rank DESC, e.date DESC, e.id DESC LIMIT $" + strconv.Itoa(len(args))
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+	var results []domain.SearchResult
+	for rows.Next() {
+		var res domain.SearchResult
+		t := &res.Transaction
+		if err := rows.Scan(&t.ID, &t.Amount, &t.CategoryID, &t.Category, &t.Description, &t.Date, pq.Array(&t.Tags), &t.CreatedBy, &t.Kind, &res.Rank); err != nil {
+			return results, err
+		}
+		results = append(results, res)
+	}
+	if err = rows.Err(); err != nil {
+		return results, err
+	}
+	return results, nil
+}
+
+func (r *TransactionPgRepository) FindDuplicateCandidates(ctx context.Context, transaction *domain.Transaction, windowDays int) ([]domain.Transaction, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, COALESCE(external_id, ''), amount, COALESCE(description, ''), date FROM expenses WHERE space_id IS NOT DISTINCT FROM $5 AND (external_id = NULLIF($1, '') OR (amount = ROUND($2::numeric, 2) AND date BETWEEN $3::date - $4::int AND $3::date + $4::int)) ORDER BY date DESC, id DESC",
+		transaction.ExternalID, transaction.Amount, transaction.Date, windowDays, spaceID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+	var candidates []domain.Transaction
+	for rows.Next() {
+		var t domain.Transaction
+		if err := rows.Scan(&t.ID, &t.ExternalID, &t.Amount, &t.Description, &t.Date); err != nil {
+			return candidates, err
+		}
+		candidates = append(candidates, t)
+	}
+	if err = rows.Err(); err != nil {
+		return candidates, err
+	}
+	return candidates, nil
+}
@@ -8,6 +8,7 @@ import (
 	"ledger/internal/domain"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -22,41 +23,41 @@ func TestTransactionPgRepository_GetAmountTransactionByCategory(t *testing.T) {
 
 	tests := []struct {
 		name        string
-		category    string
+		categoryID  int64
 		mockSetup   func()
 		expected    float64
 		expectedErr bool
 	}{
 		{
-			name:     "successful get amount",
-			category: "Food",
+			name:       "successful get amount",
+			categoryID: 1,
 			mockSetup: func() {
 				rows := sqlmock.NewRows([]string{"coalesce"}).AddRow(150.50)
-				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\),0\) FROM expenses WHERE category=\$1`).
-					WithArgs("Food").
+				mock.ExpectQuery(`WITH RECURSIVE subtree AS \(SELECT id FROM categories WHERE id=\$1 UNION ALL SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id\) SELECT COALESCE\(SUM\(amount\),0\) FROM expenses WHERE category_id IN \(SELECT id FROM subtree\) AND space_id IS NOT DISTINCT FROM \$2 AND kind = 'expense'`).
+					WithArgs(1, nil).
 					WillReturnRows(rows)
 			},
 			expected:    150.50,
 			expectedErr: false,
 		},
 		{
-			name:     "no transactions",
-			category: "Empty",
+			name:       "no transactions",
+			categoryID: 2,
 			mockSetup: func() {
 				rows := sqlmock.NewRows([]string{"coalesce"}).AddRow(0.0)
-				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\),0\) FROM expenses WHERE category=\$1`).
-					WithArgs("Empty").
+				mock.ExpectQuery(`WITH RECURSIVE subtree AS \(SELECT id FROM categories WHERE id=\$1 UNION ALL SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id\) SELECT COALESCE\(SUM\(amount\),0\) FROM expenses WHERE category_id IN \(SELECT id FROM subtree\) AND space_id IS NOT DISTINCT FROM \$2 AND kind = 'expense'`).
+					WithArgs(2, nil).
 					WillReturnRows(rows)
 			},
 			expected:    0.0,
 			expectedErr: false,
 		},
 		{
-			name:     "database error",
-			category: "Food",
+			name:       "database error",
+			categoryID: 1,
 			mockSetup: func() {
-				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\),0\) FROM expenses WHERE category=\$1`).
-					WithArgs("Food").
+				mock.ExpectQuery(`WITH RECURSIVE subtree AS \(SELECT id FROM categories WHERE id=\$1 UNION ALL SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id\) SELECT COALESCE\(SUM\(amount\),0\) FROM expenses WHERE category_id IN \(SELECT id FROM subtree\) AND space_id IS NOT DISTINCT FROM \$2 AND kind = 'expense'`).
+					WithArgs(1, nil).
 					WillReturnError(sql.ErrConnDone)
 			},
 			expected:    0,
@@ -68,7 +69,7 @@ func TestTransactionPgRepository_GetAmountTransactionByCategory(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			result, err := repo.GetAmountTransactionByCategory(tt.category, ctx)
+			result, err := repo.GetAmountTransactionByCategory(tt.categoryID, ctx)
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -91,20 +92,20 @@ func TestTransactionPgRepository_GetAmountTransactionByCategoryAndMonth(t *testi
 
 	tests := []struct {
 		name        string
-		category    string
+		categoryID  int64
 		date        string
 		mockSetup   func()
 		expected    float64
 		expectedErr bool
 	}{
 		{
-			name:     "successful get amount",
-			category: "Food",
-			date:     "2025-12-15",
+			name:       "successful get amount",
+			categoryID: 1,
+			date:       "2025-12-15",
 			mockSetup: func() {
 				rows := sqlmock.NewRows([]string{"coalesce"}).AddRow(200.75)
-				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\),0\) FROM expenses WHERE category=\$1 AND date between \$2 and \$3`).
-					WithArgs("Food", "2025-12-01", "2026-01-01").
+				mock.ExpectQuery(`WITH RECURSIVE subtree AS \(SELECT id FROM categories WHERE id=\$1 UNION ALL SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id\) SELECT COALESCE\(SUM\(amount\),0\) FROM expenses WHERE category_id IN \(SELECT id FROM subtree\) AND date between \$2 and \$3 AND space_id IS NOT DISTINCT FROM \$4 AND kind = 'expense'`).
+					WithArgs(1, "2025-12-01", "2026-01-01", nil).
 					WillReturnRows(rows)
 			},
 			expected:    200.75,
@@ -112,19 +113,19 @@ func TestTransactionPgRepository_GetAmountTransactionByCategoryAndMonth(t *testi
 		},
 		{
 			name:        "invalid date",
-			category:    "Food",
+			categoryID:  1,
 			date:        "invalid-date",
 			mockSetup:   func() {},
 			expected:    0,
 			expectedErr: true,
 		},
 		{
-			name:     "database error",
-			category: "Food",
-			date:     "2025-12-15",
+			name:       "database error",
+			categoryID: 1,
+			date:       "2025-12-15",
 			mockSetup: func() {
-				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\),0\) FROM expenses WHERE category=\$1 AND date between \$2 and \$3`).
-					WithArgs("Food", "2025-12-01", "2026-01-01").
+				mock.ExpectQuery(`WITH RECURSIVE subtree AS \(SELECT id FROM categories WHERE id=\$1 UNION ALL SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id\) SELECT COALESCE\(SUM\(amount\),0\) FROM expenses WHERE category_id IN \(SELECT id FROM subtree\) AND date between \$2 and \$3 AND space_id IS NOT DISTINCT FROM \$4 AND kind = 'expense'`).
+					WithArgs(1, "2025-12-01", "2026-01-01", nil).
 					WillReturnError(sql.ErrConnDone)
 			},
 			expected:    0,
@@ -136,7 +137,7 @@ func TestTransactionPgRepository_GetAmountTransactionByCategoryAndMonth(t *testi
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			result, err := repo.GetAmountTransactionByCategoryAndMonth(ctx, tt.category, tt.date)
+			result, err := repo.GetAmountTransactionByCategoryAndMonth(ctx, tt.categoryID, tt.date)
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -161,10 +162,44 @@ func TestTransactionPgRepository_AddTransaction(t *testing.T) {
 
 	transaction := &domain.Transaction{
 		Amount:      100.50,
+		CategoryID:  1,
 		Category:    "Food",
 		Description: "Lunch",
 		Date:        "2025-12-01",
 	}
+	taggedTransaction := &domain.Transaction{
+		Amount:      100.50,
+		CategoryID:  1,
+		Category:    "Food",
+		Description: "Lunch",
+		Date:        "2025-12-01",
+		Tags:        []string{"vacation-2025"},
+	}
+	anomalousTransaction := &domain.Transaction{
+		Amount:      900,
+		CategoryID:  1,
+		Category:    "Food",
+		Description: "Banquet",
+		Date:        "2025-12-01",
+		Anomaly:     &domain.AnomalyScore{Score: 40.47, Ratio: 9, Median: 100, MAD: 13.33},
+	}
+	hugeAnomalyTransaction := &domain.Transaction{
+		Amount:      500000000000,
+		CategoryID:  1,
+		Category:    "Food",
+		Description: "Typo",
+		Date:        "2025-12-01",
+		Anomaly:     &domain.AnomalyScore{Score: 674499999999.33, Ratio: 1000000000000, Median: 0.5, MAD: 0.5},
+	}
+	idempotentTransaction := &domain.Transaction{
+		Amount:         100.50,
+		CategoryID:     1,
+		Category:       "Food",
+		Description:    "Lunch",
+		Date:           "2025-12-01",
+		IdempotencyKey: "key-1",
+		RequestHash:    "hash",
+	}
 
 	tests := []struct {
 		name        string
@@ -177,20 +212,166 @@ func TestTransactionPgRepository_AddTransaction(t *testing.T) {
 			name:        "successful add",
 			transaction: transaction,
 			mockSetup: func() {
-				mock.ExpectQuery(`INSERT INTO expenses\(amount, category, description, date\) VALUES\(\$1,\$2,\$3,\$4\) RETURNING id`).
-					WithArgs(100.50, "Food", "Lunch", "2025-12-01").
+				mock.ExpectBegin()
+				expectLockWriters(mock)
+				mock.ExpectQuery(`INSERT INTO expenses\(amount, category_id, description, date, external_id, space_id, created_by\) VALUES\(\$1,\$2,\$3,\$4,NULLIF\(\$5, ''\),\$6,NULLIF\(\$7, ''\)\) RETURNING id`).
+					WithArgs(100.50, 1, "Lunch", "2025-12-01", "", nil, "").
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				expectOutbox(mock, "transaction.created", 1, `{"id":1,"amount":100.5,"category_id":1,"category":"Food","description":"Lunch","date":"2025-12-01"}`, "")
+				expectEnqueue(mock, "transaction.created", `{"id":1,"amount":100.5,"category_id":1,"category":"Food","description":"Lunch","date":"2025-12-01"}`, "")
+				expectAudit(mock, "transaction.create", "transaction", 1, domain.Actor{}, nil, `{"id":1,"amount":100.5,"category_id":1,"category":"Food","description":"Lunch","date":"2025-12-01"}`)
+				mock.ExpectCommit()
 			},
 			expected:    1,
 			expectedErr: false,
 		},
+		{
+			name: "successful add with webhook events",
+			transaction: &domain.Transaction{Amount: 100.50, CategoryID: 1, Category: "Food", Description: "Lunch", Date: "2025-12-01", Events: []domain.Event{
+				{Type: domain.EventBudgetThresholdReached, Payload: domain.BudgetEvent{CategoryID: 1, Category: "Food", Limit: 1000, Spent: 850.5, Amount: 100.5, Date: "2025-12-01"}},
+			}},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				expectLockWriters(mock)
+				mock.ExpectQuery(`INSERT INTO expenses\(amount, category_id, description, date, external_id, space_id, created_by\) VALUES\(\$1,\$2,\$3,\$4,NULLIF\(\$5, ''\),\$6,NULLIF\(\$7, ''\)\) RETURNING id`).
+					WithArgs(100.50, 1, "Lunch", "2025-12-01", "", nil, "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				expectOutbox(mock, "transaction.created", 5, `{"id":5,"amount":100.5,"category_id":1,"category":"Food","description":"Lunch","date":"2025-12-01"}`, "")
+				expectEnqueue(mock, "transaction.created", `{"id":5,"amount":100.5,"category_id":1,"category":"Food","description":"Lunch","date":"2025-12-01"}`, "")
+				expectEnqueue(mock, "budget.threshold_reached", `{"category_id":1,"category":"Food","limit":1000,"spent":850.5,"amount":100.5,"date":"2025-12-01"}`, "")
+				expectAudit(mock, "transaction.create", "transaction", 5, domain.Actor{}, nil, `{"id":5,"amount":100.5,"category_id":1,"category":"Food","description":"Lunch","date":"2025-12-01"}`)
+				mock.ExpectCommit()
+			},
+			expected:    5,
+			expectedErr: false,
+		},
+		{
+			name:        "successful add with anomaly",
+			transaction: anomalousTransaction,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				expectLockWriters(mock)
+				mock.ExpectQuery(`INSERT INTO expenses\(amount, category_id, description, date, external_id, space_id, created_by\) VALUES\(\$1,\$2,\$3,\$4,NULLIF\(\$5, ''\),\$6,NULLIF\(\$7, ''\)\) RETURNING id`).
+					WithArgs(900.0, 1, "Banquet", "2025-12-01", "", nil, "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectExec(`INSERT INTO expense_anomalies\(expense_id, score, ratio, median, mad\) VALUES\(\$1,\$2,\$3,\$4,\$5\)`).
+					WithArgs(3, 40.47, 9.0, 100.0, 13.33).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectOutbox(mock, "transaction.created", 3, `{"id":3,"amount":900,"category_id":1,"category":"Food","description":"Banquet","date":"2025-12-01"}`, "")
+				expectEnqueue(mock, "transaction.created", `{"id":3,"amount":900,"category_id":1,"category":"Food","description":"Banquet","date":"2025-12-01"}`, "")
+				expectAudit(mock, "transaction.create", "transaction", 3, domain.Actor{}, nil, `{"id":3,"amount":900,"category_id":1,"category":"Food","description":"Banquet","date":"2025-12-01"}`)
+				mock.ExpectCommit()
+			},
+			expected:    3,
+			expectedErr: false,
+		},
+		{
+			name:        "successful add with huge anomaly ratio",
+			transaction: hugeAnomalyTransaction,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				expectLockWriters(mock)
+				mock.ExpectQuery(`INSERT INTO expenses\(amount, category_id, description, date, external_id, space_id, created_by\) VALUES\(\$1,\$2,\$3,\$4,NULLIF\(\$5, ''\),\$6,NULLIF\(\$7, ''\)\) RETURNING id`).
+					WithArgs(500000000000.0, 1, "Typo", "2025-12-01", "", nil, "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				mock.ExpectExec(`INSERT INTO expense_anomalies\(expense_id, score, ratio, median, mad\) VALUES\(\$1,\$2,\$3,\$4,\$5\)`).
+					WithArgs(4, 674499999999.33, 1000000000000.0, 0.5, 0.5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectOutbox(mock, "transaction.created", 4, `{"id":4,"amount":500000000000,"category_id":1,"category":"Food","description":"Typo","date":"2025-12-01"}`, "")
+				expectEnqueue(mock, "transaction.created", `{"id":4,"amount":500000000000,"category_id":1,"category":"Food","description":"Typo","date":"2025-12-01"}`, "")
+				expectAudit(mock, "transaction.create", "transaction", 4, domain.Actor{}, nil, `{"id":4,"amount":500000000000,"category_id":1,"category":"Food","description":"Typo","date":"2025-12-01"}`)
+				mock.ExpectCommit()
+			},
+			expected:    4,
+			expectedErr: false,
+		},
+		{
+			name:        "successful add with tags",
+			transaction: taggedTransaction,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				expectLockWriters(mock)
+				mock.ExpectQuery(`INSERT INTO expenses\(amount, category_id, description, date, external_id, space_id, created_by\) VALUES\(\$1,\$2,\$3,\$4,NULLIF\(\$5, ''\),\$6,NULLIF\(\$7, ''\)\) RETURNING id`).
+					WithArgs(100.50, 1, "Lunch", "2025-12-01", "", nil, "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectQuery(`INSERT INTO tags\(name, space_id\) VALUES\(\$1,\$2\) ON CONFLICT\(space_id, name\) DO UPDATE SET name=EXCLUDED.name RETURNING id`).
+					WithArgs("vacation-2025", nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectExec(`INSERT INTO expense_tags\(expense_id, tag_id\) VALUES\(\$1,\$2\) ON CONFLICT DO NOTHING`).
+					WithArgs(2, 7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectOutbox(mock, "transaction.created", 2, `{"id":2,"amount":100.5,"category_id":1,"category":"Food","description":"Lunch","date":"2025-12-01","tags":["vacation-2025"]}`, "")
+				expectEnqueue(mock, "transaction.created", `{"id":2,"amount":100.5,"category_id":1,"category":"Food","description":"Lunch","date":"2025-12-01","tags":["vacation-2025"]}`, "")
+				expectAudit(mock, "transaction.create", "transaction", 2, domain.Actor{}, nil, `{"id":2,"amount":100.5,"category_id":1,"category":"Food","description":"Lunch","date":"2025-12-01","tags":["vacation-2025"]}`)
+				mock.ExpectCommit()
+			},
+			expected:    2,
+			expectedErr: false,
+		},
+		{
+			name:        "tag insert error",
+			transaction: taggedTransaction,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				expectLockWriters(mock)
+				mock.ExpectQuery(`INSERT INTO expenses\(amount, category_id, description, date, external_id, space_id, created_by\) VALUES\(\$1,\$2,\$3,\$4,NULLIF\(\$5, ''\),\$6,NULLIF\(\$7, ''\)\) RETURNING id`).
+					WithArgs(100.50, 1, "Lunch", "2025-12-01", "", nil, "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectQuery(`INSERT INTO tags\(name, space_id\) VALUES\(\$1,\$2\) ON CONFLICT\(space_id, name\) DO UPDATE SET name=EXCLUDED.name RETURNING id`).
+					WithArgs("vacation-2025", nil).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expected:    0,
+			expectedErr: true,
+		},
+		{
+			name:        "successful add with idempotency key",
+			transaction: idempotentTransaction,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				expectLockWriters(mock)
+				mock.ExpectQuery(`INSERT INTO expenses\(amount, category_id, description, date, external_id, space_id, created_by\) VALUES\(\$1,\$2,\$3,\$4,NULLIF\(\$5, ''\),\$6,NULLIF\(\$7, ''\)\) RETURNING id`).
+					WithArgs(100.50, 1, "Lunch", "2025-12-01", "", nil, "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectExec(`INSERT INTO idempotency_keys\(key, request_hash, transaction_id, space_id\) VALUES\(\$1,\$2,\$3,\$4\) ON CONFLICT\(space_id, key\) DO UPDATE SET request_hash=EXCLUDED.request_hash, transaction_id=EXCLUDED.transaction_id, created_at=now\(\) WHERE idempotency_keys.created_at <= now\(\) - make_interval\(secs => \$5\)`).
+					WithArgs("key-1", "hash", 3, nil, 86400.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectOutbox(mock, "transaction.created", 3, `{"id":3,"amount":100.5,"category_id":1,"category":"Food","description":"Lunch","date":"2025-12-01"}`, "")
+				expectEnqueue(mock, "transaction.created", `{"id":3,"amount":100.5,"category_id":1,"category":"Food","description":"Lunch","date":"2025-12-01"}`, "")
+				expectAudit(mock, "transaction.create", "transaction", 3, domain.Actor{}, nil, `{"id":3,"amount":100.5,"category_id":1,"category":"Food","description":"Lunch","date":"2025-12-01"}`)
+				mock.ExpectCommit()
+			},
+			expected:    3,
+			expectedErr: false,
+		},
+		{
+			name:        "unexpired idempotency key already stored",
+			transaction: idempotentTransaction,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				expectLockWriters(mock)
+				mock.ExpectQuery(`INSERT INTO expenses\(amount, category_id, description, date, external_id, space_id, created_by\) VALUES\(\$1,\$2,\$3,\$4,NULLIF\(\$5, ''\),\$6,NULLIF\(\$7, ''\)\) RETURNING id`).
+					WithArgs(100.50, 1, "Lunch", "2025-12-01", "", nil, "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				mock.ExpectExec(`INSERT INTO idempotency_keys\(key, request_hash, transaction_id, space_id\) VALUES\(\$1,\$2,\$3,\$4\) ON CONFLICT\(space_id, key\) DO UPDATE SET request_hash=EXCLUDED.request_hash, transaction_id=EXCLUDED.transaction_id, created_at=now\(\) WHERE idempotency_keys.created_at <= now\(\) - make_interval\(secs => \$5\)`).
+					WithArgs("key-1", "hash", 4, nil, 86400.0).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expected:    0,
+			expectedErr: true,
+		},
 		{
 			name:        "database error",
 			transaction: transaction,
 			mockSetup: func() {
-				mock.ExpectQuery(`INSERT INTO expenses\(amount, category, description, date\) VALUES\(\$1,\$2,\$3,\$4\) RETURNING id`).
-					WithArgs(100.50, "Food", "Lunch", "2025-12-01").
+				mock.ExpectBegin()
+				expectLockWriters(mock)
+				mock.ExpectQuery(`INSERT INTO expenses\(amount, category_id, description, date, external_id, space_id, created_by\) VALUES\(\$1,\$2,\$3,\$4,NULLIF\(\$5, ''\),\$6,NULLIF\(\$7, ''\)\) RETURNING id`).
+					WithArgs(100.50, 1, "Lunch", "2025-12-01", "", nil, "").
 					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
 			},
 			expected:    0,
 			expectedErr: true,
@@ -214,6 +395,32 @@ func TestTransactionPgRepository_AddTransaction(t *testing.T) {
 	}
 }
 
+func TestTransactionPgRepository_AddTransaction_ExternalIDTaken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewTransactionPgRepository(db)
+	ctx := domain.WithActor(context.Background(), domain.Actor{SpaceID: testSpaceID})
+
+	mock.ExpectBegin()
+	expectLockWriters(mock)
+	mock.ExpectQuery(`INSERT INTO expenses\(amount, category_id, description, date, external_id, space_id, created_by\)`).
+		WithArgs(10.0, 1, "Bread", "2025-12-01", "bank-1", testSpaceID, "").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "expenses_space_id_external_id_key"})
+	mock.ExpectRollback()
+	mock.ExpectQuery(`SELECT id FROM expenses WHERE external_id=\$1 AND space_id IS NOT DISTINCT FROM \$2`).
+		WithArgs("bank-1", testSpaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+
+	_, err = repo.AddTransaction(&domain.Transaction{Amount: 10, CategoryID: 1, Description: "Bread", Date: "2025-12-01", ExternalID: "bank-1"}, ctx)
+
+	var duplicate *domain.DuplicateTransactionError
+	require.ErrorAs(t, err, &duplicate)
+	assert.Equal(t, int64(5), duplicate.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestTransactionPgRepository_GetTransaction(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -233,18 +440,21 @@ func TestTransactionPgRepository_GetTransaction(t *testing.T) {
 			name: "successful get",
 			id:   1,
 			mockSetup: func() {
-				rows := sqlmock.NewRows([]string{"id", "amount", "category", "description", "date"}).
-					AddRow(1, 100.50, "Food", "Lunch", "2025-12-01")
-				mock.ExpectQuery(`SELECT id, amount, category, description, date FROM expenses where id=\$1`).
-					WithArgs(1).
+				rows := sqlmock.NewRows([]string{"id", "amount", "category_id", "name", "description", "date", "tags", "created_by", "kind"}).
+					AddRow(1, 100.50, 1, "Food", "Lunch", "2025-12-01", "{reimbursable,vacation-2025}", "", "expense")
+				mock.ExpectQuery(`SELECT e.id, e.amount, e.category_id, c.name, e.description, e.date, ARRAY\(SELECT t.name FROM expense_tags et JOIN tags t ON t.id = et.tag_id WHERE et.expense_id = e.id ORDER BY t.name\), COALESCE\(e.created_by, ''\), e.kind FROM expenses e JOIN categories c ON c.id = e.category_id WHERE e.id=\$1 AND e.space_id IS NOT DISTINCT FROM \$2`).
+					WithArgs(1, nil).
 					WillReturnRows(rows)
 			},
 			expected: &domain.Transaction{
 				ID:          1,
 				Amount:      100.50,
+				CategoryID:  1,
 				Category:    "Food",
 				Description: "Lunch",
 				Date:        "2025-12-01",
+				Tags:        []string{"reimbursable", "vacation-2025"},
+				Kind:        "expense",
 			},
 			expectedErr: false,
 		},
@@ -252,8 +462,8 @@ func TestTransactionPgRepository_GetTransaction(t *testing.T) {
 			name: "transaction not found",
 			id:   999,
 			mockSetup: func() {
-				mock.ExpectQuery(`SELECT id, amount, category, description, date FROM expenses where id=\$1`).
-					WithArgs(999).
+				mock.ExpectQuery(`SELECT e.id, e.amount, e.category_id, c.name, e.description, e.date, ARRAY\(SELECT t.name FROM expense_tags et JOIN tags t ON t.id = et.tag_id WHERE et.expense_id = e.id ORDER BY t.name\), COALESCE\(e.created_by, ''\), e.kind FROM expenses e JOIN categories c ON c.id = e.category_id WHERE e.id=\$1 AND e.space_id IS NOT DISTINCT FROM \$2`).
+					WithArgs(999, nil).
 					WillReturnError(sql.ErrNoRows)
 			},
 			expected:    nil,
@@ -263,8 +473,8 @@ func TestTransactionPgRepository_GetTransaction(t *testing.T) {
 			name: "database error",
 			id:   1,
 			mockSetup: func() {
-				mock.ExpectQuery(`SELECT id, amount, category, description, date FROM expenses where id=\$1`).
-					WithArgs(1).
+				mock.ExpectQuery(`SELECT e.id, e.amount, e.category_id, c.name, e.description, e.date, ARRAY\(SELECT t.name FROM expense_tags et JOIN tags t ON t.id = et.tag_id WHERE et.expense_id = e.id ORDER BY t.name\), COALESCE\(e.created_by, ''\), e.kind FROM expenses e JOIN categories c ON c.id = e.category_id WHERE e.id=\$1 AND e.space_id IS NOT DISTINCT FROM \$2`).
+					WithArgs(1, nil).
 					WillReturnError(sql.ErrConnDone)
 			},
 			expected:    nil,
@@ -299,6 +509,7 @@ func TestTransactionPgRepository_ListTransactions(t *testing.T) {
 
 	tests := []struct {
 		name        string
+		filter      domain.TransactionFilter
 		mockSetup   func()
 		expected    []domain.Transaction
 		expectedErr bool
@@ -306,26 +517,82 @@ func TestTransactionPgRepository_ListTransactions(t *testing.T) {
 		{
 			name: "successful list",
 			mockSetup: func() {
-				rows := sqlmock.NewRows([]string{"id", "amount", "category", "description", "date"}).
-					AddRow(1, 100.50, "Food", "Lunch", "2025-12-01").
-					AddRow(2, 50.25, "Transport", "Bus", "2025-12-02")
-				mock.ExpectQuery(`SELECT id, amount, category, description, date FROM expenses ORDER BY date DESC, id DESC`).
+				rows := sqlmock.NewRows([]string{"id", "amount", "category_id", "name", "description", "date", "tags", "created_by", "kind"}).
+					AddRow(1, 100.50, 1, "Food", "Lunch", "2025-12-01", "{reimbursable,vacation-2025}", "98ac2ffe", "expense").
+					AddRow(2, 50.25, 2, "Transport", "Bus", "2025-12-02", "{}", "", "expense")
+				mock.ExpectQuery(`SELECT e.id, e.amount, e.category_id, c.name, e.description, e.date, ARRAY\(SELECT t.name FROM expense_tags et JOIN tags t ON t.id = et.tag_id WHERE et.expense_id = e.id ORDER BY t.name\), COALESCE\(e.created_by, ''\), e.kind FROM expenses e JOIN categories c ON c.id = e.category_id WHERE e.space_id IS NOT DISTINCT FROM \$1 ORDER BY e.date DESC, e.id DESC`).
+					WithArgs(nil).
 					WillReturnRows(rows)
 			},
 			expected: []domain.Transaction{
 				{
 					ID:          1,
 					Amount:      100.50,
+					CategoryID:  1,
 					Category:    "Food",
 					Description: "Lunch",
 					Date:        "2025-12-01",
+					Tags:        []string{"reimbursable", "vacation-2025"},
+					CreatedBy:   "98ac2ffe",
+					Kind:        "expense",
 				},
 				{
 					ID:          2,
 					Amount:      50.25,
+					CategoryID:  2,
 					Category:    "Transport",
 					Description: "Bus",
 					Date:        "2025-12-02",
+					Tags:        []string{},
+					Kind:        "expense",
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name:   "filter by tag",
+			filter: domain.TransactionFilter{Tag: "vacation-2025"},
+			mockSetup: func() {
+				rows := sqlmock.NewRows([]string{"id", "amount", "category_id", "name", "description", "date", "tags", "created_by", "kind"}).
+					AddRow(1, 100.50, 1, "Food", "Lunch", "2025-12-01", "{vacation-2025}", "", "expense")
+				mock.ExpectQuery(`JOIN categories c ON c.id = e.category_id WHERE e.space_id IS NOT DISTINCT FROM \$1 AND e.id IN \(SELECT et.expense_id FROM expense_tags et JOIN tags t ON t.id = et.tag_id WHERE t.name = \$2 AND t.space_id IS NOT DISTINCT FROM \$1\) ORDER BY e.date DESC, e.id DESC`).
+					WithArgs(nil, "vacation-2025").
+					WillReturnRows(rows)
+			},
+			expected: []domain.Transaction{
+				{
+					ID:          1,
+					Amount:      100.50,
+					CategoryID:  1,
+					Category:    "Food",
+					Description: "Lunch",
+					Date:        "2025-12-01",
+					Tags:        []string{"vacation-2025"},
+					Kind:        "expense",
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name:   "filter by tag with limit",
+			filter: domain.TransactionFilter{Tag: "vacation-2025", Limit: 1},
+			mockSetup: func() {
+				rows := sqlmock.NewRows([]string{"id", "amount", "category_id", "name", "description", "date", "tags", "created_by", "kind"}).
+					AddRow(1, 100.50, 1, "Food", "Lunch", "2025-12-01", "{vacation-2025}", "", "expense")
+				mock.ExpectQuery(`WHERE t.name = \$2 AND t.space_id IS NOT DISTINCT FROM \$1\) ORDER BY e.date DESC, e.id DESC LIMIT \$3`).
+					WithArgs(nil, "vacation-2025", 1).
+					WillReturnRows(rows)
+			},
+			expected: []domain.Transaction{
+				{
+					ID:          1,
+					Amount:      100.50,
+					CategoryID:  1,
+					Category:    "Food",
+					Description: "Lunch",
+					Date:        "2025-12-01",
+					Tags:        []string{"vacation-2025"},
+					Kind:        "expense",
 				},
 			},
 			expectedErr: false,
@@ -333,8 +600,9 @@ func TestTransactionPgRepository_ListTransactions(t *testing.T) {
 		{
 			name: "empty result",
 			mockSetup: func() {
-				rows := sqlmock.NewRows([]string{"id", "amount", "category", "description", "date"})
-				mock.ExpectQuery(`SELECT id, amount, category, description, date FROM expenses ORDER BY date DESC, id DESC`).
+				rows := sqlmock.NewRows([]string{"id", "amount", "category_id", "name", "description", "date", "tags", "created_by", "kind"})
+				mock.ExpectQuery(`SELECT e.id, e.amount, e.category_id, c.name, e.description, e.date, ARRAY\(SELECT t.name FROM expense_tags et JOIN tags t ON t.id = et.tag_id WHERE et.expense_id = e.id ORDER BY t.name\), COALESCE\(e.created_by, ''\), e.kind FROM expenses e JOIN categories c ON c.id = e.category_id WHERE e.space_id IS NOT DISTINCT FROM \$1 ORDER BY e.date DESC, e.id DESC`).
+					WithArgs(nil).
 					WillReturnRows(rows)
 			},
 			expected:    nil,
@@ -343,7 +611,73 @@ func TestTransactionPgRepository_ListTransactions(t *testing.T) {
 		{
 			name: "database error",
 			mockSetup: func() {
-				mock.ExpectQuery(`SELECT id, amount, category, description, date FROM expenses ORDER BY date DESC, id DESC`).
+				mock.ExpectQuery(`SELECT e.id, e.amount, e.category_id, c.name, e.description, e.date, ARRAY\(SELECT t.name FROM expense_tags et JOIN tags t ON t.id = et.tag_id WHERE et.expense_id = e.id ORDER BY t.name\), COALESCE\(e.created_by, ''\), e.kind FROM expenses e JOIN categories c ON c.id = e.category_id WHERE e.space_id IS NOT DISTINCT FROM \$1 ORDER BY e.date DESC, e.id DESC`).
+					WithArgs(nil).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expected:    nil,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			result, err := repo.ListTransactions(ctx, tt.filter)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransactionPgRepository_FindDuplicateCandidates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewTransactionPgRepository(db)
+	ctx := context.Background()
+	transaction := &domain.Transaction{
+		Amount:      42.10,
+		Description: "CARD *COFFEE HOUSE",
+		Date:        "2025-12-05",
+		ExternalID:  "bank-7781",
+	}
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expected    []domain.Transaction
+		expectedErr bool
+	}{
+		{
+			name: "candidates found",
+			mockSetup: func() {
+				rows := sqlmock.NewRows([]string{"id", "external_id", "amount", "description", "date"}).
+					AddRow(9, "", 42.10, "Coffee House", "2025-12-04").
+					AddRow(3, "bank-7781", 42.10, "CARD *COFFEE HOUSE", "2025-11-01")
+				mock.ExpectQuery(`SELECT id, COALESCE\(external_id, ''\), amount, COALESCE\(description, ''\), date FROM expenses WHERE space_id IS NOT DISTINCT FROM \$5 AND \(external_id = NULLIF\(\$1, ''\) OR \(amount = ROUND\(\$2::numeric, 2\) AND date BETWEEN \$3::date - \$4::int AND \$3::date \+ \$4::int\)\) ORDER BY date DESC, id DESC`).
+					WithArgs("bank-7781", 42.10, "2025-12-05", 3, nil).
+					WillReturnRows(rows)
+			},
+			expected: []domain.Transaction{
+				{ID: 9, Amount: 42.10, Description: "Coffee House", Date: "2025-12-04"},
+				{ID: 3, ExternalID: "bank-7781", Amount: 42.10, Description: "CARD *COFFEE HOUSE", Date: "2025-11-01"},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "database error",
+			mockSetup: func() {
+				mock.ExpectQuery(`FROM expenses WHERE space_id IS NOT DISTINCT FROM \$5 AND \(external_id = NULLIF\(\$1, ''\)`).
+					WithArgs("bank-7781", 42.10, "2025-12-05", 3, nil).
 					WillReturnError(sql.ErrConnDone)
 			},
 			expected:    nil,
@@ -355,7 +689,72 @@ func TestTransactionPgRepository_ListTransactions(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			result, err := repo.ListTransactions(ctx)
+			result, err := repo.FindDuplicateCandidates(ctx, transaction, 3)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransactionPgRepository_SearchTransactions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewTransactionPgRepository(db)
+	ctx := context.Background()
+	columns := []string{"id", "amount", "category_id", "name", "description", "date", "tags", "created_by", "kind", "rank"}
+
+	tests := []struct {
+		name        string
+		search      domain.TransactionSearch
+		mockSetup   func()
+		expected    []domain.SearchResult
+		expectedErr bool
+	}{
+		{
+			name:   "query only",
+			search: domain.TransactionSearch{Query: "pharm", Language: "english", Limit: 50},
+			mockSetup: func() {
+				mock.ExpectQuery(`SELECT e.id, .+, ts_rank\(e.search_vector, q\) AS rank FROM expenses e JOIN categories c ON c.id = e.category_id, to_tsquery\(\$2::regconfig, \$3\) q WHERE e.search_vector @@ q AND e.space_id IS NOT DISTINCT FROM \$1 ORDER BY rank DESC, e.date DESC, e.id DESC LIMIT \$4`).
+					WithArgs(nil, "english", "pharm:*", 50).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(4, 12.5, 2, "Health", "Pharmacy", "2025-03-14", "{}", "", "expense", 0.6))
+			},
+			expected: []domain.SearchResult{
+				{Transaction: domain.Transaction{ID: 4, Amount: 12.5, CategoryID: 2, Category: "Health", Description: "Pharmacy", Date: "2025-03-14", Tags: []string{}, Kind: "expense"}, Rank: 0.6},
+			},
+		},
+		{
+			name:   "with category and dates",
+			search: domain.TransactionSearch{Query: "pharm", Language: "english", From: "2025-03-01", To: "2025-03-31", CategoryID: 1, Limit: 10},
+			mockSetup: func() {
+				mock.ExpectQuery(`WITH RECURSIVE subtree AS \(.+\) SELECT e.id, .+ to_tsquery\(\$3::regconfig, \$4\) q WHERE e.search_vector @@ q AND e.category_id IN \(SELECT id FROM subtree\) AND e.space_id IS NOT DISTINCT FROM \$2 AND e.date >= \$5 AND e.date <= \$6 ORDER BY rank DESC, e.date DESC, e.id DESC LIMIT \$7`).
+					WithArgs(1, nil, "english", "pharm:*", "2025-03-01", "2025-03-31", 10).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expected: nil,
+		},
+		{
+			name:   "database error",
+			search: domain.TransactionSearch{Query: "pharm", Language: "english", Limit: 50},
+			mockSetup: func() {
+				mock.ExpectQuery(`SELECT e.id`).WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			result, err := repo.SearchTransactions(ctx, tt.search)
 
 			if tt.expectedErr {
 				assert.Error(t, err)
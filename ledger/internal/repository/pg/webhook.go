@@ -0,0 +1,178 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"ledger/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type WebhookPgRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookPgRepository(db *sql.DB) *WebhookPgRepository {
+	return &WebhookPgRepository{
+		db: db,
+	}
+}
+
+func (r *WebhookPgRepository) AddWebhook(ctx context.Context, webhook *domain.Webhook) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var newID int64
+	err = tx.QueryRowContext(ctx, "INSERT INTO webhooks(url, secret, events, space_id) VALUES($1,$2,$3,$4) RETURNING id", webhook.URL, webhook.Secret, pq.Array(webhook.Events), spaceID(ctx)).Scan(&newID)
+	if err != nil {
+		return 0, err
+	}
+	after := domain.Webhook{ID: newID, URL: webhook.URL, Events: webhook.Events}
+	err = appendAudit(ctx, tx, domain.AuditWebhookCreate, domain.AuditEntityWebhook, newID, nil, after)
+	if err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return newID, nil
+}
+
+func (r *WebhookPgRepository) DeleteWebhook(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var before domain.Webhook
+	err = tx.QueryRowContext(ctx, "SELECT id, url, events FROM webhooks WHERE id=$1 AND space_id IS NOT DISTINCT FROM $2 FOR UPDATE", id, spaceID(ctx)).Scan(&before.ID, &before.URL, pq.Array(&before.Events))
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM webhooks WHERE id=$1", id)
+	if err != nil {
+		return err
+	}
+	err = appendAudit(ctx, tx, domain.AuditWebhookDelete, domain.AuditEntityWebhook, id, before, nil)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *WebhookPgRepository) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, url, events FROM webhooks WHERE space_id IS NOT DISTINCT FROM $1 ORDER BY id", spaceID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+	var webhooks []domain.Webhook
+	for rows.Next() {
+		var w domain.Webhook
+		if err := rows.Scan(&w.ID, &w.URL, pq.Array(&w.Events)); err != nil {
+			return webhooks, err
+		}
+		webhooks = append(webhooks, w)
+	}
+	if err = rows.Err(); err != nil {
+		return webhooks, err
+	}
+	return webhooks, nil
+}
+
+// enqueueDeliveries skips the webhooks that already have a delivery with the
+// event's dedup key. Events without a key are always queued.
+const enqueueDeliveries = "INSERT INTO webhook_deliveries(webhook_id, event, payload, dedup_key) SELECT id, $1, $2, NULLIF($4, '') FROM webhooks WHERE $1 = ANY(events) AND space_id IS NOT DISTINCT FROM $3 ON CONFLICT (webhook_id, dedup_key) DO NOTHING"
+
+func (r *WebhookPgRepository) EnqueueEvent(ctx context.Context, event domain.Event) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, enqueueDeliveries, event.Type, string(payload), spaceID(ctx), event.Key)
+	return err
+}
+
+// enqueueEvents queues the deliveries of events in tx, so that they are
+// sent only for changes that were committed.
+func enqueueEvents(ctx context.Context, tx *sql.Tx, events []domain.Event) error {
+	for _, event := range events {
+		payload, err := json.Marshal(event.Payload)
+		if err != nil {
+			return err
+		}
+		if _, err = tx.ExecContext(ctx, enqueueDeliveries, event.Type, string(payload), spaceID(ctx), event.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClaimDueDeliveries pushes next_attempt_at forward by the lease so another
+// instance polling the same table skips the claimed rows while they are sent.
+func (r *WebhookPgRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]domain.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, "WITH due AS (SELECT id FROM webhook_deliveries WHERE status = 'pending' AND next_attempt_at <= now() ORDER BY next_attempt_at, id LIMIT $1 FOR UPDATE SKIP LOCKED), "+
+		"claimed AS (UPDATE webhook_deliveries d SET next_attempt_at = now() + make_interval(secs => $2) FROM due WHERE d.id = due.id RETURNING d.id, d.webhook_id, d.event, d.payload, d.attempts, d.created_at) "+
+		"SELECT c.id, c.webhook_id, w.url, w.secret, c.event, c.payload, c.attempts, c.created_at FROM claimed c JOIN webhooks w ON w.id = c.webhook_id ORDER BY c.id", limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		d := domain.WebhookDelivery{Status: domain.DeliveryPending}
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.URL, &d.Secret, &d.Event, &d.Payload, &d.Attempts, &d.CreatedAt); err != nil {
+			return deliveries, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err = rows.Err(); err != nil {
+		return deliveries, err
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookPgRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE webhook_deliveries SET status=$1, attempts=$2, last_status_code=$3, last_error=$4, next_attempt_at=$5, delivered_at=$6 WHERE id=$7",
+		delivery.Status, delivery.Attempts, delivery.LastStatusCode, delivery.LastError, delivery.NextAttemptAt, delivery.DeliveredAt, delivery.ID)
+	return err
+}
+
+func (r *WebhookPgRepository) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]domain.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, webhook_id, event, payload, status, attempts, last_status_code, last_error, next_attempt_at, created_at, delivered_at FROM webhook_deliveries WHERE webhook_id=$1 AND webhook_id IN (SELECT id FROM webhooks WHERE space_id IS NOT DISTINCT FROM $3) ORDER BY id DESC LIMIT $2", webhookID, limit, spaceID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		var d domain.WebhookDelivery
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.Event, &d.Payload, &d.Status, &d.Attempts, &d.LastStatusCode, &d.LastError, &d.NextAttemptAt, &d.CreatedAt, &d.DeliveredAt); err != nil {
+			return deliveries, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err = rows.Err(); err != nil {
+		return deliveries, err
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,189 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"ledger/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookPgRepository_AddWebhook(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWebhookPgRepository(db)
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO webhooks\(url, secret, events, space_id\) VALUES\(\$1,\$2,\$3,\$4\) RETURNING id`).
+		WithArgs("https://bot.example.com/hook", "s3cret", pq.Array([]string{"budget.exceeded"}), nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	expectAudit(mock, "webhook.create", "webhook", 1, domain.Actor{}, nil, `{"id":1,"url":"https://bot.example.com/hook","events":["budget.exceeded"]}`)
+	mock.ExpectCommit()
+
+	id, err := repo.AddWebhook(ctx, &domain.Webhook{URL: "https://bot.example.com/hook", Secret: "s3cret", Events: []string{"budget.exceeded"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookPgRepository_DeleteWebhook(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWebhookPgRepository(db)
+	ctx := context.Background()
+	lockQuery := `SELECT id, url, events FROM webhooks WHERE id=\$1 AND space_id IS NOT DISTINCT FROM \$2 FOR UPDATE`
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		expectedErr error
+	}{
+		{
+			name: "successful delete",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(1, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "url", "events"}).AddRow(1, "https://bot.example.com/hook", "{budget.exceeded}"))
+				mock.ExpectExec(`DELETE FROM webhooks WHERE id=\$1`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAudit(mock, "webhook.delete", "webhook", 1, domain.Actor{}, `{"id":1,"url":"https://bot.example.com/hook","events":["budget.exceeded"]}`, nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "not found",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockQuery).
+					WithArgs(1, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "url", "events"}))
+				mock.ExpectRollback()
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			err := repo.DeleteWebhook(ctx, 1)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func expectEnqueue(mock sqlmock.Sqlmock, eventType string, payload string, space string) {
+	mock.ExpectExec(`INSERT INTO webhook_deliveries\(webhook_id, event, payload, dedup_key\) SELECT id, \$1, \$2, NULLIF\(\$4, ''\) FROM webhooks WHERE \$1 = ANY\(events\) AND space_id IS NOT DISTINCT FROM \$3 ON CONFLICT \(webhook_id, dedup_key\) DO NOTHING`).
+		WithArgs(eventType, payload, spaceArg(space), "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestWebhookPgRepository_EnqueueEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWebhookPgRepository(db)
+	ctx := context.Background()
+
+	mock.ExpectExec(`INSERT INTO webhook_deliveries\(webhook_id, event, payload, dedup_key\) SELECT id, \$1, \$2, NULLIF\(\$4, ''\) FROM webhooks WHERE \$1 = ANY\(events\) AND space_id IS NOT DISTINCT FROM \$3 ON CONFLICT \(webhook_id, dedup_key\) DO NOTHING`).
+		WithArgs("transaction.created", `{"id":7,"amount":50,"category_id":1,"category":"Food","description":"","date":"2025-12-10"}`, nil, "").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = repo.EnqueueEvent(ctx, domain.Event{
+		Type:    domain.EventTransactionCreated,
+		Payload: domain.TransactionEvent{ID: 7, Amount: 50, CategoryID: 1, Category: "Food", Date: "2025-12-10"},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookPgRepository_EnqueueEventWithKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWebhookPgRepository(db)
+	ctx := domain.WithActor(context.Background(), domain.Actor{SpaceID: testSpaceID})
+
+	mock.ExpectExec(`INSERT INTO webhook_deliveries\(webhook_id, event, payload, dedup_key\) SELECT id, \$1, \$2, NULLIF\(\$4, ''\) FROM webhooks WHERE \$1 = ANY\(events\) AND space_id IS NOT DISTINCT FROM \$3 ON CONFLICT \(webhook_id, dedup_key\) DO NOTHING`).
+		WithArgs("budget.exceeded", `{"category_id":1,"category":"Food","limit":1000,"spent":950,"amount":100,"date":"2025-12-01"}`, testSpaceID, "budget.exceeded:1:abc").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.EnqueueEvent(ctx, domain.Event{
+		Type:    domain.EventBudgetExceeded,
+		Payload: domain.BudgetEvent{CategoryID: 1, Category: "Food", Limit: 1000, Spent: 950, Amount: 100, Date: "2025-12-01"},
+		Key:     "budget.exceeded:1:abc",
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookPgRepository_ClaimDueDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWebhookPgRepository(db)
+	ctx := context.Background()
+	createdAt := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`WITH due AS \(SELECT id FROM webhook_deliveries WHERE status = 'pending' AND next_attempt_at <= now\(\) .* FOR UPDATE SKIP LOCKED\)`).
+		WithArgs(50, 60.0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "url", "secret", "event", "payload", "attempts", "created_at"}).
+			AddRow(3, 1, "https://bot.example.com/hook", "s3cret", "budget.exceeded", []byte(`{}`), 1, createdAt))
+
+	deliveries, err := repo.ClaimDueDeliveries(ctx, 50, time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.WebhookDelivery{{
+		ID:        3,
+		WebhookID: 1,
+		URL:       "https://bot.example.com/hook",
+		Secret:    "s3cret",
+		Event:     "budget.exceeded",
+		Payload:   []byte(`{}`),
+		Status:    domain.DeliveryPending,
+		Attempts:  1,
+		CreatedAt: createdAt,
+	}}, deliveries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookPgRepository_UpdateDelivery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWebhookPgRepository(db)
+	ctx := context.Background()
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`UPDATE webhook_deliveries SET status=\$1, attempts=\$2, last_status_code=\$3, last_error=\$4, next_attempt_at=\$5, delivered_at=\$6 WHERE id=\$7`).
+		WithArgs("pending", 2, 500, "unexpected status 500", now, nil, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.UpdateDelivery(ctx, &domain.WebhookDelivery{ID: 3, Status: "pending", Attempts: 2, LastStatusCode: 500, LastError: "unexpected status 500", NextAttemptAt: now})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
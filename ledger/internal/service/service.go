@@ -1,45 +1,137 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"ledger/internal/domain"
+	"log"
+	"math"
+	"sort"
 	"sync"
+	"time"
 )
 
 type LedgerService interface {
 	BudgetAdd(ctx context.Context, budget *domain.Budget) error
-	BudgetGet(ctx context.Context, category string) (*domain.Budget, error)
-	BudgetsList(ctx context.Context) (map[string]domain.Budget, error)
-	TransactionAdd(ctx context.Context, transaction *domain.Transaction) (int64, error)
+	BudgetGet(ctx context.Context, category string, asOf time.Time) (*domain.Budget, error)
+	BudgetsList(ctx context.Context, asOf time.Time) (map[string]domain.Budget, error)
+	TransactionAdd(ctx context.Context, transaction *domain.Transaction, force bool) (int64, error)
 	TransactionGet(ctx context.Context, id int64) (*domain.Transaction, error)
-	TransactionsList(ctx context.Context) ([]domain.Transaction, error)
+	TransactionsList(ctx context.Context, filter domain.TransactionFilter) ([]domain.Transaction, error)
+	TransactionSearch(ctx context.Context, search *domain.TransactionSearch) ([]domain.SearchResult, error)
 	BulkAddTransactions(ctx context.Context, transactions []domain.Transaction, numWorkers int) (*BulkTransactionResult, error)
-	GetReportSummary(ctx context.Context, from string, to string) (*domain.Summary, error)
+	GetReportSummary(ctx context.Context, from string, to string, asOf time.Time) (*domain.Summary, error)
+	GetReportTagSummary(ctx context.Context, from string, to string, asOf time.Time) (*domain.Summary, error)
+	GetReportForecast(ctx context.Context, historyPeriods int) (*domain.Forecast, error)
+	GetReportAnomalies(ctx context.Context, from string, to string) ([]domain.Anomaly, error)
+	CategoryAdd(ctx context.Context, category *domain.Category) (int64, error)
+	CategoryUpdate(ctx context.Context, category *domain.Category) error
+	CategoriesList(ctx context.Context) ([]domain.Category, error)
+	RuleAdd(ctx context.Context, rule *domain.Rule) (int64, error)
+	RuleUpdate(ctx context.Context, rule *domain.Rule) error
+	RuleDelete(ctx context.Context, id int64) error
+	RulesList(ctx context.Context) ([]domain.Rule, error)
+	RuleTest(ctx context.Context, rule *domain.Rule, last int) ([]domain.RuleMatch, error)
+	AttachmentUpload(ctx context.Context, attachment *domain.Attachment, data io.Reader) (int64, error)
+	AttachmentGet(ctx context.Context, transactionID int64, id int64) (*domain.Attachment, io.ReadCloser, error)
+	AttachmentDelete(ctx context.Context, transactionID int64, id int64) error
+	AttachmentsList(ctx context.Context, transactionID int64) ([]domain.Attachment, error)
+	GoalAdd(ctx context.Context, goal *domain.Goal) (int64, error)
+	GoalUpdate(ctx context.Context, goal *domain.Goal) error
+	GoalDelete(ctx context.Context, id int64) error
+	GoalGet(ctx context.Context, id int64) (*domain.GoalProgress, error)
+	GoalsList(ctx context.Context) ([]domain.GoalProgress, error)
+	GoalContribute(ctx context.Context, contribution *domain.GoalContribution) (int64, error)
+	WebhookAdd(ctx context.Context, webhook *domain.Webhook) (int64, error)
+	WebhookDelete(ctx context.Context, id int64) error
+	WebhooksList(ctx context.Context) ([]domain.Webhook, error)
+	WebhookDeliveriesList(ctx context.Context, webhookID int64, limit int) ([]domain.WebhookDelivery, error)
+	Subscribe(ctx context.Context, fromEventID int64, types []string, send func(domain.OutboxEvent) error) error
+	AuditList(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
 }
 
 type LedgerServiceImpl struct {
 	budgetRepository      domain.BudgetRepository
 	transactionRepository domain.TransactionRepository
 	summaryRepository     domain.SummaryRepository
+	categoryRepository    domain.CategoryRepository
+	ruleRepository        domain.RuleRepository
+	idempotencyRepository domain.IdempotencyRepository
+	attachmentRepository  domain.AttachmentRepository
+	blobStorage           domain.BlobStorage
+	goalRepository        domain.GoalRepository
+	anomalyRepository     domain.AnomalyRepository
+	webhookRepository     domain.WebhookRepository
+	eventStream           domain.EventStream
+	auditRepository       domain.AuditRepository
+	anomalyDetector       domain.AnomalyDetector
+	now                   func() time.Time
+	duplicateWindowDays   int
+	maxAttachmentSize     int64
+	searchLanguage        string
 }
 
 var _ LedgerService = (*LedgerServiceImpl)(nil)
 
-func NewLedgerService(budgetRepository domain.BudgetRepository, transactionsRepository domain.TransactionRepository, summaryRepository domain.SummaryRepository) *LedgerServiceImpl {
+func NewLedgerService(budgetRepository domain.BudgetRepository, transactionsRepository domain.TransactionRepository, summaryRepository domain.SummaryRepository, categoryRepository domain.CategoryRepository, ruleRepository domain.RuleRepository, idempotencyRepository domain.IdempotencyRepository, attachmentRepository domain.AttachmentRepository, blobStorage domain.BlobStorage, goalRepository domain.GoalRepository, anomalyRepository domain.AnomalyRepository, webhookRepository domain.WebhookRepository, eventStream domain.EventStream, auditRepository domain.AuditRepository) *LedgerServiceImpl {
 	return &LedgerServiceImpl{
 		budgetRepository:      budgetRepository,
 		transactionRepository: transactionsRepository,
 		summaryRepository:     summaryRepository,
+		categoryRepository:    categoryRepository,
+		ruleRepository:        ruleRepository,
+		idempotencyRepository: idempotencyRepository,
+		attachmentRepository:  attachmentRepository,
+		blobStorage:           blobStorage,
+		goalRepository:        goalRepository,
+		anomalyRepository:     anomalyRepository,
+		webhookRepository:     webhookRepository,
+		eventStream:           eventStream,
+		auditRepository:       auditRepository,
+		anomalyDetector:       domain.DefaultAnomalyDetector(),
+		now:                   time.Now,
+		duplicateWindowDays:   3,
+		maxAttachmentSize:     10 << 20,
+		searchLanguage:        "simple",
 	}
 }
 
+func (l *LedgerServiceImpl) SetDuplicateWindow(days int) {
+	l.duplicateWindowDays = days
+}
+
+func (l *LedgerServiceImpl) SetMaxAttachmentSize(size int64) {
+	l.maxAttachmentSize = size
+}
+
+func (l *LedgerServiceImpl) SetSearchLanguage(language string) {
+	l.searchLanguage = language
+}
+
+func (l *LedgerServiceImpl) SetAnomalyDetector(detector domain.AnomalyDetector) {
+	l.anomalyDetector = detector
+}
+
 func (l *LedgerServiceImpl) BudgetAdd(ctx context.Context, budget *domain.Budget) error {
 	err := budget.Validate()
 	if err != nil {
 		return err
 	}
+	if budget.CategoryID == 0 {
+		category, err := l.categoryRepository.GetCategoryByName(ctx, budget.Category)
+		if errors.Is(err, sql.ErrNoRows) {
+			category = &domain.Category{Name: budget.Category}
+			category.ID, err = l.categoryRepository.AddCategory(ctx, category)
+		}
+		if err != nil {
+			return err
+		}
+		budget.CategoryID = category.ID
+	}
 	err = l.budgetRepository.SetBudget(budget, ctx)
 	if err != nil {
 		return err
@@ -47,7 +139,19 @@ func (l *LedgerServiceImpl) BudgetAdd(ctx context.Context, budget *domain.Budget
 	return nil
 }
 
-func (l *LedgerServiceImpl) BudgetGet(ctx context.Context, category string) (*domain.Budget, error) {
+func (l *LedgerServiceImpl) BudgetGet(ctx context.Context, category string, asOf time.Time) (*domain.Budget, error) {
+	if !asOf.IsZero() {
+		budgets, err := l.budgetsAsOf(ctx, asOf)
+		if err != nil {
+			return nil, err
+		}
+		for _, budget := range budgets {
+			if budget.Category == category {
+				return &budget, nil
+			}
+		}
+		return nil, sql.ErrNoRows
+	}
 	budget, err := l.budgetRepository.GetBudget(category, ctx)
 	if err != nil {
 		return nil, err
@@ -55,8 +159,14 @@ func (l *LedgerServiceImpl) BudgetGet(ctx context.Context, category string) (*do
 	return budget, nil
 }
 
-func (l *LedgerServiceImpl) BudgetsList(ctx context.Context) (map[string]domain.Budget, error) {
-	budgets, err := l.budgetRepository.GetBudgets(ctx)
+func (l *LedgerServiceImpl) BudgetsList(ctx context.Context, asOf time.Time) (map[string]domain.Budget, error) {
+	var budgets []domain.Budget
+	var err error
+	if asOf.IsZero() {
+		budgets, err = l.budgetRepository.GetBudgets(ctx)
+	} else {
+		budgets, err = l.budgetsAsOf(ctx, asOf)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -67,29 +177,217 @@ func (l *LedgerServiceImpl) BudgetsList(ctx context.Context) (map[string]domain.
 	return budgetMap, nil
 }
 
-func (l *LedgerServiceImpl) TransactionAdd(ctx context.Context, transaction *domain.Transaction) (int64, error) {
-	err := transaction.Validate()
+func (l *LedgerServiceImpl) TransactionAdd(ctx context.Context, transaction *domain.Transaction, force bool) (int64, error) {
+	if transaction.IdempotencyKey != "" {
+		transaction.RequestHash = transaction.Fingerprint()
+		id, replayed, err := l.replayIdempotent(ctx, transaction)
+		if err != nil || replayed {
+			return id, err
+		}
+	}
+	err := l.applyRules(ctx, transaction)
+	if err != nil {
+		return 0, err
+	}
+	err = transaction.Validate()
 	if err != nil {
 		return 0, errors.New("invalid transaction")
 	}
-	budget, err := l.budgetRepository.GetBudget(transaction.Category, ctx)
+	if !force {
+		err = l.checkDuplicate(ctx, transaction)
+		if err != nil {
+			return 0, err
+		}
+	}
+	err = l.checkBudgets(ctx, transaction)
 	if err != nil {
 		return 0, err
 	}
-	amount, err := l.transactionRepository.GetAmountTransactionByCategoryAndMonth(ctx, transaction.Category, transaction.Date)
+	err = l.detectAnomaly(ctx, transaction)
 	if err != nil {
 		return 0, err
 	}
-	if amount+transaction.Amount > budget.Limit {
-		return 0, errors.New("budget exceeded")
-	}
 	id, err := l.transactionRepository.AddTransaction(transaction, ctx)
+	if errors.Is(err, domain.ErrIdempotencyKeyConflict) {
+		id, replayed, err := l.replayIdempotent(ctx, transaction)
+		if err == nil && !replayed {
+			err = domain.ErrIdempotencyKeyConflict
+		}
+		return id, err
+	}
 	if err != nil {
 		return 0, err
 	}
+	transaction.ID = id
 	return id, nil
 }
 
+// budgetThreshold is the share of a monthly limit at which subscribers get
+// a budget.threshold_reached event.
+const budgetThreshold = 0.8
+
+// checkBudgets rejects a transaction that would take one of its budgets over
+// the monthly limit, publishing budget.exceeded. Otherwise it leaves the
+// budget.threshold_reached events in transaction.Events, which the insert
+// queues together with the transaction.
+func (l *LedgerServiceImpl) checkBudgets(ctx context.Context, transaction *domain.Transaction) error {
+	budgets, err := l.categoryBudgets(ctx, transaction)
+	if err != nil {
+		return err
+	}
+	transaction.Events = nil
+	for _, budget := range budgets {
+		amount, err := l.transactionRepository.GetAmountTransactionByCategoryAndMonth(ctx, budget.CategoryID, transaction.Date)
+		if err != nil {
+			return err
+		}
+		if amount+transaction.Amount > budget.Limit {
+			err = l.publish(ctx, domain.Event{
+				Type:    domain.EventBudgetExceeded,
+				Payload: budgetEvent(budget, amount, transaction),
+				Key:     budgetExceededKey(budget, transaction),
+			})
+			if err != nil {
+				return err
+			}
+			return errors.New("budget exceeded")
+		}
+		if domain.BudgetThresholdCrossed(budget.Limit, amount, amount+transaction.Amount, budgetThreshold) {
+			transaction.Events = append(transaction.Events, domain.Event{Type: domain.EventBudgetThresholdReached, Payload: budgetEvent(budget, amount+transaction.Amount, transaction)})
+		}
+	}
+	return nil
+}
+
+// publish queues an event that is not tied to a stored change for webhook
+// delivery. The event should carry a Key, so that a client retrying after an
+// enqueue error does not get it delivered twice.
+func (l *LedgerServiceImpl) publish(ctx context.Context, event domain.Event) error {
+	if l.webhookRepository == nil {
+		return nil
+	}
+	return l.webhookRepository.EnqueueEvent(ctx, event)
+}
+
+// budgetExceededKey is the same for every retry of a rejected transaction.
+func budgetExceededKey(budget domain.Budget, transaction *domain.Transaction) string {
+	return fmt.Sprintf("%s:%d:%s", domain.EventBudgetExceeded, budget.CategoryID, transaction.Fingerprint())
+}
+
+func budgetEvent(budget domain.Budget, spent float64, transaction *domain.Transaction) domain.BudgetEvent {
+	return domain.BudgetEvent{
+		CategoryID: budget.CategoryID,
+		Category:   budget.Category,
+		Limit:      budget.Limit,
+		Spent:      spent,
+		Amount:     transaction.Amount,
+		Date:       transaction.Date,
+	}
+}
+
+func (l *LedgerServiceImpl) replayIdempotent(ctx context.Context, transaction *domain.Transaction) (int64, bool, error) {
+	record, err := l.idempotencyRepository.GetIdempotencyRecord(ctx, transaction.IdempotencyKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if record.RequestHash != transaction.RequestHash {
+		return 0, false, domain.ErrIdempotencyKeyReused
+	}
+	transaction.ID = record.TransactionID
+	transaction.Anomaly, err = l.storedAnomaly(ctx, record.TransactionID)
+	if err != nil {
+		return 0, false, err
+	}
+	return record.TransactionID, true, nil
+}
+
+// storedAnomaly returns the anomaly recorded when the transaction was added,
+// so that a replayed request reports the same flag as the original one.
+func (l *LedgerServiceImpl) storedAnomaly(ctx context.Context, id int64) (*domain.AnomalyScore, error) {
+	if l.anomalyRepository == nil {
+		return nil, nil
+	}
+	score, err := l.anomalyRepository.GetAnomaly(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return score, err
+}
+
+func (l *LedgerServiceImpl) checkDuplicate(ctx context.Context, transaction *domain.Transaction) error {
+	candidates, err := l.transactionRepository.FindDuplicateCandidates(ctx, transaction, l.duplicateWindowDays)
+	if err != nil {
+		return err
+	}
+	if duplicate := domain.FindDuplicate(transaction, candidates); duplicate != nil {
+		return &domain.DuplicateTransactionError{ID: duplicate.ID}
+	}
+	return nil
+}
+
+const anomalyHistorySize = 100
+
+func (l *LedgerServiceImpl) detectAnomaly(ctx context.Context, transaction *domain.Transaction) error {
+	if l.anomalyRepository == nil {
+		return nil
+	}
+	amounts, err := l.anomalyRepository.GetCategoryAmounts(ctx, transaction.CategoryID, anomalyHistorySize)
+	if err != nil {
+		return err
+	}
+	transaction.Anomaly = l.anomalyDetector.Check(domain.NewCategoryStats(amounts), transaction.Amount)
+	return nil
+}
+
+func (l *LedgerServiceImpl) applyRules(ctx context.Context, transaction *domain.Transaction) error {
+	rules, err := l.ruleRepository.ListRules(ctx)
+	if err != nil {
+		return err
+	}
+	domain.ApplyRules(rules, transaction)
+	return nil
+}
+
+func (l *LedgerServiceImpl) categoryBudgets(ctx context.Context, transaction *domain.Transaction) ([]domain.Budget, error) {
+	var category *domain.Category
+	var err error
+	if transaction.CategoryID != 0 {
+		category, err = l.categoryRepository.GetCategory(ctx, transaction.CategoryID)
+	} else {
+		category, err = l.categoryRepository.GetCategoryByName(ctx, transaction.Category)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("no budget category")
+		}
+		return nil, err
+	}
+	transaction.CategoryID = category.ID
+	transaction.Category = category.Name
+	path, err := l.categoryRepository.GetCategoryPath(ctx, category.ID)
+	if err != nil {
+		return nil, err
+	}
+	var budgets []domain.Budget
+	for _, c := range path {
+		budget, err := l.budgetRepository.GetBudget(c.Name, ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, *budget)
+	}
+	if len(budgets) == 0 {
+		return nil, errors.New("no budget category")
+	}
+	return budgets, nil
+}
+
 func (l *LedgerServiceImpl) TransactionGet(ctx context.Context, id int64) (*domain.Transaction, error) {
 	transaction, err := l.transactionRepository.GetTransaction(id, ctx)
 	if err != nil {
@@ -98,22 +396,279 @@ func (l *LedgerServiceImpl) TransactionGet(ctx context.Context, id int64) (*doma
 	return transaction, err
 }
 
-func (l *LedgerServiceImpl) TransactionsList(ctx context.Context) ([]domain.Transaction, error) {
-	transactions, err := l.transactionRepository.ListTransactions(ctx)
+func (l *LedgerServiceImpl) TransactionsList(ctx context.Context, filter domain.TransactionFilter) ([]domain.Transaction, error) {
+	transactions, err := l.transactionRepository.ListTransactions(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 	return transactions, nil
 }
 
-func (l *LedgerServiceImpl) GetReportSummary(ctx context.Context, from string, to string) (*domain.Summary, error) {
-	return l.summaryRepository.GetSummary(ctx, from, to)
+func (l *LedgerServiceImpl) TransactionSearch(ctx context.Context, search *domain.TransactionSearch) ([]domain.SearchResult, error) {
+	if search.Language == "" {
+		search.Language = l.searchLanguage
+	}
+	if search.Limit == 0 {
+		search.Limit = 50
+	}
+	err := search.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return l.transactionRepository.SearchTransactions(ctx, *search)
+}
+
+func (l *LedgerServiceImpl) GetReportSummary(ctx context.Context, from string, to string, asOf time.Time) (*domain.Summary, error) {
+	summary, err := l.summaryRepository.GetSummary(ctx, from, to, asOf)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := l.categoryRepository.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	summary.Tree = domain.BuildCategoryTree(categories, summary.Categories)
+	return summary, nil
+}
+
+func (l *LedgerServiceImpl) GetReportTagSummary(ctx context.Context, from string, to string, asOf time.Time) (*domain.Summary, error) {
+	return l.summaryRepository.GetTagSummary(ctx, from, to, asOf)
+}
+
+// budgetsAsOf returns the budgets as they stood at asOf, with what had been
+// recorded as spent in that month, subcategories included, by then.
+func (l *LedgerServiceImpl) budgetsAsOf(ctx context.Context, asOf time.Time) ([]domain.Budget, error) {
+	budgets, err := l.budgetRepository.GetBudgetsAsOf(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+	const layout = "2006-01-02"
+	day := asOf.Add(-time.Nanosecond).UTC()
+	monthStart := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	summary, err := l.summaryRepository.GetSummary(ctx, monthStart.Format(layout), day.Format(layout), asOf)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := l.categoryRepository.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	totals := domain.CategoryTotals(domain.BuildCategoryTree(categories, summary.Categories))
+	for i := range budgets {
+		budgets[i].Spent = totals[budgets[i].CategoryID]
+	}
+	return budgets, nil
+}
+
+func (l *LedgerServiceImpl) GetReportForecast(ctx context.Context, historyPeriods int) (*domain.Forecast, error) {
+	const layout = "2006-01-02"
+	now := l.now()
+	period := domain.MonthPeriod(now)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	spent, err := l.summaryRepository.GetSummary(ctx, period.Start.Format(layout), today.Format(layout), time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	scheduled := &domain.Summary{}
+	if period.DaysElapsed < period.DaysInPeriod {
+		scheduled, err = l.summaryRepository.GetSummary(ctx, today.AddDate(0, 0, 1).Format(layout), period.End.Format(layout), time.Time{})
+		if err != nil {
+			return nil, err
+		}
+	}
+	names := make(map[string]bool)
+	for _, summary := range []*domain.Summary{spent, scheduled} {
+		for name := range summary.Categories {
+			names[name] = true
+		}
+	}
+	past := make([]map[string]float64, 0, historyPeriods)
+	for i := 1; i <= historyPeriods; i++ {
+		start := period.Start.AddDate(0, -i, 0)
+		summary, err := l.summaryRepository.GetSummary(ctx, start.Format(layout), start.AddDate(0, 1, -1).Format(layout), time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		for name := range summary.Categories {
+			names[name] = true
+		}
+		past = append(past, summary.Categories)
+	}
+	forecast := &domain.Forecast{
+		PeriodStart:  period.Start.Format(layout),
+		PeriodEnd:    period.End.Format(layout),
+		DaysElapsed:  period.DaysElapsed,
+		DaysInPeriod: period.DaysInPeriod,
+	}
+	projected := make(map[string]float64, len(names))
+	for name := range names {
+		history := make([]float64, len(past))
+		for i, totals := range past {
+			history[i] = totals[name]
+		}
+		projected[name] = domain.ProjectSpend(spent.Categories[name], scheduled.Categories[name], history, period)
+		forecast.Categories = append(forecast.Categories, domain.CategoryForecast{
+			Category:          name,
+			Spent:             spent.Categories[name],
+			Scheduled:         scheduled.Categories[name],
+			HistoricalAverage: math.Round(domain.Mean(history)*100) / 100,
+			Projected:         projected[name],
+		})
+	}
+	sort.Slice(forecast.Categories, func(i, j int) bool { return forecast.Categories[i].Category < forecast.Categories[j].Category })
+	budgets, err := l.budgetRepository.GetBudgets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := l.categoryRepository.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, budget := range budgets {
+		var total float64
+		for _, c := range categories {
+			if domain.IsDescendant(categories, c.ID, budget.CategoryID) {
+				total += projected[c.Name]
+			}
+		}
+		total = math.Round(total*100) / 100
+		forecast.Budgets = append(forecast.Budgets, domain.BudgetForecast{
+			CategoryID:     budget.CategoryID,
+			Category:       budget.Category,
+			Limit:          budget.Limit,
+			Projected:      total,
+			LikelyExceeded: total > budget.Limit,
+		})
+	}
+	sort.Slice(forecast.Budgets, func(i, j int) bool { return forecast.Budgets[i].Category < forecast.Budgets[j].Category })
+	return forecast, nil
+}
+
+func (l *LedgerServiceImpl) GetReportAnomalies(ctx context.Context, from string, to string) ([]domain.Anomaly, error) {
+	return l.anomalyRepository.ListAnomalies(ctx, from, to)
+}
+
+func (l *LedgerServiceImpl) CategoryAdd(ctx context.Context, category *domain.Category) (int64, error) {
+	err := category.Validate()
+	if err != nil {
+		return 0, err
+	}
+	if category.ParentID != 0 {
+		if _, err := l.categoryRepository.GetCategory(ctx, category.ParentID); err != nil {
+			return 0, err
+		}
+	}
+	return l.categoryRepository.AddCategory(ctx, category)
+}
+
+func (l *LedgerServiceImpl) CategoryUpdate(ctx context.Context, category *domain.Category) error {
+	err := category.Validate()
+	if err != nil {
+		return err
+	}
+	if category.ParentID != 0 {
+		if _, err := l.categoryRepository.GetCategory(ctx, category.ParentID); err != nil {
+			return err
+		}
+		categories, err := l.categoryRepository.ListCategories(ctx)
+		if err != nil {
+			return err
+		}
+		if domain.IsDescendant(categories, category.ParentID, category.ID) {
+			return errors.New("category can not be moved under its own descendant")
+		}
+	}
+	return l.categoryRepository.UpdateCategory(ctx, category)
+}
+
+func (l *LedgerServiceImpl) CategoriesList(ctx context.Context) ([]domain.Category, error) {
+	return l.categoryRepository.ListCategories(ctx)
+}
+
+func (l *LedgerServiceImpl) RuleAdd(ctx context.Context, rule *domain.Rule) (int64, error) {
+	err := l.prepareRule(ctx, rule)
+	if err != nil {
+		return 0, err
+	}
+	return l.ruleRepository.AddRule(ctx, rule)
+}
+
+func (l *LedgerServiceImpl) RuleUpdate(ctx context.Context, rule *domain.Rule) error {
+	err := l.prepareRule(ctx, rule)
+	if err != nil {
+		return err
+	}
+	return l.ruleRepository.UpdateRule(ctx, rule)
+}
+
+func (l *LedgerServiceImpl) RuleDelete(ctx context.Context, id int64) error {
+	return l.ruleRepository.DeleteRule(ctx, id)
+}
+
+func (l *LedgerServiceImpl) RulesList(ctx context.Context) ([]domain.Rule, error) {
+	return l.ruleRepository.ListRules(ctx)
+}
+
+func (l *LedgerServiceImpl) RuleTest(ctx context.Context, rule *domain.Rule, last int) ([]domain.RuleMatch, error) {
+	if rule.ID != 0 {
+		stored, err := l.ruleRepository.GetRule(ctx, rule.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := stored.Compile(); err != nil {
+			return nil, err
+		}
+		rule = stored
+	} else if err := l.prepareRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	transactions, err := l.transactionRepository.ListTransactions(ctx, domain.TransactionFilter{Limit: last})
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]domain.RuleMatch, 0)
+	for _, transaction := range transactions {
+		applied := transaction
+		if !rule.Apply(&applied) {
+			continue
+		}
+		matches = append(matches, domain.RuleMatch{
+			Transaction: transaction,
+			CategoryID:  applied.CategoryID,
+			Category:    applied.Category,
+			Description: applied.Description,
+		})
+	}
+	return matches, nil
+}
+
+func (l *LedgerServiceImpl) prepareRule(ctx context.Context, rule *domain.Rule) error {
+	err := rule.Validate()
+	if err != nil {
+		return err
+	}
+	var category *domain.Category
+	if rule.CategoryID != 0 {
+		category, err = l.categoryRepository.GetCategory(ctx, rule.CategoryID)
+	} else {
+		category, err = l.categoryRepository.GetCategoryByName(ctx, rule.Category)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("category not found")
+	}
+	if err != nil {
+		return err
+	}
+	rule.CategoryID = category.ID
+	rule.Category = category.Name
+	return nil
 }
 
 type Result struct {
-	success  bool
-	errorStr string
-	index    int64
+	success     bool
+	errorStr    string
+	index       int64
+	duplicateOf int64
 }
 
 func (r *LedgerServiceImpl) worker(id int, jobs <-chan WorkerJob, results chan<- Result, ctx context.Context, wg *sync.WaitGroup) {
@@ -126,30 +681,41 @@ func (r *LedgerServiceImpl) worker(id int, jobs <-chan WorkerJob, results chan<-
 				return
 			}
 			transaction := job.transaction
-			err := transaction.Validate()
+			err := r.applyRules(ctx, &transaction)
+			if err != nil {
+				results <- Result{success: false, errorStr: err.Error(), index: job.index}
+				break
+			}
+			err = transaction.Validate()
 			if err != nil {
 				results <- Result{success: false, errorStr: "invalid transaction", index: job.index}
 				break
 			}
-			budget, err := r.budgetRepository.GetBudget(transaction.Category, ctx)
+			err = r.checkDuplicate(ctx, &transaction)
+			var duplicate *domain.DuplicateTransactionError
+			if errors.As(err, &duplicate) {
+				results <- Result{success: false, index: job.index, duplicateOf: duplicate.ID}
+				break
+			}
+			if err != nil {
+				results <- Result{success: false, errorStr: err.Error(), index: job.index}
+				break
+			}
+			err = r.checkBudgets(ctx, &transaction)
 			if err != nil {
-				if err.Error() == "sql: no rows in result set" {
-					results <- Result{success: false, errorStr: "no budget category", index: job.index}
-					break
-				}
 				results <- Result{success: false, errorStr: err.Error(), index: job.index}
 				break
 			}
-			amount, err := r.transactionRepository.GetAmountTransactionByCategory(transaction.Category, ctx)
+			err = r.detectAnomaly(ctx, &transaction)
 			if err != nil {
 				results <- Result{success: false, errorStr: err.Error(), index: job.index}
 				break
 			}
-			if amount+transaction.Amount > budget.Limit {
-				results <- Result{success: false, errorStr: "budget exceeded", index: job.index}
+			transaction.ID, err = r.transactionRepository.AddTransaction(&transaction, ctx)
+			if errors.As(err, &duplicate) {
+				results <- Result{success: false, index: job.index, duplicateOf: duplicate.ID}
 				break
 			}
-			_, err = r.transactionRepository.AddTransaction(&transaction, ctx)
 			if err != nil {
 				results <- Result{success: false, errorStr: err.Error(), index: job.index}
 				break
@@ -162,11 +728,16 @@ func (r *LedgerServiceImpl) worker(id int, jobs <-chan WorkerJob, results chan<-
 	}
 }
 
+// BulkTransactionResult keys rejected rows by their index in the request.
+// Duplicates point at the stored transaction a row repeats, BatchDuplicates
+// at the earlier row of the same request.
 type BulkTransactionResult struct {
-	Accepted int64
-	Rejected int64
-	Errors   map[int64]string
-	m        sync.Mutex
+	Accepted        int64
+	Rejected        int64
+	Errors          map[int64]string
+	Duplicates      map[int64]int64
+	BatchDuplicates map[int64]int64
+	m               sync.Mutex
 }
 
 type WorkerJob struct {
@@ -175,8 +746,28 @@ type WorkerJob struct {
 }
 
 func (r *LedgerServiceImpl) BulkAddTransactions(ctx context.Context, transactions []domain.Transaction, numWorkers int) (*BulkTransactionResult, error) {
-	jobs := make(chan WorkerJob, len(transactions))
-	results := make(chan Result, len(transactions))
+	res := BulkTransactionResult{Accepted: 0, Rejected: 0}
+	res.Errors = make(map[int64]string)
+	res.Duplicates = make(map[int64]int64)
+	res.BatchDuplicates = make(map[int64]int64)
+
+	// Workers check for duplicates concurrently, so of identical rows in a
+	// batch only the first one is handed to them.
+	pending := make([]WorkerJob, 0, len(transactions))
+	firstRows := make(map[string]int64, len(transactions))
+	for j := range transactions {
+		fingerprint := transactions[j].Fingerprint()
+		if first, ok := firstRows[fingerprint]; ok {
+			res.Rejected++
+			res.BatchDuplicates[int64(j)] = first
+			continue
+		}
+		firstRows[fingerprint] = int64(j)
+		pending = append(pending, WorkerJob{index: int64(j), transaction: transactions[j]})
+	}
+
+	jobs := make(chan WorkerJob, len(pending))
+	results := make(chan Result, len(pending))
 	var wg sync.WaitGroup
 	for w := range numWorkers {
 		wg.Add(1)
@@ -184,8 +775,8 @@ func (r *LedgerServiceImpl) BulkAddTransactions(ctx context.Context, transaction
 	}
 
 	go func() {
-		for j := 0; j < len(transactions); j++ {
-			jobs <- WorkerJob{index: int64(j), transaction: transactions[j]}
+		for _, job := range pending {
+			jobs <- job
 		}
 		close(jobs)
 	}()
@@ -194,13 +785,14 @@ func (r *LedgerServiceImpl) BulkAddTransactions(ctx context.Context, transaction
 		wg.Wait()
 		close(results)
 	}()
-	res := BulkTransactionResult{Accepted: 0, Rejected: 0}
-	res.Errors = make(map[int64]string)
 
 	for result := range results {
 		res.m.Lock()
 		if result.success {
 			res.Accepted++
+		} else if result.duplicateOf != 0 {
+			res.Rejected++
+			res.Duplicates[result.index] = result.duplicateOf
 		} else {
 			res.Rejected++
 			res.Errors[result.index] = result.errorStr
@@ -210,3 +802,185 @@ func (r *LedgerServiceImpl) BulkAddTransactions(ctx context.Context, transaction
 
 	return &res, nil
 }
+
+func (l *LedgerServiceImpl) AttachmentUpload(ctx context.Context, attachment *domain.Attachment, data io.Reader) (int64, error) {
+	_, err := l.transactionRepository.GetTransaction(attachment.TransactionID, ctx)
+	if err != nil {
+		return 0, err
+	}
+	content, err := io.ReadAll(io.LimitReader(data, l.maxAttachmentSize+1))
+	if err != nil {
+		return 0, err
+	}
+	if int64(len(content)) > l.maxAttachmentSize {
+		return 0, domain.ErrAttachmentTooLarge
+	}
+	attachment.ContentType, err = domain.DetectAttachmentType(content)
+	if err != nil {
+		return 0, err
+	}
+	attachment.Size = int64(len(content))
+	attachment.StorageKey = domain.NewAttachmentKey(attachment.TransactionID)
+	err = l.blobStorage.Put(ctx, attachment.StorageKey, bytes.NewReader(content), attachment.Size, attachment.ContentType)
+	if err != nil {
+		return 0, err
+	}
+	id, err := l.attachmentRepository.AddAttachment(ctx, attachment)
+	if err != nil {
+		if delErr := l.blobStorage.Delete(ctx, attachment.StorageKey); delErr != nil {
+			log.Printf("delete attachment blob %s: %v", attachment.StorageKey, delErr)
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+func (l *LedgerServiceImpl) AttachmentGet(ctx context.Context, transactionID int64, id int64) (*domain.Attachment, io.ReadCloser, error) {
+	attachment, err := l.transactionAttachment(ctx, transactionID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := l.blobStorage.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, data, nil
+}
+
+func (l *LedgerServiceImpl) AttachmentDelete(ctx context.Context, transactionID int64, id int64) error {
+	attachment, err := l.transactionAttachment(ctx, transactionID, id)
+	if err != nil {
+		return err
+	}
+	err = l.attachmentRepository.DeleteAttachment(ctx, id)
+	if err != nil {
+		return err
+	}
+	// The attachment is gone with its row; a blob left behind only wastes
+	// space, and failing here would turn the retry into a not found.
+	if err := l.blobStorage.Delete(ctx, attachment.StorageKey); err != nil {
+		log.Printf("delete attachment blob %s: %v", attachment.StorageKey, err)
+	}
+	return nil
+}
+
+func (l *LedgerServiceImpl) AttachmentsList(ctx context.Context, transactionID int64) ([]domain.Attachment, error) {
+	return l.attachmentRepository.ListAttachments(ctx, transactionID)
+}
+
+func (l *LedgerServiceImpl) transactionAttachment(ctx context.Context, transactionID int64, id int64) (*domain.Attachment, error) {
+	attachment, err := l.attachmentRepository.GetAttachment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.TransactionID != transactionID {
+		return nil, sql.ErrNoRows
+	}
+	return attachment, nil
+}
+
+func (l *LedgerServiceImpl) GoalAdd(ctx context.Context, goal *domain.Goal) (int64, error) {
+	err := goal.Validate()
+	if err != nil {
+		return 0, err
+	}
+	return l.goalRepository.AddGoal(ctx, goal)
+}
+
+func (l *LedgerServiceImpl) GoalUpdate(ctx context.Context, goal *domain.Goal) error {
+	err := goal.Validate()
+	if err != nil {
+		return err
+	}
+	return l.goalRepository.UpdateGoal(ctx, goal)
+}
+
+func (l *LedgerServiceImpl) GoalDelete(ctx context.Context, id int64) error {
+	goal, err := l.goalRepository.GetGoal(ctx, id)
+	if err != nil {
+		return err
+	}
+	if goal.Saved != 0 {
+		return domain.ErrGoalHasContributions
+	}
+	return l.goalRepository.DeleteGoal(ctx, id)
+}
+
+func (l *LedgerServiceImpl) GoalGet(ctx context.Context, id int64) (*domain.GoalProgress, error) {
+	goal, err := l.goalRepository.GetGoal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	progress := goal.Progress(l.now())
+	return &progress, nil
+}
+
+func (l *LedgerServiceImpl) GoalsList(ctx context.Context) ([]domain.GoalProgress, error) {
+	goals, err := l.goalRepository.ListGoals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := l.now()
+	progress := make([]domain.GoalProgress, 0, len(goals))
+	for _, goal := range goals {
+		progress = append(progress, goal.Progress(now))
+	}
+	return progress, nil
+}
+
+func (l *LedgerServiceImpl) GoalContribute(ctx context.Context, contribution *domain.GoalContribution) (int64, error) {
+	if contribution.Date == "" {
+		contribution.Date = l.now().Format("2006-01-02")
+	}
+	err := contribution.Validate()
+	if err != nil {
+		return 0, err
+	}
+	goal, err := l.goalRepository.GetGoal(ctx, contribution.GoalID)
+	if err != nil {
+		return 0, err
+	}
+	if contribution.Description == "" {
+		contribution.Description = goal.Name
+	}
+	category, err := l.categoryRepository.GetCategoryByName(ctx, domain.GoalContributionCategory)
+	if errors.Is(err, sql.ErrNoRows) {
+		category = &domain.Category{Name: domain.GoalContributionCategory}
+		category.ID, err = l.categoryRepository.AddCategory(ctx, category)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return l.goalRepository.AddContribution(ctx, contribution, category.ID)
+}
+
+func (l *LedgerServiceImpl) WebhookAdd(ctx context.Context, webhook *domain.Webhook) (int64, error) {
+	err := webhook.Validate()
+	if err != nil {
+		return 0, err
+	}
+	return l.webhookRepository.AddWebhook(ctx, webhook)
+}
+
+func (l *LedgerServiceImpl) WebhookDelete(ctx context.Context, id int64) error {
+	return l.webhookRepository.DeleteWebhook(ctx, id)
+}
+
+func (l *LedgerServiceImpl) WebhooksList(ctx context.Context) ([]domain.Webhook, error) {
+	return l.webhookRepository.ListWebhooks(ctx)
+}
+
+func (l *LedgerServiceImpl) WebhookDeliveriesList(ctx context.Context, webhookID int64, limit int) ([]domain.WebhookDelivery, error) {
+	return l.webhookRepository.ListDeliveries(ctx, webhookID, limit)
+}
+
+func (l *LedgerServiceImpl) Subscribe(ctx context.Context, fromEventID int64, types []string, send func(domain.OutboxEvent) error) error {
+	return l.eventStream.Stream(ctx, domain.ActorFromContext(ctx).Ledger(), fromEventID, types, send)
+}
+
+func (l *LedgerServiceImpl) AuditList(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return l.auditRepository.ListAudit(ctx, filter)
+}
@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"ledger/internal/domain"
 
@@ -33,17 +37,22 @@ func (m *MockBudgetRepository) GetBudgets(ctx context.Context) ([]domain.Budget,
 	return args.Get(0).([]domain.Budget), args.Error(1)
 }
 
+func (m *MockBudgetRepository) GetBudgetsAsOf(ctx context.Context, asOf time.Time) ([]domain.Budget, error) {
+	args := m.Called(ctx, asOf)
+	return args.Get(0).([]domain.Budget), args.Error(1)
+}
+
 type MockTransactionRepository struct {
 	mock.Mock
 }
 
-func (m *MockTransactionRepository) GetAmountTransactionByCategory(category string, ctx context.Context) (float64, error) {
-	args := m.Called(category, ctx)
+func (m *MockTransactionRepository) GetAmountTransactionByCategory(categoryID int64, ctx context.Context) (float64, error) {
+	args := m.Called(categoryID, ctx)
 	return args.Get(0).(float64), args.Error(1)
 }
 
-func (m *MockTransactionRepository) GetAmountTransactionByCategoryAndMonth(ctx context.Context, category string, date string) (float64, error) {
-	args := m.Called(ctx, category, date)
+func (m *MockTransactionRepository) GetAmountTransactionByCategoryAndMonth(ctx context.Context, categoryID int64, date string) (float64, error) {
+	args := m.Called(ctx, categoryID, date)
 	return args.Get(0).(float64), args.Error(1)
 }
 
@@ -60,29 +69,298 @@ func (m *MockTransactionRepository) GetTransaction(id int64, ctx context.Context
 	return args.Get(0).(*domain.Transaction), args.Error(1)
 }
 
-func (m *MockTransactionRepository) ListTransactions(ctx context.Context) ([]domain.Transaction, error) {
-	args := m.Called(ctx)
+func (m *MockTransactionRepository) FindDuplicateCandidates(ctx context.Context, transaction *domain.Transaction, windowDays int) ([]domain.Transaction, error) {
+	args := m.Called(ctx, transaction, windowDays)
+	return args.Get(0).([]domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListTransactions(ctx context.Context, filter domain.TransactionFilter) ([]domain.Transaction, error) {
+	args := m.Called(ctx, filter)
 	return args.Get(0).([]domain.Transaction), args.Error(1)
 }
 
+func (m *MockTransactionRepository) SearchTransactions(ctx context.Context, search domain.TransactionSearch) ([]domain.SearchResult, error) {
+	args := m.Called(ctx, search)
+	return args.Get(0).([]domain.SearchResult), args.Error(1)
+}
+
 type MockSummaryRepository struct {
 	mock.Mock
 }
 
-func (m *MockSummaryRepository) GetSummary(ctx context.Context, from string, to string) (*domain.Summary, error) {
-	args := m.Called(ctx, from, to)
+func (m *MockSummaryRepository) GetSummary(ctx context.Context, from string, to string, asOf time.Time) (*domain.Summary, error) {
+	args := m.Called(ctx, from, to, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Summary), args.Error(1)
+}
+
+func (m *MockSummaryRepository) GetTagSummary(ctx context.Context, from string, to string, asOf time.Time) (*domain.Summary, error) {
+	args := m.Called(ctx, from, to, asOf)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Summary), args.Error(1)
 }
 
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) AddCategory(ctx context.Context, category *domain.Category) (int64, error) {
+	args := m.Called(ctx, category)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCategoryRepository) UpdateCategory(ctx context.Context, category *domain.Category) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) GetCategory(ctx context.Context, id int64) (*domain.Category, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) GetCategoryByName(ctx context.Context, name string) (*domain.Category, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) GetCategoryPath(ctx context.Context, id int64) ([]domain.Category, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]domain.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Category), args.Error(1)
+}
+
+type MockRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRuleRepository) AddRule(ctx context.Context, rule *domain.Rule) (int64, error) {
+	args := m.Called(ctx, rule)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRuleRepository) UpdateRule(ctx context.Context, rule *domain.Rule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockRuleRepository) DeleteRule(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRuleRepository) GetRule(ctx context.Context, id int64) (*domain.Rule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Rule), args.Error(1)
+}
+
+func (m *MockRuleRepository) ListRules(ctx context.Context) ([]domain.Rule, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Rule), args.Error(1)
+}
+
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyRepository) GetIdempotencyRecord(ctx context.Context, key string) (*domain.IdempotencyRecord, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.IdempotencyRecord), args.Error(1)
+}
+
+func (m *MockIdempotencyRepository) DeleteExpiredIdempotencyRecords(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) AddAttachment(ctx context.Context, attachment *domain.Attachment) (int64, error) {
+	args := m.Called(ctx, attachment)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) GetAttachment(ctx context.Context, id int64) (*domain.Attachment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) ListAttachments(ctx context.Context, transactionID int64) ([]domain.Attachment, error) {
+	args := m.Called(ctx, transactionID)
+	return args.Get(0).([]domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) DeleteAttachment(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockBlobStorage struct {
+	mock.Mock
+}
+
+func (m *MockBlobStorage) Put(ctx context.Context, key string, data io.Reader, size int64, contentType string) error {
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	args := m.Called(ctx, key, content, size, contentType)
+	return args.Error(0)
+}
+
+func (m *MockBlobStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockBlobStorage) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+type MockGoalRepository struct {
+	mock.Mock
+}
+
+func (m *MockGoalRepository) AddGoal(ctx context.Context, goal *domain.Goal) (int64, error) {
+	args := m.Called(ctx, goal)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockGoalRepository) UpdateGoal(ctx context.Context, goal *domain.Goal) error {
+	args := m.Called(ctx, goal)
+	return args.Error(0)
+}
+
+func (m *MockGoalRepository) DeleteGoal(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockGoalRepository) GetGoal(ctx context.Context, id int64) (*domain.Goal, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Goal), args.Error(1)
+}
+
+func (m *MockGoalRepository) ListGoals(ctx context.Context) ([]domain.Goal, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Goal), args.Error(1)
+}
+
+func (m *MockGoalRepository) AddContribution(ctx context.Context, contribution *domain.GoalContribution, categoryID int64) (int64, error) {
+	args := m.Called(ctx, contribution, categoryID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockAnomalyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAnomalyRepository) GetCategoryAmounts(ctx context.Context, categoryID int64, limit int) ([]float64, error) {
+	args := m.Called(ctx, categoryID, limit)
+	return args.Get(0).([]float64), args.Error(1)
+}
+
+func (m *MockAnomalyRepository) GetAnomaly(ctx context.Context, transactionID int64) (*domain.AnomalyScore, error) {
+	args := m.Called(ctx, transactionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AnomalyScore), args.Error(1)
+}
+
+func (m *MockAnomalyRepository) ListAnomalies(ctx context.Context, from string, to string) ([]domain.Anomaly, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).([]domain.Anomaly), args.Error(1)
+}
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) AddWebhook(ctx context.Context, webhook *domain.Webhook) (int64, error) {
+	args := m.Called(ctx, webhook)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWebhookRepository) DeleteWebhook(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) EnqueueEvent(ctx context.Context, event domain.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, limit, lease)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID, limit)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) ListAudit(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]domain.AuditEntry), args.Error(1)
+}
+
 func TestLedgerServiceImpl_BudgetAdd(t *testing.T) {
 	mockBudgetRepo := &MockBudgetRepository{}
 	mockTransactionRepo := &MockTransactionRepository{}
 	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
 
-	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo)
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -97,6 +375,35 @@ func TestLedgerServiceImpl_BudgetAdd(t *testing.T) {
 				Category: "Food",
 				Limit:    1000.0,
 			},
+			mockSetup: func() {
+				mockCategoryRepo.On("GetCategoryByName", ctx, "Food").Return(&domain.Category{ID: 1, Name: "Food"}, nil)
+				mockBudgetRepo.On("SetBudget", mock.MatchedBy(func(b *domain.Budget) bool {
+					return b.CategoryID == 1
+				}), ctx).Return(nil)
+			},
+			expectedErr: false,
+		},
+		{
+			name: "new category is created",
+			budget: &domain.Budget{
+				Category: "Travel",
+				Limit:    500.0,
+			},
+			mockSetup: func() {
+				mockCategoryRepo.On("GetCategoryByName", ctx, "Travel").Return(nil, sql.ErrNoRows)
+				mockCategoryRepo.On("AddCategory", ctx, &domain.Category{Name: "Travel"}).Return(int64(5), nil)
+				mockBudgetRepo.On("SetBudget", mock.MatchedBy(func(b *domain.Budget) bool {
+					return b.CategoryID == 5
+				}), ctx).Return(nil)
+			},
+			expectedErr: false,
+		},
+		{
+			name: "budget by category id",
+			budget: &domain.Budget{
+				CategoryID: 2,
+				Limit:      300.0,
+			},
 			mockSetup: func() {
 				mockBudgetRepo.On("SetBudget", mock.AnythingOfType("*domain.Budget"), ctx).Return(nil)
 			},
@@ -118,6 +425,7 @@ func TestLedgerServiceImpl_BudgetAdd(t *testing.T) {
 				Limit:    1000.0,
 			},
 			mockSetup: func() {
+				mockCategoryRepo.On("GetCategoryByName", ctx, "Food").Return(&domain.Category{ID: 1, Name: "Food"}, nil)
 				mockBudgetRepo.On("SetBudget", mock.AnythingOfType("*domain.Budget"), ctx).Return(errors.New("db error"))
 			},
 			expectedErr: true,
@@ -127,6 +435,7 @@ func TestLedgerServiceImpl_BudgetAdd(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockBudgetRepo.ExpectedCalls = nil
+			mockCategoryRepo.ExpectedCalls = nil
 			tt.mockSetup()
 
 			err := service.BudgetAdd(ctx, tt.budget)
@@ -137,6 +446,7 @@ func TestLedgerServiceImpl_BudgetAdd(t *testing.T) {
 				assert.NoError(t, err)
 			}
 			mockBudgetRepo.AssertExpectations(t)
+			mockCategoryRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -145,8 +455,10 @@ func TestLedgerServiceImpl_BudgetGet(t *testing.T) {
 	mockBudgetRepo := &MockBudgetRepository{}
 	mockTransactionRepo := &MockTransactionRepository{}
 	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
 
-	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo)
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -185,7 +497,7 @@ func TestLedgerServiceImpl_BudgetGet(t *testing.T) {
 			mockBudgetRepo.ExpectedCalls = nil
 			tt.mockSetup()
 
-			result, err := service.BudgetGet(ctx, tt.category)
+			result, err := service.BudgetGet(ctx, tt.category, time.Time{})
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -202,8 +514,10 @@ func TestLedgerServiceImpl_BudgetsList(t *testing.T) {
 	mockBudgetRepo := &MockBudgetRepository{}
 	mockTransactionRepo := &MockTransactionRepository{}
 	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
 
-	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo)
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -242,7 +556,7 @@ func TestLedgerServiceImpl_BudgetsList(t *testing.T) {
 			mockBudgetRepo.ExpectedCalls = nil
 			tt.mockSetup()
 
-			result, err := service.BudgetsList(ctx)
+			result, err := service.BudgetsList(ctx, time.Time{})
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -255,17 +569,60 @@ func TestLedgerServiceImpl_BudgetsList(t *testing.T) {
 	}
 }
 
+func TestLedgerServiceImpl_BudgetsListAsOf(t *testing.T) {
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+
+	service := NewLedgerService(mockBudgetRepo, nil, mockSummaryRepo, mockCategoryRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+	asOf := time.Date(2025, 12, 16, 0, 0, 0, 0, time.UTC)
+
+	mockBudgetRepo.On("GetBudgetsAsOf", ctx, asOf).Return([]domain.Budget{
+		{CategoryID: 1, Category: "Food", Limit: 800.0},
+		{CategoryID: 2, Category: "Transport", Limit: 500.0},
+	}, nil)
+	mockSummaryRepo.On("GetSummary", ctx, "2025-12-01", "2025-12-15", asOf).Return(&domain.Summary{
+		Categories: map[string]float64{"Food": 100.0, "Groceries": 50.0, "Transport": 30.0},
+	}, nil)
+	mockCategoryRepo.On("ListCategories", ctx).Return([]domain.Category{
+		{ID: 1, Name: "Food"},
+		{ID: 2, Name: "Transport"},
+		{ID: 3, Name: "Groceries", ParentID: 1},
+	}, nil)
+
+	result, err := service.BudgetsList(ctx, asOf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]domain.Budget{
+		"Food":      {CategoryID: 1, Category: "Food", Limit: 800.0, Spent: 150.0},
+		"Transport": {CategoryID: 2, Category: "Transport", Limit: 500.0, Spent: 30.0},
+	}, result)
+
+	budget, err := service.BudgetGet(ctx, "Food", asOf)
+	assert.NoError(t, err)
+	assert.Equal(t, 150.0, budget.Spent)
+
+	_, err = service.BudgetGet(ctx, "Rent", asOf)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
 func TestLedgerServiceImpl_TransactionAdd(t *testing.T) {
 	mockBudgetRepo := &MockBudgetRepository{}
 	mockTransactionRepo := &MockTransactionRepository{}
 	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
 
-	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo)
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	tests := []struct {
 		name        string
 		transaction *domain.Transaction
+		force       bool
+		rules       []domain.Rule
+		candidates  []domain.Transaction
 		mockSetup   func()
 		expected    int64
 		expectedErr bool
@@ -279,74 +636,253 @@ func TestLedgerServiceImpl_TransactionAdd(t *testing.T) {
 				Date:        "2025-12-01",
 			},
 			mockSetup: func() {
-				budget := &domain.Budget{Category: "Food", Limit: 1000.0}
+				food := &domain.Category{ID: 1, Name: "Food"}
+				mockCategoryRepo.On("GetCategoryByName", ctx, "Food").Return(food, nil)
+				mockCategoryRepo.On("GetCategoryPath", ctx, int64(1)).Return([]domain.Category{*food}, nil)
+				budget := &domain.Budget{CategoryID: 1, Category: "Food", Limit: 1000.0}
 				mockBudgetRepo.On("GetBudget", "Food", ctx).Return(budget, nil)
-				mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, "Food", "2025-12-01").Return(200.0, nil)
-				mockTransactionRepo.On("AddTransaction", mock.AnythingOfType("*domain.Transaction"), ctx).Return(int64(1), nil)
+				mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, int64(1), "2025-12-01").Return(200.0, nil)
+				mockTransactionRepo.On("AddTransaction", mock.MatchedBy(func(tr *domain.Transaction) bool {
+					return tr.CategoryID == 1
+				}), ctx).Return(int64(1), nil)
 			},
 			expected:    1,
 			expectedErr: false,
 		},
 		{
-			name: "invalid transaction",
+			name: "fuzzy duplicate rejected",
 			transaction: &domain.Transaction{
-				Amount:      -100.0,
+				Amount:      42.0,
+				Category:    "Food",
+				Description: "CARD 1234 COFFEE HOUSE",
+				Date:        "2025-12-05",
+			},
+			candidates: []domain.Transaction{
+				{ID: 8, Amount: 42.0, Description: "Pharmacy", Date: "2025-12-05"},
+				{ID: 9, Amount: 42.0, Description: "Coffee House", Date: "2025-12-04"},
+			},
+			mockSetup:   func() {},
+			expected:    0,
+			expectedErr: true,
+		},
+		{
+			name: "external id duplicate rejected",
+			transaction: &domain.Transaction{
+				Amount:      42.0,
 				Category:    "Food",
 				Description: "Lunch",
-				Date:        "2025-12-01",
+				Date:        "2025-12-05",
+				ExternalID:  "bank-1",
+			},
+			candidates: []domain.Transaction{
+				{ID: 3, ExternalID: "bank-1", Amount: 40.0, Description: "Dinner", Date: "2025-10-01"},
 			},
 			mockSetup:   func() {},
 			expected:    0,
 			expectedErr: true,
 		},
 		{
-			name: "budget exceeded",
+			name: "forced duplicate accepted",
 			transaction: &domain.Transaction{
-				Amount:      900.0,
+				Amount:      100.0,
 				Category:    "Food",
 				Description: "Lunch",
 				Date:        "2025-12-01",
 			},
+			force: true,
 			mockSetup: func() {
-				budget := &domain.Budget{Category: "Food", Limit: 1000.0}
-				mockBudgetRepo.On("GetBudget", "Food", ctx).Return(budget, nil)
-				mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, "Food", "2025-12-01").Return(200.0, nil)
+				food := &domain.Category{ID: 1, Name: "Food"}
+				mockCategoryRepo.On("GetCategoryByName", ctx, "Food").Return(food, nil)
+				mockCategoryRepo.On("GetCategoryPath", ctx, int64(1)).Return([]domain.Category{*food}, nil)
+				mockBudgetRepo.On("GetBudget", "Food", ctx).Return(&domain.Budget{CategoryID: 1, Category: "Food", Limit: 1000.0}, nil)
+				mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, int64(1), "2025-12-01").Return(200.0, nil)
+				mockTransactionRepo.On("AddTransaction", mock.Anything, ctx).Return(int64(11), nil)
+			},
+			expected:    11,
+			expectedErr: false,
+		},
+		{
+			name: "rule assigns category and cleans description",
+			transaction: &domain.Transaction{
+				Amount:      12.5,
+				Description: "CARD PAYMENT *UBER TRIP 1234",
+				Date:        "2025-12-01",
+			},
+			rules: []domain.Rule{
+				{ID: 1, Priority: 1, DescriptionContains: "netflix", CategoryID: 5, Category: "Subscriptions"},
+				{ID: 2, Priority: 2, DescriptionRegex: `\*UBER (\w+)`, CategoryID: 3, Category: "Taxi", Description: "Uber $1"},
+			},
+			mockSetup: func() {
+				taxi := &domain.Category{ID: 3, Name: "Taxi"}
+				mockCategoryRepo.On("GetCategory", ctx, int64(3)).Return(taxi, nil)
+				mockCategoryRepo.On("GetCategoryPath", ctx, int64(3)).Return([]domain.Category{*taxi}, nil)
+				mockBudgetRepo.On("GetBudget", "Taxi", ctx).Return(&domain.Budget{CategoryID: 3, Category: "Taxi", Limit: 100.0}, nil)
+				mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, int64(3), "2025-12-01").Return(20.0, nil)
+				mockTransactionRepo.On("AddTransaction", mock.MatchedBy(func(tr *domain.Transaction) bool {
+					return tr.CategoryID == 3 && tr.Description == "Uber TRIP"
+				}), ctx).Return(int64(7), nil)
+			},
+			expected:    7,
+			expectedErr: false,
+		},
+		{
+			name: "no rule matches uncategorised transaction",
+			transaction: &domain.Transaction{
+				Amount:      12.5,
+				Description: "UNKNOWN MERCHANT",
+				Date:        "2025-12-01",
+			},
+			rules: []domain.Rule{
+				{ID: 1, Priority: 1, DescriptionContains: "netflix", CategoryID: 5, Category: "Subscriptions"},
 			},
+			mockSetup:   func() {},
 			expected:    0,
 			expectedErr: true,
 		},
 		{
-			name: "budget not found",
+			name: "parent budget covers child category",
 			transaction: &domain.Transaction{
 				Amount:      100.0,
-				Category:    "NonExistent",
-				Description: "Lunch",
+				CategoryID:  2,
+				Description: "Supermarket",
 				Date:        "2025-12-01",
 			},
 			mockSetup: func() {
-				mockBudgetRepo.On("GetBudget", "NonExistent", ctx).Return(nil, errors.New("not found"))
+				groceries := &domain.Category{ID: 2, Name: "Groceries", ParentID: 1}
+				mockCategoryRepo.On("GetCategory", ctx, int64(2)).Return(groceries, nil)
+				mockCategoryRepo.On("GetCategoryPath", ctx, int64(2)).Return([]domain.Category{*groceries, {ID: 1, Name: "Food"}}, nil)
+				mockBudgetRepo.On("GetBudget", "Groceries", ctx).Return(nil, sql.ErrNoRows)
+				mockBudgetRepo.On("GetBudget", "Food", ctx).Return(&domain.Budget{CategoryID: 1, Category: "Food", Limit: 1000.0}, nil)
+				mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, int64(1), "2025-12-01").Return(500.0, nil)
+				mockTransactionRepo.On("AddTransaction", mock.MatchedBy(func(tr *domain.Transaction) bool {
+					return tr.CategoryID == 2 && tr.Category == "Groceries"
+				}), ctx).Return(int64(3), nil)
+			},
+			expected:    3,
+			expectedErr: false,
+		},
+		{
+			name: "parent budget exceeded by child spending",
+			transaction: &domain.Transaction{
+				Amount:      100.0,
+				CategoryID:  2,
+				Description: "Supermarket",
+				Date:        "2025-12-01",
+			},
+			mockSetup: func() {
+				groceries := &domain.Category{ID: 2, Name: "Groceries", ParentID: 1}
+				mockCategoryRepo.On("GetCategory", ctx, int64(2)).Return(groceries, nil)
+				mockCategoryRepo.On("GetCategoryPath", ctx, int64(2)).Return([]domain.Category{*groceries, {ID: 1, Name: "Food"}}, nil)
+				mockBudgetRepo.On("GetBudget", "Groceries", ctx).Return(&domain.Budget{CategoryID: 2, Category: "Groceries", Limit: 500.0}, nil)
+				mockBudgetRepo.On("GetBudget", "Food", ctx).Return(&domain.Budget{CategoryID: 1, Category: "Food", Limit: 1000.0}, nil)
+				mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, int64(2), "2025-12-01").Return(100.0, nil)
+				mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, int64(1), "2025-12-01").Return(950.0, nil)
 			},
 			expected:    0,
 			expectedErr: true,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockBudgetRepo.ExpectedCalls = nil
-			mockTransactionRepo.ExpectedCalls = nil
-			tt.mockSetup()
-
-			result, err := service.TransactionAdd(ctx, tt.transaction)
-
-			if tt.expectedErr {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.expected, result)
-			}
-			mockBudgetRepo.AssertExpectations(t)
+		{
+			name: "invalid transaction",
+			transaction: &domain.Transaction{
+				Amount:      -100.0,
+				Category:    "Food",
+				Description: "Lunch",
+				Date:        "2025-12-01",
+			},
+			mockSetup:   func() {},
+			expected:    0,
+			expectedErr: true,
+		},
+		{
+			name: "blank tag",
+			transaction: &domain.Transaction{
+				Amount:      100.0,
+				Category:    "Food",
+				Description: "Lunch",
+				Date:        "2025-12-01",
+				Tags:        []string{"vacation-2025", " "},
+			},
+			mockSetup:   func() {},
+			expected:    0,
+			expectedErr: true,
+		},
+		{
+			name: "budget exceeded",
+			transaction: &domain.Transaction{
+				Amount:      900.0,
+				Category:    "Food",
+				Description: "Lunch",
+				Date:        "2025-12-01",
+			},
+			mockSetup: func() {
+				food := &domain.Category{ID: 1, Name: "Food"}
+				mockCategoryRepo.On("GetCategoryByName", ctx, "Food").Return(food, nil)
+				mockCategoryRepo.On("GetCategoryPath", ctx, int64(1)).Return([]domain.Category{*food}, nil)
+				budget := &domain.Budget{CategoryID: 1, Category: "Food", Limit: 1000.0}
+				mockBudgetRepo.On("GetBudget", "Food", ctx).Return(budget, nil)
+				mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, int64(1), "2025-12-01").Return(200.0, nil)
+			},
+			expected:    0,
+			expectedErr: true,
+		},
+		{
+			name: "budget not found",
+			transaction: &domain.Transaction{
+				Amount:      100.0,
+				Category:    "NonExistent",
+				Description: "Lunch",
+				Date:        "2025-12-01",
+			},
+			mockSetup: func() {
+				mockCategoryRepo.On("GetCategoryByName", ctx, "NonExistent").Return(nil, sql.ErrNoRows)
+			},
+			expected:    0,
+			expectedErr: true,
+		},
+		{
+			name: "no budget on category or its parents",
+			transaction: &domain.Transaction{
+				Amount:      100.0,
+				Category:    "Groceries",
+				Description: "Lunch",
+				Date:        "2025-12-01",
+			},
+			mockSetup: func() {
+				groceries := &domain.Category{ID: 2, Name: "Groceries", ParentID: 1}
+				mockCategoryRepo.On("GetCategoryByName", ctx, "Groceries").Return(groceries, nil)
+				mockCategoryRepo.On("GetCategoryPath", ctx, int64(2)).Return([]domain.Category{*groceries, {ID: 1, Name: "Food"}}, nil)
+				mockBudgetRepo.On("GetBudget", "Groceries", ctx).Return(nil, sql.ErrNoRows)
+				mockBudgetRepo.On("GetBudget", "Food", ctx).Return(nil, sql.ErrNoRows)
+			},
+			expected:    0,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBudgetRepo.ExpectedCalls = nil
+			mockTransactionRepo.ExpectedCalls = nil
+			mockCategoryRepo.ExpectedCalls = nil
+			mockRuleRepo.ExpectedCalls = nil
+			mockRuleRepo.On("ListRules", ctx).Return(tt.rules, nil)
+			if !tt.force {
+				mockTransactionRepo.On("FindDuplicateCandidates", ctx, mock.Anything, 3).Return(tt.candidates, nil).Maybe()
+			}
+			tt.mockSetup()
+
+			result, err := service.TransactionAdd(ctx, tt.transaction, tt.force)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			mockBudgetRepo.AssertExpectations(t)
 			mockTransactionRepo.AssertExpectations(t)
+			mockCategoryRepo.AssertExpectations(t)
+			mockRuleRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -355,8 +891,10 @@ func TestLedgerServiceImpl_TransactionGet(t *testing.T) {
 	mockBudgetRepo := &MockBudgetRepository{}
 	mockTransactionRepo := &MockTransactionRepository{}
 	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
 
-	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo)
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -421,8 +959,10 @@ func TestLedgerServiceImpl_TransactionsList(t *testing.T) {
 	mockBudgetRepo := &MockBudgetRepository{}
 	mockTransactionRepo := &MockTransactionRepository{}
 	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
 
-	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo)
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -450,7 +990,7 @@ func TestLedgerServiceImpl_TransactionsList(t *testing.T) {
 						Date:        "2025-12-02",
 					},
 				}
-				mockTransactionRepo.On("ListTransactions", ctx).Return(transactions, nil)
+				mockTransactionRepo.On("ListTransactions", ctx, domain.TransactionFilter{}).Return(transactions, nil)
 			},
 			expected: []domain.Transaction{
 				{
@@ -473,7 +1013,7 @@ func TestLedgerServiceImpl_TransactionsList(t *testing.T) {
 		{
 			name: "repository error",
 			mockSetup: func() {
-				mockTransactionRepo.On("ListTransactions", ctx).Return([]domain.Transaction{}, errors.New("db error"))
+				mockTransactionRepo.On("ListTransactions", ctx, domain.TransactionFilter{}).Return([]domain.Transaction{}, errors.New("db error"))
 			},
 			expected:    nil,
 			expectedErr: true,
@@ -485,7 +1025,7 @@ func TestLedgerServiceImpl_TransactionsList(t *testing.T) {
 			mockTransactionRepo.ExpectedCalls = nil
 			tt.mockSetup()
 
-			result, err := service.TransactionsList(ctx)
+			result, err := service.TransactionsList(ctx, domain.TransactionFilter{})
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -498,12 +1038,86 @@ func TestLedgerServiceImpl_TransactionsList(t *testing.T) {
 	}
 }
 
+func TestLedgerServiceImpl_TransactionSearch(t *testing.T) {
+	mockTransactionRepo := &MockTransactionRepository{}
+
+	service := NewLedgerService(nil, mockTransactionRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	service.SetSearchLanguage("russian")
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		search      *domain.TransactionSearch
+		mockSetup   func()
+		expected    []domain.SearchResult
+		expectedErr bool
+	}{
+		{
+			name:   "defaults applied",
+			search: &domain.TransactionSearch{Query: "аптека"},
+			mockSetup: func() {
+				mockTransactionRepo.On("SearchTransactions", ctx, domain.TransactionSearch{Query: "аптека", Language: "russian", Limit: 50}).
+					Return([]domain.SearchResult{{Transaction: domain.Transaction{ID: 4, Description: "Аптека"}, Rank: 0.6}}, nil)
+			},
+			expected: []domain.SearchResult{{Transaction: domain.Transaction{ID: 4, Description: "Аптека"}, Rank: 0.6}},
+		},
+		{
+			name:        "punctuation only",
+			search:      &domain.TransactionSearch{Query: "!!!"},
+			mockSetup:   func() {},
+			expectedErr: true,
+		},
+		{
+			name:        "unsupported language",
+			search:      &domain.TransactionSearch{Query: "pharmacy", Language: "klingon"},
+			mockSetup:   func() {},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid date",
+			search:      &domain.TransactionSearch{Query: "pharmacy", From: "March"},
+			mockSetup:   func() {},
+			expectedErr: true,
+		},
+		{
+			name:        "limit too large",
+			search:      &domain.TransactionSearch{Query: "pharmacy", Limit: 501},
+			mockSetup:   func() {},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransactionRepo.ExpectedCalls = nil
+			tt.mockSetup()
+
+			result, err := service.TransactionSearch(ctx, tt.search)
+
+			if tt.expectedErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidSearch)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			mockTransactionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionSearchTSQuery(t *testing.T) {
+	search := domain.TransactionSearch{Query: "Pharm, March's  receipt #7"}
+	assert.Equal(t, "pharm:* & march:* & receipt:* & 7:*", search.TSQuery())
+}
+
 func TestLedgerServiceImpl_GetReportSummary(t *testing.T) {
 	mockBudgetRepo := &MockBudgetRepository{}
 	mockTransactionRepo := &MockTransactionRepository{}
 	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
 
-	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo)
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, nil, nil, nil)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -523,11 +1137,47 @@ func TestLedgerServiceImpl_GetReportSummary(t *testing.T) {
 					Categories:  map[string]float64{"Food": 500.0, "Transport": 200.0},
 					CacheResult: false,
 				}
-				mockSummaryRepo.On("GetSummary", ctx, "2025-12-01", "2025-12-31").Return(summary, nil)
+				mockSummaryRepo.On("GetSummary", ctx, "2025-12-01", "2025-12-31", time.Time{}).Return(summary, nil)
+				mockCategoryRepo.On("ListCategories", ctx).Return([]domain.Category{
+					{ID: 1, Name: "Food"},
+					{ID: 2, Name: "Transport"},
+				}, nil)
 			},
 			expected: &domain.Summary{
 				Categories:  map[string]float64{"Food": 500.0, "Transport": 200.0},
 				CacheResult: false,
+				Tree: []domain.CategoryNode{
+					{ID: 1, Name: "Food", Amount: 500.0, Total: 500.0},
+					{ID: 2, Name: "Transport", Amount: 200.0, Total: 200.0},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "nested categories roll up",
+			from: "2025-12-01",
+			to:   "2025-12-31",
+			mockSetup: func() {
+				summary := &domain.Summary{
+					Categories: map[string]float64{"Food": 50.0, "Groceries": 300.0, "Restaurants": 200.0},
+				}
+				mockSummaryRepo.On("GetSummary", ctx, "2025-12-01", "2025-12-31", time.Time{}).Return(summary, nil)
+				mockCategoryRepo.On("ListCategories", ctx).Return([]domain.Category{
+					{ID: 1, Name: "Food"},
+					{ID: 2, Name: "Groceries", ParentID: 1},
+					{ID: 3, Name: "Restaurants", ParentID: 1},
+					{ID: 4, Name: "Transport"},
+					{ID: 5, Name: "Bakery", ParentID: 2},
+				}, nil)
+			},
+			expected: &domain.Summary{
+				Categories: map[string]float64{"Food": 50.0, "Groceries": 300.0, "Restaurants": 200.0},
+				Tree: []domain.CategoryNode{
+					{ID: 1, Name: "Food", Amount: 50.0, Total: 550.0, Children: []domain.CategoryNode{
+						{ID: 2, Name: "Groceries", Amount: 300.0, Total: 300.0},
+						{ID: 3, Name: "Restaurants", Amount: 200.0, Total: 200.0},
+					}},
+				},
 			},
 			expectedErr: false,
 		},
@@ -536,7 +1186,7 @@ func TestLedgerServiceImpl_GetReportSummary(t *testing.T) {
 			from: "2025-12-01",
 			to:   "2025-12-31",
 			mockSetup: func() {
-				mockSummaryRepo.On("GetSummary", ctx, "2025-12-01", "2025-12-31").Return(nil, errors.New("db error"))
+				mockSummaryRepo.On("GetSummary", ctx, "2025-12-01", "2025-12-31", time.Time{}).Return(nil, errors.New("db error"))
 			},
 			expected:    nil,
 			expectedErr: true,
@@ -546,9 +1196,10 @@ func TestLedgerServiceImpl_GetReportSummary(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockSummaryRepo.ExpectedCalls = nil
+			mockCategoryRepo.ExpectedCalls = nil
 			tt.mockSetup()
 
-			result, err := service.GetReportSummary(ctx, tt.from, tt.to)
+			result, err := service.GetReportSummary(ctx, tt.from, tt.to, time.Time{})
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -560,3 +1211,1067 @@ func TestLedgerServiceImpl_GetReportSummary(t *testing.T) {
 		})
 	}
 }
+
+func TestLedgerServiceImpl_GetReportTagSummary(t *testing.T) {
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockTransactionRepo := &MockTransactionRepository{}
+	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
+
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	summary := &domain.Summary{
+		Tags:        map[string]float64{"vacation-2025": 300.0, "reimbursable": 50.0},
+		CacheResult: false,
+	}
+	mockSummaryRepo.On("GetTagSummary", ctx, "2025-12-01", "2025-12-31", time.Time{}).Return(summary, nil)
+
+	result, err := service.GetReportTagSummary(ctx, "2025-12-01", "2025-12-31", time.Time{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, summary, result)
+	mockSummaryRepo.AssertExpectations(t)
+}
+
+func TestLedgerServiceImpl_CategoryAdd(t *testing.T) {
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockTransactionRepo := &MockTransactionRepository{}
+	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
+
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		category    *domain.Category
+		mockSetup   func()
+		expected    int64
+		expectedErr bool
+	}{
+		{
+			name:     "successful root category add",
+			category: &domain.Category{Name: "Food"},
+			mockSetup: func() {
+				mockCategoryRepo.On("AddCategory", ctx, &domain.Category{Name: "Food"}).Return(int64(1), nil)
+			},
+			expected:    1,
+			expectedErr: false,
+		},
+		{
+			name:     "successful child category add",
+			category: &domain.Category{Name: "Groceries", ParentID: 1},
+			mockSetup: func() {
+				mockCategoryRepo.On("GetCategory", ctx, int64(1)).Return(&domain.Category{ID: 1, Name: "Food"}, nil)
+				mockCategoryRepo.On("AddCategory", ctx, &domain.Category{Name: "Groceries", ParentID: 1}).Return(int64(2), nil)
+			},
+			expected:    2,
+			expectedErr: false,
+		},
+		{
+			name:     "unknown parent",
+			category: &domain.Category{Name: "Groceries", ParentID: 99},
+			mockSetup: func() {
+				mockCategoryRepo.On("GetCategory", ctx, int64(99)).Return(nil, sql.ErrNoRows)
+			},
+			expected:    0,
+			expectedErr: true,
+		},
+		{
+			name:        "empty name",
+			category:    &domain.Category{},
+			mockSetup:   func() {},
+			expected:    0,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCategoryRepo.ExpectedCalls = nil
+			tt.mockSetup()
+
+			result, err := service.CategoryAdd(ctx, tt.category)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			mockCategoryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerServiceImpl_CategoryUpdate(t *testing.T) {
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockTransactionRepo := &MockTransactionRepository{}
+	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
+
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	categories := []domain.Category{
+		{ID: 1, Name: "Food"},
+		{ID: 2, Name: "Groceries", ParentID: 1},
+		{ID: 3, Name: "Bakery", ParentID: 2},
+	}
+
+	tests := []struct {
+		name        string
+		category    *domain.Category
+		mockSetup   func()
+		expectedErr bool
+	}{
+		{
+			name:     "successful rename",
+			category: &domain.Category{ID: 2, Name: "Supermarket", ParentID: 1},
+			mockSetup: func() {
+				mockCategoryRepo.On("GetCategory", ctx, int64(1)).Return(&categories[0], nil)
+				mockCategoryRepo.On("ListCategories", ctx).Return(categories, nil)
+				mockCategoryRepo.On("UpdateCategory", ctx, &domain.Category{ID: 2, Name: "Supermarket", ParentID: 1}).Return(nil)
+			},
+			expectedErr: false,
+		},
+		{
+			name:     "parent of another space",
+			category: &domain.Category{ID: 2, Name: "Groceries", ParentID: 9},
+			mockSetup: func() {
+				mockCategoryRepo.On("GetCategory", ctx, int64(9)).Return(nil, sql.ErrNoRows)
+			},
+			expectedErr: true,
+		},
+		{
+			name:     "move to root",
+			category: &domain.Category{ID: 3, Name: "Bakery"},
+			mockSetup: func() {
+				mockCategoryRepo.On("UpdateCategory", ctx, &domain.Category{ID: 3, Name: "Bakery"}).Return(nil)
+			},
+			expectedErr: false,
+		},
+		{
+			name:     "move under own descendant",
+			category: &domain.Category{ID: 1, Name: "Food", ParentID: 3},
+			mockSetup: func() {
+				mockCategoryRepo.On("GetCategory", ctx, int64(3)).Return(&categories[2], nil)
+				mockCategoryRepo.On("ListCategories", ctx).Return(categories, nil)
+			},
+			expectedErr: true,
+		},
+		{
+			name:        "own parent",
+			category:    &domain.Category{ID: 2, Name: "Groceries", ParentID: 2},
+			mockSetup:   func() {},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCategoryRepo.ExpectedCalls = nil
+			tt.mockSetup()
+
+			err := service.CategoryUpdate(ctx, tt.category)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockCategoryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerServiceImpl_RuleAdd(t *testing.T) {
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockTransactionRepo := &MockTransactionRepository{}
+	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
+
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		rule        *domain.Rule
+		mockSetup   func()
+		expected    int64
+		expectedErr bool
+	}{
+		{
+			name: "category resolved by name",
+			rule: &domain.Rule{Priority: 1, DescriptionContains: "uber", Category: "Taxi"},
+			mockSetup: func() {
+				mockCategoryRepo.On("GetCategoryByName", ctx, "Taxi").Return(&domain.Category{ID: 3, Name: "Taxi"}, nil)
+				mockRuleRepo.On("AddRule", ctx, &domain.Rule{Priority: 1, DescriptionContains: "uber", CategoryID: 3, Category: "Taxi"}).Return(int64(1), nil)
+			},
+			expected:    1,
+			expectedErr: false,
+		},
+		{
+			name: "unknown category",
+			rule: &domain.Rule{DescriptionContains: "uber", CategoryID: 42},
+			mockSetup: func() {
+				mockCategoryRepo.On("GetCategory", ctx, int64(42)).Return(nil, sql.ErrNoRows)
+			},
+			expected:    0,
+			expectedErr: true,
+		},
+		{
+			name:        "invalid regex",
+			rule:        &domain.Rule{DescriptionRegex: "(", CategoryID: 3},
+			mockSetup:   func() {},
+			expected:    0,
+			expectedErr: true,
+		},
+		{
+			name:        "no conditions",
+			rule:        &domain.Rule{CategoryID: 3},
+			mockSetup:   func() {},
+			expected:    0,
+			expectedErr: true,
+		},
+		{
+			name:        "invalid amount range",
+			rule:        &domain.Rule{MinAmount: 100, MaxAmount: 10, CategoryID: 3},
+			mockSetup:   func() {},
+			expected:    0,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCategoryRepo.ExpectedCalls = nil
+			mockRuleRepo.ExpectedCalls = nil
+			tt.mockSetup()
+
+			result, err := service.RuleAdd(ctx, tt.rule)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			mockCategoryRepo.AssertExpectations(t)
+			mockRuleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerServiceImpl_RuleTest(t *testing.T) {
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockTransactionRepo := &MockTransactionRepository{}
+	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
+
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	transactions := []domain.Transaction{
+		{ID: 3, Amount: 15, CategoryID: 9, Category: "Misc", Description: "UBER *TRIP", Date: "2025-12-03"},
+		{ID: 2, Amount: 250, CategoryID: 9, Category: "Misc", Description: "UBER *TRIP", Date: "2025-12-02"},
+		{ID: 1, Amount: 10, CategoryID: 9, Category: "Misc", Description: "Coffee", Date: "2025-12-01"},
+	}
+
+	tests := []struct {
+		name        string
+		rule        *domain.Rule
+		mockSetup   func()
+		expected    []domain.RuleMatch
+		expectedErr bool
+	}{
+		{
+			name: "unsaved rule",
+			rule: &domain.Rule{DescriptionContains: "uber", MaxAmount: 100, CategoryID: 3, Description: "Uber"},
+			mockSetup: func() {
+				mockCategoryRepo.On("GetCategory", ctx, int64(3)).Return(&domain.Category{ID: 3, Name: "Taxi"}, nil)
+				mockTransactionRepo.On("ListTransactions", ctx, domain.TransactionFilter{Limit: 10}).Return(transactions, nil)
+			},
+			expected: []domain.RuleMatch{
+				{Transaction: transactions[0], CategoryID: 3, Category: "Taxi", Description: "Uber"},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "stored rule",
+			rule: &domain.Rule{ID: 4},
+			mockSetup: func() {
+				mockRuleRepo.On("GetRule", ctx, int64(4)).Return(&domain.Rule{ID: 4, DescriptionRegex: "^Coffee$", CategoryID: 1, Category: "Food"}, nil)
+				mockTransactionRepo.On("ListTransactions", ctx, domain.TransactionFilter{Limit: 10}).Return(transactions, nil)
+			},
+			expected: []domain.RuleMatch{
+				{Transaction: transactions[2], CategoryID: 1, Category: "Food", Description: "Coffee"},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "stored rule not found",
+			rule: &domain.Rule{ID: 5},
+			mockSetup: func() {
+				mockRuleRepo.On("GetRule", ctx, int64(5)).Return(nil, sql.ErrNoRows)
+			},
+			expected:    nil,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCategoryRepo.ExpectedCalls = nil
+			mockRuleRepo.ExpectedCalls = nil
+			mockTransactionRepo.ExpectedCalls = nil
+			tt.mockSetup()
+
+			result, err := service.RuleTest(ctx, tt.rule, 10)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			mockCategoryRepo.AssertExpectations(t)
+			mockRuleRepo.AssertExpectations(t)
+			mockTransactionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerServiceImpl_BulkAddTransactionsDuplicates(t *testing.T) {
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockTransactionRepo := &MockTransactionRepository{}
+	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
+
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	food := &domain.Category{ID: 1, Name: "Food"}
+	mockRuleRepo.On("ListRules", ctx).Return([]domain.Rule(nil), nil)
+	mockTransactionRepo.On("FindDuplicateCandidates", ctx, mock.MatchedBy(func(tr *domain.Transaction) bool {
+		return tr.ExternalID == "bank-1"
+	}), 3).Return([]domain.Transaction{{ID: 5, ExternalID: "bank-1", Amount: 10, Description: "Bread", Date: "2025-12-01"}}, nil)
+	mockTransactionRepo.On("FindDuplicateCandidates", ctx, mock.MatchedBy(func(tr *domain.Transaction) bool {
+		return tr.ExternalID == "bank-2"
+	}), 3).Return([]domain.Transaction(nil), nil)
+	mockCategoryRepo.On("GetCategoryByName", ctx, "Food").Return(food, nil)
+	mockCategoryRepo.On("GetCategoryPath", ctx, int64(1)).Return([]domain.Category{*food}, nil)
+	mockBudgetRepo.On("GetBudget", "Food", ctx).Return(&domain.Budget{CategoryID: 1, Category: "Food", Limit: 1000.0}, nil)
+	mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, int64(1), mock.Anything).Return(100.0, nil)
+	mockTransactionRepo.On("AddTransaction", mock.Anything, ctx).Return(int64(6), nil)
+
+	result, err := service.BulkAddTransactions(ctx, []domain.Transaction{
+		{Amount: 10, Category: "Food", Description: "Bread", Date: "2025-12-01", ExternalID: "bank-1"},
+		{Amount: 20, Category: "Food", Description: "Milk", Date: "2025-12-02", ExternalID: "bank-2"},
+		{Amount: -1, Category: "Food", Description: "Broken", Date: "2025-12-03"},
+	}, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.Accepted)
+	assert.Equal(t, int64(2), result.Rejected)
+	assert.Equal(t, map[int64]int64{0: 5}, result.Duplicates)
+	assert.Equal(t, map[int64]string{2: "invalid transaction"}, result.Errors)
+	mockTransactionRepo.AssertNumberOfCalls(t, "AddTransaction", 1)
+}
+
+func TestLedgerServiceImpl_BulkAddTransactionsSameBatch(t *testing.T) {
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockTransactionRepo := &MockTransactionRepository{}
+	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
+
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	food := &domain.Category{ID: 1, Name: "Food"}
+	mockRuleRepo.On("ListRules", ctx).Return([]domain.Rule(nil), nil)
+	mockTransactionRepo.On("FindDuplicateCandidates", ctx, mock.Anything, 3).Return([]domain.Transaction(nil), nil)
+	mockCategoryRepo.On("GetCategoryByName", ctx, "Food").Return(food, nil)
+	mockCategoryRepo.On("GetCategoryPath", ctx, int64(1)).Return([]domain.Category{*food}, nil)
+	mockBudgetRepo.On("GetBudget", "Food", ctx).Return(&domain.Budget{CategoryID: 1, Category: "Food", Limit: 1000.0}, nil)
+	mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, int64(1), mock.Anything).Return(100.0, nil)
+	mockTransactionRepo.On("AddTransaction", mock.MatchedBy(func(tr *domain.Transaction) bool {
+		return tr.ExternalID == "bank-1"
+	}), ctx).Return(int64(6), nil)
+	mockTransactionRepo.On("AddTransaction", mock.MatchedBy(func(tr *domain.Transaction) bool {
+		return tr.ExternalID == "bank-2"
+	}), ctx).Return(int64(0), &domain.DuplicateTransactionError{ID: 4})
+
+	result, err := service.BulkAddTransactions(ctx, []domain.Transaction{
+		{Amount: 10, Category: "Food", Description: "Bread", Date: "2025-12-01", ExternalID: "bank-1"},
+		{Amount: 10, Category: "Food", Description: "Bread", Date: "2025-12-01", ExternalID: "bank-1"},
+		{Amount: 20, Category: "Food", Description: "Milk", Date: "2025-12-02", ExternalID: "bank-2"},
+	}, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.Accepted)
+	assert.Equal(t, int64(2), result.Rejected)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, map[int64]int64{2: 4}, result.Duplicates)
+	assert.Equal(t, map[int64]int64{1: 0}, result.BatchDuplicates)
+	mockTransactionRepo.AssertNumberOfCalls(t, "AddTransaction", 2)
+}
+
+func TestLedgerServiceImpl_TransactionAddIdempotent(t *testing.T) {
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockTransactionRepo := &MockTransactionRepository{}
+	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
+	mockIdempotencyRepo := &MockIdempotencyRepository{}
+	mockAnomalyRepo := &MockAnomalyRepository{}
+
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, mockSummaryRepo, mockCategoryRepo, mockRuleRepo, mockIdempotencyRepo, nil, nil, nil, mockAnomalyRepo, nil, nil, nil)
+	ctx := context.Background()
+
+	newTransaction := func() *domain.Transaction {
+		return &domain.Transaction{
+			Amount:         100.0,
+			Category:       "Food",
+			Description:    "Lunch",
+			Date:           "2025-12-01",
+			IdempotencyKey: "key-1",
+		}
+	}
+	hash := newTransaction().Fingerprint()
+	storeSetup := func(id int64, err error) {
+		food := &domain.Category{ID: 1, Name: "Food"}
+		mockRuleRepo.On("ListRules", ctx).Return([]domain.Rule(nil), nil)
+		mockTransactionRepo.On("FindDuplicateCandidates", ctx, mock.Anything, 3).Return([]domain.Transaction(nil), nil)
+		mockCategoryRepo.On("GetCategoryByName", ctx, "Food").Return(food, nil)
+		mockCategoryRepo.On("GetCategoryPath", ctx, int64(1)).Return([]domain.Category{*food}, nil)
+		mockBudgetRepo.On("GetBudget", "Food", ctx).Return(&domain.Budget{CategoryID: 1, Category: "Food", Limit: 1000.0}, nil)
+		mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, int64(1), "2025-12-01").Return(200.0, nil)
+		mockAnomalyRepo.On("GetCategoryAmounts", ctx, int64(1), 100).Return([]float64(nil), nil)
+		mockTransactionRepo.On("AddTransaction", mock.MatchedBy(func(tr *domain.Transaction) bool {
+			return tr.IdempotencyKey == "key-1" && tr.RequestHash == hash
+		}), ctx).Return(id, err)
+	}
+
+	tests := []struct {
+		name            string
+		transaction     *domain.Transaction
+		mockSetup       func()
+		expected        int64
+		expectedAnomaly *domain.AnomalyScore
+		expectedErr     error
+	}{
+		{
+			name:        "first request stores key",
+			transaction: newTransaction(),
+			mockSetup: func() {
+				mockIdempotencyRepo.On("GetIdempotencyRecord", ctx, "key-1").Return(nil, sql.ErrNoRows)
+				storeSetup(7, nil)
+			},
+			expected: 7,
+		},
+		{
+			name:        "replay returns original id",
+			transaction: newTransaction(),
+			mockSetup: func() {
+				mockIdempotencyRepo.On("GetIdempotencyRecord", ctx, "key-1").
+					Return(&domain.IdempotencyRecord{Key: "key-1", RequestHash: hash, TransactionID: 7}, nil)
+				mockAnomalyRepo.On("GetAnomaly", ctx, int64(7)).Return(nil, sql.ErrNoRows)
+			},
+			expected: 7,
+		},
+		{
+			name:        "replay reports stored anomaly",
+			transaction: newTransaction(),
+			mockSetup: func() {
+				mockIdempotencyRepo.On("GetIdempotencyRecord", ctx, "key-1").
+					Return(&domain.IdempotencyRecord{Key: "key-1", RequestHash: hash, TransactionID: 7}, nil)
+				mockAnomalyRepo.On("GetAnomaly", ctx, int64(7)).Return(&domain.AnomalyScore{Score: 40.47, Ratio: 9, Median: 100, MAD: 13.33}, nil)
+			},
+			expected:        7,
+			expectedAnomaly: &domain.AnomalyScore{Score: 40.47, Ratio: 9, Median: 100, MAD: 13.33},
+		},
+		{
+			name: "replay with different body",
+			transaction: func() *domain.Transaction {
+				tr := newTransaction()
+				tr.Amount = 150.0
+				return tr
+			}(),
+			mockSetup: func() {
+				mockIdempotencyRepo.On("GetIdempotencyRecord", ctx, "key-1").
+					Return(&domain.IdempotencyRecord{Key: "key-1", RequestHash: hash, TransactionID: 7}, nil)
+			},
+			expectedErr: domain.ErrIdempotencyKeyReused,
+		},
+		{
+			name:        "concurrent request stored key first",
+			transaction: newTransaction(),
+			mockSetup: func() {
+				mockIdempotencyRepo.On("GetIdempotencyRecord", ctx, "key-1").Return(nil, sql.ErrNoRows).Once()
+				storeSetup(0, domain.ErrIdempotencyKeyConflict)
+				mockIdempotencyRepo.On("GetIdempotencyRecord", ctx, "key-1").
+					Return(&domain.IdempotencyRecord{Key: "key-1", RequestHash: hash, TransactionID: 7}, nil).Once()
+				mockAnomalyRepo.On("GetAnomaly", ctx, int64(7)).Return(nil, sql.ErrNoRows)
+			},
+			expected: 7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBudgetRepo.ExpectedCalls = nil
+			mockTransactionRepo.ExpectedCalls = nil
+			mockCategoryRepo.ExpectedCalls = nil
+			mockRuleRepo.ExpectedCalls = nil
+			mockIdempotencyRepo.ExpectedCalls = nil
+			mockAnomalyRepo.ExpectedCalls = nil
+			tt.mockSetup()
+
+			result, err := service.TransactionAdd(ctx, tt.transaction, false)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.expectedAnomaly, tt.transaction.Anomaly)
+			mockBudgetRepo.AssertExpectations(t)
+			mockTransactionRepo.AssertExpectations(t)
+			mockCategoryRepo.AssertExpectations(t)
+			mockRuleRepo.AssertExpectations(t)
+			mockIdempotencyRepo.AssertExpectations(t)
+			mockAnomalyRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerServiceImpl_AttachmentUpload(t *testing.T) {
+	mockTransactionRepo := &MockTransactionRepository{}
+	mockAttachmentRepo := &MockAttachmentRepository{}
+	mockStorage := &MockBlobStorage{}
+
+	service := NewLedgerService(nil, mockTransactionRepo, nil, nil, nil, nil, mockAttachmentRepo, mockStorage, nil, nil, nil, nil, nil)
+	service.SetMaxAttachmentSize(16)
+	ctx := context.Background()
+	pdf := "%PDF-1.4 receipt"
+
+	tests := []struct {
+		name        string
+		data        string
+		mockSetup   func()
+		expected    int64
+		expectedErr error
+	}{
+		{
+			name: "pdf stored",
+			data: pdf,
+			mockSetup: func() {
+				mockTransactionRepo.On("GetTransaction", int64(3), ctx).Return(&domain.Transaction{ID: 3}, nil)
+				mockStorage.On("Put", ctx, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, "transactions/3/")
+				}), []byte(pdf), int64(16), "application/pdf").Return(nil)
+				mockAttachmentRepo.On("AddAttachment", ctx, mock.MatchedBy(func(a *domain.Attachment) bool {
+					return a.ContentType == "application/pdf" && a.Size == 16 && a.FileName == "receipt.pdf"
+				})).Return(int64(5), nil)
+			},
+			expected: 5,
+		},
+		{
+			name: "too large",
+			data: pdf + "!",
+			mockSetup: func() {
+				mockTransactionRepo.On("GetTransaction", int64(3), ctx).Return(&domain.Transaction{ID: 3}, nil)
+			},
+			expectedErr: domain.ErrAttachmentTooLarge,
+		},
+		{
+			name: "unsupported type",
+			data: "plain text",
+			mockSetup: func() {
+				mockTransactionRepo.On("GetTransaction", int64(3), ctx).Return(&domain.Transaction{ID: 3}, nil)
+			},
+			expectedErr: domain.ErrUnsupportedAttachmentType,
+		},
+		{
+			name: "transaction not found",
+			data: pdf,
+			mockSetup: func() {
+				mockTransactionRepo.On("GetTransaction", int64(3), ctx).Return(nil, sql.ErrNoRows)
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			name: "blob removed when insert fails",
+			data: pdf,
+			mockSetup: func() {
+				mockTransactionRepo.On("GetTransaction", int64(3), ctx).Return(&domain.Transaction{ID: 3}, nil)
+				mockStorage.On("Put", ctx, mock.Anything, []byte(pdf), int64(16), "application/pdf").Return(nil)
+				mockAttachmentRepo.On("AddAttachment", ctx, mock.Anything).Return(int64(0), sql.ErrConnDone)
+				mockStorage.On("Delete", ctx, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, "transactions/3/")
+				})).Return(nil)
+			},
+			expectedErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTransactionRepo.ExpectedCalls = nil
+			mockAttachmentRepo.ExpectedCalls = nil
+			mockStorage.ExpectedCalls = nil
+			tt.mockSetup()
+
+			attachment := &domain.Attachment{TransactionID: 3, FileName: "receipt.pdf"}
+			result, err := service.AttachmentUpload(ctx, attachment, strings.NewReader(tt.data))
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, result)
+			mockTransactionRepo.AssertExpectations(t)
+			mockAttachmentRepo.AssertExpectations(t)
+			mockStorage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerServiceImpl_AttachmentDelete(t *testing.T) {
+	mockAttachmentRepo := &MockAttachmentRepository{}
+	mockStorage := &MockBlobStorage{}
+
+	service := NewLedgerService(nil, nil, nil, nil, nil, nil, mockAttachmentRepo, mockStorage, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	attachment := &domain.Attachment{ID: 5, TransactionID: 3, StorageKey: "transactions/3/abc"}
+	mockAttachmentRepo.On("GetAttachment", ctx, int64(5)).Return(attachment, nil)
+	mockAttachmentRepo.On("DeleteAttachment", ctx, int64(5)).Return(nil).Once()
+	mockAttachmentRepo.On("DeleteAttachment", ctx, int64(5)).Return(sql.ErrNoRows).Once()
+	mockStorage.On("Delete", ctx, "transactions/3/abc").Return(errors.New("storage unavailable")).Once()
+
+	assert.Equal(t, sql.ErrNoRows, service.AttachmentDelete(ctx, 4, 5))
+	assert.NoError(t, service.AttachmentDelete(ctx, 3, 5))
+	assert.Equal(t, sql.ErrNoRows, service.AttachmentDelete(ctx, 3, 5))
+	mockAttachmentRepo.AssertExpectations(t)
+	mockStorage.AssertExpectations(t)
+}
+
+func TestSimilarDescriptions(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected bool
+	}{
+		{"CARD 1234 COFFEE HOUSE", "Coffee House", true},
+		{"POS 0042 UBER TRIP", "POS 0043 UBER TRIP", true},
+		{"Coffee House", "Pharmacy", false},
+		{"Rent December", "Rent", true},
+		{"Rent December Flat", "Rent", false},
+		{"", "", true},
+		{"1234", "Coffee", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, domain.SimilarDescriptions(tt.a, tt.b), "%q vs %q", tt.a, tt.b)
+	}
+}
+
+func TestGoalProgress(t *testing.T) {
+	now := time.Date(2025, 12, 10, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		goal     domain.Goal
+		expected domain.GoalProgress
+	}{
+		{
+			name:     "deadline day after today",
+			goal:     domain.Goal{TargetAmount: 1200, Deadline: "2026-06-30", Saved: 500},
+			expected: domain.GoalProgress{Saved: 500, Remaining: 700, Percent: 41.67, MonthsLeft: 7, MonthlyRequired: 100},
+		},
+		{
+			name:     "deadline day before today",
+			goal:     domain.Goal{TargetAmount: 1000, Deadline: "2026-06-05", Saved: 100},
+			expected: domain.GoalProgress{Saved: 100, Remaining: 900, Percent: 10, MonthsLeft: 6, MonthlyRequired: 150},
+		},
+		{
+			name:     "deadline this month",
+			goal:     domain.Goal{TargetAmount: 300, Deadline: "2025-12-10"},
+			expected: domain.GoalProgress{Remaining: 300, MonthsLeft: 1, MonthlyRequired: 300},
+		},
+		{
+			name:     "deadline passed",
+			goal:     domain.Goal{TargetAmount: 300, Deadline: "2025-11-30", Saved: 100},
+			expected: domain.GoalProgress{Saved: 100, Remaining: 200, Percent: 33.33, MonthlyRequired: 200},
+		},
+		{
+			name:     "goal reached",
+			goal:     domain.Goal{TargetAmount: 300, Deadline: "2026-01-31", Saved: 350},
+			expected: domain.GoalProgress{Saved: 350, Percent: 100, MonthsLeft: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.expected.Goal = tt.goal
+			assert.Equal(t, tt.expected, tt.goal.Progress(now))
+		})
+	}
+}
+
+func TestLedgerServiceImpl_GoalContribute(t *testing.T) {
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockGoalRepo := &MockGoalRepository{}
+
+	service := NewLedgerService(nil, nil, nil, mockCategoryRepo, nil, nil, nil, nil, mockGoalRepo, nil, nil, nil, nil)
+	service.now = func() time.Time { return time.Date(2025, 12, 10, 0, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		contribution *domain.GoalContribution
+		mockSetup    func()
+		expected     int64
+		expectedErr  error
+	}{
+		{
+			name:         "creates savings category",
+			contribution: &domain.GoalContribution{GoalID: 1, Amount: 150},
+			mockSetup: func() {
+				mockGoalRepo.On("GetGoal", ctx, int64(1)).Return(&domain.Goal{ID: 1, Name: "Vacation"}, nil)
+				mockCategoryRepo.On("GetCategoryByName", ctx, "Savings").Return(nil, sql.ErrNoRows)
+				mockCategoryRepo.On("AddCategory", ctx, &domain.Category{Name: "Savings"}).Return(int64(7), nil)
+				mockGoalRepo.On("AddContribution", ctx, &domain.GoalContribution{GoalID: 1, Amount: 150, Date: "2025-12-10", Description: "Vacation"}, int64(7)).Return(int64(42), nil)
+			},
+			expected: 42,
+		},
+		{
+			name:         "goal not found",
+			contribution: &domain.GoalContribution{GoalID: 2, Amount: 150, Date: "2025-12-01"},
+			mockSetup: func() {
+				mockGoalRepo.On("GetGoal", ctx, int64(2)).Return(nil, sql.ErrNoRows)
+			},
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			name:         "invalid amount",
+			contribution: &domain.GoalContribution{GoalID: 1, Amount: -5},
+			mockSetup:    func() {},
+			expectedErr:  errors.New("invalid amount"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCategoryRepo.ExpectedCalls = nil
+			mockGoalRepo.ExpectedCalls = nil
+			tt.mockSetup()
+
+			id, err := service.GoalContribute(ctx, tt.contribution)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, id)
+			mockCategoryRepo.AssertExpectations(t)
+			mockGoalRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLedgerServiceImpl_GoalDelete(t *testing.T) {
+	mockGoalRepo := &MockGoalRepository{}
+
+	service := NewLedgerService(nil, nil, nil, nil, nil, nil, nil, nil, mockGoalRepo, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	mockGoalRepo.On("GetGoal", ctx, int64(1)).Return(&domain.Goal{ID: 1, Saved: 100}, nil)
+	mockGoalRepo.On("GetGoal", ctx, int64(2)).Return(&domain.Goal{ID: 2}, nil)
+	mockGoalRepo.On("DeleteGoal", ctx, int64(2)).Return(nil).Once()
+
+	assert.Equal(t, domain.ErrGoalHasContributions, service.GoalDelete(ctx, 1))
+	assert.NoError(t, service.GoalDelete(ctx, 2))
+	mockGoalRepo.AssertExpectations(t)
+}
+
+func TestLedgerServiceImpl_GetReportForecast(t *testing.T) {
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockSummaryRepo := &MockSummaryRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+
+	service := NewLedgerService(mockBudgetRepo, nil, mockSummaryRepo, mockCategoryRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	service.now = func() time.Time { return time.Date(2025, 12, 10, 18, 30, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	mockSummaryRepo.On("GetSummary", ctx, "2025-12-01", "2025-12-10", time.Time{}).Return(&domain.Summary{Categories: map[string]float64{"Food": 100, "Taxi": 50}}, nil)
+	mockSummaryRepo.On("GetSummary", ctx, "2025-12-11", "2025-12-31", time.Time{}).Return(&domain.Summary{Categories: map[string]float64{"Rent": 1000}}, nil)
+	mockSummaryRepo.On("GetSummary", ctx, "2025-11-01", "2025-11-30", time.Time{}).Return(&domain.Summary{Categories: map[string]float64{"Food": 300, "Rent": 1000}}, nil)
+	mockSummaryRepo.On("GetSummary", ctx, "2025-10-01", "2025-10-31", time.Time{}).Return(&domain.Summary{Categories: map[string]float64{"Food": 320, "Rent": 1000}}, nil)
+	mockBudgetRepo.On("GetBudgets", ctx).Return([]domain.Budget{
+		{CategoryID: 3, Category: "Taxi", Limit: 100},
+		{CategoryID: 1, Category: "Food", Limit: 300},
+	}, nil)
+	mockCategoryRepo.On("ListCategories", ctx).Return([]domain.Category{
+		{ID: 1, Name: "Food"},
+		{ID: 2, Name: "Rent"},
+		{ID: 3, Name: "Taxi"},
+	}, nil)
+
+	forecast, err := service.GetReportForecast(ctx, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Forecast{
+		PeriodStart:  "2025-12-01",
+		PeriodEnd:    "2025-12-31",
+		DaysElapsed:  10,
+		DaysInPeriod: 31,
+		Categories: []domain.CategoryForecast{
+			{Category: "Food", Spent: 100, HistoricalAverage: 310, Projected: 310},
+			{Category: "Rent", Scheduled: 1000, HistoricalAverage: 1000, Projected: 1000},
+			{Category: "Taxi", Spent: 50, Projected: 83.87},
+		},
+		Budgets: []domain.BudgetForecast{
+			{CategoryID: 1, Category: "Food", Limit: 300, Projected: 310, LikelyExceeded: true},
+			{CategoryID: 3, Category: "Taxi", Limit: 100, Projected: 83.87},
+		},
+	}, forecast)
+	mockSummaryRepo.AssertExpectations(t)
+}
+
+func TestProjectSpend(t *testing.T) {
+	period := domain.MonthPeriod(time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, 28, period.DaysInPeriod)
+	assert.Equal(t, 7, period.DaysElapsed)
+	assert.Equal(t, 280.0, domain.ProjectSpend(70, 0, nil, period))
+	assert.Equal(t, 70.0+21*(0.25*10+0.75*20), domain.ProjectSpend(70, 0, []float64{560}, period))
+	assert.Equal(t, 570.0, domain.ProjectSpend(70, 500, []float64{560}, period))
+	assert.Equal(t, 0.0, domain.ProjectSpend(0, 0, []float64{0, 0}, period))
+}
+
+func TestAnomalyDetector_Check(t *testing.T) {
+	detector := domain.DefaultAnomalyDetector()
+	history := []float64{90, 100, 110, 95, 105, 100, 120}
+	stats := domain.NewCategoryStats(history)
+
+	assert.Equal(t, domain.CategoryStats{Count: 7, Median: 100, MAD: 5}, stats)
+
+	tests := []struct {
+		name     string
+		stats    domain.CategoryStats
+		amount   float64
+		expected *domain.AnomalyScore
+	}{
+		{name: "typical amount", stats: stats, amount: 115},
+		{name: "small amount", stats: stats, amount: 1},
+		{name: "high z-score", stats: stats, amount: 130, expected: &domain.AnomalyScore{Score: 4.05, Ratio: 1.3, Median: 100, MAD: 5}},
+		{name: "too little history", stats: domain.NewCategoryStats(history[:4]), amount: 1000},
+		{
+			name:   "constant history uses median ratio",
+			stats:  domain.NewCategoryStats([]float64{4.5, 4.5, 4.5, 4.5, 4.5}),
+			amount: 6,
+		},
+		{
+			name:     "five times the median",
+			stats:    domain.NewCategoryStats([]float64{4.5, 4.5, 4.5, 4.5, 4.5}),
+			amount:   22.5,
+			expected: &domain.AnomalyScore{Ratio: 5, Median: 4.5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, detector.Check(tt.stats, tt.amount))
+		})
+	}
+}
+
+func TestLedgerServiceImpl_TransactionAddFlagsAnomaly(t *testing.T) {
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockTransactionRepo := &MockTransactionRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
+	mockAnomalyRepo := &MockAnomalyRepository{}
+
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, nil, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, mockAnomalyRepo, nil, nil, nil)
+	ctx := context.Background()
+
+	food := &domain.Category{ID: 1, Name: "Food"}
+	mockRuleRepo.On("ListRules", ctx).Return([]domain.Rule(nil), nil)
+	mockTransactionRepo.On("FindDuplicateCandidates", ctx, mock.Anything, 3).Return([]domain.Transaction(nil), nil)
+	mockCategoryRepo.On("GetCategoryByName", ctx, "Food").Return(food, nil)
+	mockCategoryRepo.On("GetCategoryPath", ctx, int64(1)).Return([]domain.Category{*food}, nil)
+	mockBudgetRepo.On("GetBudget", "Food", ctx).Return(&domain.Budget{CategoryID: 1, Category: "Food", Limit: 10000.0}, nil)
+	mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, int64(1), "2025-12-01").Return(200.0, nil)
+	mockAnomalyRepo.On("GetCategoryAmounts", ctx, int64(1), 100).Return([]float64{90, 100, 110, 95, 105}, nil)
+	mockTransactionRepo.On("AddTransaction", mock.MatchedBy(func(tr *domain.Transaction) bool {
+		return tr.Anomaly != nil && tr.Anomaly.Ratio == 9 && tr.Anomaly.Median == 100
+	}), ctx).Return(int64(7), nil)
+
+	transaction := &domain.Transaction{Amount: 900, Category: "Food", Description: "Banquet", Date: "2025-12-01"}
+	id, err := service.TransactionAdd(ctx, transaction, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+	assert.NotNil(t, transaction.Anomaly)
+	mockAnomalyRepo.AssertExpectations(t)
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestLedgerServiceImpl_TransactionAddPublishesEvents(t *testing.T) {
+	ctx := context.Background()
+	food := &domain.Category{ID: 1, Name: "Food"}
+	budget := domain.Budget{CategoryID: 1, Category: "Food", Limit: 1000.0}
+	lunch := domain.Transaction{Amount: 100, CategoryID: 1, Category: "Food", Description: "Lunch", Date: "2025-12-01"}
+	lunchExceededKey := "budget.exceeded:1:" + lunch.Fingerprint()
+
+	tests := []struct {
+		name        string
+		spent       float64
+		amount      float64
+		enqueueErr  error
+		expectedErr error
+		queued      []domain.Event
+		published   []domain.Event
+	}{
+		{
+			name:   "below threshold",
+			spent:  100,
+			amount: 50,
+		},
+		{
+			name:   "threshold crossed",
+			spent:  750,
+			amount: 100,
+			queued: []domain.Event{
+				{Type: domain.EventBudgetThresholdReached, Payload: domain.BudgetEvent{CategoryID: 1, Category: "Food", Limit: 1000, Spent: 850, Amount: 100, Date: "2025-12-01"}},
+			},
+		},
+		{
+			name:   "already above threshold",
+			spent:  850,
+			amount: 100,
+		},
+		{
+			name:        "budget exceeded",
+			spent:       950,
+			amount:      100,
+			expectedErr: errors.New("budget exceeded"),
+			published: []domain.Event{
+				{Type: domain.EventBudgetExceeded, Payload: domain.BudgetEvent{CategoryID: 1, Category: "Food", Limit: 1000, Spent: 950, Amount: 100, Date: "2025-12-01"}, Key: lunchExceededKey},
+			},
+		},
+		{
+			name:        "budget exceeded event not queued",
+			spent:       950,
+			amount:      100,
+			enqueueErr:  errors.New("connection reset"),
+			expectedErr: errors.New("connection reset"),
+			published: []domain.Event{
+				{Type: domain.EventBudgetExceeded, Payload: domain.BudgetEvent{CategoryID: 1, Category: "Food", Limit: 1000, Spent: 950, Amount: 100, Date: "2025-12-01"}, Key: lunchExceededKey},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBudgetRepo := &MockBudgetRepository{}
+			mockTransactionRepo := &MockTransactionRepository{}
+			mockCategoryRepo := &MockCategoryRepository{}
+			mockRuleRepo := &MockRuleRepository{}
+			mockWebhookRepo := &MockWebhookRepository{}
+			service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, nil, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, mockWebhookRepo, nil, nil)
+
+			mockRuleRepo.On("ListRules", ctx).Return([]domain.Rule(nil), nil)
+			mockTransactionRepo.On("FindDuplicateCandidates", ctx, mock.Anything, 3).Return([]domain.Transaction(nil), nil)
+			mockCategoryRepo.On("GetCategoryByName", ctx, "Food").Return(food, nil)
+			mockCategoryRepo.On("GetCategoryPath", ctx, int64(1)).Return([]domain.Category{*food}, nil)
+			mockBudgetRepo.On("GetBudget", "Food", ctx).Return(&budget, nil)
+			mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, int64(1), "2025-12-01").Return(tt.spent, nil)
+			if tt.expectedErr == nil {
+				mockTransactionRepo.On("AddTransaction", mock.MatchedBy(func(tr *domain.Transaction) bool {
+					return assert.ObjectsAreEqual(tt.queued, tr.Events)
+				}), ctx).Return(int64(5), nil).Once()
+			}
+			for _, event := range tt.published {
+				mockWebhookRepo.On("EnqueueEvent", ctx, event).Return(tt.enqueueErr).Once()
+			}
+
+			_, err := service.TransactionAdd(ctx, &domain.Transaction{Amount: tt.amount, Category: "Food", Description: "Lunch", Date: "2025-12-01"}, false)
+
+			assert.Equal(t, tt.expectedErr, err)
+			mockTransactionRepo.AssertExpectations(t)
+			mockWebhookRepo.AssertExpectations(t)
+			mockWebhookRepo.AssertNumberOfCalls(t, "EnqueueEvent", len(tt.published))
+		})
+	}
+}
+
+func TestLedgerServiceImpl_BulkAddTransactionsPublishesEvents(t *testing.T) {
+	mockBudgetRepo := &MockBudgetRepository{}
+	mockTransactionRepo := &MockTransactionRepository{}
+	mockCategoryRepo := &MockCategoryRepository{}
+	mockRuleRepo := &MockRuleRepository{}
+	mockWebhookRepo := &MockWebhookRepository{}
+	service := NewLedgerService(mockBudgetRepo, mockTransactionRepo, nil, mockCategoryRepo, mockRuleRepo, nil, nil, nil, nil, nil, mockWebhookRepo, nil, nil)
+	ctx := context.Background()
+
+	food := &domain.Category{ID: 1, Name: "Food"}
+	mockRuleRepo.On("ListRules", ctx).Return([]domain.Rule(nil), nil)
+	mockTransactionRepo.On("FindDuplicateCandidates", ctx, mock.Anything, 3).Return([]domain.Transaction(nil), nil)
+	mockCategoryRepo.On("GetCategoryByName", ctx, "Food").Return(food, nil)
+	mockCategoryRepo.On("GetCategoryPath", ctx, int64(1)).Return([]domain.Category{*food}, nil)
+	mockBudgetRepo.On("GetBudget", "Food", ctx).Return(&domain.Budget{CategoryID: 1, Category: "Food", Limit: 1000.0}, nil)
+	mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, int64(1), "2025-11-30").Return(750.0, nil)
+	mockTransactionRepo.On("GetAmountTransactionByCategoryAndMonth", ctx, int64(1), "2025-12-01").Return(950.0, nil)
+	mockTransactionRepo.On("AddTransaction", mock.MatchedBy(func(tr *domain.Transaction) bool {
+		return assert.ObjectsAreEqual([]domain.Event{
+			{Type: domain.EventBudgetThresholdReached, Payload: domain.BudgetEvent{CategoryID: 1, Category: "Food", Limit: 1000, Spent: 850, Amount: 100, Date: "2025-11-30"}},
+		}, tr.Events)
+	}), ctx).Return(int64(6), nil).Once()
+	dinner := domain.Transaction{Amount: 100, CategoryID: 1, Category: "Food", Description: "Dinner", Date: "2025-12-01"}
+	mockWebhookRepo.On("EnqueueEvent", ctx, domain.Event{Type: domain.EventBudgetExceeded, Payload: domain.BudgetEvent{CategoryID: 1, Category: "Food", Limit: 1000, Spent: 950, Amount: 100, Date: "2025-12-01"}, Key: "budget.exceeded:1:" + dinner.Fingerprint()}).Return(nil).Once()
+
+	result, err := service.BulkAddTransactions(ctx, []domain.Transaction{
+		{Amount: 100, Category: "Food", Description: "Groceries", Date: "2025-11-30"},
+		{Amount: 100, Category: "Food", Description: "Dinner", Date: "2025-12-01"},
+	}, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.Accepted)
+	assert.Equal(t, map[int64]string{1: "budget exceeded"}, result.Errors)
+	mockTransactionRepo.AssertExpectations(t)
+	mockWebhookRepo.AssertExpectations(t)
+}
+
+func TestBudgetThresholdCrossed(t *testing.T) {
+	assert.True(t, domain.BudgetThresholdCrossed(1000, 799, 800, 0.8))
+	assert.False(t, domain.BudgetThresholdCrossed(1000, 800, 900, 0.8))
+	assert.False(t, domain.BudgetThresholdCrossed(1000, 100, 799, 0.8))
+}
+
+func TestSignWebhook(t *testing.T) {
+	assert.Equal(t,
+		"sha256=204d0191016293ab109e4186bcf9152e942cd10918a21492a04af00178a167f7",
+		domain.SignWebhook("s3cret", 1765368000, []byte(`{"id":7}`)))
+}
+
+func TestLedgerServiceImpl_AuditList(t *testing.T) {
+	mockAuditRepo := &MockAuditRepository{}
+
+	service := NewLedgerService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockAuditRepo)
+	ctx := context.Background()
+	filter := domain.AuditFilter{EntityType: "budget", EntityID: 1, Limit: 10}
+	entries := []domain.AuditEntry{{ID: 3, Action: "budget.set", EntityType: "budget", EntityID: 1}}
+	mockAuditRepo.On("ListAudit", ctx, filter).Return(entries, nil).Once()
+
+	got, err := service.AuditList(ctx, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, entries, got)
+
+	_, err = service.AuditList(ctx, domain.AuditFilter{EntityID: 1})
+	assert.EqualError(t, err, "entity_id requires entity_type")
+	mockAuditRepo.AssertExpectations(t)
+}
@@ -0,0 +1,44 @@
+package snapshot
+
+import (
+	"context"
+	"ledger/internal/domain"
+	"log"
+	"time"
+)
+
+// Builder takes periodic ledger snapshots. Every ledger instance may run one;
+// a snapshot younger than half the interval makes the others skip their turn.
+type Builder struct {
+	repository domain.SnapshotRepository
+}
+
+func NewBuilder(repository domain.SnapshotRepository) *Builder {
+	return &Builder{
+		repository: repository,
+	}
+}
+
+func (b *Builder) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := b.Build(ctx, interval/2); err != nil {
+			log.Printf("ledger snapshot: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *Builder) Build(ctx context.Context, minAge time.Duration) error {
+	snapshot, err := b.repository.CreateSnapshot(ctx, minAge)
+	if err != nil || snapshot == nil {
+		return err
+	}
+	log.Printf("ledger snapshot %d taken at %s: %d rows, %d old snapshots pruned", snapshot.ID, snapshot.TakenAt.Format(time.RFC3339), snapshot.Rows, snapshot.Pruned)
+	return nil
+}
@@ -0,0 +1,41 @@
+package snapshot
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"ledger/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRepository struct {
+	mu     sync.Mutex
+	minAge []time.Duration
+	built  chan struct{}
+}
+
+func (f *fakeRepository) CreateSnapshot(ctx context.Context, minAge time.Duration) (*domain.Snapshot, error) {
+	f.mu.Lock()
+	f.minAge = append(f.minAge, minAge)
+	f.mu.Unlock()
+	f.built <- struct{}{}
+	return &domain.Snapshot{ID: 1, TakenAt: time.Now(), Rows: 3}, nil
+}
+
+func TestBuilder_RunBuildsOnStartAndStops(t *testing.T) {
+	repo := &fakeRepository{built: make(chan struct{}, 1)}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- NewBuilder(repo).Run(ctx, time.Hour)
+	}()
+
+	<-repo.built
+	cancel()
+
+	assert.NoError(t, <-done)
+	assert.Equal(t, []time.Duration{30 * time.Minute}, repo.minAge)
+}
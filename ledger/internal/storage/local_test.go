@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	err = s.Put(ctx, "transactions/3/abc", strings.NewReader("%PDF-1.4"), 8, "application/pdf")
+	require.NoError(t, err)
+
+	r, err := s.Get(ctx, "transactions/3/abc")
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "%PDF-1.4", string(data))
+
+	assert.NoError(t, s.Delete(ctx, "transactions/3/abc"))
+	assert.NoError(t, s.Delete(ctx, "transactions/3/abc"))
+	_, err = s.Get(ctx, "transactions/3/abc")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	assert.Error(t, s.Put(ctx, "../escape", strings.NewReader("x"), 1, "text/plain"))
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is a minimal in-memory stand-in for an S3-compatible endpoint.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	auth    []string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	if r.Header.Get("X-Amz-Date") == "" || r.Header.Get("X-Amz-Content-Sha256") != "UNSIGNED-PAYLOAD" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = data
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	s, err := NewS3Storage(S3Config{Endpoint: srv.URL, Bucket: "receipts", Region: "us-east-1", AccessKey: "minio", SecretKey: "minio123"})
+	require.NoError(t, err)
+	s.now = func() time.Time { return time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	err = s.Put(ctx, "transactions/3/abc", strings.NewReader("%PDF-1.4"), 8, "application/pdf")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("%PDF-1.4"), fake.objects["/receipts/transactions/3/abc"])
+	assert.Regexp(t, `^AWS4-HMAC-SHA256 Credential=minio/20251201/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}$`, fake.auth[0])
+
+	r, err := s.Get(ctx, "transactions/3/abc")
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "%PDF-1.4", string(data))
+
+	assert.NoError(t, s.Delete(ctx, "transactions/3/abc"))
+	_, err = s.Get(ctx, "transactions/3/abc")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestEscapePath(t *testing.T) {
+	assert.Equal(t, "transactions/3/a%20b%2Bc~d", escapePath("transactions/3/a b+c~d"))
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"fmt"
+	"ledger/internal/domain"
+	"os"
+)
+
+func Connect() (domain.BlobStorage, error) {
+	switch backend := os.Getenv("ATTACHMENT_STORAGE"); backend {
+	case "", "local":
+		dir := os.Getenv("ATTACHMENT_DIR")
+		if dir == "" {
+			dir = "attachments"
+		}
+		return NewLocalStorage(dir)
+	case "s3":
+		region := os.Getenv("S3_REGION")
+		if region == "" {
+			region = "us-east-1"
+		}
+		return NewS3Storage(S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			Region:    region,
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+		})
+	default:
+		return nil, fmt.Errorf("unknown attachment storage %q", backend)
+	}
+}
@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"ledger/internal/domain"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxAttempts = 6
+	defaultBackoff     = 5 * time.Second
+	defaultBatchSize   = 50
+	claimLease         = time.Minute
+)
+
+type Dispatcher struct {
+	repository  domain.WebhookRepository
+	client      *http.Client
+	now         func() time.Time
+	maxAttempts int
+	backoff     time.Duration
+	batchSize   int
+}
+
+func NewDispatcher(repository domain.WebhookRepository, client *http.Client) *Dispatcher {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Dispatcher{
+		repository:  repository,
+		client:      client,
+		now:         time.Now,
+		maxAttempts: defaultMaxAttempts,
+		backoff:     defaultBackoff,
+		batchSize:   defaultBatchSize,
+	}
+}
+
+func (d *Dispatcher) SetRetryPolicy(maxAttempts int, backoff time.Duration) {
+	d.maxAttempts = maxAttempts
+	d.backoff = backoff
+}
+
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := d.DeliverDue(ctx); err != nil {
+				log.Printf("webhook delivery: %v", err)
+			}
+		}
+	}
+}
+
+// DeliverDue sends every delivery whose next attempt is due and returns how
+// many of them were accepted by the receiver.
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := d.repository.ClaimDueDeliveries(ctx, d.batchSize, claimLease)
+	if err != nil {
+		return 0, err
+	}
+	delivered := 0
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		d.attempt(ctx, delivery)
+		if err := d.repository.UpdateDelivery(ctx, delivery); err != nil {
+			return delivered, err
+		}
+		if delivery.Status == domain.DeliveryDelivered {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, delivery *domain.WebhookDelivery) {
+	now := d.now()
+	delivery.Attempts++
+	delivery.NextAttemptAt = now
+	delivery.LastStatusCode, delivery.LastError = d.send(ctx, delivery, now)
+	if delivery.LastError == "" {
+		delivery.Status = domain.DeliveryDelivered
+		delivery.DeliveredAt = &now
+		return
+	}
+	if delivery.Attempts >= d.maxAttempts {
+		delivery.Status = domain.DeliveryFailed
+		return
+	}
+	delivery.NextAttemptAt = now.Add(d.backoff << (delivery.Attempts - 1))
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery *domain.WebhookDelivery, now time.Time) (int, string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err.Error()
+	}
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Ledger-Event", delivery.Event)
+	req.Header.Set("X-Ledger-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Ledger-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Ledger-Signature", domain.SignWebhook(delivery.Secret, timestamp, delivery.Payload))
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err.Error()
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, ""
+}
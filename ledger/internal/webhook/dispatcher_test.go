@@ -0,0 +1,174 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"ledger/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryRepository struct {
+	domain.WebhookRepository
+	mu         sync.Mutex
+	deliveries []domain.WebhookDelivery
+	now        func() time.Time
+}
+
+func (m *memoryRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]domain.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var due []domain.WebhookDelivery
+	for i := range m.deliveries {
+		d := &m.deliveries[i]
+		if d.Status != domain.DeliveryPending || d.NextAttemptAt.After(m.now()) || len(due) == limit {
+			continue
+		}
+		d.NextAttemptAt = m.now().Add(lease)
+		due = append(due, *d)
+	}
+	return due, nil
+}
+
+func (m *memoryRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.deliveries {
+		if m.deliveries[i].ID == delivery.ID {
+			m.deliveries[i] = *delivery
+		}
+	}
+	return nil
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newReceiver(t *testing.T, statuses ...int) (*httptest.Server, *[]receivedRequest) {
+	var mu sync.Mutex
+	var received []receivedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		mu.Lock()
+		received = append(received, receivedRequest{header: r.Header.Clone(), body: body})
+		status := http.StatusOK
+		if len(received) <= len(statuses) {
+			status = statuses[len(received)-1]
+		}
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &received
+}
+
+func TestDispatcher_DeliverSigned(t *testing.T) {
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+	server, received := newReceiver(t)
+	repo := &memoryRepository{now: func() time.Time { return now }}
+	repo.deliveries = []domain.WebhookDelivery{{
+		ID:            1,
+		WebhookID:     3,
+		URL:           server.URL,
+		Secret:        "s3cret",
+		Event:         domain.EventBudgetThresholdReached,
+		Payload:       []byte(`{"category":"Food","limit":300,"spent":250}`),
+		Status:        domain.DeliveryPending,
+		NextAttemptAt: now,
+	}}
+	dispatcher := NewDispatcher(repo, server.Client())
+	dispatcher.now = repo.now
+
+	delivered, err := dispatcher.DeliverDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	require.Len(t, *received, 1)
+	req := (*received)[0]
+	assert.Equal(t, `{"category":"Food","limit":300,"spent":250}`, string(req.body))
+	assert.Equal(t, "budget.threshold_reached", req.header.Get("X-Ledger-Event"))
+	assert.Equal(t, "1", req.header.Get("X-Ledger-Delivery"))
+	assert.Equal(t, strconv.FormatInt(now.Unix(), 10), req.header.Get("X-Ledger-Timestamp"))
+	assert.Equal(t, domain.SignWebhook("s3cret", now.Unix(), req.body), req.header.Get("X-Ledger-Signature"))
+
+	stored := repo.deliveries[0]
+	assert.Equal(t, domain.DeliveryDelivered, stored.Status)
+	assert.Equal(t, 1, stored.Attempts)
+	assert.Equal(t, http.StatusOK, stored.LastStatusCode)
+	assert.Equal(t, &now, stored.DeliveredAt)
+}
+
+func TestDispatcher_RetryWithBackoff(t *testing.T) {
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+	server, received := newReceiver(t, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable)
+	repo := &memoryRepository{now: func() time.Time { return now }}
+	repo.deliveries = []domain.WebhookDelivery{{
+		ID:            1,
+		URL:           server.URL,
+		Secret:        "s3cret",
+		Event:         domain.EventTransactionCreated,
+		Payload:       []byte(`{"id":7}`),
+		Status:        domain.DeliveryPending,
+		NextAttemptAt: now,
+	}}
+	dispatcher := NewDispatcher(repo, server.Client())
+	dispatcher.now = repo.now
+	dispatcher.SetRetryPolicy(3, time.Second)
+	ctx := context.Background()
+
+	_, err := dispatcher.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DeliveryPending, repo.deliveries[0].Status)
+	assert.Equal(t, http.StatusInternalServerError, repo.deliveries[0].LastStatusCode)
+	assert.Equal(t, "unexpected status 500", repo.deliveries[0].LastError)
+	assert.Equal(t, now.Add(time.Second), repo.deliveries[0].NextAttemptAt)
+
+	delivered, err := dispatcher.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Len(t, *received, 1, "retry must wait for the backoff")
+
+	now = now.Add(time.Second)
+	_, err = dispatcher.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(2*time.Second), repo.deliveries[0].NextAttemptAt)
+
+	now = now.Add(2 * time.Second)
+	_, err = dispatcher.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Len(t, *received, 3)
+	assert.Equal(t, domain.DeliveryFailed, repo.deliveries[0].Status)
+	assert.Equal(t, 3, repo.deliveries[0].Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, repo.deliveries[0].LastStatusCode)
+	assert.Nil(t, repo.deliveries[0].DeliveredAt)
+}
+
+func TestDispatcher_UnreachableReceiver(t *testing.T) {
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+	repo := &memoryRepository{now: func() time.Time { return now }}
+	repo.deliveries = []domain.WebhookDelivery{{ID: 1, URL: url, Secret: "s", Payload: []byte(`{}`), Status: domain.DeliveryPending, NextAttemptAt: now}}
+	dispatcher := NewDispatcher(repo, nil)
+	dispatcher.now = repo.now
+
+	_, err := dispatcher.DeliverDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.DeliveryPending, repo.deliveries[0].Status)
+	assert.Equal(t, 0, repo.deliveries[0].LastStatusCode)
+	assert.NotEmpty(t, repo.deliveries[0].LastError)
+	assert.Equal(t, now.Add(5*time.Second), repo.deliveries[0].NextAttemptAt)
+}
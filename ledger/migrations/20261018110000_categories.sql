@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE categories (
+    id SERIAL PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL,
+    parent_id INTEGER REFERENCES categories(id) ON DELETE RESTRICT,
+    CHECK (parent_id <> id)
+);
+INSERT INTO categories(name)
+    SELECT category FROM budgets
+    UNION
+    SELECT category FROM expenses;
+
+ALTER TABLE budgets ADD COLUMN category_id INTEGER REFERENCES categories(id) ON DELETE RESTRICT;
+UPDATE budgets b SET category_id = c.id FROM categories c WHERE c.name = b.category;
+ALTER TABLE budgets ALTER COLUMN category_id SET NOT NULL;
+ALTER TABLE budgets ADD CONSTRAINT budgets_category_id_key UNIQUE (category_id);
+ALTER TABLE budgets DROP COLUMN category;
+
+ALTER TABLE expenses ADD COLUMN category_id INTEGER REFERENCES categories(id) ON DELETE RESTRICT;
+UPDATE expenses e SET category_id = c.id FROM categories c WHERE c.name = e.category;
+ALTER TABLE expenses ALTER COLUMN category_id SET NOT NULL;
+ALTER TABLE expenses DROP COLUMN category;
+CREATE INDEX expenses_category_id_date_idx ON expenses(category_id, date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE expenses ADD COLUMN category TEXT;
+UPDATE expenses e SET category = c.name FROM categories c WHERE c.id = e.category_id;
+ALTER TABLE expenses ALTER COLUMN category SET NOT NULL;
+ALTER TABLE expenses DROP COLUMN category_id;
+
+ALTER TABLE budgets ADD COLUMN category TEXT;
+UPDATE budgets b SET category = c.name FROM categories c WHERE c.id = b.category_id;
+ALTER TABLE budgets ALTER COLUMN category SET NOT NULL;
+ALTER TABLE budgets ADD CONSTRAINT budgets_category_key UNIQUE (category);
+ALTER TABLE budgets DROP COLUMN category_id;
+
+DROP TABLE categories;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE category_rules (
+    id SERIAL PRIMARY KEY,
+    priority INTEGER NOT NULL DEFAULT 0,
+    description_regex TEXT NOT NULL DEFAULT '',
+    description_contains TEXT NOT NULL DEFAULT '',
+    min_amount NUMERIC(14,2) NOT NULL DEFAULT 0,
+    max_amount NUMERIC(14,2) NOT NULL DEFAULT 0,
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    description TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX category_rules_priority_idx ON category_rules(priority, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE category_rules;
+-- +goose StatementEnd
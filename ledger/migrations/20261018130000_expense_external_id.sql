@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE expenses ADD COLUMN external_id TEXT;
+CREATE UNIQUE INDEX expenses_external_id_key ON expenses(external_id) WHERE external_id IS NOT NULL;
+CREATE INDEX expenses_amount_date_idx ON expenses(amount, date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX expenses_amount_date_idx;
+DROP INDEX expenses_external_id_key;
+ALTER TABLE expenses DROP COLUMN external_id;
+-- +goose StatementEnd
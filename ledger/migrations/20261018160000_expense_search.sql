@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- one vector holds lexemes for every supported search language
+ALTER TABLE expenses ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    to_tsvector('simple', COALESCE(description, '')) ||
+    to_tsvector('english', COALESCE(description, '')) ||
+    to_tsvector('russian', COALESCE(description, ''))
+) STORED;
+CREATE INDEX expenses_search_vector_idx ON expenses USING GIN (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX expenses_search_vector_idx;
+ALTER TABLE expenses DROP COLUMN search_vector;
+-- +goose StatementEnd
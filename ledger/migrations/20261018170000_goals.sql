@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE goals (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    target_amount NUMERIC(14,2) NOT NULL CHECK (target_amount > 0),
+    deadline DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+ALTER TABLE expenses ADD COLUMN kind TEXT NOT NULL DEFAULT 'expense' CHECK (kind IN ('expense', 'goal_contribution'));
+ALTER TABLE expenses ADD COLUMN goal_id INTEGER REFERENCES goals(id);
+ALTER TABLE expenses ADD CONSTRAINT expenses_goal_kind_check CHECK ((kind = 'goal_contribution') = (goal_id IS NOT NULL));
+CREATE INDEX expenses_goal_id_idx ON expenses(goal_id) WHERE goal_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX expenses_goal_id_idx;
+ALTER TABLE expenses DROP CONSTRAINT expenses_goal_kind_check;
+ALTER TABLE expenses DROP COLUMN goal_id;
+ALTER TABLE expenses DROP COLUMN kind;
+DROP TABLE goals;
+-- +goose StatementEnd
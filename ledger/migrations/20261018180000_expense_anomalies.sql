@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE expense_anomalies (
+    expense_id INTEGER PRIMARY KEY REFERENCES expenses(id) ON DELETE CASCADE,
+    score NUMERIC NOT NULL,
+    ratio NUMERIC NOT NULL,
+    median NUMERIC(14,2) NOT NULL,
+    mad NUMERIC(14,2) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX expenses_category_date_idx ON expenses(category_id, date DESC, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX expenses_category_date_idx;
+DROP TABLE expense_anomalies;
+-- +goose StatementEnd
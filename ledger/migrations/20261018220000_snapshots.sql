@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE expenses ADD COLUMN recorded_at TIMESTAMPTZ;
+UPDATE expenses SET recorded_at = LEAST(date::timestamptz, now());
+ALTER TABLE expenses ALTER COLUMN recorded_at SET DEFAULT clock_timestamp();
+ALTER TABLE expenses ALTER COLUMN recorded_at SET NOT NULL;
+CREATE INDEX expenses_recorded_at_idx ON expenses(recorded_at);
+CREATE INDEX expenses_date_idx ON expenses(date);
+
+CREATE TABLE ledger_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    taken_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX ledger_snapshots_taken_at_idx ON ledger_snapshots(taken_at);
+
+CREATE TABLE ledger_snapshot_totals (
+    snapshot_id BIGINT NOT NULL REFERENCES ledger_snapshots(id) ON DELETE CASCADE,
+    month DATE NOT NULL,
+    category_id BIGINT NOT NULL,
+    total NUMERIC NOT NULL,
+    PRIMARY KEY (snapshot_id, month, category_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE ledger_snapshot_totals;
+DROP TABLE ledger_snapshots;
+DROP INDEX expenses_date_idx;
+DROP INDEX expenses_recorded_at_idx;
+ALTER TABLE expenses DROP COLUMN recorded_at;
+-- +goose StatementEnd
@@ -0,0 +1,110 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE categories ADD COLUMN space_id UUID;
+ALTER TABLE categories DROP CONSTRAINT categories_name_key;
+ALTER TABLE categories ADD CONSTRAINT categories_space_id_name_key UNIQUE NULLS NOT DISTINCT (space_id, name);
+
+ALTER TABLE tags ADD COLUMN space_id UUID;
+ALTER TABLE tags DROP CONSTRAINT tags_name_key;
+ALTER TABLE tags ADD CONSTRAINT tags_space_id_name_key UNIQUE NULLS NOT DISTINCT (space_id, name);
+
+ALTER TABLE expenses ADD COLUMN space_id UUID;
+ALTER TABLE expenses ADD COLUMN created_by TEXT;
+CREATE INDEX expenses_space_id_date_idx ON expenses(space_id, date);
+DROP INDEX expenses_external_id_key;
+CREATE UNIQUE INDEX expenses_space_id_external_id_key ON expenses(space_id, external_id) NULLS NOT DISTINCT WHERE external_id IS NOT NULL;
+
+ALTER TABLE budgets ADD COLUMN space_id UUID;
+ALTER TABLE budgets DROP CONSTRAINT budgets_category_id_key;
+ALTER TABLE budgets ADD CONSTRAINT budgets_space_id_category_id_key UNIQUE NULLS NOT DISTINCT (space_id, category_id);
+
+ALTER TABLE goals ADD COLUMN space_id UUID;
+ALTER TABLE goals DROP CONSTRAINT goals_name_key;
+ALTER TABLE goals ADD CONSTRAINT goals_space_id_name_key UNIQUE NULLS NOT DISTINCT (space_id, name);
+
+ALTER TABLE category_rules ADD COLUMN space_id UUID;
+DROP INDEX category_rules_priority_idx;
+CREATE INDEX category_rules_space_id_priority_idx ON category_rules(space_id, priority, id);
+
+ALTER TABLE idempotency_keys ADD COLUMN space_id UUID;
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD CONSTRAINT idempotency_keys_space_id_key_key UNIQUE NULLS NOT DISTINCT (space_id, key);
+
+ALTER TABLE webhooks ADD COLUMN space_id UUID;
+ALTER TABLE outbox ADD COLUMN space_id UUID;
+CREATE INDEX outbox_space_id_idx ON outbox(space_id, id);
+ALTER TABLE audit_log ADD COLUMN space_id UUID;
+CREATE INDEX audit_log_space_id_idx ON audit_log(space_id, id);
+
+ALTER TABLE ledger_snapshot_totals ADD COLUMN space_id UUID;
+ALTER TABLE ledger_snapshot_totals DROP CONSTRAINT ledger_snapshot_totals_pkey;
+ALTER TABLE ledger_snapshot_totals ADD CONSTRAINT ledger_snapshot_totals_key UNIQUE NULLS NOT DISTINCT (snapshot_id, space_id, month, category_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DELETE FROM ledger_snapshots;
+
+-- Fold categories and tags that exist in several spaces into the oldest copy
+-- before their names become unique again.
+CREATE TEMP TABLE category_merge AS
+    SELECT id, min(id) OVER (PARTITION BY name) AS keep_id FROM categories;
+UPDATE expenses e SET category_id = m.keep_id FROM category_merge m WHERE e.category_id = m.id AND m.id <> m.keep_id;
+UPDATE budgets b SET category_id = m.keep_id FROM category_merge m WHERE b.category_id = m.id AND m.id <> m.keep_id AND b.space_id IS NULL;
+UPDATE category_rules r SET category_id = m.keep_id FROM category_merge m WHERE r.category_id = m.id AND m.id <> m.keep_id AND r.space_id IS NULL;
+UPDATE categories c SET parent_id = m.keep_id FROM category_merge m WHERE c.parent_id = m.id AND m.id <> m.keep_id;
+DELETE FROM budgets WHERE space_id IS NOT NULL;
+UPDATE categories c SET parent_id = NULL FROM category_merge m WHERE c.id = m.id AND m.id <> m.keep_id;
+DELETE FROM categories c USING category_merge m WHERE c.id = m.id AND m.id <> m.keep_id;
+DROP TABLE category_merge;
+ALTER TABLE categories DROP CONSTRAINT categories_space_id_name_key;
+ALTER TABLE categories DROP COLUMN space_id;
+ALTER TABLE categories ADD CONSTRAINT categories_name_key UNIQUE (name);
+
+CREATE TEMP TABLE tag_merge AS
+    SELECT id, min(id) OVER (PARTITION BY name) AS keep_id FROM tags;
+INSERT INTO expense_tags(expense_id, tag_id)
+    SELECT et.expense_id, m.keep_id FROM expense_tags et JOIN tag_merge m ON m.id = et.tag_id WHERE m.id <> m.keep_id
+    ON CONFLICT DO NOTHING;
+DELETE FROM tags t USING tag_merge m WHERE t.id = m.id AND m.id <> m.keep_id;
+DROP TABLE tag_merge;
+ALTER TABLE tags DROP CONSTRAINT tags_space_id_name_key;
+ALTER TABLE tags DROP COLUMN space_id;
+ALTER TABLE tags ADD CONSTRAINT tags_name_key UNIQUE (name);
+ALTER TABLE ledger_snapshot_totals DROP CONSTRAINT ledger_snapshot_totals_key;
+ALTER TABLE ledger_snapshot_totals DROP COLUMN space_id;
+ALTER TABLE ledger_snapshot_totals ADD PRIMARY KEY (snapshot_id, month, category_id);
+
+DROP INDEX audit_log_space_id_idx;
+ALTER TABLE audit_log DROP COLUMN space_id;
+DROP INDEX outbox_space_id_idx;
+ALTER TABLE outbox DROP COLUMN space_id;
+ALTER TABLE webhooks DROP COLUMN space_id;
+
+DELETE FROM idempotency_keys WHERE space_id IS NOT NULL;
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_space_id_key_key;
+ALTER TABLE idempotency_keys DROP COLUMN space_id;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (key);
+
+DELETE FROM category_rules WHERE space_id IS NOT NULL;
+DROP INDEX category_rules_space_id_priority_idx;
+ALTER TABLE category_rules DROP COLUMN space_id;
+CREATE INDEX category_rules_priority_idx ON category_rules(priority, id);
+
+ALTER TABLE goals DROP CONSTRAINT goals_space_id_name_key;
+ALTER TABLE goals DROP COLUMN space_id;
+ALTER TABLE goals ADD CONSTRAINT goals_name_key UNIQUE (name);
+
+DELETE FROM budgets WHERE space_id IS NOT NULL;
+ALTER TABLE budgets DROP CONSTRAINT budgets_space_id_category_id_key;
+ALTER TABLE budgets DROP COLUMN space_id;
+ALTER TABLE budgets ADD CONSTRAINT budgets_category_id_key UNIQUE (category_id);
+
+DROP INDEX expenses_space_id_external_id_key;
+CREATE UNIQUE INDEX expenses_external_id_key ON expenses(external_id) WHERE external_id IS NOT NULL;
+DROP INDEX expenses_space_id_date_idx;
+ALTER TABLE expenses DROP COLUMN created_by;
+ALTER TABLE expenses DROP COLUMN space_id;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE webhook_deliveries ADD COLUMN dedup_key TEXT;
+CREATE UNIQUE INDEX webhook_deliveries_dedup_key_idx ON webhook_deliveries(webhook_id, dedup_key);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX webhook_deliveries_dedup_key_idx;
+ALTER TABLE webhook_deliveries DROP COLUMN dedup_key;
+-- +goose StatementEnd
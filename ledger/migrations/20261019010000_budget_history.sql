@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE budget_history (
+    id BIGSERIAL PRIMARY KEY,
+    space_id UUID,
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    limit_amount NUMERIC(14,2) NOT NULL CHECK (limit_amount > 0),
+    valid_from TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX budget_history_space_id_category_id_idx ON budget_history(space_id, category_id, valid_from DESC, id DESC);
+
+-- Budgets set before the audit log existed count as always present, with
+-- the limit they had when first audited, or the current one.
+INSERT INTO budget_history(space_id, category_id, limit_amount, valid_from)
+SELECT b.space_id, b.category_id, COALESCE((f.before->>'limit')::numeric, b.limit_amount), '-infinity'
+FROM budgets b
+LEFT JOIN LATERAL (SELECT a.id, a.before FROM audit_log a WHERE a.action = 'budget.set' AND a.entity_id = b.category_id AND a.space_id IS NOT DISTINCT FROM b.space_id ORDER BY a.id LIMIT 1) f ON true
+WHERE f.id IS NULL OR f.before IS NOT NULL;
+INSERT INTO budget_history(space_id, category_id, limit_amount, valid_from)
+SELECT a.space_id, a.entity_id, (a.after->>'limit')::numeric, a.created_at
+FROM audit_log a
+JOIN budgets b ON b.category_id = a.entity_id AND b.space_id IS NOT DISTINCT FROM a.space_id
+WHERE a.action = 'budget.set'
+ORDER BY a.id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE budget_history;
+-- +goose StatementEnd